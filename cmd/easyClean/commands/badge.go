@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	badgeOut   string
+	badgeLabel string
+)
+
+// badgeCmd represents the badge command
+var badgeCmd = &cobra.Command{
+	Use:   "badge",
+	Short: "Generate an SVG badge of unused assets for READMEs and dashboards",
+	Long: `Badge renders a shields-style SVG from the last scan, such as
+"unused assets | 12 / 3.4 MB". The message is colored by the highest severity
+among the assets: red for error, yellow for warning, green otherwise.
+
+Regenerate it in CI after scanning to keep it current:
+
+  easyClean scan --quiet && easyClean badge --out badge.svg`,
+	Args: cobra.NoArgs,
+	RunE: runBadge,
+}
+
+func init() {
+	rootCmd.AddCommand(badgeCmd)
+
+	badgeCmd.Flags().StringVarP(&badgeOut, "out", "o", "badge.svg", `file to write the badge to ("-" for stdout)`)
+	badgeCmd.Flags().StringVar(&badgeLabel, "label", "unused assets", "text on the left of the badge")
+	badgeCmd.Flags().StringVar(&scanFile, "scan-file", "", "load scan results from JSON file (default: scan-results.json)")
+}
+
+func runBadge(cmd *cobra.Command, args []string) error {
+	// Keep stdout clean when the badge itself goes there
+	if badgeOut == "-" {
+		quiet = true
+	}
+
+	result, err := loadScanResultsOrFail(false)
+	if err != nil {
+		return err
+	}
+	result.ComputeStatistics()
+
+	message, color := ui.UsageBadge(result)
+	svg := ui.RenderBadge(badgeLabel, message, color)
+
+	if badgeOut == "-" {
+		_, err := os.Stdout.Write(svg)
+		return err
+	}
+	if err := os.WriteFile(badgeOut, svg, 0644); err != nil {
+		return fmt.Errorf("failed to write badge: %w", err)
+	}
+	if !quiet {
+		fmt.Printf("✓ Wrote badge to %s (%s)\n", badgeOut, message)
+	}
+	return nil
+}
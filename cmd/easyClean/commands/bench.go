@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+	"github.com/HabibPro1999/easyClean/internal/scanner"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var benchTop int
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench [directory]",
+	Short: "Measure time spent per reference pattern",
+	Long: `Bench runs every active reference pattern over the source files a scan
+would read and reports the time spent per pattern, plus the slowest
+regexes and files.
+
+Use it to find patterns that backtrack badly (for example on minified
+code) before pruning them or excluding the offending files. File reading
+and AST parsing are not included in the timings.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBench,
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	benchCmd.Flags().IntVar(&benchTop, "top", 10, "number of slowest files to list")
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	projectRoot := "."
+	if len(args) > 0 {
+		projectRoot = args[0]
+	}
+
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return fmt.Errorf("directory does not exist: %s", absRoot)
+	}
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if !quiet {
+		ui.PrintHeader("Pattern Benchmark", "")
+	}
+
+	finder := scanner.NewReferenceFinder(absRoot, cfg)
+	profile, err := finder.ProfilePatterns()
+	if err != nil {
+		return fmt.Errorf("failed to profile patterns: %w", err)
+	}
+
+	fmt.Printf("\n🧩 %s patterns over %d files, %d lines: %s\n",
+		parser.ProviderName(finder.PatternProvider()), profile.FilesScanned, profile.LinesScanned, formatBenchDuration(profile.Total))
+
+	fmt.Println("\n⏱️  Time per pattern:")
+	for _, p := range profile.Patterns {
+		fmt.Printf("  %10s  %5.1f%%  %-18s %6d matches  %s\n",
+			formatBenchDuration(p.Duration), benchShare(p.Duration, profile.Total), p.Type, p.Matches, p.Pattern)
+	}
+
+	if len(profile.Files) > 0 {
+		top := benchTop
+		if top <= 0 || top > len(profile.Files) {
+			top = len(profile.Files)
+		}
+
+		fmt.Printf("\n🐢 Slowest %d files:\n", top)
+		for _, f := range profile.Files[:top] {
+			fmt.Printf("  %10s  %s (%d lines, %s, slowest: %s)\n",
+				formatBenchDuration(f.Duration), f.Path, f.Lines, ui.FormatBytes(f.Bytes), f.SlowestPattern)
+		}
+	}
+
+	return nil
+}
+
+// formatBenchDuration rounds a duration for display
+func formatBenchDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+// benchShare returns d as a percentage of total
+func benchShare(d, total time.Duration) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(d) / float64(total) * 100
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/inventory"
+	"github.com/HabibPro1999/easyClean/internal/scanner"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var bucketURLs []string
+
+// bucketCmd represents the bucket command
+var bucketCmd = &cobra.Command{
+	Use:   "bucket [directory]",
+	Short: "Find assets in S3/GCS buckets that no source file mentions",
+	Long: `Bucket lists the objects below each configured object storage prefix and
+reports the assets whose file name appears nowhere in the project source.
+
+Buckets come from --bucket or the buckets list in .unusedassets.yaml:
+
+  buckets:
+    - s3://my-app-assets/images/
+    - gs://my-app-assets/static/
+
+Listing uses the aws or gcloud CLI with its configured credentials. Objects
+are matched by file name, ignoring case and content hashes, so assets whose
+URLs are built at runtime from parts may be reported; check before deleting.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBucket,
+}
+
+func init() {
+	rootCmd.AddCommand(bucketCmd)
+
+	bucketCmd.Flags().StringSliceVar(&bucketURLs, "bucket", nil, "bucket prefixes to inventory (s3://bucket/prefix, gs://bucket/prefix)")
+	bucketCmd.Flags().StringVarP(&format, "format", "f", "text", "output format: text, json")
+}
+
+// bucketReport is one bucket's section of the bucket command output
+type bucketReport struct {
+	Bucket           string             `json:"bucket"`
+	Objects          int                `json:"objects"`
+	TotalSize        int64              `json:"total_size_bytes"`
+	Unreferenced     []inventory.Object `json:"unreferenced"`
+	UnreferencedSize int64              `json:"unreferenced_size_bytes"`
+}
+
+func runBucket(cmd *cobra.Command, args []string) error {
+	projectRoot := "."
+	if len(args) > 0 {
+		projectRoot = args[0]
+	}
+
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return fmt.Errorf("directory does not exist: %s", absRoot)
+	}
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	urls := cfg.Buckets
+	if len(bucketURLs) > 0 {
+		urls = bucketURLs
+	}
+	if len(urls) == 0 {
+		return fmt.Errorf("no buckets configured; pass --bucket or set buckets in %s", cfgFile)
+	}
+
+	buckets := make([]inventory.Bucket, 0, len(urls))
+	for _, url := range urls {
+		b, err := inventory.ParseBucket(url)
+		if err != nil {
+			return err
+		}
+		buckets = append(buckets, b)
+	}
+
+	mentioned, err := scanner.NewReferenceFinder(absRoot, cfg).MentionedFileNames()
+	if err != nil {
+		return fmt.Errorf("failed to read source files: %w", err)
+	}
+
+	var reports []bucketReport
+	found := false
+	for _, b := range buckets {
+		if !quiet && format != "json" {
+			fmt.Printf("☁️  Listing %s...\n", b)
+		}
+		objects, err := inventory.ListBucket(b, cfg.Extensions)
+		if err != nil {
+			return err
+		}
+		unreferenced := inventory.Unmentioned(objects, mentioned)
+		found = found || len(unreferenced) > 0
+		reports = append(reports, bucketReport{
+			Bucket:           b.String(),
+			Objects:          len(objects),
+			TotalSize:        inventory.TotalSize(objects),
+			Unreferenced:     unreferenced,
+			UnreferencedSize: inventory.TotalSize(unreferenced),
+		})
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to generate JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if !quiet {
+		for _, report := range reports {
+			printBucketReport(report)
+		}
+	}
+
+	if exitCode && found {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: exitUnused}
+	}
+	return nil
+}
+
+// printBucketReport lists a bucket's assets no source file mentions
+func printBucketReport(report bucketReport) {
+	fmt.Printf("\n☁️  %s holds %d assets (%s)\n", report.Bucket, report.Objects, ui.FormatBytes(report.TotalSize))
+	if len(report.Unreferenced) == 0 {
+		fmt.Println("✓ Every asset is mentioned in the source")
+		return
+	}
+
+	fmt.Printf("\n%s\n\n", ui.Colorize(ui.RoleHeading, "📝 Not mentioned in the source:"))
+	for _, obj := range report.Unreferenced {
+		fmt.Printf("  • %s (%s)\n", ui.Colorize(ui.RoleUnused, obj.Path), ui.FormatBytes(obj.Size))
+	}
+	fmt.Printf("\n💾 %d orphaned assets, %s\n", len(report.Unreferenced), ui.FormatBytes(report.UnreferencedSize))
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var failOn string
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check [directory]",
+	Short: "Scan and fail when findings reach a severity threshold (for CI)",
+	Long: `Check scans a project and reports findings by severity.
+
+Each classification maps to a severity (off, info, warning, error). The
+defaults are:
+
+  unused               error
+  potentially_unused   warning
+  needs_review         info
+  used, kept           off
+
+Override them with a severity section in .unusedassets.yaml:
+
+  severity:
+    unused: warning
+    potentially_unused: info
+
+Exit codes:
+  0  no findings at or above --fail-on
+  1  unused or potentially unused assets at or above --fail-on
+  2  the check could not run (bad config, unreadable project, ...)
+  3  only assets needing manual review at or above --fail-on`,
+	Args:          cobra.MaximumNArgs(1),
+	RunE:          runCheck,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringVar(&failOn, "fail-on", string(models.SeverityError), "lowest severity that fails the check: info, warning, error")
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	threshold, err := models.ParseSeverity(failOn)
+	if err != nil {
+		return &ExitError{Code: exitError, Err: err}
+	}
+
+	projectRoot := "."
+	if len(args) > 0 {
+		projectRoot = args[0]
+	}
+
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return &ExitError{Code: exitError, Err: fmt.Errorf("failed to resolve project path: %w", err)}
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return &ExitError{Code: exitError, Err: fmt.Errorf("directory does not exist: %s", absRoot)}
+	}
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return &ExitError{Code: exitError, Err: fmt.Errorf("failed to load configuration: %w", err)}
+	}
+	cfg.ShowProgress = false
+
+	result, err := performScan(absRoot, cfg)
+	if err != nil {
+		return &ExitError{Code: exitError, Err: err}
+	}
+
+	if !quiet {
+		printCheckFindings(result)
+	}
+
+	if threshold != models.SeverityOff && result.MaxSeverity().Rank() >= threshold.Rank() {
+		var failing []models.AssetFile
+		for _, asset := range result.Assets {
+			if asset.Severity.Rank() >= threshold.Rank() {
+				failing = append(failing, asset)
+			}
+		}
+		code := findingsExitCode(failing)
+		if code == exitClean {
+			// Statuses given a severity by config (e.g. kept: error) still fail
+			code = exitUnused
+		}
+		return &ExitError{
+			Code: code,
+			Err:  fmt.Errorf("check failed: %s", ui.FormatSeveritySummary(result.Stats)),
+		}
+	}
+
+	if !quiet {
+		fmt.Println("\n✓ Check passed")
+	}
+	return nil
+}
+
+// printCheckFindings lists assets grouped by severity, highest first
+func printCheckFindings(result *models.ScanResult) {
+	levels := []models.Severity{models.SeverityError, models.SeverityWarning, models.SeverityInfo}
+
+	fmt.Println()
+	for _, level := range levels {
+		for _, asset := range result.Assets {
+			if asset.Severity == level {
+				fmt.Printf("%-8s %s (%s, %s)\n", level, asset.RelativePath, asset.Status, ui.FormatBytes(asset.Size))
+			}
+		}
+	}
+
+	if summary := ui.FormatSeveritySummary(result.Stats); summary != "" {
+		fmt.Printf("\n🚦 %s\n", summary)
+	}
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	collectPort int
+	collectHost string
+	collectOut  string
+)
+
+// collectCmd represents the collect command
+var collectCmd = &cobra.Command{
+	Use:   "collect",
+	Short: "Collect runtime usage beacons reporting which assets rendered",
+	Long: `Collect runs an HTTP endpoint that apps report rendered assets to, and
+appends each report to a JSON-lines usage file for 'scan --usage'.
+
+POST a beacon to /beacon listing the asset URLs a page used:
+
+  {"assets": ["/images/logo.png", "https://cdn.example.com/fonts/inter.woff2"]}
+
+For example, from the browser after load:
+
+  const assets = performance.getEntriesByType('resource').map((e) => e.name)
+  navigator.sendBeacon('http://localhost:8787/beacon', JSON.stringify({ assets }))
+
+Usage files can also come from your own telemetry pipeline: one beacon per
+line, with an optional "count" for pre-aggregated lines.`,
+	Args: cobra.NoArgs,
+	RunE: runCollect,
+}
+
+func init() {
+	rootCmd.AddCommand(collectCmd)
+
+	collectCmd.Flags().IntVar(&collectPort, "port", 8787, "HTTP port to receive beacons on")
+	collectCmd.Flags().StringVar(&collectHost, "host", "localhost", "HTTP host to listen on (0.0.0.0 to accept remote beacons)")
+	collectCmd.Flags().StringVarP(&collectOut, "output", "o", "usage.jsonl", "usage file to append beacons to")
+}
+
+func runCollect(cmd *cobra.Command, args []string) error {
+	out, err := os.OpenFile(collectOut, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open usage file: %w", err)
+	}
+	defer out.Close()
+
+	collector := usage.NewCollector(out)
+	mux := http.NewServeMux()
+	mux.Handle("/beacon", collector)
+
+	server := &http.Server{
+		Addr:         fmt.Sprintf("%s:%d", collectHost, collectPort),
+		Handler:      mux,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	if !quiet {
+		fmt.Printf("📡 Collecting beacons at http://%s/beacon into %s\n", server.Addr, collectOut)
+		fmt.Println("\nPress Ctrl+C to stop")
+	}
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("server shutdown failed: %w", err)
+		}
+		if !quiet {
+			fmt.Printf("\n✓ Stored %d beacons; run 'easyClean scan --usage %s'\n", collector.Received(), collectOut)
+		}
+		return nil
+
+	case err := <-serverErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("server error: %w", err)
+	}
+}
@@ -0,0 +1,202 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/daemon"
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/scanner"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// daemonDebounce is how long the daemon waits after the last reported change
+// before rescanning
+const daemonDebounce = 500 * time.Millisecond
+
+var daemonSocket string
+
+// daemonCmd represents the daemon command
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Serve scan results to IDE plugins over a local socket",
+	Long: `Daemon keeps the project's scan results in memory and serves them to
+IDE plugins over JSON-RPC 2.0 on a unix socket, one JSON message per line.
+Sockets work on Linux, macOS, and Windows 10 or later.
+
+Methods:
+  scan            {"force": bool}                  current scan result
+  why             {"asset": path}                  references to an asset
+  uses            {"source": path}                 assets a source file references
+  delete          {"paths": [...], "dry_run": bool} delete unused assets
+  didChangeFiles  {"paths": [...]}                 notify of changed files
+  shutdown                                         stop the daemon
+
+Plugins send didChangeFiles as files are saved or changed; once they settle the
+project is rescanned in the background, so the next request is answered from
+fresh results. Each scan also refreshes the cache used by review and delete.
+
+The socket defaults to the project's cache directory; it is removed on exit.`,
+	Example: `  # Serve the current project
+  easyClean daemon
+
+  # Serve another project on a chosen socket
+  easyClean daemon --project ../web --socket /tmp/easyclean.sock
+
+  # Query it
+  echo '{"jsonrpc":"2.0","id":1,"method":"why","params":{"asset":"assets/logo.png"}}' | nc -U /tmp/easyclean.sock`,
+	Args: cobra.NoArgs,
+	RunE: runDaemon,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+
+	daemonCmd.Flags().StringVar(&daemonSocket, "socket", "", "socket path (default: in the project cache directory)")
+	daemonCmd.Flags().StringVar(&projectDir, "project", "", "project directory to serve (default: current directory)")
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	socketPath := daemonSocket
+	if socketPath == "" {
+		if socketPath, err = utils.GetDaemonSocketPath(projectRoot); err != nil {
+			return fmt.Errorf("failed to get socket path: %w", err)
+		}
+		if err := utils.EnsureCacheDirExists(filepath.Dir(socketPath)); err != nil {
+			return fmt.Errorf("failed to create cache directory: %w", err)
+		}
+	}
+
+	listener, err := listenSocket(socketPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(socketPath)
+
+	project := &daemonProject{cmd: cmd, root: projectRoot}
+	d := daemon.New(daemon.Options{
+		Root:     projectRoot,
+		Scan:     project.scan,
+		Watches:  project.watches,
+		Debounce: daemonDebounce,
+	})
+
+	if !quiet {
+		fmt.Printf("🔌 Serving %s on %s\n", projectRoot, socketPath)
+		fmt.Println("\nPress Ctrl+C to stop")
+	}
+	// Scan progress would interleave with the daemon's own output
+	quiet = true
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		d.Close()
+	}()
+
+	return d.Serve(listener)
+}
+
+// listenSocket listens on a unix socket, replacing a stale socket file left by
+// a daemon that didn't exit cleanly
+func listenSocket(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a daemon is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	return listener, nil
+}
+
+// daemonProject scans the served project with its current configuration,
+// keeping the settings of the last scan to decide which changes matter
+type daemonProject struct {
+	cmd  *cobra.Command
+	root string
+
+	mu         sync.Mutex
+	cfg        *models.ProjectConfig
+	sourceExts []string
+}
+
+func (p *daemonProject) scan() (*models.ScanResult, error) {
+	cfg, err := loadProjectConfig(p.cmd, p.root)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ShowProgress = false
+
+	result, err := performScan(p.root, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := saveScanCache(result); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.cfg = cfg
+	p.sourceExts = scanner.NewReferenceFinder(p.root, cfg).SourceExtensions()
+	p.mu.Unlock()
+	return result, nil
+}
+
+// watches reports whether a changed file can affect the scan: the config file,
+// or an asset or source file in a directory the scan walks
+func (p *daemonProject) watches(path string) bool {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.root, path)
+	}
+	if name := filepath.Base(path); name == filepath.Base(cfgFile) || name == scanner.KeepFileName {
+		return true
+	}
+
+	p.mu.Lock()
+	cfg, sourceExts := p.cfg, p.sourceExts
+	p.mu.Unlock()
+	if cfg == nil {
+		return true // not scanned yet
+	}
+
+	path = filepath.Clean(path)
+	if !strings.HasPrefix(path, p.root+string(filepath.Separator)) || scanner.IsSkippedPath(path, p.root, cfg) {
+		return false
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range cfg.Extensions {
+		if strings.ToLower(e) == ext {
+			return true
+		}
+	}
+	for _, e := range sourceExts {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
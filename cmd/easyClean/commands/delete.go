@@ -2,12 +2,14 @@ package commands
 
 import (
 	"bufio"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/HabibPro1999/easyClean/internal/classifier"
 	"github.com/HabibPro1999/easyClean/internal/models"
 	"github.com/HabibPro1999/easyClean/internal/ui"
 	"github.com/HabibPro1999/easyClean/internal/utils"
@@ -19,6 +21,10 @@ var (
 	interactive bool
 	force       bool
 	scanFile    string
+	projectDir  string
+	staleDays   int
+	verifyCmd   string
+	noVerify    bool
 )
 
 // deleteCmd represents the delete command
@@ -34,7 +40,8 @@ Safety features:
 - Dry-run mode to preview deletions
 - Confirmation prompts before deleting
 - Git repository detection
-- Recovery instructions`,
+- Recovery instructions
+- Build verification (--verify-cmd) with automatic restore on failure`,
 	RunE: runDelete,
 }
 
@@ -43,8 +50,12 @@ func init() {
 
 	deleteCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be deleted without deleting")
 	deleteCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "prompt for confirmation before each file")
-	deleteCmd.Flags().BoolVar(&force, "force", false, "skip confirmation prompts")
+	deleteCmd.Flags().BoolVar(&force, "force", false, "skip confirmation prompts, and delete even if the scan was incomplete")
 	deleteCmd.Flags().StringVar(&scanFile, "scan-file", "", "load scan results from JSON file (default: scan-results.json)")
+	deleteCmd.Flags().StringVar(&projectDir, "project", "", "project directory whose cached results to use (default: current directory)")
+	deleteCmd.Flags().StringVar(&verifyCmd, "verify-cmd", "", "command to run after moving files to trash; files are restored if it fails")
+	deleteCmd.Flags().BoolVar(&noVerify, "no-verify", false, "use scan results even if their signature does not match")
+	deleteCmd.Flags().IntVar(&staleDays, "stale-days", 0, "only delete unused assets untouched for at least N days")
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
@@ -52,25 +63,46 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		ui.PrintHeader("Delete Unused Assets", "")
 	}
 
-	result, err := loadScanResultsOrFail()
+	result, err := loadScanResultsOrFail(true)
 	if err != nil {
 		return err
 	}
+	if err := checkCompleteScan(result, !dryRun && !force); err != nil {
+		return err
+	}
 
 	filesToDelete := selectFilesToDelete(result, args)
 	if len(filesToDelete) == 0 {
 		if !quiet {
 			fmt.Println("\n✓ No files to delete")
 		}
+		if dryRun {
+			// Nothing to delete, but assets needing review still count
+			return findingsExit(cmd, result.NeedsReviewAssets)
+		}
 		return nil
 	}
 
 	if dryRun {
-		return showDryRun(filesToDelete, calculateTotalSize(filesToDelete))
+		if !quiet {
+			printAttributionWarning(filesToDelete)
+		}
+		if err := showDryRun(filesToDelete, calculateTotalSize(filesToDelete)); err != nil {
+			return err
+		}
+		return findingsExit(cmd, filesToDelete)
+	}
+
+	if verifyCmd != "" && interactive {
+		return fmt.Errorf("--verify-cmd cannot be combined with --interactive")
 	}
 
 	isGitRepo := isGitRepository(result.ProjectRoot)
 
+	if force && !quiet {
+		printAttributionWarning(filesToDelete)
+	}
+
 	if !force && !confirmDeletion(filesToDelete, isGitRepo) {
 		if !quiet {
 			fmt.Println("\n⊘ Deletion cancelled")
@@ -82,16 +114,21 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return deleteInteractive(filesToDelete, isGitRepo)
 	}
 
+	if verifyCmd != "" {
+		return deleteWithVerification(filesToDelete, result.ProjectRoot, isGitRepo)
+	}
+
 	return deleteBatch(filesToDelete, isGitRepo)
 }
 
-// loadScanResultsOrFail loads scan results or returns error with helpful message
-func loadScanResultsOrFail() (*models.ScanResult, error) {
-	if scanFile == "" {
-		// Get current working directory
-		projectRoot, err := os.Getwd()
+// loadScanResultsOrFail loads scan results or returns error with helpful message.
+// With verify, results whose signature does not match are refused.
+func loadScanResultsOrFail(verify bool) (*models.ScanResult, error) {
+	cached := scanFile == ""
+	if cached {
+		projectRoot, err := resolveProjectRoot()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get current directory: %w", err)
+			return nil, err
 		}
 
 		// Get cache path for this project
@@ -119,6 +156,12 @@ func loadScanResultsOrFail() (*models.ScanResult, error) {
 		}
 	}
 
+	if verify {
+		if err := verifyScanFile(scanFile, cached); err != nil {
+			return nil, err
+		}
+	}
+
 	result, err := loadScanResults(scanFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load scan results: %w", err)
@@ -127,12 +170,49 @@ func loadScanResultsOrFail() (*models.ScanResult, error) {
 	return result, nil
 }
 
+// checkCompleteScan warns when the scan hit a traversal limit: an asset
+// referenced only from a file the walk never reached looks unused. With
+// refuse, such results are an error.
+func checkCompleteScan(result *models.ScanResult, refuse bool) error {
+	if len(result.Warnings) == 0 {
+		return nil
+	}
+	if !quiet {
+		fmt.Println("\n⚠️  These scan results are incomplete:")
+		for _, warning := range result.Warnings {
+			fmt.Printf("   • %s\n", warning)
+		}
+	}
+	if refuse {
+		return fmt.Errorf("refusing to delete assets of an incomplete scan.\n" +
+			"Raise max_depth/max_files and scan again, or pass --force to delete anyway")
+	}
+	return nil
+}
+
 // selectFilesToDelete determines which files should be deleted based on args
 func selectFilesToDelete(result *models.ScanResult, args []string) []models.AssetFile {
+	candidates := result.UnusedAssets
+	if staleDays > 0 {
+		candidates = filterStaleAssets(candidates, staleDays)
+	}
+
 	if len(args) > 0 {
-		return filterAssetsByPaths(result.UnusedAssets, args)
+		return filterAssetsByPaths(candidates, args)
+	}
+	return candidates
+}
+
+// filterStaleAssets keeps assets untouched for at least minDays days
+func filterStaleAssets(assets []models.AssetFile, minDays int) []models.AssetFile {
+	now := time.Now()
+	var filtered []models.AssetFile
+	for _, asset := range assets {
+		if classifier.AgeInDays(&asset, now) >= minDays {
+			filtered = append(filtered, asset)
+		}
 	}
-	return result.UnusedAssets
+	return filtered
 }
 
 // calculateTotalSize computes total size of asset files
@@ -150,6 +230,8 @@ func confirmDeletion(files []models.AssetFile, isGitRepo bool) bool {
 		totalSize := calculateTotalSize(files)
 		fmt.Printf("\nFound %d unused assets (%s)\n\n", len(files), ui.FormatBytes(totalSize))
 
+		printAttributionWarning(files)
+
 		if isGitRepo {
 			fmt.Println("⚠️  You are about to delete files. Files will remain in git history.")
 		} else {
@@ -170,18 +252,82 @@ func confirmDeletion(files []models.AssetFile, isGitRepo bool) bool {
 	return true
 }
 
-func loadScanResults(path string) (*models.ScanResult, error) {
-	data, err := os.ReadFile(path)
+// printAttributionWarning lists files whose licenses require retaining notices
+func printAttributionWarning(files []models.AssetFile) {
+	var attributed []models.AssetFile
+	for _, asset := range files {
+		if asset.RequiresAttribution {
+			attributed = append(attributed, asset)
+		}
+	}
+	if len(attributed) == 0 {
+		return
+	}
+
+	fmt.Printf("📜 %d assets carry attribution requirements:\n", len(attributed))
+	for _, asset := range attributed {
+		notice := asset.License
+		if asset.Attribution != "" {
+			notice += " — " + asset.Attribution
+		}
+		fmt.Printf("  • %s (%s)\n", asset.RelativePath, notice)
+	}
+	fmt.Println("   Review credits/NOTICE files that may reference them.")
+	fmt.Println()
+}
+
+// loadScanResults streams scan results from a plain or gzip-compressed JSON file
+// resolveProjectRoot returns the --project directory as an absolute path, or
+// the current directory, whose cache entry review and delete use
+func resolveProjectRoot() (string, error) {
+	if projectDir == "" {
+		projectRoot, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+		return projectRoot, nil
+	}
+
+	projectRoot, err := filepath.Abs(projectDir)
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to resolve project directory: %w", err)
 	}
+	if !utils.IsDir(projectRoot) {
+		return "", fmt.Errorf("project directory not found: %s", projectDir)
+	}
+	return projectRoot, nil
+}
 
-	var result models.ScanResult
-	if err := json.Unmarshal(data, &result); err != nil {
+// verifyScanFile refuses scan results modified or truncated since the scan
+// signed them. Cached results must be signed; a --scan-file without a
+// signature is accepted. --no-verify skips the check.
+func verifyScanFile(path string, cached bool) error {
+	if noVerify {
+		return nil
+	}
+
+	err := utils.VerifySignedFile(path, cached)
+	switch {
+	case errors.Is(err, utils.ErrNoSignature):
+		return fmt.Errorf("scan results at %s are not signed.\n"+
+			"Run 'easyClean scan' again, or pass --no-verify to use them anyway", path)
+	case errors.Is(err, utils.ErrSignatureMismatch):
+		return fmt.Errorf("scan results at %s were modified or truncated after the scan wrote them.\n"+
+			"Run 'easyClean scan' again, or pass --no-verify to use them anyway", path)
+	case err != nil:
+		return fmt.Errorf("failed to verify scan results: %w", err)
+	}
+	return nil
+}
+
+func loadScanResults(path string) (*models.ScanResult, error) {
+	file, err := utils.OpenMaybeGzip(path)
+	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
-	return &result, nil
+	return models.ReadScanResult(file)
 }
 
 func filterAssetsByPaths(assets []models.AssetFile, paths []string) []models.AssetFile {
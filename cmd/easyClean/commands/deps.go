@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/inventory"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	depsManifest string
+	depsMinSize  int64
+)
+
+// depsAssetsShown caps the assets listed under each package in text output
+const depsAssetsShown = 5
+
+// depsCmd represents the deps command
+var depsCmd = &cobra.Command{
+	Use:   "deps [directory]",
+	Short: "Weigh the assets dependencies from node_modules add to the bundle",
+	Long: `Deps reads a build manifest and lists the asset files (fonts, images,
+videos, ...) the bundler pulled in from node_modules, grouped by package and
+heaviest first. A source scan only sees the project's own assets; this shows
+the asset weight each dependency brings along.
+
+Supported manifests are Vite's (build.manifest: true) and webpack stats
+(webpack --json > stats.json). Without --manifest, dist/.vite/manifest.json,
+dist/manifest.json, and stats.json are tried:
+
+  vite build --manifest && easyClean deps
+  easyClean deps --manifest build/stats.json --min-size 50000`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDeps,
+}
+
+func init() {
+	rootCmd.AddCommand(depsCmd)
+
+	depsCmd.Flags().StringVar(&depsManifest, "manifest", "", "Vite manifest or webpack stats file (default: auto-detect in the build output)")
+	depsCmd.Flags().Int64Var(&depsMinSize, "min-size", 0, "only list assets of at least N bytes")
+	depsCmd.Flags().StringVarP(&format, "format", "f", "text", "output format: text, json")
+}
+
+// depsReport is the JSON output of the deps command
+type depsReport struct {
+	Manifest string                    `json:"manifest"`
+	Assets   int                       `json:"assets"`
+	Size     int64                     `json:"size_bytes"`
+	Packages []inventory.PackageWeight `json:"packages"`
+}
+
+func runDeps(cmd *cobra.Command, args []string) error {
+	projectRoot := "."
+	if len(args) > 0 {
+		projectRoot = args[0]
+	}
+
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return fmt.Errorf("directory does not exist: %s", absRoot)
+	}
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	manifest := depsManifest
+	if manifest == "" {
+		found, ok := inventory.FindManifest(absRoot)
+		if !ok {
+			return fmt.Errorf("no build manifest found in %s; build with a Vite manifest or webpack stats, or pass --manifest", absRoot)
+		}
+		manifest = found
+	}
+
+	assets, err := inventory.ReadDependencyAssets(manifest, absRoot, cfg.Extensions)
+	if err != nil {
+		return err
+	}
+	kept := assets[:0]
+	for _, asset := range assets {
+		if asset.Size >= depsMinSize {
+			kept = append(kept, asset)
+		}
+	}
+
+	report := depsReport{Manifest: manifest, Assets: len(kept), Packages: inventory.GroupByPackage(kept)}
+	for _, pkg := range report.Packages {
+		report.Size += pkg.Size
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to generate JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if !quiet {
+		printDepsReport(report)
+	}
+	return nil
+}
+
+// printDepsReport lists each package's bundled assets, heaviest first
+func printDepsReport(report depsReport) {
+	if len(report.Packages) == 0 {
+		fmt.Printf("\n✓ %s bundles no assets from node_modules\n", report.Manifest)
+		return
+	}
+
+	fmt.Printf("\n📦 %d dependency assets (%s) from %d packages in %s\n",
+		report.Assets, ui.FormatBytes(report.Size), len(report.Packages), report.Manifest)
+	for _, pkg := range report.Packages {
+		fmt.Printf("\n  %s  %s (%d assets)\n", pkg.Package, ui.FormatBytes(pkg.Size), len(pkg.Assets))
+		for i, asset := range pkg.Assets {
+			if i == depsAssetsShown {
+				fmt.Printf("    … and %d more\n", len(pkg.Assets)-depsAssetsShown)
+				break
+			}
+			fmt.Printf("    • %s (%s)\n", asset.Source, ui.FormatBytes(asset.Size))
+		}
+	}
+}
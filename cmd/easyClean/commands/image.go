@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/inventory"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var imageRoot string
+
+// imageCmd represents the image command
+var imageCmd = &cobra.Command{
+	Use:   "image <image.tar|build-dir> [directory]",
+	Short: "Find assets shipped in a container image or build output that nothing references",
+	Long: `Image lists the asset files in a built container image or build output
+directory and cross-references them with a scan of the project source.
+
+Assets the build copies in (from node_modules, generators, or stale output)
+never appear in a source scan. Any shipped asset whose file name matches no
+referenced source asset is reported; bundler content hashes (logo.8f3a2b1c.png)
+are ignored when matching.
+
+The image may be a docker save or OCI layout tarball, or a filesystem tarball
+from docker export:
+
+  docker save myapp:latest -o myapp.tar
+  easyClean image myapp.tar --root usr/share/nginx/html`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runImage,
+}
+
+func init() {
+	rootCmd.AddCommand(imageCmd)
+
+	imageCmd.Flags().StringVar(&imageRoot, "root", "", "directory inside the image the app serves assets from (e.g. usr/share/nginx/html)")
+	imageCmd.Flags().StringVarP(&format, "format", "f", "text", "output format: text, json")
+}
+
+// imageReport is the JSON output of the image command
+type imageReport struct {
+	Image            string             `json:"image"`
+	Shipped          int                `json:"shipped_assets"`
+	ShippedSize      int64              `json:"shipped_size_bytes"`
+	Unreferenced     []inventory.Object `json:"unreferenced"`
+	UnreferencedSize int64              `json:"unreferenced_size_bytes"`
+}
+
+func runImage(cmd *cobra.Command, args []string) error {
+	projectRoot := "."
+	if len(args) > 1 {
+		projectRoot = args[1]
+	}
+
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return fmt.Errorf("directory does not exist: %s", absRoot)
+	}
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ShowProgress = false
+
+	shipped, err := inventory.ReadImage(args[0], imageRoot, cfg.Extensions)
+	if err != nil {
+		return err
+	}
+
+	wasQuiet := quiet
+	quiet = true
+	result, err := performScan(absRoot, cfg)
+	quiet = wasQuiet
+	if err != nil {
+		return err
+	}
+
+	unreferenced := inventory.Unreferenced(shipped, result)
+	report := imageReport{
+		Image:            args[0],
+		Shipped:          len(shipped),
+		ShippedSize:      inventory.TotalSize(shipped),
+		Unreferenced:     unreferenced,
+		UnreferencedSize: inventory.TotalSize(unreferenced),
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to generate JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if !quiet {
+		printImageReport(report)
+	}
+
+	if exitCode && len(unreferenced) > 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: exitUnused}
+	}
+	return nil
+}
+
+// printImageReport lists the shipped assets nothing references
+func printImageReport(report imageReport) {
+	fmt.Printf("\n📦 %s ships %d assets (%s)\n", report.Image, report.Shipped, ui.FormatBytes(report.ShippedSize))
+	if len(report.Unreferenced) == 0 {
+		fmt.Println("\n✓ Every shipped asset is referenced by the source")
+		return
+	}
+
+	fmt.Printf("\n%s\n\n", ui.Colorize(ui.RoleHeading, "📝 Shipped but unreferenced:"))
+	for _, obj := range report.Unreferenced {
+		fmt.Printf("  • %s (%s)\n", ui.Colorize(ui.RoleUnused, obj.Path), ui.FormatBytes(obj.Size))
+	}
+	fmt.Printf("\n💾 Removing them would save %s\n", ui.FormatBytes(report.UnreferencedSize))
+}
@@ -8,13 +8,17 @@ import (
 
 	"github.com/HabibPro1999/easyClean/internal/config"
 	"github.com/HabibPro1999/easyClean/internal/detector"
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+	"github.com/HabibPro1999/easyClean/internal/scanner"
 	"github.com/HabibPro1999/easyClean/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	showConfig bool
-	showPaths  bool
+	showConfig   bool
+	showPaths    bool
+	showPatterns bool
 )
 
 // infoCmd represents the info command
@@ -26,7 +30,11 @@ var infoCmd = &cobra.Command{
 - Configuration file location and status
 - Asset directories and file counts
 - Excluded paths
-- Configured file extensions`,
+- Configured file extensions
+
+With --patterns, info shows how references are detected: the pattern
+provider in use, its regexes and confidence, the source file extensions
+scanned, and whether AST parsing is enabled.`,
 	RunE: runInfo,
 }
 
@@ -35,6 +43,7 @@ func init() {
 
 	infoCmd.Flags().BoolVar(&showConfig, "show-config", false, "display current configuration")
 	infoCmd.Flags().BoolVar(&showPaths, "show-paths", false, "list detected asset paths")
+	infoCmd.Flags().BoolVar(&showPatterns, "patterns", false, "display reference detection patterns")
 }
 
 func runInfo(cmd *cobra.Command, args []string) error {
@@ -73,7 +82,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show asset directories
-	if showPaths || !showConfig {
+	if showPaths || (!showConfig && !showPatterns) {
 		fmt.Println("\n📂 Asset Directories:")
 		for _, path := range cfg.AssetPaths {
 			fullPath := filepath.Join(currentDir, path)
@@ -169,9 +178,37 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		fmt.Printf("color_output: %t\n", cfg.ColorOutput)
 	}
 
+	if showPatterns {
+		printPatternInfo(currentDir, cfg, projectType)
+	}
+
 	return nil
 }
 
+// printPatternInfo shows the reference detection setup a scan would use
+func printPatternInfo(root string, cfg *models.ProjectConfig, detected models.ProjectType) {
+	finder := scanner.NewReferenceFinder(root, cfg)
+	provider := finder.PatternProvider()
+
+	fmt.Printf("\n🧩 Pattern Provider: %s (project type: %s)\n", parser.ProviderName(provider), cfg.ProjectType)
+	if cfg.ProjectType != detected {
+		fmt.Printf("   Detected type %s is not used for patterns; set project_type to select its provider\n", detected)
+	}
+
+	fmt.Println("\n🔎 Active Patterns:")
+	for _, pattern := range finder.Patterns() {
+		fmt.Printf("  • %-18s %3.0f%%  %s\n", pattern.Type, pattern.Confidence*100, pattern.Pattern.String())
+	}
+
+	fmt.Printf("\n📝 Source Extensions: %s\n", strings.Join(finder.SourceExtensions(), ", "))
+
+	astStatus := "disabled"
+	if provider.UseASTParsing() {
+		astStatus = "enabled (.js, .jsx, .ts, .tsx)"
+	}
+	fmt.Printf("🌳 AST Parsing: %s\n", astStatus)
+}
+
 func countFilesInDir(dir string, extensions []string) int {
 	count := 0
 	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/HabibPro1999/easyClean/internal/config"
 	"github.com/HabibPro1999/easyClean/internal/detector"
@@ -12,8 +14,10 @@ import (
 )
 
 var (
-	forceInit bool
-	template  string
+	forceInit  bool
+	template   string
+	acceptInit bool
+	autoInit   bool
 )
 
 // initCmd represents the init command
@@ -25,10 +29,17 @@ var initCmd = &cobra.Command{
 The configuration file controls which directories are scanned, which file types
 are considered assets, and which paths should be excluded.
 
+When run in a terminal without --template, init starts a wizard that shows
+the detected project type, lets you pick asset directories from the ones
+discovered in the project, add exclusions, and preview the YAML before it is
+written. Use --yes to skip the wizard.
+
 Templates:
   default       - Standard configuration for most projects
   minimal       - Minimal configuration (fewer options)
-  comprehensive - All available options with comments`,
+  comprehensive - Also seeds constant files and base path variables
+
+Generated files document every option with comments.`,
 	RunE: runInit,
 }
 
@@ -37,6 +48,8 @@ func init() {
 
 	initCmd.Flags().BoolVar(&forceInit, "force", false, "overwrite existing config file")
 	initCmd.Flags().StringVar(&template, "template", "default", "config template: default, minimal, comprehensive")
+	initCmd.Flags().BoolVarP(&acceptInit, "yes", "y", false, "skip the interactive wizard and use the template")
+	initCmd.Flags().BoolVar(&autoInit, "auto", false, "use asset directories discovered in the project (no prompts)")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -58,17 +71,34 @@ func runInit(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\n✓ Detected project type: %s\n", projectType)
 	}
 
-	// Create configuration based on template
+	// Create configuration interactively or from a template
 	var cfg *models.ProjectConfig
-	switch template {
-	case "minimal":
+	useWizard := !acceptInit && !autoInit && !quiet && !cmd.Flags().Changed("template") && isInteractiveTerminal()
+
+	switch {
+	case useWizard:
+		wizard := &initWizard{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+		wizardCfg, err := wizard.run(".", projectType)
+		if err != nil {
+			return fmt.Errorf("init wizard failed: %w", err)
+		}
+		if wizardCfg == nil {
+			fmt.Println("\n⊘ Initialization cancelled")
+			return nil
+		}
+		cfg = wizardCfg
+	case template == "minimal":
 		cfg = createMinimalConfig(projectType)
-	case "comprehensive":
+	case template == "comprehensive":
 		cfg = createComprehensiveConfig(projectType)
 	default:
 		cfg = createDefaultConfig(projectType)
 	}
 
+	if autoInit {
+		applyDiscoveredAssetPaths(cfg)
+	}
+
 	// Save configuration
 	if err := config.SaveConfig(cfg, configPath); err != nil {
 		return fmt.Errorf("failed to create config file: %w", err)
@@ -87,6 +117,22 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// applyDiscoveredAssetPaths replaces asset paths with directories discovered in the project
+func applyDiscoveredAssetPaths(cfg *models.ProjectConfig) {
+	discovered := detector.DiscoverAssetPaths(".", cfg.Extensions, cfg.ExcludePaths, cfg.DiscoveryMinFiles)
+	if len(discovered) == 0 {
+		if !quiet {
+			fmt.Println("⚠️  No asset directories discovered, keeping conventional paths")
+		}
+		return
+	}
+
+	cfg.AssetPaths = discovered
+	if !quiet {
+		fmt.Printf("✓ Discovered asset directories: %s\n", strings.Join(discovered, ", "))
+	}
+}
+
 func createMinimalConfig(projectType models.ProjectType) *models.ProjectConfig {
 	cfg := config.DefaultConfig()
 
@@ -105,11 +151,11 @@ func createMinimalConfig(projectType models.ProjectType) *models.ProjectConfig {
 	}
 
 	// Basic excludes
-	cfg.ExcludePaths = []string{
+	cfg.ExcludePaths = append([]string{
 		"node_modules/",
 		"dist/",
 		"build/",
-	}
+	}, config.ExcludePathsForProjectType(projectType)...)
 
 	return cfg
 }
@@ -120,6 +166,7 @@ func createDefaultConfig(projectType models.ProjectType) *models.ProjectConfig {
 	// Customize based on project type
 	if projectType != models.ProjectTypeUnknown {
 		cfg.AssetPaths = config.DefaultAssetPathsForProjectType(projectType)
+		cfg.ExcludePaths = append(cfg.ExcludePaths, config.ExcludePathsForProjectType(projectType)...)
 		cfg.ProjectType = projectType
 	}
 
@@ -151,52 +198,3 @@ func createComprehensiveConfig(projectType models.ProjectType) *models.ProjectCo
 
 	return cfg
 }
-
-// WriteConfigToFile writes a config file with comments (for comprehensive template)
-func WriteConfigToFile(cfg *models.ProjectConfig, path string) error {
-	file, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	// Write commented YAML
-	content := fmt.Sprintf(`# Asset Cleaner Configuration
-# Generated for project type: %s
-
-# Directories to scan for asset files
-asset_paths:
-`, cfg.ProjectType)
-
-	for _, path := range cfg.AssetPaths {
-		content += fmt.Sprintf("  - %s\n", path)
-	}
-
-	content += "\n# File extensions to consider as assets\nextensions:\n"
-	for _, ext := range cfg.Extensions {
-		content += fmt.Sprintf("  - %s\n", ext)
-	}
-
-	content += "\n# Paths and patterns to exclude from scanning\nexclude_paths:\n"
-	for _, path := range cfg.ExcludePaths {
-		content += fmt.Sprintf("  - %s\n", path)
-	}
-
-	if len(cfg.ConstantFiles) > 0 {
-		content += "\n# Asset constant files to analyze\nconstant_files:\n"
-		for _, file := range cfg.ConstantFiles {
-			content += fmt.Sprintf("  - %s\n", file)
-		}
-	}
-
-	content += fmt.Sprintf(`
-# Advanced settings
-max_workers: %d           # Concurrent workers (0 = auto-detect)
-follow_symlinks: %t      # Follow symbolic links
-show_progress: %t        # Show progress bar
-color_output: %t         # Enable colored output
-`, cfg.MaxWorkers, cfg.FollowSymlinks, cfg.ShowProgress, cfg.ColorOutput)
-
-	_, err = file.WriteString(content)
-	return err
-}
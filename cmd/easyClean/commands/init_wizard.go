@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/detector"
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// initWizard walks the user through building a configuration interactively
+type initWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// isInteractiveTerminal reports whether stdin is attached to a terminal
+func isInteractiveTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// run prompts for asset directories and exclusions, previews the YAML, and
+// returns the config to write (nil if the user declined)
+func (w *initWizard) run(root string, projectType models.ProjectType) (*models.ProjectConfig, error) {
+	cfg := createDefaultConfig(projectType)
+
+	// Asset directories: discovered ones first, then existing conventional ones
+	candidates := detector.DiscoverAssetDirs(root, cfg.Extensions, cfg.ExcludePaths, cfg.DiscoveryMinFiles)
+	options, labels := assetDirOptions(root, candidates, cfg.AssetPaths)
+
+	if len(options) > 0 {
+		selected, err := w.multiSelect("Asset directories to scan", labels)
+		if err != nil {
+			return nil, err
+		}
+		cfg.AssetPaths = nil
+		for _, i := range selected {
+			cfg.AssetPaths = append(cfg.AssetPaths, options[i])
+		}
+	}
+	extra, err := w.input("Additional asset directories (comma-separated)", "")
+	if err != nil {
+		return nil, err
+	}
+	cfg.AssetPaths = append(cfg.AssetPaths, splitList(extra)...)
+
+	// Exclusions
+	fmt.Fprintf(w.out, "\n🚫 Default exclusions: %s\n", strings.Join(cfg.ExcludePaths, ", "))
+	extra, err = w.input("Additional paths to exclude (comma-separated)", "")
+	if err != nil {
+		return nil, err
+	}
+	cfg.ExcludePaths = append(cfg.ExcludePaths, splitList(extra)...)
+
+	// Preview
+	data, err := config.MarshalConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(w.out, "\n📄 Preview:\n\n%s\n", data)
+
+	confirmed, err := w.confirm("Write this configuration?")
+	if err != nil || !confirmed {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// assetDirOptions merges discovered directories with conventional defaults that exist
+func assetDirOptions(root string, candidates []detector.AssetDirCandidate, defaults []string) ([]string, []string) {
+	var options, labels []string
+	seen := make(map[string]bool)
+
+	for _, c := range candidates {
+		seen[c.Path] = true
+		options = append(options, c.Path)
+		labels = append(labels, fmt.Sprintf("%s (%d assets)", c.Path, c.Count))
+	}
+	for _, dir := range defaults {
+		if seen[dir] || !utils.IsDir(filepath.Join(root, dir)) {
+			continue
+		}
+		seen[dir] = true
+		options = append(options, dir)
+		labels = append(labels, fmt.Sprintf("%s (convention)", dir))
+	}
+
+	return options, labels
+}
+
+// multiSelect shows a numbered list and returns the chosen indices (all by default)
+func (w *initWizard) multiSelect(message string, labels []string) ([]int, error) {
+	fmt.Fprintf(w.out, "\n📂 %s:\n", message)
+	for i, label := range labels {
+		fmt.Fprintf(w.out, "  %d) %s\n", i+1, label)
+	}
+
+	answer, err := w.input("Select numbers (comma-separated, Enter for all)", "")
+	if err != nil {
+		return nil, err
+	}
+
+	if answer == "" {
+		all := make([]int, len(labels))
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+
+	var selected []int
+	for _, part := range splitList(answer) {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 || n > len(labels) {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		selected = append(selected, n-1)
+	}
+	return selected, nil
+}
+
+// input prompts for a line of text, returning def when empty
+func (w *initWizard) input(message, def string) (string, error) {
+	fmt.Fprintf(w.out, "%s: ", message)
+	line, err := w.in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def, nil
+	}
+	return line, nil
+}
+
+// confirm prompts for a yes/no answer, defaulting to yes
+func (w *initWizard) confirm(message string) (bool, error) {
+	answer, err := w.input(message+" [Y/n]", "y")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// splitList splits a comma-separated answer into trimmed, non-empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
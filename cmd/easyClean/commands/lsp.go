@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/HabibPro1999/easyClean/internal/lsp"
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+// lspCmd represents the lsp command
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server that shows scan results in your editor",
+	Long: `Lsp runs a minimal Language Server over stdio for editor plugins
+(VS Code, JetBrains, Neovim, ...). It publishes:
+
+- Diagnostics on unused and potentially unused asset files, at the severity
+  configured for their status
+- Code lenses on references to assets that don't exist ("asset missing")
+
+The project is scanned when the editor connects and again whenever a file is
+saved; each scan also refreshes the cache used by review and delete.
+
+Point your editor's generic LSP client at the command, e.g. for Neovim:
+
+  vim.lsp.start({ name = 'easyClean', cmd = { 'easyClean', 'lsp' } })`,
+	Args: cobra.NoArgs,
+	RunE: runLsp,
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
+
+func runLsp(cmd *cobra.Command, args []string) error {
+	// Stdout carries the protocol, so anything else printed goes to stderr
+	protocol := os.Stdout
+	os.Stdout = os.Stderr
+	quiet = true
+
+	return lsp.NewServer(&lspAnalyzer{cmd: cmd}, protocol).Serve(os.Stdin)
+}
+
+// lspAnalyzer scans with the project's configuration, keeping the settings
+// the last scan resolved (detected asset paths) for reference lookups
+type lspAnalyzer struct {
+	cmd *cobra.Command
+	cfg *models.ProjectConfig
+}
+
+func (a *lspAnalyzer) Scan(root string) (*models.ScanResult, error) {
+	cfg, err := loadProjectConfig(a.cmd, root)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ShowProgress = false
+
+	result, err := performScan(root, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := saveScanCache(result); err != nil {
+		return nil, err
+	}
+	a.cfg = cfg
+	return result, nil
+}
+
+func (a *lspAnalyzer) MissingReferences(root, file string) ([]*models.Reference, error) {
+	if a.cfg == nil {
+		cfg, err := loadProjectConfig(a.cmd, root)
+		if err != nil {
+			return nil, err
+		}
+		a.cfg = cfg
+	}
+	return scanner.NewReferenceFinder(root, a.cfg).MissingReferences(file)
+}
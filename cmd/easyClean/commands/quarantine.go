@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commitQuarantine   bool
+	rollbackQuarantine bool
+	listQuarantine     bool
+)
+
+// quarantineCmd represents the quarantine command
+var quarantineCmd = &cobra.Command{
+	Use:   "quarantine [paths...]",
+	Short: "Move unused assets aside before deleting them permanently",
+	Long: `Quarantine is a two-phase alternative to delete.
+
+Unused assets from the last scan are moved under .easyclean-quarantine/ in the
+project root, keeping their directory layout. Run and test your app for a
+grace period, then either:
+
+  easyClean quarantine --commit     permanently delete quarantined files
+  easyClean quarantine --rollback   restore quarantined files
+
+Use --list to see what is currently quarantined. Every mode works on the
+quarantine of the same project: --project, or the current directory.`,
+	RunE: runQuarantine,
+}
+
+func init() {
+	rootCmd.AddCommand(quarantineCmd)
+
+	quarantineCmd.Flags().BoolVar(&commitQuarantine, "commit", false, "permanently delete quarantined files")
+	quarantineCmd.Flags().BoolVar(&rollbackQuarantine, "rollback", false, "restore quarantined files")
+	quarantineCmd.Flags().BoolVar(&listQuarantine, "list", false, "list quarantined files")
+	quarantineCmd.Flags().StringVar(&projectDir, "project", "", "project directory whose assets to quarantine (default: current directory)")
+	quarantineCmd.Flags().StringVar(&scanFile, "scan-file", "", "load scan results from JSON file (default: scan-results.json)")
+	quarantineCmd.Flags().BoolVar(&force, "force", false, "skip confirmation prompts")
+	quarantineCmd.Flags().BoolVar(&noVerify, "no-verify", false, "use scan results even if their signature does not match")
+}
+
+func runQuarantine(cmd *cobra.Command, args []string) error {
+	if commitQuarantine && rollbackQuarantine {
+		return fmt.Errorf("--commit and --rollback cannot be used together")
+	}
+
+	if !quiet {
+		ui.PrintHeader("Quarantine Unused Assets", "")
+	}
+
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case listQuarantine:
+		return listQuarantined(projectRoot)
+	case commitQuarantine:
+		return commitQuarantined(projectRoot)
+	case rollbackQuarantine:
+		return rollbackQuarantined(projectRoot)
+	}
+
+	result, err := loadScanResultsOrFail(true)
+	if err != nil {
+		return err
+	}
+	// Quarantine can be rolled back, so an incomplete scan only warns
+	if err := checkCompleteScan(result, false); err != nil {
+		return err
+	}
+	// --list, --commit and --rollback look in projectRoot's quarantine
+	if !utils.SamePath(result.ProjectRoot, projectRoot) {
+		return fmt.Errorf("scan results are for %s, not the project at %s; run from that project or pass --project", result.ProjectRoot, projectRoot)
+	}
+
+	files := selectFilesToDelete(result, args)
+	if len(files) == 0 {
+		if !quiet {
+			fmt.Println("\n✓ No files to quarantine")
+		}
+		return nil
+	}
+
+	return quarantineFiles(projectRoot, files)
+}
+
+// quarantineFiles moves assets into the project quarantine
+func quarantineFiles(projectRoot string, files []models.AssetFile) error {
+	q, err := utils.LoadQuarantine(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	var errors []string
+	moved := 0
+	for _, asset := range files {
+		if err := q.Add(asset.RelativePath, asset.Size); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", asset.RelativePath, err))
+			continue
+		}
+		moved++
+		if !quiet && verbose {
+			fmt.Printf("  → %s (%s)\n", asset.RelativePath, ui.FormatBytes(asset.Size))
+		}
+	}
+
+	if err := q.Save(); err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("\n📦 Quarantined %d files in %s\n", moved, utils.QuarantineDirName)
+		for _, e := range errors {
+			fmt.Printf("  ✗ %s\n", e)
+		}
+		fmt.Println("\nTest your app, then run:")
+		fmt.Println("  easyClean quarantine --commit     to delete permanently")
+		fmt.Println("  easyClean quarantine --rollback   to restore")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%d files failed to quarantine", len(errors))
+	}
+	return nil
+}
+
+// listQuarantined prints the current quarantine contents
+func listQuarantined(projectRoot string) error {
+	q, err := utils.LoadQuarantine(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if len(q.Files) == 0 {
+		fmt.Println("\nQuarantine is empty")
+		return nil
+	}
+
+	fmt.Printf("\n📦 %d quarantined files (%s):\n\n", len(q.Files), ui.FormatBytes(q.TotalSize()))
+	for _, f := range q.Files {
+		fmt.Printf("  • %s (%s, since %s)\n", f.RelativePath, ui.FormatBytes(f.Size), f.QuarantinedAt.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// commitQuarantined permanently deletes quarantined files after confirmation
+func commitQuarantined(projectRoot string) error {
+	q, err := utils.LoadQuarantine(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if len(q.Files) == 0 {
+		if !quiet {
+			fmt.Println("\n✓ Quarantine is empty")
+		}
+		return nil
+	}
+
+	count, size := len(q.Files), q.TotalSize()
+	if !force {
+		confirmed, err := promptConfirmation(fmt.Sprintf("\nPermanently delete %d quarantined files (%s)?", count, ui.FormatBytes(size)))
+		if err != nil || !confirmed {
+			if !quiet {
+				fmt.Println("\n⊘ Commit cancelled")
+			}
+			return nil
+		}
+	}
+
+	if err := q.Commit(); err != nil {
+		return err
+	}
+
+	printDeletionSummary(count, size, nil, isGitRepository(projectRoot))
+	return nil
+}
+
+// rollbackQuarantined restores all quarantined files
+func rollbackQuarantined(projectRoot string) error {
+	q, err := utils.LoadQuarantine(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	total := len(q.Files)
+	failed := q.Rollback()
+
+	if !quiet {
+		fmt.Printf("\n↩️  Restored %d files\n", total-len(failed))
+		if len(failed) > 0 {
+			fmt.Printf("\n⚠️  %d errors occurred:\n  • %s\n", len(failed), strings.Join(failed, "\n  • "))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d files failed to restore", len(failed))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/HabibPro1999/easyClean/internal/tickets"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createTickets bool
+	trackerName   string
+	jiraURL       string
+	jiraProject   string
+	jiraIssueType string
+	linearTeam    string
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Group unused assets by owner and file tickets in Jira or Linear",
+	Long: `Report groups the unused assets of the last scan by owning feature/team
+(the features mapping in .unusedassets.yaml; the rest are "unowned") and
+prints the ticket each owner would get.
+
+With --create-tickets, the tickets are filed in Jira or Linear. Each carries a
+stable key derived from its owner, so reruns update the owner's open ticket
+instead of filing a duplicate. Credentials come from the environment:
+
+  Jira:   JIRA_EMAIL and JIRA_API_TOKEN (JIRA_URL for --jira-url)
+  Linear: LINEAR_API_KEY
+
+  easyClean report --create-tickets --tracker jira --jira-url https://acme.atlassian.net --jira-project WEB
+  easyClean report --create-tickets --tracker linear --linear-team <team-id>`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().BoolVar(&createTickets, "create-tickets", false, "file or update one ticket per owner")
+	reportCmd.Flags().StringVar(&trackerName, "tracker", "", "issue tracker for --create-tickets: jira, linear")
+	reportCmd.Flags().StringVar(&jiraURL, "jira-url", os.Getenv("JIRA_URL"), "Jira base URL (default: $JIRA_URL)")
+	reportCmd.Flags().StringVar(&jiraProject, "jira-project", "", "Jira project key tickets are filed in")
+	reportCmd.Flags().StringVar(&jiraIssueType, "jira-issue-type", "Task", "Jira issue type of new tickets")
+	reportCmd.Flags().StringVar(&linearTeam, "linear-team", "", "Linear team ID tickets are filed in")
+	reportCmd.Flags().StringVarP(&format, "format", "f", "text", "output format: text, json")
+	reportCmd.Flags().StringVar(&scanFile, "scan-file", "", "load scan results from JSON file (default: scan-results.json)")
+	reportCmd.Flags().StringVar(&projectDir, "project", "", "project directory whose cached results to use (default: current directory)")
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	var tracker tickets.Tracker
+	if createTickets {
+		var err error
+		if tracker, err = newTracker(); err != nil {
+			return err
+		}
+	}
+
+	if format == "json" {
+		quiet = true
+	}
+	result, err := loadScanResultsOrFail(false)
+	if err != nil {
+		return err
+	}
+	owned := tickets.Build(result, ui.FormatBytes)
+
+	if tracker == nil {
+		if format == "json" {
+			return printJSON(owned)
+		}
+		if len(owned) == 0 {
+			fmt.Println("\n✓ No unused assets to report")
+			return nil
+		}
+		for _, t := range owned {
+			fmt.Printf("\n── %s (%s) ──\n%s", t.Title, t.Key, t.Body)
+		}
+		fmt.Println("\nPass --create-tickets --tracker jira|linear to file these tickets")
+		return nil
+	}
+
+	filed, err := tickets.File(context.Background(), tracker, owned)
+	if format == "json" {
+		if jsonErr := printJSON(filed); jsonErr != nil && err == nil {
+			err = jsonErr
+		}
+	} else {
+		for _, f := range filed {
+			action := "Updated"
+			if f.Created {
+				action = "Created"
+			}
+			fmt.Printf("✓ %s %s %s: %d unused assets (%s) for %s\n",
+				action, tracker.Name(), f.ID, len(f.Ticket.Assets), ui.FormatBytes(f.Ticket.Size), f.Ticket.Owner)
+		}
+	}
+	return err
+}
+
+// newTracker builds the --tracker client from flags and environment credentials
+func newTracker() (tickets.Tracker, error) {
+	switch trackerName {
+	case "jira":
+		email, token := os.Getenv("JIRA_EMAIL"), os.Getenv("JIRA_API_TOKEN")
+		if jiraURL == "" || jiraProject == "" {
+			return nil, fmt.Errorf("--tracker jira requires --jira-url (or JIRA_URL) and --jira-project")
+		}
+		if email == "" || token == "" {
+			return nil, fmt.Errorf("--tracker jira requires JIRA_EMAIL and JIRA_API_TOKEN")
+		}
+		return &tickets.Jira{BaseURL: jiraURL, Email: email, Token: token, Project: jiraProject, IssueType: jiraIssueType}, nil
+	case "linear":
+		apiKey := os.Getenv("LINEAR_API_KEY")
+		if linearTeam == "" {
+			return nil, fmt.Errorf("--tracker linear requires --linear-team")
+		}
+		if apiKey == "" {
+			return nil, fmt.Errorf("--tracker linear requires LINEAR_API_KEY")
+		}
+		return &tickets.Linear{APIKey: apiKey, TeamID: linearTeam}, nil
+	case "":
+		return nil, fmt.Errorf("--create-tickets requires --tracker jira or --tracker linear")
+	default:
+		return nil, fmt.Errorf("unknown tracker %q: want jira or linear", trackerName)
+	}
+}
+
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to generate JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
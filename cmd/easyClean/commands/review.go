@@ -37,7 +37,8 @@ The web interface allows you to:
 - Export results
 
 Multiple projects can run review servers simultaneously on different ports.
-Use --list to see all active servers, or --kill to stop a specific server.`,
+Use --list to see all active servers, or --kill to stop a specific server.
+Use --project to review a project without changing into its directory.`,
 	RunE: runReview,
 }
 
@@ -48,6 +49,8 @@ func init() {
 	reviewCmd.Flags().StringVar(&host, "host", "localhost", "HTTP server host")
 	reviewCmd.Flags().BoolVar(&noBrowser, "no-browser", false, "don't auto-open browser")
 	reviewCmd.Flags().StringVar(&scanFile, "scan-file", "", "load scan results from JSON file (default: scan-results.json)")
+	reviewCmd.Flags().BoolVar(&noVerify, "no-verify", false, "use scan results even if their signature does not match")
+	reviewCmd.Flags().StringVar(&projectDir, "project", "", "project directory to review (default: current directory)")
 	reviewCmd.Flags().BoolVar(&listServers, "list", false, "list all active review servers")
 	reviewCmd.Flags().IntVar(&killPort, "kill", 0, "stop server running on specified port")
 }
@@ -69,13 +72,14 @@ func runReview(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get project root
-	projectRoot, err := os.Getwd()
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
 
 	// Auto-discover scan file if not specified
-	if scanFile == "" {
+	cached := scanFile == ""
+	if cached {
 		// Get cache path for this project
 		cachePath, err := utils.GetScanResultsPath(projectRoot)
 		if err != nil {
@@ -101,10 +105,17 @@ func runReview(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if err := verifyScanFile(scanFile, cached); err != nil {
+		return err
+	}
+
 	result, err := loadScanResults(scanFile)
 	if err != nil {
 		return fmt.Errorf("failed to load scan results: %w", err)
 	}
+	if err := checkCompleteScan(result, false); err != nil {
+		return err
+	}
 
 	if !quiet {
 		totalToReview := result.Stats.UnusedCount + result.Stats.PotentiallyUnusedCount + result.Stats.NeedsReviewCount
@@ -127,7 +138,7 @@ func runReview(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create server
-	server, err := ui.NewReviewServer(result, host, actualPort)
+	server, err := ui.NewReviewServer(result, projectRoot, scanFile, host, actualPort, !noVerify)
 	if err != nil {
 		return fmt.Errorf("failed to create server: %w", err)
 	}
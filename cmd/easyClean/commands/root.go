@@ -1,9 +1,15 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/HabibPro1999/easyClean/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +18,9 @@ var (
 	verbose     bool
 	quiet       bool
 	noColor     bool
+	theme       string
+	noPager     bool
+	exitCode    bool
 	showVersion bool
 )
 
@@ -25,14 +34,73 @@ unused asset files (images, fonts, videos, etc.) from codebases.
 It uses smart scanning with multi-pattern reference detection and supports
 multiple project types (React, Vue, Flutter, iOS, Android).`,
 	Version: "1.0.1",
+	// Execute prints errors itself, once
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		applyCacheDir()
+		return ui.ConfigureColor(!noColor && ui.ShouldColor(os.Stdout), theme)
+	},
+}
+
+// Exit codes shared by scan --exit-code, delete --dry-run --exit-code, and check
+const (
+	exitClean       = 0 // nothing to clean up
+	exitUnused      = 1 // unused or potentially unused assets found
+	exitError       = 2 // the command could not run
+	exitNeedsReview = 3 // no unused assets, but some need manual review
+)
+
+// ExitError carries a specific process exit code out of a command.
+// A nil Err exits with Code without printing anything.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// applyCacheDir points the cache at the config file's cache_dir, if set. A
+// config that fails to load is left for the commands reading it to report.
+func applyCacheDir() {
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil || cfg.CacheDir == "" {
+		return
+	}
+	dir := cfg.CacheDir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(filepath.Dir(cfgFile), dir)
+	}
+	utils.SetCacheDir(dir)
 }
 
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		code, report := exitCodeOf(err)
+		if report {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(code)
+	}
+}
+
+// exitCodeOf returns the process exit code for a command's error, and
+// whether the error should be printed
+func exitCodeOf(err error) (int, bool) {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code, exitErr.Err != nil
 	}
+	return exitError, true
 }
 
 func init() {
@@ -40,7 +108,39 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", ".unusedassets.yaml", "config file path")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose logging")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress all output except errors")
-	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (also NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&exitCode, "exit-code", false, "exit 1 when unused assets are found, 3 when some need review (scan, image, bucket, delete --dry-run)")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "print long output directly instead of through $PAGER")
+	rootCmd.PersistentFlags().StringVar(&theme, "theme", ui.DefaultTheme, "color theme for the terminal background: dark, light")
+}
+
+// findingsExitCode maps assets to the exit-code contract: exitUnused when any
+// is unused or potentially unused, exitNeedsReview when some only need review
+func findingsExitCode(assets []models.AssetFile) int {
+	code := exitClean
+	for _, asset := range assets {
+		switch asset.Status {
+		case models.StatusUnused, models.StatusTransitivelyUnused, models.StatusPotentiallyUnused:
+			return exitUnused
+		case models.StatusNeedsManualReview:
+			code = exitNeedsReview
+		}
+	}
+	return code
+}
+
+// findingsExit returns a silent ExitError for the assets' findings, or nil
+// when --exit-code is off or there is nothing to report
+func findingsExit(cmd *cobra.Command, assets []models.AssetFile) error {
+	if !exitCode {
+		return nil
+	}
+	if code := findingsExitCode(assets); code != exitClean {
+		// Findings are results, not usage mistakes
+		cmd.SilenceUsage = true
+		return &ExitError{Code: code}
+	}
+	return nil
 }
 
 // GetConfigFile returns the config file path
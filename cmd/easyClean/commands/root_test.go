@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestFindingsExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []models.AssetStatus
+		want     int
+	}{
+		{"no assets", nil, exitClean},
+		{"all used", []models.AssetStatus{models.StatusUsed, models.StatusUsed}, exitClean},
+		{"unused", []models.AssetStatus{models.StatusUsed, models.StatusUnused}, exitUnused},
+		{"transitively unused", []models.AssetStatus{models.StatusTransitivelyUnused}, exitUnused},
+		{"potentially unused", []models.AssetStatus{models.StatusPotentiallyUnused}, exitUnused},
+		{"needs review", []models.AssetStatus{models.StatusUsed, models.StatusNeedsManualReview}, exitNeedsReview},
+		{"unused outranks review", []models.AssetStatus{models.StatusNeedsManualReview, models.StatusUnused}, exitUnused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var assets []models.AssetFile
+			for _, status := range tt.statuses {
+				assets = append(assets, models.AssetFile{Status: status})
+			}
+			if got := findingsExitCode(assets); got != tt.want {
+				t.Errorf("findingsExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   int
+		wantReport bool
+	}{
+		{"failure", errors.New("scan failed"), exitError, true},
+		{"silent findings", &ExitError{Code: exitUnused}, exitUnused, false},
+		{"review findings", &ExitError{Code: exitNeedsReview}, exitNeedsReview, false},
+		{"wrapped with message", fmt.Errorf("delete: %w", &ExitError{Code: exitUnused, Err: errors.New("refused")}), exitUnused, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, report := exitCodeOf(tt.err)
+			if code != tt.wantCode || report != tt.wantReport {
+				t.Errorf("exitCodeOf() = %d, %v, want %d, %v", code, report, tt.wantCode, tt.wantReport)
+			}
+		})
+	}
+}
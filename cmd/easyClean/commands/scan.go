@@ -1,29 +1,76 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/HabibPro1999/easyClean/internal/classifier"
 	"github.com/HabibPro1999/easyClean/internal/config"
 	"github.com/HabibPro1999/easyClean/internal/detector"
 	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/optimizer"
 	"github.com/HabibPro1999/easyClean/internal/scanner"
 	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/HabibPro1999/easyClean/internal/usage"
 	"github.com/HabibPro1999/easyClean/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 var (
-	extensions  []string
-	exclude     []string
-	outputFile  string
-	format      string
-	noProgress  bool
+	extensions   []string
+	exclude      []string
+	outputFile   string
+	format       string
+	noProgress   bool
+	sortBy       string
+	optimize     bool
+	maxDimension int
+	privacyScan  bool
+	discoverDirs bool
+	deadCode     bool
+	reachability bool
+	entryPoints  []string
+	emailScan    bool
+	publicURLs   []string
+	explainConf  bool
+	topN         int
+	batchFile    string
+	parallel     int
+	repoURL      string
+	repoRef      string
+	accessLogs   []string
+	usageFiles   []string
+	maxDepth     int
+	maxFiles     int
+	noCache      bool
+	templateFile string
+	scanProfile  string
+	resumeScan   bool
+	niceScan     bool
 )
 
+// batchThrottle is shared by the projects of a --batch --nice scan, so
+// parallel projects together stay within its read limit
+var batchThrottle *utils.Throttle
+
+// scanCheckpointing has performScan checkpoint the reference scan; only the
+// scan command sets it, so the scans of other commands run as before
+type scanCheckpointing struct {
+	ctx    context.Context
+	stop   context.CancelFunc // restores the default signal handling
+	resume bool
+}
+
+var checkpointing *scanCheckpointing
+
 // scanCmd represents the scan command
 var scanCmd = &cobra.Command{
 	Use:   "scan [directory]",
@@ -31,7 +78,19 @@ var scanCmd = &cobra.Command{
 	Long: `Scan scans a project directory recursively to find unused asset files.
 
 It identifies assets (images, fonts, videos, etc.) and searches for references
-in source code. Assets without references are marked as unused.`,
+in source code. Assets without references are marked as unused.
+
+With --batch, project directories are read one per line from a file (or stdin
+with "-"). Each project is scanned with its own config file and saved to its
+own cache, then an aggregate summary is printed.
+
+With --repo, a remote git repository is shallow-cloned into a temporary
+directory, scanned with its own config file, and removed afterwards. Nothing is
+cached, so export reports with --output.
+
+Reference scanning saves its progress as it goes. An interrupted scan
+(Ctrl+C, a CI timeout) continues with --resume, rereading only the
+directories it hadn't finished or whose files changed since.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runScan,
 }
@@ -42,16 +101,69 @@ func init() {
 	scanCmd.Flags().StringSliceVar(&extensions, "extensions", nil, "asset extensions to scan (e.g., .png,.jpg)")
 	scanCmd.Flags().StringSliceVar(&exclude, "exclude", nil, "paths to exclude (glob patterns)")
 	scanCmd.Flags().StringVarP(&outputFile, "output", "o", "", "export results to file (JSON/CSV based on extension)")
-	scanCmd.Flags().StringVarP(&format, "format", "f", "text", "output format: text, json, csv")
+	scanCmd.Flags().StringVarP(&format, "format", "f", "text", "output format: text, table, json, csv, template")
+	scanCmd.Flags().StringVar(&templateFile, "template", "", "Go text/template file applied to the scan result (with --format template)")
+	scanCmd.Flags().StringVar(&scanProfile, "profile", "", "apply a named entry of the config's profiles (e.g. quick, deep)")
 	scanCmd.Flags().BoolVar(&noProgress, "no-progress", false, "disable progress bar")
+	scanCmd.Flags().BoolVar(&noCache, "no-cache", false, "don't save results to the cache (export with --format json --output for --scan-file)")
+	scanCmd.Flags().BoolVar(&optimize, "optimize", false, "report compression and resize suggestions for used images")
+	scanCmd.Flags().IntVar(&maxDimension, "max-dimension", optimizer.DefaultMaxDimension, "longest image edge in pixels before suggesting a resize (with --optimize)")
+	scanCmd.Flags().BoolVar(&explainConf, "explain-confidence", false, "summarize reference types and confidences behind each status")
+	scanCmd.Flags().BoolVar(&privacyScan, "privacy", false, "flag images containing EXIF GPS coordinates or camera serials")
+	scanCmd.Flags().StringVar(&sortBy, "sort", "", "sort assets by: path, size, staleness, status")
+	scanCmd.Flags().IntVar(&topN, "top", 0, "list at most N assets per section (default 10 for text, all for table)")
+	scanCmd.Flags().BoolVar(&reachability, "reachability", false, "treat assets used only by files unreachable from entry points as unused")
+	scanCmd.Flags().StringSliceVar(&entryPoints, "entry", nil, "entry point globs for --reachability (e.g. src/main.tsx,src/sw.ts)")
+	scanCmd.Flags().BoolVar(&emailScan, "email", false, "scan email templates (.mjml, background-url, <td background>)")
+	scanCmd.Flags().StringSliceVar(&publicURLs, "public-url", nil, "base URLs public assets are served from (e.g. https://cdn.example.com/)")
+	scanCmd.Flags().BoolVar(&deadCode, "dead-code", false, "ignore references inside unreachable code")
+	scanCmd.Flags().StringVar(&batchFile, "batch", "", "scan the project directories listed one per line in a file (- for stdin)")
+	scanCmd.Flags().IntVar(&parallel, "parallel", 1, "number of projects to scan at once with --batch")
+	scanCmd.Flags().StringSliceVar(&accessLogs, "access-log", nil, "access logs or CSVs of requested paths; flag assets production never requested")
+	scanCmd.Flags().StringSliceVar(&usageFiles, "usage", nil, "runtime usage beacon files (see collect); used assets never rendered become potentially unused")
+	scanCmd.Flags().StringVar(&repoURL, "repo", "", "shallow-clone and scan a remote git repository")
+	scanCmd.Flags().StringVar(&repoRef, "ref", "", "branch or tag to clone with --repo (default: remote HEAD)")
+	scanCmd.Flags().IntVar(&maxDepth, "max-depth", 0, "directory levels below the root to walk (overrides max_depth)")
+	scanCmd.Flags().IntVar(&maxFiles, "max-files", 0, "files a walk visits before stopping (overrides max_files)")
+	scanCmd.Flags().BoolVar(&discoverDirs, "discover-paths", false, "discover asset directories by counting asset files instead of using conventions")
+	scanCmd.Flags().BoolVar(&resumeScan, "resume", false, "continue an interrupted scan from its checkpoint")
+	scanCmd.Flags().BoolVar(&niceScan, "nice", false, "pause between directories (unless io_throttle is set); with --batch, read one file at a time across all projects")
 }
 
 func runScan(cmd *cobra.Command, args []string) error {
+	if resumeScan && (batchFile != "" || repoURL != "") {
+		return fmt.Errorf("--resume cannot be combined with --batch or --repo")
+	}
+	if batchFile != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--batch cannot be combined with a directory argument")
+		}
+		return runBatchScan(cmd)
+	}
+
 	// Determine project root
 	projectRoot := "."
 	if len(args) > 0 {
 		projectRoot = args[0]
 	}
+	configPath := cfgFile
+	if repoURL != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--repo cannot be combined with a directory argument")
+		}
+		cloneDir, err := cloneRepo(repoURL, repoRef)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(cloneDir)
+		projectRoot = cloneDir
+		// The remote repository's own config applies unless --config is given
+		if !cmd.Flags().Changed("config") {
+			configPath = filepath.Join(cloneDir, cfgFile)
+		}
+	} else if repoRef != "" {
+		return fmt.Errorf("--ref requires --repo")
+	}
 
 	// Make path absolute
 	absRoot, err := filepath.Abs(projectRoot)
@@ -65,12 +177,90 @@ func runScan(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load configuration from file or use defaults
-	cfg, err := config.LoadConfig(cfgFile)
+	cfg, err := config.LoadConfigProfile(configPath, scanProfile)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	// Override with command-line flags
+	if err := applyScanFlags(cfg); err != nil {
+		return err
+	}
+
+	// Parse the report template up front so a typo doesn't cost a whole scan
+	var reportTemplate *ui.ReportTemplate
+	if format == "template" {
+		if templateFile == "" {
+			return fmt.Errorf("--format template requires --template")
+		}
+		if reportTemplate, err = ui.LoadReportTemplate(templateFile); err != nil {
+			return err
+		}
+	} else if templateFile != "" {
+		return fmt.Errorf("--template requires --format template")
+	}
+
+	// Print header
+	if !quiet {
+		ui.PrintHeader("easyClean", "1.0.1")
+	}
+
+	// A cloned repository is gone by the next run, so it has nothing to resume
+	if repoURL == "" {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		checkpointing = &scanCheckpointing{ctx: ctx, stop: stop, resume: resumeScan}
+		defer func() { checkpointing = nil }()
+	}
+
+	result, err := performScan(absRoot, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Display results based on format
+	var displayErr error
+	switch format {
+	case "template":
+		displayErr = outputTemplate(result, reportTemplate, outputFile)
+	case "json":
+		displayErr = outputJSON(result, outputFile)
+	case "csv":
+		displayErr = outputCSV(result, outputFile)
+	case "table":
+		displayErr = outputTable(result, outputFile)
+	default:
+		displayErr = outputText(result, outputFile)
+	}
+
+	// Always auto-save JSON results to cache for review/delete commands; a
+	// cloned repository is removed on exit, so its results have nowhere to point
+	if repoURL != "" {
+		if !quiet && outputFile == "" {
+			fmt.Printf("\nℹ️  Results for %s are not cached; use --output to keep a report\n", repoURL)
+		}
+	} else if noCache {
+		if !quiet && outputFile == "" {
+			fmt.Printf("\nℹ️  Results are not cached (--no-cache); use --format json --output and pass the file to review/delete with --scan-file\n")
+		}
+	} else if cachePath, err := saveScanCache(result); err != nil {
+		if !quiet {
+			fmt.Printf("\n⚠️  Warning: %v\n", err)
+		}
+	} else if !quiet {
+		fmt.Printf("\n💾 Scan results saved to cache:\n")
+		fmt.Printf("   %s\n", cachePath)
+		fmt.Printf("   Use 'asset-cleaner review' or 'asset-cleaner delete' to proceed\n")
+	}
+
+	if displayErr != nil {
+		return displayErr
+	}
+	return findingsExit(cmd, result.Assets)
+}
+
+// applyScanFlags overrides config values with the scan command-line flags
+func applyScanFlags(cfg *models.ProjectConfig) error {
 	if len(extensions) > 0 {
 		cfg.Extensions = extensions
 	}
@@ -78,12 +268,233 @@ func runScan(cmd *cobra.Command, args []string) error {
 		cfg.ExcludePaths = exclude
 	}
 	cfg.ShowProgress = !noProgress && !quiet
+	if topN < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+	if topN > 0 {
+		ui.MaxDisplayedAssets = topN
+	}
+	if maxDepth < 0 || maxFiles < 0 {
+		return fmt.Errorf("--max-depth and --max-files must not be negative")
+	}
+	if maxDepth > 0 {
+		cfg.MaxDepth = maxDepth
+	}
+	if maxFiles > 0 {
+		cfg.MaxFiles = maxFiles
+	}
+	if niceScan && cfg.IOThrottle.IsZero() {
+		cfg.IOThrottle = models.NiceIOThrottle
+	}
+	if !cfg.ColorOutput {
+		ui.DisableColor()
+	}
+	if privacyScan {
+		cfg.PrivacyScan = true
+	}
+	if discoverDirs {
+		cfg.DiscoverAssetPaths = true
+	}
+	if deadCode {
+		cfg.DeadCodeAnalysis = true
+	}
+	if reachability {
+		cfg.ReachabilityAnalysis = true
+	}
+	if len(entryPoints) > 0 {
+		cfg.EntryPoints = entryPoints
+		cfg.ReachabilityAnalysis = true
+	}
+	if emailScan {
+		cfg.EmailTemplates = true
+	}
+	if len(publicURLs) > 0 {
+		cfg.PublicBaseURLs = publicURLs
+	}
+	// Runtime evidence files given on the command line are relative to the
+	// working directory, not the project
+	if len(accessLogs) > 0 {
+		logs, err := absPaths(accessLogs)
+		if err != nil {
+			return fmt.Errorf("failed to resolve access log path: %w", err)
+		}
+		cfg.AccessLogs = logs
+	}
+	if len(usageFiles) > 0 {
+		files, err := absPaths(usageFiles)
+		if err != nil {
+			return fmt.Errorf("failed to resolve usage file path: %w", err)
+		}
+		cfg.UsageFiles = files
+	}
+	return nil
+}
 
-	// Print header
+// checkFilesystem probes how fast absRoot answers stat calls. On a network
+// or slow filesystem, reads are capped where io_throttle sets no limit and
+// a warning is returned.
+func checkFilesystem(absRoot string, cfg *models.ProjectConfig) string {
+	latency, slow := utils.ProbeFilesystem(absRoot)
+	if !slow {
+		return ""
+	}
+	if cfg.IOThrottle.IsZero() {
+		cfg.IOThrottle = models.NetworkIOThrottle
+	}
+	return fmt.Sprintf("%s looks like a network or slow filesystem (%s per stat), so this scan may take a while; "+
+		"for repeated scans keep results warm with 'easyClean daemon', and continue interrupted ones with 'easyClean scan --resume'",
+		absRoot, latency.Round(time.Microsecond))
+}
+
+// scanThrottle returns the throttle a scan with cfg reads files within
+func scanThrottle(cfg *models.ProjectConfig) *utils.Throttle {
+	if batchThrottle != nil {
+		return batchThrottle
+	}
+	return utils.NewThrottle(cfg.IOThrottle.MaxConcurrentReads, time.Duration(cfg.IOThrottle.DirectoryPauseMs)*time.Millisecond)
+}
+
+// absPaths makes each path absolute against the working directory
+func absPaths(paths []string) ([]string, error) {
+	abs := make([]string, len(paths))
+	for i, p := range paths {
+		a, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		abs[i] = a
+	}
+	return abs, nil
+}
+
+// projectPaths resolves config paths against the project root
+func projectPaths(root string, paths []string) []string {
+	resolved := make([]string, len(paths))
+	for i, p := range paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(root, p)
+		}
+		resolved[i] = p
+	}
+	return resolved
+}
+
+// cloneRepo shallow-clones url into a new temporary directory and returns it
+func cloneRepo(url, ref string) (string, error) {
+	dir, err := os.MkdirTemp("", "easyclean-repo-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create clone directory: %w", err)
+	}
 	if !quiet {
-		ui.PrintHeader("easyClean", "1.0.1")
+		fmt.Printf("📥 Cloning %s...\n", url)
+	}
+	if err := utils.GitShallowClone(url, ref, dir); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// saveScanCache writes the result and its reference index to the project's
+// cache for review/delete, returning the results path
+func saveScanCache(result *models.ScanResult) (string, error) {
+	cachePath, err := utils.GetScanResultsPath(result.ProjectRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache path: %w", err)
+	}
+	if err := utils.EnsureCacheDirExists(filepath.Dir(cachePath)); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := autoSaveJSON(result, cachePath); err != nil {
+		return "", fmt.Errorf("failed to save results to cache: %w", err)
+	}
+	if err := saveReferenceIndex(result); err != nil {
+		return "", fmt.Errorf("failed to save reference index: %w", err)
+	}
+	if err := saveScanHistory(result); err != nil {
+		return "", fmt.Errorf("failed to save scan history: %w", err)
+	}
+	return cachePath, nil
+}
+
+// saveScanHistory stores a timestamped copy of the result for the review UI
+// to switch between, then prunes history past the configured retention
+func saveScanHistory(result *models.ScanResult) error {
+	historyDir, err := utils.GetScanHistoryDir(result.ProjectRoot)
+	if err != nil {
+		return err
+	}
+
+	keep, maxAgeDays := config.DefaultHistoryLimit, 0
+	if result.Config != nil {
+		keep, maxAgeDays = result.Config.HistoryLimit, result.Config.HistoryMaxAgeDays
+	}
+	if keep > 0 {
+		if err := utils.EnsureCacheDirExists(historyDir); err != nil {
+			return err
+		}
+		if err := autoSaveJSON(result, utils.ScanHistoryPath(historyDir, result.Timestamp)); err != nil {
+			return err
+		}
+	}
+	return utils.PruneScanHistory(historyDir, keep, time.Duration(maxAgeDays)*24*time.Hour)
+}
+
+// markUnreachableReferences builds the module graph from the project's entry
+// points and flags references made by files none of them reach, returning
+// the importer chain of each such file
+func markUnreachableReferences(absRoot string, projectType models.ProjectType, configured, keepPaths []string, finder *scanner.ReferenceFinder, references map[string][]*models.Reference) (map[string][]string, error) {
+	graph, err := finder.BuildModuleGraph()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build module graph: %w", err)
+	}
+
+	entries := detector.EntryPoints(absRoot, projectType, configured)
+	reachable := graph.Reachable(entries)
+	if len(reachable) > 0 && len(keepPaths) > 0 {
+		// Kept files are used by definition, and so is what they import
+		reachable = graph.Reachable(append(entries, keepPaths...))
+	}
+	if len(reachable) == 0 {
+		// Without a known entry point every file would look unreachable
+		if !quiet {
+			fmt.Println("⚠️  No entry points found; skipping reachability analysis")
+		}
+		return nil, nil
+	}
+
+	marked := graph.MarkUnreachable(references, reachable)
+	if !quiet {
+		fmt.Printf("✓ Reached %d of %d modules from entry points (%d references from unreachable files)\n",
+			len(reachable), len(graph.Files()), marked)
 	}
+	return graph.UnreachableChains(references), nil
+}
+
+// openScanCheckpoint opens the project's reference scan checkpoint, loading
+// it with --resume when the config and assets still match it
+func openScanCheckpoint(absRoot string, cfg *models.ProjectConfig, assets []models.AssetFile) (*scanner.Checkpoint, error) {
+	dir, err := utils.GetCheckpointDir(absRoot)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := scanner.OpenCheckpoint(dir, scanner.CheckpointFingerprint(cfg, assets), checkpointing.resume)
+	if err != nil {
+		return nil, err
+	}
+	if checkpointing.resume && !quiet {
+		if restored := checkpoint.Restored(); restored > 0 {
+			fmt.Printf("✓ Resuming from checkpoint: %d directories already scanned\n", restored)
+		} else {
+			fmt.Println("ℹ️  No checkpoint matches this project and config; scanning from the start")
+		}
+	}
+	return checkpoint, nil
+}
 
+// performScan runs the full detection pipeline on absRoot and returns the classified result.
+// Progress is printed unless --quiet is set.
+func performScan(absRoot string, cfg *models.ProjectConfig) (*models.ScanResult, error) {
 	// Detect project type
 	if !quiet {
 		fmt.Println("\n🔍 Detecting project type...")
@@ -96,24 +507,60 @@ func runScan(cmd *cobra.Command, args []string) error {
 	// Adjust config based on project type
 	if cfg.AutoDetectProjectType && projectType != models.ProjectTypeUnknown {
 		cfg.AssetPaths = config.DefaultAssetPathsForProjectType(projectType)
+		// Build output names every asset it was built from
+		for _, exclude := range config.ExcludePathsForProjectType(projectType) {
+			if !slices.Contains(cfg.ExcludePaths, exclude) {
+				cfg.ExcludePaths = append(cfg.ExcludePaths, exclude)
+			}
+		}
+	}
+
+	// Replace conventional asset paths with directories discovered in the tree
+	if cfg.DiscoverAssetPaths {
+		if discovered := detector.DiscoverAssetPaths(absRoot, cfg.Extensions, cfg.ExcludePaths, cfg.DiscoveryMinFiles); len(discovered) > 0 {
+			cfg.AssetPaths = discovered
+			if !quiet {
+				fmt.Printf("✓ Discovered asset paths: %s\n", strings.Join(discovered, ", "))
+			}
+		}
 	}
 
 	// Start scan
 	startTime := time.Now()
 
+	// Warn up front rather than after a half-hour walk of a network mount.
+	// The results aren't incomplete, so this isn't one of their warnings.
+	if fsWarning := checkFilesystem(absRoot, cfg); fsWarning != "" && !quiet {
+		fmt.Printf("\n⚠️  Warning: %s\n", fsWarning)
+	}
+
 	if !quiet {
 		fmt.Println("\n📁 Scanning asset directories...")
 	}
 
-	// Find assets
+	// Walk the project once for both assets and source files
+	tree, err := scanner.WalkTree(absRoot, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk project: %w", err)
+	}
+
+	// Find assets; they are read within the same limits as source files
+	throttle := scanThrottle(cfg)
 	assetFinder := scanner.NewAssetFinder(absRoot, cfg)
+	assetFinder.SetFileTree(tree)
+	assetFinder.SetThrottle(throttle)
 	assets, err := assetFinder.FindAssets()
 	if err != nil {
-		return fmt.Errorf("failed to scan assets: %w", err)
+		return nil, fmt.Errorf("failed to scan assets: %w", err)
 	}
 
+	discoveryTime := time.Since(startTime)
+
 	if !quiet {
 		fmt.Printf("✓ Found %d asset files\n", len(assets))
+		for _, dirCfg := range tree.DirConfigs() {
+			fmt.Printf("  Applied %s/%s\n", dirCfg.Dir, scanner.DirConfigFileName)
+		}
 	}
 
 	// Find references
@@ -122,14 +569,58 @@ func runScan(cmd *cobra.Command, args []string) error {
 	}
 
 	referenceFinder := scanner.NewReferenceFinder(absRoot, cfg)
+	referenceFinder.SetFileTree(tree)
+	referenceFinder.SetAssetIndex(scanner.NewAssetIndex(assets))
+	referenceFinder.SetThrottle(throttle)
+	var checkpoint *scanner.Checkpoint
+	if checkpointing != nil {
+		if checkpoint, err = openScanCheckpoint(absRoot, cfg, assets); err != nil {
+			return nil, err
+		}
+		referenceFinder.SetContext(checkpointing.ctx)
+		referenceFinder.SetCheckpoint(checkpoint)
+	}
 	references, err := referenceFinder.FindReferences()
+	if checkpointing != nil {
+		checkpointing.stop()
+	}
+	if errors.Is(err, context.Canceled) {
+		return nil, fmt.Errorf("scan interrupted; run 'easyClean scan --resume' to continue from the checkpoint")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to scan references: %w", err)
+		return nil, fmt.Errorf("failed to scan references: %w", err)
+	}
+	if checkpoint != nil {
+		if err := checkpoint.Remove(); err != nil && !quiet {
+			fmt.Printf("⚠️  Warning: failed to remove scan checkpoint: %v\n", err)
+		}
+	}
+	// Lottie animations load the image files listed in their assets
+	for path, refs := range assetFinder.LottieReferences() {
+		references[path] = append(references[path], refs...)
 	}
 
 	if !quiet {
 		fmt.Printf("✓ Found %d references\n", len(references))
 	}
+	warnings := tree.Warnings()
+	if !quiet {
+		for _, warning := range warnings {
+			fmt.Printf("⚠️  Warning: %s\n", warning)
+		}
+	}
+
+	// Flag references from source files no entry point reaches
+	keepPaths := append(append([]string{}, cfg.KeepPaths...), tree.KeepPaths()...)
+	var unreachableChains map[string][]string
+	if cfg.ReachabilityAnalysis {
+		unreachableChains, err = markUnreachableReferences(absRoot, projectType, cfg.EntryPoints, keepPaths, referenceFinder, references)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	referencesTime := time.Since(startTime) - discoveryTime
 
 	// Match references to assets
 	assets = classifier.MatchReferencesToAssets(assets, references)
@@ -137,6 +628,63 @@ func runScan(cmd *cobra.Command, args []string) error {
 	// Classify assets
 	assets = classifier.ClassifyAssets(assets)
 
+	// Honor easyclean:keep annotations and .easycleankeep sidecar files
+	keepPatterns := append(assetFinder.KeepPatterns(), referenceFinder.KeepPatterns()...)
+	assets = classifier.ApplyKeepRules(assets, keepPatterns)
+
+	// Files requested by well-known path (favicon.ico, robots.txt, .well-known/)
+	conventional := append(append([]string{}, scanner.ConventionalFiles...), cfg.ConventionalFiles...)
+	assets = classifier.ApplyConventionRules(assets, conventional)
+
+	// Assign feature ownership for per-feature reporting
+	assets = classifier.AssignFeatures(assets, cfg.Features)
+
+	// Apply license/attribution rules
+	licenses, err := config.LoadLicenses(filepath.Join(absRoot, cfg.LicenseFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load licenses: %w", err)
+	}
+	assets = classifier.AssignLicenses(assets, licenses)
+
+	// Downgrade used assets runtime beacons never saw rendered
+	if len(cfg.UsageFiles) > 0 {
+		files := projectPaths(absRoot, cfg.UsageFiles)
+		renders, err := usage.ReadBeacons(files)
+		if err != nil {
+			return nil, err
+		}
+		assets = classifier.ApplyRenderCounts(assets, renders)
+		if !quiet {
+			fmt.Printf("✓ Read %d rendered paths from %d usage files\n", len(renders), len(files))
+		}
+	}
+
+	// Record production requests from access logs
+	if len(cfg.AccessLogs) > 0 {
+		logs := projectPaths(absRoot, cfg.AccessLogs)
+		requests, err := usage.ReadAccessLogs(logs)
+		if err != nil {
+			return nil, err
+		}
+		assets = classifier.ApplyRequestCounts(assets, requests)
+		if !quiet {
+			fmt.Printf("✓ Read %d requested paths from %d access logs\n", len(requests), len(logs))
+		}
+	}
+
+	// Apply configured status rules, then keep_paths, so project policy wins
+	assets = classifier.ApplyStatusRules(assets, cfg.StatusRules)
+	assets = classifier.ApplyConfigKeepPaths(assets, keepPaths)
+
+	// Assets used only by unused assets or unreachable files are unused too.
+	// Policy comes first so a kept stylesheet doesn't make its images unused,
+	// and keep_paths apply again to the assets this marks.
+	assets = classifier.ApplyTransitiveUnused(assets, unreachableChains)
+	assets = classifier.ApplyConfigKeepPaths(assets, keepPaths)
+
+	// Score staleness from git history (falls back to file mtime)
+	assets = classifier.ScoreStaleness(assets, loadGitHistory(absRoot), time.Now())
+
 	// Create scan result
 	duration := time.Since(startTime)
 	result := &models.ScanResult{
@@ -146,62 +694,95 @@ func runScan(cmd *cobra.Command, args []string) error {
 		Duration:    duration.Milliseconds(),
 		Assets:      assets,
 		Config:      cfg,
+
+		LicensesTracked: licenses != nil,
+		RequestsTracked: len(cfg.AccessLogs) > 0,
+		RendersTracked:  len(cfg.UsageFiles) > 0,
+		Warnings:        warnings,
+	}
+	result.Stats.FilesScanned = referenceFinder.FilesScanned()
+	result.Stats.BytesRead = referenceFinder.BytesRead()
+	result.Stats.Phases = models.PhaseDurations{
+		Discovery:      discoveryTime.Milliseconds(),
+		References:     referencesTime.Milliseconds(),
+		Classification: (duration - discoveryTime - referencesTime).Milliseconds(),
+	}
+	if verbose && !quiet {
+		fmt.Printf("✓ Read %d source files (%s): discovery %s, references %s, classification %s\n",
+			result.Stats.FilesScanned, ui.FormatBytes(result.Stats.BytesRead),
+			discoveryTime.Round(time.Millisecond), referencesTime.Round(time.Millisecond),
+			(duration - discoveryTime - referencesTime).Round(time.Millisecond))
+	}
+
+	// Optional optimization pass over used images
+	if optimize {
+		result.Optimizations = optimizer.Analyze(assets, maxDimension)
 	}
 
-	// Compute statistics
+	// Map statuses to severities, then compute statistics
+	result.AssignSeverities()
 	result.ComputeStatistics()
+	result.SortAssets(sortBy)
 	result.PopulateFilteredLists()
 
-	// Display results based on format
-	var displayErr error
-	switch format {
-	case "json":
-		displayErr = outputJSON(result, outputFile)
-	case "csv":
-		displayErr = outputCSV(result, outputFile)
-	default:
-		displayErr = outputText(result, outputFile)
+	// Optional calibration report of the references behind each status
+	if explainConf {
+		result.ConfidenceReport = result.ConfidenceBreakdown()
 	}
 
-	// Always auto-save JSON results to cache for review/delete commands
-	cachePath, err := utils.GetScanResultsPath(absRoot)
+	return result, nil
+}
+
+// saveReferenceIndex persists the asset/source reference index next to the cached results
+func saveReferenceIndex(result *models.ScanResult) error {
+	indexPath, err := utils.GetReferenceIndexPath(result.ProjectRoot)
 	if err != nil {
-		if !quiet {
-			fmt.Printf("\n⚠️  Warning: Failed to get cache path: %v\n", err)
-		}
-	} else {
-		// Ensure cache directory exists
-		cacheDir := filepath.Dir(cachePath)
-		if err := utils.EnsureCacheDirExists(cacheDir); err != nil {
-			if !quiet {
-				fmt.Printf("\n⚠️  Warning: Failed to create cache directory: %v\n", err)
-			}
-		} else {
-			// Save to cache
-			if err := autoSaveJSON(result, cachePath); err != nil {
-				if !quiet {
-					fmt.Printf("\n⚠️  Warning: Failed to save results to cache: %v\n", err)
-				}
-			} else if !quiet {
-				fmt.Printf("\n💾 Scan results saved to cache:\n")
-				fmt.Printf("   %s\n", cachePath)
-				fmt.Printf("   Use 'asset-cleaner review' or 'asset-cleaner delete' to proceed\n")
-			}
-		}
+		return err
 	}
+	return utils.WriteCacheFile(indexPath, false, models.BuildReferenceIndex(result).Encode)
+}
 
-	return displayErr
+// loadGitHistory returns last commit times for files in the project,
+// or nil when the project is not a git repository
+func loadGitHistory(root string) map[string]time.Time {
+	if !utils.IsGitRepository(root) {
+		return nil
+	}
+
+	history, err := utils.GitLastCommitTimes(root)
+	if err != nil {
+		if verbose && !quiet {
+			fmt.Printf("⚠️  Warning: %v (using file modification times)\n", err)
+		}
+		return nil
+	}
+	return history
 }
 
 func outputText(result *models.ScanResult, file string) error {
+	if file != "" {
+		ui.DisableColor()
+	}
 	output := ui.FormatScanResult(result)
 
 	if file != "" {
 		return os.WriteFile(file, []byte(output), 0644)
 	}
 
-	fmt.Println(output)
-	return nil
+	return ui.PrintPaged(output+"\n", !noPager)
+}
+
+func outputTable(result *models.ScanResult, file string) error {
+	if file != "" {
+		ui.DisableColor()
+	}
+	output := ui.FormatTable(result.Assets, topN)
+
+	if file != "" {
+		return os.WriteFile(file, []byte(output), 0644)
+	}
+
+	return ui.PrintPaged(output, !noPager)
 }
 
 func outputJSON(result *models.ScanResult, file string) error {
@@ -218,6 +799,20 @@ func outputJSON(result *models.ScanResult, file string) error {
 	return nil
 }
 
+func outputTemplate(result *models.ScanResult, tmpl *ui.ReportTemplate, file string) error {
+	output, err := tmpl.Render(result)
+	if err != nil {
+		return err
+	}
+
+	if file != "" {
+		return os.WriteFile(file, []byte(output), 0644)
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
 func outputCSV(result *models.ScanResult, file string) error {
 	data, err := result.ToCSV()
 	if err != nil {
@@ -232,11 +827,17 @@ func outputCSV(result *models.ScanResult, file string) error {
 	return nil
 }
 
-// autoSaveJSON saves scan results to JSON file silently (for review/delete commands)
+// autoSaveJSON streams scan results to the cache file silently (for review/delete commands)
 func autoSaveJSON(result *models.ScanResult, filename string) error {
-	data, err := result.ToJSON()
+	compress := result.Config != nil && result.Config.CompressCache
+	if err := utils.WriteCacheFile(filename, compress, result.WriteJSON); err != nil {
+		return err
+	}
+
+	// Signed so delete and review can refuse a tampered or truncated file
+	key, err := utils.GetIntegrityKey()
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filename, data, 0644)
+	return utils.SignFile(filename, key)
 }
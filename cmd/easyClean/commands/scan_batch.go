@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// batchProject is one project's line in the aggregate batch summary
+type batchProject struct {
+	Project     string `json:"project"`
+	TotalAssets int    `json:"total_assets"`
+	Unused      int    `json:"unused_count"`
+	NeedsReview int    `json:"needs_review_count"`
+	UnusedSize  int64  `json:"unused_size_bytes"`
+	CachePath   string `json:"cache_path,omitempty"`
+	Error       string `json:"error,omitempty"`
+
+	assets []models.AssetFile
+}
+
+// batchSummary is the aggregate result of scan --batch
+type batchSummary struct {
+	Projects    []*batchProject `json:"projects"`
+	Scanned     int             `json:"scanned"`
+	Failed      int             `json:"failed"`
+	TotalAssets int             `json:"total_assets"`
+	Unused      int             `json:"unused_count"`
+	UnusedSize  int64           `json:"unused_size_bytes"`
+}
+
+// runBatchScan scans every project directory listed in batchFile ("-" for
+// stdin), saving each result to its own cache and printing an aggregate summary
+func runBatchScan(cmd *cobra.Command) error {
+	if parallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1")
+	}
+	if outputFile != "" {
+		return fmt.Errorf("--output cannot be used with --batch; results are saved to each project's cache")
+	}
+
+	var in io.Reader = os.Stdin
+	if batchFile != "-" {
+		f, err := os.Open(batchFile)
+		if err != nil {
+			return fmt.Errorf("failed to open batch file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	dirs, err := readBatchList(in)
+	if err != nil {
+		return fmt.Errorf("failed to read batch list: %w", err)
+	}
+	if len(dirs) == 0 {
+		return fmt.Errorf("no project directories given to --batch")
+	}
+
+	if niceScan {
+		nice := models.NiceIOThrottle
+		batchThrottle = utils.NewThrottle(nice.MaxConcurrentReads, time.Duration(nice.DirectoryPauseMs)*time.Millisecond)
+		defer func() { batchThrottle = nil }()
+	}
+
+	// Per-project progress would interleave between workers
+	wasQuiet := quiet
+	quiet = true
+	projects := make([]*batchProject, len(dirs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallel && w < len(dirs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				projects[i] = scanBatchProject(cmd, dirs[i])
+			}
+		}()
+	}
+	for i := range dirs {
+		if !wasQuiet && format == "text" {
+			fmt.Fprintf(os.Stderr, "🔍 [%d/%d] %s\n", i+1, len(dirs), dirs[i])
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	quiet = wasQuiet
+
+	summary := &batchSummary{Projects: projects}
+	code := exitClean
+	for _, p := range projects {
+		if p.Error != "" {
+			summary.Failed++
+			continue
+		}
+		summary.Scanned++
+		summary.TotalAssets += p.TotalAssets
+		summary.Unused += p.Unused
+		summary.UnusedSize += p.UnusedSize
+		if c := findingsExitCode(p.assets); c > code {
+			code = c
+		}
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to generate JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if !quiet {
+		fmt.Print(formatBatchSummary(summary))
+	}
+
+	if summary.Failed > 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: exitError, Err: fmt.Errorf("%d of %d projects failed to scan", summary.Failed, len(projects))}
+	}
+	if exitCode && code != exitClean {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: code}
+	}
+	return nil
+}
+
+// readBatchList returns the non-empty lines of r, skipping # comments
+func readBatchList(r io.Reader) ([]string, error) {
+	var dirs []string
+	lines := bufio.NewScanner(r)
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dirs = append(dirs, line)
+	}
+	return dirs, lines.Err()
+}
+
+// loadProjectConfig loads the config file of the project at absRoot; an
+// explicit --config applies to every project instead
+func loadProjectConfig(cmd *cobra.Command, absRoot string) (*models.ProjectConfig, error) {
+	cfgPath := cfgFile
+	if !cmd.Flags().Changed("config") {
+		cfgPath = filepath.Join(absRoot, cfgFile)
+	}
+	cfg, err := config.LoadConfigProfile(cfgPath, scanProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// scanBatchProject scans one project of a batch with the project's own
+// config file, saving the result to its cache
+func scanBatchProject(cmd *cobra.Command, dir string) *batchProject {
+	p := &batchProject{Project: dir}
+	fail := func(err error) *batchProject {
+		p.Error = err.Error()
+		return p
+	}
+
+	absRoot, err := filepath.Abs(dir)
+	if err != nil {
+		return fail(fmt.Errorf("failed to resolve project path: %w", err))
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return fail(fmt.Errorf("directory does not exist: %s", absRoot))
+	}
+
+	cfg, err := loadProjectConfig(cmd, absRoot)
+	if err != nil {
+		return fail(err)
+	}
+	if err := applyScanFlags(cfg); err != nil {
+		return fail(err)
+	}
+	cfg.ShowProgress = false
+
+	result, err := performScan(absRoot, cfg)
+	if err != nil {
+		return fail(err)
+	}
+	if !noCache {
+		cachePath, err := saveScanCache(result)
+		if err != nil {
+			return fail(err)
+		}
+		p.CachePath = cachePath
+	}
+
+	p.TotalAssets = result.Stats.TotalAssets
+	p.Unused = result.Stats.UnusedCount
+	p.NeedsReview = result.Stats.NeedsReviewCount
+	p.UnusedSize = result.Stats.UnusedSize
+	p.assets = result.Assets
+	return p
+}
+
+// formatBatchSummary renders the aggregate batch summary as a table
+func formatBatchSummary(summary *batchSummary) string {
+	width := len("PROJECT")
+	for _, p := range summary.Projects {
+		if len(p.Project) > width {
+			width = len(p.Project)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n" + ui.Colorize(ui.RoleHeading, "📦 Batch Summary") + "\n\n")
+	sb.WriteString(fmt.Sprintf("%-*s  %7s  %7s  %10s\n", width, "PROJECT", "ASSETS", "UNUSED", "SAVINGS"))
+	for _, p := range summary.Projects {
+		if p.Error != "" {
+			sb.WriteString(fmt.Sprintf("%-*s  %s\n", width, p.Project, ui.Colorize(ui.RoleNegative, "error: "+p.Error)))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%-*s  %7d  %7d  %10s\n", width, p.Project, p.TotalAssets, p.Unused, ui.FormatBytes(p.UnusedSize)))
+	}
+	sb.WriteString(fmt.Sprintf("\n✓ %d scanned, %d failed: %d unused of %d assets (%s)\n",
+		summary.Scanned, summary.Failed, summary.Unused, summary.TotalAssets, ui.FormatBytes(summary.UnusedSize)))
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+	"github.com/HabibPro1999/easyClean/internal/scanner"
+	"github.com/spf13/cobra"
+)
+
+var extraPatterns []string
+
+// testPatternCmd represents the test-pattern command
+var testPatternCmd = &cobra.Command{
+	Use:   "test-pattern [file]",
+	Short: "Show how reference patterns match a source file or snippet",
+	Long: `Test-pattern runs every active reference pattern over a source file (or
+stdin when no file is given, or the file is "-") and shows each match with
+its capture groups, type, confidence, and the asset path it resolves to.
+
+Use it when adding custom_patterns or to find out why a reference was not
+detected. Patterns passed with --pattern are tried alongside the config.
+
+Examples:
+  easyClean test-pattern src/App.tsx
+  echo 'icon("close.svg")' | easyClean test-pattern --pattern 'icon\("([^"]+)"\)'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTestPattern,
+}
+
+func init() {
+	rootCmd.AddCommand(testPatternCmd)
+
+	testPatternCmd.Flags().StringArrayVarP(&extraPatterns, "pattern", "p", nil, "extra regex to test (repeatable)")
+}
+
+func runTestPattern(cmd *cobra.Command, args []string) error {
+	root, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if _, err := parser.CompileCustomPatterns(extraPatterns); err != nil {
+		return err
+	}
+	cfg.CustomPatterns = append(cfg.CustomPatterns, extraPatterns...)
+
+	// Read the sample
+	var input io.Reader = os.Stdin
+	source, dir := "stdin", root
+	if len(args) == 1 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open source file: %w", err)
+		}
+		defer file.Close()
+		input = file
+		source = args[0]
+		if dir, err = filepath.Abs(filepath.Dir(args[0])); err != nil {
+			return fmt.Errorf("failed to resolve source directory: %w", err)
+		}
+	}
+
+	finder := scanner.NewReferenceFinder(root, cfg)
+	matches, err := finder.MatchPatterns(input, dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", source, err)
+	}
+
+	fmt.Printf("🧪 %s — %s patterns, %d active\n", source, parser.ProviderName(finder.PatternProvider()), len(finder.Patterns()))
+	if len(args) == 1 && finder.PatternProvider().UseASTParsing() && isASTSource(args[0]) {
+		fmt.Println("   AST parsing also runs on this file during scans")
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("\nNo pattern matched.")
+		return nil
+	}
+
+	for _, m := range matches {
+		fmt.Printf("\nL%d  %s (%.0f%%)", m.LineNumber, m.Type, m.Confidence*100)
+		if m.IsComment {
+			fmt.Print("  [comment]")
+		}
+		if m.IsDynamic {
+			fmt.Print("  [dynamic]")
+		}
+		fmt.Printf("\n  line:    %s\n", m.Line)
+		fmt.Printf("  pattern: %s\n", m.Pattern)
+		for i, group := range m.Groups {
+			fmt.Printf("  [%d]      %q\n", i, group)
+		}
+
+		switch {
+		case m.Resolved == "":
+			fmt.Println("  → no capture group, not resolved")
+		case m.Exists:
+			fmt.Printf("  → %s ✓\n", m.Resolved)
+		default:
+			fmt.Printf("  → %s ✗ not found\n", m.Resolved)
+		}
+	}
+
+	fmt.Printf("\n%d matches\n", len(matches))
+	return nil
+}
+
+// isASTSource reports whether a file type is parsed with the AST parser
+func isASTSource(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".js", ".jsx", ".ts", ".tsx":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// usesCmd represents the uses command
+var usesCmd = &cobra.Command{
+	Use:   "uses <source-file>",
+	Short: "List the assets a source file references",
+	Long: `Uses lists every asset referenced by a source file in the last scan.
+
+Lookups use the reference index saved in the project cache.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUses,
+}
+
+func init() {
+	rootCmd.AddCommand(usesCmd)
+
+	usesCmd.Flags().StringVar(&scanFile, "scan-file", "", "build the index from a scan results file instead of the cache")
+}
+
+func runUses(cmd *cobra.Command, args []string) error {
+	idx, err := loadReferenceIndexOrFail()
+	if err != nil {
+		return err
+	}
+
+	assets := idx.Uses(args[0])
+	if len(assets) == 0 {
+		fmt.Printf("%s references no assets\n", args[0])
+		return nil
+	}
+
+	fmt.Printf("%s references %d assets:\n", args[0], len(assets))
+	for _, asset := range assets {
+		fmt.Printf("  • %s (%s)\n", asset, idx.Status[asset])
+	}
+
+	return nil
+}
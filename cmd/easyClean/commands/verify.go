@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/ui"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// deleteWithVerification moves files to a temporary trash, runs the verify
+// command, and restores every file if the command fails
+func deleteWithVerification(files []models.AssetFile, projectRoot string, isGitRepo bool) error {
+	trashDir, err := utils.GetTrashDir(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to get trash directory: %w", err)
+	}
+	trash := utils.NewTrash(trashDir)
+
+	if !quiet {
+		fmt.Printf("\nMoving %d files to trash...\n", len(files))
+	}
+
+	if err := moveToTrash(trash, files); err != nil {
+		restoreFromTrash(trash)
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("\n🔨 Running verification: %s\n\n", verifyCmd)
+	}
+
+	if err := runVerifyCommand(verifyCmd, projectRoot); err != nil {
+		if !quiet {
+			fmt.Printf("\n✗ Verification failed: %v\n", err)
+			fmt.Println("Restoring files...")
+		}
+		if failed := restoreFromTrash(trash); len(failed) > 0 {
+			return fmt.Errorf("verification failed and %d files could not be restored (trash: %s)", len(failed), trashDir)
+		}
+		return fmt.Errorf("verification command failed, deletion aborted (all files restored)")
+	}
+
+	if err := trash.Empty(); err != nil && !quiet {
+		fmt.Printf("⚠️  Warning: failed to empty trash %s: %v\n", trashDir, err)
+	}
+
+	printDeletionSummary(len(files), calculateTotalSize(files), nil, isGitRepo)
+	return nil
+}
+
+// moveToTrash moves all files into the trash, stopping at the first failure
+func moveToTrash(trash *utils.Trash, files []models.AssetFile) error {
+	for _, asset := range files {
+		if err := trash.Move(asset.Path, asset.RelativePath); err != nil {
+			return fmt.Errorf("failed to move %s to trash: %w", asset.RelativePath, err)
+		}
+		if !quiet && verbose {
+			fmt.Printf("  → %s (%s)\n", asset.RelativePath, ui.FormatBytes(asset.Size))
+		}
+	}
+	return nil
+}
+
+// restoreFromTrash restores trashed files and reports any that failed
+func restoreFromTrash(trash *utils.Trash) []string {
+	failed := trash.Restore()
+	if !quiet {
+		for _, f := range failed {
+			fmt.Printf("  ✗ %s\n", f)
+		}
+	}
+	return failed
+}
+
+// runVerifyCommand runs a shell command in dir, streaming its output
+func runVerifyCommand(command, dir string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	cmd.Dir = dir
+	if !quiet {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+
+	return cmd.Run()
+}
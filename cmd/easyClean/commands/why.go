@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// whyCmd represents the why command
+var whyCmd = &cobra.Command{
+	Use:   "why <asset>",
+	Short: "Show where an asset is referenced",
+	Long: `Why lists every reference to an asset found by the last scan, with
+its source file, line, and reference type.
+
+Lookups use the reference index saved in the project cache, so they are
+instant even for very large scans.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWhy,
+}
+
+func init() {
+	rootCmd.AddCommand(whyCmd)
+
+	whyCmd.Flags().StringVar(&scanFile, "scan-file", "", "build the index from a scan results file instead of the cache")
+}
+
+func runWhy(cmd *cobra.Command, args []string) error {
+	idx, err := loadReferenceIndexOrFail()
+	if err != nil {
+		return err
+	}
+
+	refs, known := idx.Why(args[0])
+	if !known {
+		return fmt.Errorf("%s is not an asset in the last scan", args[0])
+	}
+
+	status := idx.Status[idx.RelativePath(args[0])]
+	if len(refs) == 0 {
+		fmt.Printf("%s (%s): no references found\n", args[0], status)
+		return nil
+	}
+
+	fmt.Printf("%s (%s): %d references\n", args[0], status, len(refs))
+	for _, ref := range refs {
+		var flags string
+		if ref.IsComment {
+			flags += " [comment]"
+		}
+		if ref.IsDynamic {
+			flags += " [dynamic]"
+		}
+		if ref.IsDeadCode {
+			flags += " [dead code]"
+		}
+		if ref.FromUnreachable {
+			flags += " [unreachable]"
+		}
+		location := fmt.Sprintf("%s:%d", ref.SourceFile, ref.LineNumber)
+		if ref.Column > 0 {
+			location += fmt.Sprintf(":%d", ref.Column)
+		}
+		fmt.Printf("  %s  %s (%.0f%%)%s\n", location, ref.Type, ref.Confidence*100, flags)
+	}
+
+	return nil
+}
+
+// loadReferenceIndexOrFail loads the cached reference index for the current
+// project, falling back to building it from the scan results
+func loadReferenceIndexOrFail() (*models.ReferenceIndex, error) {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	indexPath, err := utils.GetReferenceIndexPath(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache path: %w", err)
+	}
+
+	// An explicit --scan-file always wins over the cached index
+	if scanFile == "" {
+		if idx := readReferenceIndex(indexPath); idx != nil {
+			return idx, nil
+		}
+	}
+
+	// No usable index (older cache); build one from the scan results
+	resultsPath, err := utils.GetScanResultsPathOrDefault(projectRoot, scanFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache path: %w", err)
+	}
+	result, err := loadScanResults(resultsPath)
+	if err != nil {
+		return nil, fmt.Errorf("no scan results found for this project, run 'easyClean scan' first: %w", err)
+	}
+	return models.BuildReferenceIndex(result), nil
+}
+
+// readReferenceIndex reads the index at path, returning nil if it is missing or unreadable
+func readReferenceIndex(path string) *models.ReferenceIndex {
+	file, err := utils.OpenMaybeGzip(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	idx, err := models.DecodeReferenceIndex(file)
+	if err != nil {
+		return nil
+	}
+	return idx
+}
@@ -2,24 +2,49 @@
 //
 // It provides a conservative classification system:
 // - Used: Has active code references
-// - Unused: No references found
-// - PotentiallyUnused: Only referenced in comments
-// - NeedsManualReview: Dynamic path construction detected
+// - Unused: No references found, or only from files unreachable from entry points
+// - TransitivelyUnused: Referenced only by unused assets or unreachable files
+// - PotentiallyUnused: Only referenced in comments or dead code
+// - NeedsManualReview: Dynamic path construction detected, or an unreferenced extension-less asset
 package classifier
 
-import "github.com/HabibPro1999/easyClean/internal/models"
+import (
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
 
 // ClassifyAsset determines the status of an asset based on its references
 func ClassifyAsset(asset *models.AssetFile) models.AssetStatus {
 	if len(asset.References) == 0 {
+		// Extension-less blobs are mostly named by manifests or computed
+		// hashes no reference pattern recognizes
+		if asset.SniffedType != "" {
+			return models.StatusNeedsManualReview
+		}
 		return models.StatusUnused
 	}
 
 	hasActiveRef := false
 	allInComments := true
 	hasDynamicRef := false
+	onlyUnreachable := true
 
 	for _, ref := range asset.References {
+		// Source files no entry point imports don't count as usage
+		if ref.FromUnreachable {
+			continue
+		}
+		onlyUnreachable = false
+
+		// References in unreachable code count like comments
+		if ref.IsDeadCode {
+			continue
+		}
+
 		// If any reference is dynamic, mark for manual review
 		if ref.IsDynamic {
 			hasDynamicRef = true
@@ -32,12 +57,17 @@ func ClassifyAsset(asset *models.AssetFile) models.AssetStatus {
 		}
 	}
 
+	// Every reference comes from an unreachable source file
+	if onlyUnreachable {
+		return models.StatusUnused
+	}
+
 	// Conservative approach: dynamic references need manual review
 	if hasDynamicRef {
 		return models.StatusNeedsManualReview
 	}
 
-	// All references are in comments
+	// All references are in comments or dead code
 	if allInComments {
 		return models.StatusPotentiallyUnused
 	}
@@ -54,48 +84,126 @@ func ClassifyAsset(asset *models.AssetFile) models.AssetStatus {
 func ClassifyAssets(assets []models.AssetFile) []models.AssetFile {
 	for i := range assets {
 		assets[i].Status = ClassifyAsset(&assets[i])
+		assets[i].UsageConfidence = UsageConfidence(&assets[i])
+		assets[i].ReviewReasons = ReviewReasons(&assets[i])
 	}
 	return assets
 }
 
-// MatchReferencesToAssets matches found references to asset files
+// UsageConfidence combines the confidence of an asset's active references.
+// The strongest reference from each source file is independent evidence, so
+// two files referencing an asset at 0.7 give 0.91 (1 - 0.3 × 0.3), while ten
+// matches in one file still give 0.7. Comments, dead code, and unreachable
+// files add nothing.
+func UsageConfidence(asset *models.AssetFile) float32 {
+	best := make(map[string]float32)
+	for _, ref := range asset.References {
+		if ref.FromUnreachable || ref.IsDeadCode || ref.IsComment {
+			continue
+		}
+		if ref.Confidence > best[ref.SourceFile] {
+			best[ref.SourceFile] = ref.Confidence
+		}
+	}
+	if len(best) == 0 {
+		return 0
+	}
+
+	doubt := 1.0
+	for _, confidence := range best {
+		doubt *= 1 - float64(confidence)
+	}
+	return float32(math.Round((1-doubt)*100) / 100)
+}
+
+// MatchReferencesToAssets attaches each group of references to every asset
+// whose path ends with the group's path, looked up in an index of asset path
+// suffixes rather than by comparing every asset with every group
 func MatchReferencesToAssets(assets []models.AssetFile, references map[string][]*models.Reference) []models.AssetFile {
-	// Match references to assets using path matching
-	for i := range assets {
-		for refPath, refs := range references {
-			if matchesAssetPath(&assets[i], refPath) {
-				assets[i].References = append(assets[i].References, refs...)
-				assets[i].RefCount = len(assets[i].References)
-				break
+	index := newSuffixIndex(assets)
+
+	refPaths := make([]string, 0, len(references))
+	for refPath := range references {
+		refPaths = append(refPaths, refPath)
+	}
+	sort.Strings(refPaths)
+
+	// A reference grouped under several paths (@2x, @3x, ...) counts once
+	seen := make(map[int]map[*models.Reference]bool)
+	for _, refPath := range refPaths {
+		for _, i := range index[referenceKey(refPath)] {
+			if seen[i] == nil {
+				seen[i] = make(map[*models.Reference]bool)
+			}
+			for _, ref := range references[refPath] {
+				if !seen[i][ref] {
+					seen[i][ref] = true
+					assets[i].References = append(assets[i].References, ref)
+				}
 			}
+			assets[i].RefCount = len(assets[i].References)
 		}
 	}
 
 	return assets
 }
 
-// matchesAssetPath checks if a reference path matches an asset
-func matchesAssetPath(asset *models.AssetFile, refPath string) bool {
-	// Try exact matches
-	if asset.Path == refPath || asset.RelativePath == refPath || asset.Name == refPath {
-		return true
+// suffixIndex maps every trailing run of path elements of an asset
+// ("logo.png", "images/logo.png", ...) to the assets whose path ends with it
+type suffixIndex map[string][]int
+
+func newSuffixIndex(assets []models.AssetFile) suffixIndex {
+	index := make(suffixIndex)
+	for i := range assets {
+		for _, key := range assetKeys(&assets[i]) {
+			index[key] = append(index[key], i)
+		}
 	}
+	return index
+}
 
-	// Try suffix matching (e.g., "images/logo.png" matches "src/assets/images/logo.png")
-	if len(refPath) > 0 && len(asset.Path) >= len(refPath) {
-		suffix := asset.Path[len(asset.Path)-len(refPath):]
-		if suffix == refPath {
-			return true
+// assetKeys returns the distinct paths a reference may use for an asset: its
+// name and each path-element suffix of its absolute and relative paths
+func assetKeys(asset *models.AssetFile) []string {
+	seen := map[string]bool{}
+	var keys []string
+	add := func(key string) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
 		}
 	}
 
-	// Try relative path suffix matching
-	if len(refPath) > 0 && len(asset.RelativePath) >= len(refPath) {
-		suffix := asset.RelativePath[len(asset.RelativePath)-len(refPath):]
-		if suffix == refPath {
-			return true
+	add(asset.Name)
+	for _, p := range []string{asset.Path, asset.RelativePath} {
+		p = strings.TrimPrefix(filepath.ToSlash(p), "/")
+		for {
+			add(p)
+			slash := strings.Index(p, "/")
+			if slash < 0 {
+				break
+			}
+			p = p[slash+1:]
 		}
 	}
+	return keys
+}
+
+// referenceKey normalizes a reference path for lookup in a suffixIndex; asset
+// keys have no leading slash, so absolute paths and root URLs both match
+func referenceKey(refPath string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(filepath.ToSlash(refPath), "./"), "/")
+}
 
+// matchesAssetPath checks if a reference path matches an asset: exactly, by
+// name, or as a suffix of whole path elements ("images/logo.png" matches
+// "src/assets/images/logo.png", "go.png" doesn't match "logo.png")
+func matchesAssetPath(asset *models.AssetFile, refPath string) bool {
+	key := referenceKey(refPath)
+	for _, k := range assetKeys(asset) {
+		if k == key {
+			return true
+		}
+	}
 	return false
 }
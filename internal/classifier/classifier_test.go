@@ -1,6 +1,8 @@
 package classifier
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/HabibPro1999/easyClean/internal/models"
@@ -81,6 +83,52 @@ func TestClassifyAsset(t *testing.T) {
 			},
 			expectedStatus: models.StatusNeedsManualReview,
 		},
+		{
+			name: "Only dead code references",
+			references: []*models.Reference{
+				{
+					SourceFile:  "src/app.js",
+					LineNumber:  10,
+					MatchedText: "./assets/logo.png",
+					Type:        models.RefTypeImport,
+					IsDynamic:   true,
+					IsDeadCode:  true,
+				},
+			},
+			expectedStatus: models.StatusPotentiallyUnused,
+		},
+		{
+			name: "Dead code and active reference",
+			references: []*models.Reference{
+				{
+					SourceFile:  "src/app.js",
+					LineNumber:  10,
+					MatchedText: "./assets/logo.png",
+					Type:        models.RefTypeImport,
+					IsDeadCode:  true,
+				},
+				{
+					SourceFile:  "src/util.js",
+					LineNumber:  20,
+					MatchedText: "./assets/logo.png",
+					Type:        models.RefTypeImport,
+				},
+			},
+			expectedStatus: models.StatusUsed,
+		},
+		{
+			name: "Only references from unreachable files",
+			references: []*models.Reference{
+				{
+					SourceFile:      "src/old/Legacy.tsx",
+					LineNumber:      1,
+					MatchedText:     "./assets/logo.png",
+					Type:            models.RefTypeImport,
+					FromUnreachable: true,
+				},
+			},
+			expectedStatus: models.StatusUnused,
+		},
 	}
 
 	for _, tt := range tests {
@@ -99,6 +147,87 @@ func TestClassifyAsset(t *testing.T) {
 			}
 		})
 	}
+
+	// Nothing names a content-sniffed blob, so no references is not proof
+	blob := &models.AssetFile{Path: "/project/assets/3f9a1c", Name: "3f9a1c", SniffedType: ".png"}
+	if got := ClassifyAsset(blob); got != models.StatusNeedsManualReview {
+		t.Errorf("ClassifyAsset(sniffed blob) = %v, want %v", got, models.StatusNeedsManualReview)
+	}
+}
+
+func TestUsageConfidence(t *testing.T) {
+	literal := func(source string, confidence float32) *models.Reference {
+		return &models.Reference{SourceFile: source, Type: models.RefTypeStringLiteral, Confidence: confidence}
+	}
+	tests := []struct {
+		name string
+		refs []*models.Reference
+		want float32
+	}{
+		{"no references", nil, 0},
+		{"one literal", []*models.Reference{literal("a.ts", 0.7)}, 0.7},
+		{"ten literals in one file", []*models.Reference{
+			literal("a.ts", 0.7), literal("a.ts", 0.7), literal("a.ts", 0.7), literal("a.ts", 0.7), literal("a.ts", 0.7),
+			literal("a.ts", 0.7), literal("a.ts", 0.7), literal("a.ts", 0.7), literal("a.ts", 0.7), literal("a.ts", 0.7),
+		}, 0.7},
+		{"two files", []*models.Reference{literal("a.ts", 0.7), literal("b.ts", 0.7)}, 0.91},
+		{"strongest per file", []*models.Reference{literal("a.ts", 0.7), literal("a.ts", 0.95), literal("b.ts", 0.8)}, 0.99},
+		{"inactive references", []*models.Reference{
+			literal("a.ts", 0.7),
+			{SourceFile: "b.ts", Confidence: 1.0, IsComment: true},
+			{SourceFile: "c.ts", Confidence: 1.0, IsDeadCode: true},
+			{SourceFile: "d.ts", Confidence: 1.0, FromUnreachable: true},
+		}, 0.7},
+	}
+
+	for _, tt := range tests {
+		if got := UsageConfidence(&models.AssetFile{References: tt.refs}); got != tt.want {
+			t.Errorf("%s: UsageConfidence() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReviewReasons(t *testing.T) {
+	asset := func(status models.AssetStatus, refs ...*models.Reference) *models.AssetFile {
+		return &models.AssetFile{
+			Path: "/project/src/assets/logo.png", RelativePath: "src/assets/logo.png", Name: "logo.png",
+			Status: status, References: refs,
+		}
+	}
+	tests := []struct {
+		name  string
+		asset *models.AssetFile
+		want  []string
+	}{
+		{"used asset", asset(models.StatusUsed, &models.Reference{SourceFile: "/project/src/a.ts", LineNumber: 1, MatchedText: "logo.png"}), nil},
+		{"dynamic reference", asset(models.StatusNeedsManualReview,
+			&models.Reference{SourceFile: "/project/src/x.ts", LineNumber: 10, MatchedText: "assets/${name}.png", Type: models.RefTypeTemplateLiteral, IsDynamic: true}),
+			[]string{"dynamic template literal in src/x.ts:10"}},
+		{"comment and basename", asset(models.StatusPotentiallyUnused,
+			&models.Reference{SourceFile: "/project/src/y.ts", LineNumber: 3, MatchedText: "src/assets/logo.png", IsComment: true},
+			&models.Reference{SourceFile: "/project/src/z.ts", LineNumber: 7, MatchedText: "logo.png"}),
+			[]string{"reference inside comment at src/y.ts:3", "only basename match (logo.png)"}},
+		{"dead code only", asset(models.StatusPotentiallyUnused,
+			&models.Reference{SourceFile: "/project/src/old.ts", LineNumber: 5, MatchedText: "logo.png", IsDeadCode: true}),
+			[]string{"reference in dead code at src/old.ts:5"}},
+		{"sniffed blob", &models.AssetFile{Name: "3f9a1c", Status: models.StatusNeedsManualReview, SniffedType: ".png"},
+			[]string{"extension-less file detected as .png; no reference names it"}},
+	}
+
+	for _, tt := range tests {
+		if got := ReviewReasons(tt.asset); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ReviewReasons() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	var refs []*models.Reference
+	for i := 1; i <= maxReviewReasons+2; i++ {
+		refs = append(refs, &models.Reference{SourceFile: "/project/src/c.ts", LineNumber: i, MatchedText: "src/assets/logo.png", IsComment: true})
+	}
+	got := ReviewReasons(asset(models.StatusPotentiallyUnused, refs...))
+	if len(got) != maxReviewReasons+1 || got[maxReviewReasons] != "and 2 more" {
+		t.Errorf("ReviewReasons() over the cap = %q, want %d reasons and \"and 2 more\"", got, maxReviewReasons)
+	}
 }
 
 func TestMatchesAssetPath(t *testing.T) {
@@ -169,3 +298,353 @@ func TestMatchesAssetPath(t *testing.T) {
 		})
 	}
 }
+
+func TestAssignFeatures(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "src/checkout/images/cart.png"},
+		{RelativePath: "src/profile/avatar.png"},
+		{RelativePath: "public/logo.png"},
+	}
+	features := map[string]string{
+		"checkout": "src/checkout/**",
+		"profile":  "src/profile/*.png",
+	}
+
+	assets = AssignFeatures(assets, features)
+
+	expected := []string{"checkout", "profile", ""}
+	for i, want := range expected {
+		if assets[i].Feature != want {
+			t.Errorf("AssignFeatures() %s feature = %q, want %q", assets[i].RelativePath, assets[i].Feature, want)
+		}
+	}
+}
+
+func TestAssignLicenses(t *testing.T) {
+	rules := []models.LicenseRule{
+		{Path: "assets/icons/**", License: "CC-BY-4.0"},
+		{Path: "assets/photos/**", License: "CC0-1.0"},
+		{Path: "assets/photos/credited.jpg", License: "CC0-1.0", Attribution: "Photo by Jane"},
+	}
+
+	assets := []models.AssetFile{
+		{RelativePath: "assets/icons/home.svg"},
+		{RelativePath: "assets/photos/beach.jpg"},
+		{RelativePath: "assets/logo.png"},
+	}
+
+	AssignLicenses(assets, rules)
+
+	tests := []struct {
+		index       int
+		license     string
+		attribution bool
+	}{
+		{0, "CC-BY-4.0", true},
+		{1, "CC0-1.0", false},
+		{2, "", false},
+	}
+
+	for _, tt := range tests {
+		asset := assets[tt.index]
+		if asset.License != tt.license {
+			t.Errorf("AssignLicenses() %s license = %q, want %q", asset.RelativePath, asset.License, tt.license)
+		}
+		if asset.RequiresAttribution != tt.attribution {
+			t.Errorf("AssignLicenses() %s RequiresAttribution = %v, want %v", asset.RelativePath, asset.RequiresAttribution, tt.attribution)
+		}
+	}
+}
+
+func TestRequiresAttribution(t *testing.T) {
+	tests := []struct {
+		rule models.LicenseRule
+		want bool
+	}{
+		{models.LicenseRule{License: "MIT"}, true},
+		{models.LicenseRule{License: "cc-by-sa-4.0"}, true},
+		{models.LicenseRule{License: "CC0-1.0"}, false},
+		{models.LicenseRule{License: "CC0-1.0", Attribution: "Jane"}, true},
+		{models.LicenseRule{}, false},
+	}
+
+	for _, tt := range tests {
+		if got := RequiresAttribution(tt.rule); got != tt.want {
+			t.Errorf("RequiresAttribution(%+v) = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestApplyKeepRules(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "assets/legacy/old.png", Status: models.StatusUnused},
+		{RelativePath: "assets/legacy/used.png", Status: models.StatusUsed},
+		{RelativePath: "assets/legacy/maybe.png", Status: models.StatusPotentiallyUnused},
+		{RelativePath: "assets/new.png", Status: models.StatusUnused},
+	}
+
+	ApplyKeepRules(assets, []string{"assets/legacy/**"})
+
+	want := []models.AssetStatus{models.StatusKept, models.StatusUsed, models.StatusKept, models.StatusUnused}
+	for i, asset := range assets {
+		if asset.Status != want[i] {
+			t.Errorf("ApplyKeepRules() %s status = %v, want %v", asset.RelativePath, asset.Status, want[i])
+		}
+	}
+}
+
+func TestApplyConfigKeepPaths(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "assets/brand/logo.svg", Status: models.StatusUnused},
+		{RelativePath: "assets/brand/used.svg", Status: models.StatusUsed},
+		{RelativePath: "assets/brand/ruled.svg", Status: models.StatusUnused, Severity: models.SeverityWarning, Rule: "legacy"},
+		{RelativePath: "assets/old.png", Status: models.StatusUnused},
+	}
+
+	assets = ApplyConfigKeepPaths(assets, []string{"assets/brand/**"})
+
+	want := []models.AssetStatus{models.StatusKept, models.StatusUsed, models.StatusKept, models.StatusUnused}
+	for i, asset := range assets {
+		if asset.Status != want[i] || asset.KeptByConfig != (want[i] == models.StatusKept) {
+			t.Errorf("ApplyConfigKeepPaths() %s = %v (by config %v), want %v", asset.RelativePath, asset.Status, asset.KeptByConfig, want[i])
+		}
+	}
+
+	result := &models.ScanResult{Assets: assets}
+	result.AssignSeverities()
+	result.ComputeStatistics()
+	result.PopulateFilteredLists()
+	if result.Assets[2].Severity != models.SeverityOff {
+		t.Errorf("kept asset severity = %q, want %q", result.Assets[2].Severity, models.SeverityOff)
+	}
+	if result.Stats.KeptByConfigCount != 2 || len(result.KeptByConfigAssets) != 2 || result.Stats.UnusedCount != 1 {
+		t.Errorf("kept by config = %d/%d, unused = %d, want 2/2, 1",
+			result.Stats.KeptByConfigCount, len(result.KeptByConfigAssets), result.Stats.UnusedCount)
+	}
+}
+
+func TestApplyConventionRules(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "public/robots.txt", Status: models.StatusUnused},
+		{RelativePath: "public/og-image.png", Status: models.StatusUsed},
+		{RelativePath: "public/.well-known/apple-app-site-association", Status: models.StatusPotentiallyUnused},
+		{RelativePath: "public/maskable_icon.png", Status: models.StatusKept},
+		{RelativePath: "public/hero.png", Status: models.StatusUnused},
+	}
+
+	ApplyConventionRules(assets, []string{"public/robots.txt", "public/og-image.*", "public/.well-known/**", "public/maskable_icon*.png"})
+
+	want := []models.AssetStatus{models.StatusConventional, models.StatusUsed, models.StatusConventional, models.StatusKept, models.StatusUnused}
+	for i, asset := range assets {
+		if asset.Status != want[i] {
+			t.Errorf("ApplyConventionRules() %s status = %v, want %v", asset.RelativePath, asset.Status, want[i])
+		}
+	}
+}
+
+func TestApplyTransitiveUnused(t *testing.T) {
+	asset := func(rel string, status models.AssetStatus, sources ...string) models.AssetFile {
+		a := models.AssetFile{Path: "/project/" + rel, RelativePath: rel, Status: status}
+		for _, source := range sources {
+			a.References = append(a.References, &models.Reference{SourceFile: source, FromUnreachable: source == "/project/src/legacy.css"})
+		}
+		return a
+	}
+	assets := []models.AssetFile{
+		asset("anim/intro.json", models.StatusUnused),
+		asset("anim/frame.png", models.StatusUsed, "/project/anim/intro.json"),
+		asset("anim/frame-bg.png", models.StatusUsed, "/project/anim/frame.png"),
+		asset("img/bg.png", models.StatusUnused, "/project/src/legacy.css"),
+		asset("img/shared.png", models.StatusUsed, "/project/anim/intro.json", "/project/src/app.ts"),
+		asset("img/kept.png", models.StatusKept, "/project/anim/intro.json"),
+	}
+
+	chains := map[string][]string{"/project/src/legacy.css": {"src/legacy.css", "src/old-theme.css"}}
+	ApplyTransitiveUnused(assets, chains)
+
+	tests := []struct {
+		status models.AssetStatus
+		chain  []string
+	}{
+		{models.StatusUnused, nil},
+		{models.StatusTransitivelyUnused, []string{"anim/intro.json"}},
+		{models.StatusTransitivelyUnused, []string{"anim/frame.png", "anim/intro.json"}},
+		{models.StatusTransitivelyUnused, []string{"src/legacy.css", "src/old-theme.css"}},
+		{models.StatusUsed, nil},
+		{models.StatusKept, nil},
+	}
+	for i, tt := range tests {
+		if assets[i].Status != tt.status || !reflect.DeepEqual(assets[i].UnusedChain, tt.chain) {
+			t.Errorf("ApplyTransitiveUnused() %s = %v %v, want %v %v",
+				assets[i].RelativePath, assets[i].Status, assets[i].UnusedChain, tt.status, tt.chain)
+		}
+	}
+}
+
+func TestApplyTransitiveUnused_KeptSource(t *testing.T) {
+	css := func(rel string) models.AssetFile {
+		return models.AssetFile{Path: "/project/" + rel, RelativePath: rel, Status: models.StatusUnused}
+	}
+	image := func(rel, source string) models.AssetFile {
+		return models.AssetFile{
+			Path: "/project/" + rel, RelativePath: rel, Status: models.StatusUsed,
+			References: []*models.Reference{{SourceFile: "/project/" + source, FromUnreachable: true}},
+		}
+	}
+	assets := []models.AssetFile{
+		css("styles/print.css"),
+		image("img/print-logo.png", "styles/print.css"),
+		css("styles/legacy.css"),
+		image("img/legacy-bg.png", "styles/legacy.css"),
+		image("img/ruled.png", "styles/legacy.css"),
+	}
+	assets[4].Rule = "legacy images stay"
+
+	// An orphaned stylesheet kept by keep_paths still uses its images, even
+	// though no entry point imports it
+	assets = ApplyConfigKeepPaths(assets, []string{"styles/print.css"})
+	assets = ApplyTransitiveUnused(assets, nil)
+
+	want := []models.AssetStatus{
+		models.StatusKept,
+		models.StatusUsed,
+		models.StatusUnused,
+		models.StatusTransitivelyUnused,
+		models.StatusUsed,
+	}
+	for i, status := range want {
+		if assets[i].Status != status {
+			t.Errorf("%s status = %v, want %v", assets[i].RelativePath, assets[i].Status, status)
+		}
+	}
+}
+
+func TestApplyTransitiveUnused_Cycle(t *testing.T) {
+	asset := func(rel string, sources ...string) models.AssetFile {
+		a := models.AssetFile{Path: "/project/" + rel, RelativePath: rel, Status: models.StatusUsed}
+		for _, source := range sources {
+			a.References = append(a.References, &models.Reference{SourceFile: "/project/" + source})
+		}
+		return a
+	}
+	assets := []models.AssetFile{
+		// Orphaned stylesheets importing each other, and their image
+		asset("styles/a.css", "styles/b.css"),
+		asset("styles/b.css", "styles/a.css"),
+		asset("img/bg.png", "styles/a.css"),
+		// The same pair, but the app imports one of them
+		asset("styles/c.css", "styles/d.css", "src/app.ts"),
+		asset("styles/d.css", "styles/c.css"),
+		asset("img/logo.png", "styles/d.css"),
+	}
+
+	assets = ApplyTransitiveUnused(assets, nil)
+
+	tests := []struct {
+		status models.AssetStatus
+		chain  []string
+	}{
+		{models.StatusTransitivelyUnused, []string{"styles/b.css", "styles/a.css"}},
+		{models.StatusTransitivelyUnused, []string{"styles/a.css", "styles/b.css"}},
+		{models.StatusTransitivelyUnused, []string{"styles/a.css", "styles/b.css", "styles/a.css"}},
+		{models.StatusUsed, nil},
+		{models.StatusUsed, nil},
+		{models.StatusUsed, nil},
+	}
+	for i, tt := range tests {
+		if assets[i].Status != tt.status || !reflect.DeepEqual(assets[i].UnusedChain, tt.chain) {
+			t.Errorf("ApplyTransitiveUnused() %s = %v %v, want %v %v",
+				assets[i].RelativePath, assets[i].Status, assets[i].UnusedChain, tt.status, tt.chain)
+		}
+	}
+}
+
+func TestMatchReferencesToAssets(t *testing.T) {
+	assets := []models.AssetFile{
+		{Path: "/project/assets/logo.png", RelativePath: "assets/logo.png", Name: "logo.png"},
+		{Path: "/project/assets/icons/logo.png", RelativePath: "assets/icons/logo.png", Name: "logo.png"},
+		{Path: "/project/assets/go.png", RelativePath: "assets/go.png", Name: "go.png"},
+	}
+	shared := &models.Reference{SourceFile: "/project/src/app.js", LineNumber: 3}
+	references := map[string][]*models.Reference{
+		"/project/assets/logo.png": {{SourceFile: "/project/src/a.js", LineNumber: 1}},
+		"icons/logo.png":           {{SourceFile: "/project/src/b.js", LineNumber: 2}, shared},
+		"/icons/logo.png":          {shared},
+		"go.png":                   {{SourceFile: "/project/src/c.js", LineNumber: 4}},
+	}
+
+	assets = MatchReferencesToAssets(assets, references)
+
+	// Every matching group is attached, each reference once
+	if assets[0].RefCount != 1 {
+		t.Errorf("logo.png RefCount = %d, want 1", assets[0].RefCount)
+	}
+	if assets[1].RefCount != 2 {
+		t.Errorf("icons/logo.png RefCount = %d, want 2", assets[1].RefCount)
+	}
+	// Suffixes match whole path elements: go.png isn't logo.png
+	if assets[2].RefCount != 1 {
+		t.Errorf("go.png RefCount = %d, want 1", assets[2].RefCount)
+	}
+}
+
+func BenchmarkMatchReferencesToAssets(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		b.Run(fmt.Sprintf("assets=%d", n), func(b *testing.B) {
+			assets := make([]models.AssetFile, n)
+			references := make(map[string][]*models.Reference, n/2)
+			for i := range assets {
+				rel := fmt.Sprintf("assets/dir%d/image%d.png", i%100, i)
+				assets[i] = models.AssetFile{Path: "/project/" + rel, RelativePath: rel, Name: fmt.Sprintf("image%d.png", i)}
+				if i%2 == 0 {
+					references["/project/"+rel] = []*models.Reference{{SourceFile: "/project/src/app.js", LineNumber: i}}
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fresh := make([]models.AssetFile, len(assets))
+				copy(fresh, assets)
+				MatchReferencesToAssets(fresh, references)
+			}
+		})
+	}
+}
+
+func TestApplyStatusRules(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "assets/brand/logo.svg", Status: models.StatusUnused},
+		{RelativePath: "assets/legacy/old.png", Status: models.StatusPotentiallyUnused, RefCount: 1},
+		{RelativePath: "assets/legacy/gone.png", Status: models.StatusUnused},
+		{RelativePath: "assets/icons/home.svg", Status: models.StatusUnused},
+		{RelativePath: "assets/icons/weak.svg", Status: models.StatusUsed, RefCount: 1, UsageConfidence: 0.7},
+		{RelativePath: "assets/icons/strong.svg", Status: models.StatusUsed, RefCount: 2, UsageConfidence: 0.91},
+	}
+	rules := []models.StatusRule{
+		{Name: "brand", Path: "assets/brand/**", SetStatus: "kept"},
+		{Name: "weak evidence", Status: "used", UsageConfidence: "< 0.8", SetStatus: "needs_review"},
+		{Path: "assets/legacy/**", RefCount: "== 0", Severity: "warning"},
+		{Path: "assets/legacy/**", Status: "potentially_unused", SetStatus: "needs_review", Severity: "info"},
+	}
+
+	assets = ApplyStatusRules(assets, rules)
+
+	expected := []struct {
+		status   models.AssetStatus
+		severity models.Severity
+		rule     string
+	}{
+		{models.StatusKept, "", "brand"},
+		{models.StatusNeedsManualReview, models.SeverityInfo, "assets/legacy/**"},
+		{models.StatusUnused, models.SeverityWarning, "assets/legacy/**"},
+		{models.StatusUnused, "", ""},
+		{models.StatusNeedsManualReview, "", "weak evidence"},
+		{models.StatusUsed, "", ""},
+	}
+	for i, want := range expected {
+		if assets[i].Status != want.status || assets[i].Severity != want.severity || assets[i].Rule != want.rule {
+			t.Errorf("%s = %s/%q/%q, want %s/%q/%q", assets[i].RelativePath,
+				assets[i].Status, assets[i].Severity, assets[i].Rule, want.status, want.severity, want.rule)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Package classifier - Convention rules
+//
+// Some assets are never referenced because browsers, crawlers, and platforms
+// fetch them by well-known path (favicon.ico, robots.txt, .well-known/).
+// They are marked Conventional rather than flagged for deletion; assets with
+// active references stay Used and explicitly Kept assets stay Kept.
+package classifier
+
+import (
+	"path/filepath"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// ApplyConventionRules marks non-used, non-kept assets matching any
+// convention glob as Conventional
+func ApplyConventionRules(assets []models.AssetFile, patterns []string) []models.AssetFile {
+	if len(patterns) == 0 {
+		return assets
+	}
+
+	for i := range assets {
+		if assets[i].Status == models.StatusUsed || assets[i].Status == models.StatusKept {
+			continue
+		}
+
+		relPath := filepath.ToSlash(assets[i].RelativePath)
+		for _, pattern := range patterns {
+			if utils.MatchGlob(pattern, relPath) {
+				assets[i].Status = models.StatusConventional
+				assets[i].ReviewReasons = nil
+				break
+			}
+		}
+	}
+
+	return assets
+}
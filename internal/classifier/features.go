@@ -0,0 +1,38 @@
+// Package classifier - Feature ownership assignment
+//
+// Maps assets to named features/teams using path globs from the
+// `features` config section, so unused statistics can be grouped per owner.
+package classifier
+
+import (
+	"sort"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// AssignFeatures sets the Feature field of each asset whose relative path
+// matches one of the configured feature globs. Features are checked in
+// alphabetical order so the first match is deterministic.
+func AssignFeatures(assets []models.AssetFile, features map[string]string) []models.AssetFile {
+	if len(features) == 0 {
+		return assets
+	}
+
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i := range assets {
+		for _, name := range names {
+			if utils.MatchGlob(features[name], assets[i].RelativePath) {
+				assets[i].Feature = name
+				break
+			}
+		}
+	}
+
+	return assets
+}
@@ -0,0 +1,65 @@
+// Package classifier - Keep rules
+//
+// Assets matching an easyclean:keep annotation, a .easycleankeep sidecar
+// glob, or a keep_paths glob are marked Kept so they never count toward
+// unused totals. Assets with active references stay Used.
+package classifier
+
+import (
+	"path/filepath"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// ApplyKeepRules marks non-used assets matching any keep glob as Kept
+func ApplyKeepRules(assets []models.AssetFile, patterns []string) []models.AssetFile {
+	if len(patterns) == 0 {
+		return assets
+	}
+
+	for i := range assets {
+		if assets[i].Status == models.StatusUsed {
+			continue
+		}
+
+		relPath := filepath.ToSlash(assets[i].RelativePath)
+		for _, pattern := range patterns {
+			if utils.MatchGlob(pattern, relPath) {
+				assets[i].Status = models.StatusKept
+				assets[i].ReviewReasons = nil
+				break
+			}
+		}
+	}
+
+	return assets
+}
+
+// ApplyConfigKeepPaths marks non-used assets matching a keep_paths glob as
+// Kept, flagging them as kept by config. It runs after every other rule, so
+// keep_paths matches are never unused or deletable.
+func ApplyConfigKeepPaths(assets []models.AssetFile, patterns []string) []models.AssetFile {
+	if len(patterns) == 0 {
+		return assets
+	}
+
+	for i := range assets {
+		if assets[i].Status == models.StatusUsed {
+			continue
+		}
+
+		relPath := filepath.ToSlash(assets[i].RelativePath)
+		for _, pattern := range patterns {
+			if utils.MatchGlob(pattern, relPath) {
+				assets[i].Status = models.StatusKept
+				assets[i].KeptByConfig = true
+				assets[i].ReviewReasons = nil
+				assets[i].Severity = "" // a status rule's severity no longer applies
+				break
+			}
+		}
+	}
+
+	return assets
+}
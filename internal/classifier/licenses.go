@@ -0,0 +1,53 @@
+// Package classifier - License and attribution assignment
+//
+// Applies rules from asset-licenses.yaml to assets. Licenses that require
+// retaining a notice (CC-BY, MIT, OFL, ...) are flagged so the delete command
+// can warn that attribution text elsewhere may need updating.
+package classifier
+
+import (
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// attributionLicensePrefixes are SPDX-style license IDs that require attribution notices
+var attributionLicensePrefixes = []string{
+	"CC-BY", "MIT", "APACHE", "BSD", "OFL", "GPL", "LGPL", "MPL",
+}
+
+// AssignLicenses sets license info on assets matching a rule (first matching rule wins)
+func AssignLicenses(assets []models.AssetFile, rules []models.LicenseRule) []models.AssetFile {
+	if len(rules) == 0 {
+		return assets
+	}
+
+	for i := range assets {
+		for _, rule := range rules {
+			if utils.MatchGlob(rule.Path, assets[i].RelativePath) {
+				assets[i].License = rule.License
+				assets[i].Attribution = rule.Attribution
+				assets[i].RequiresAttribution = RequiresAttribution(rule)
+				break
+			}
+		}
+	}
+
+	return assets
+}
+
+// RequiresAttribution reports whether a rule carries an attribution requirement
+func RequiresAttribution(rule models.LicenseRule) bool {
+	if rule.Attribution != "" {
+		return true
+	}
+
+	license := strings.ToUpper(rule.License)
+	for _, prefix := range attributionLicensePrefixes {
+		if strings.HasPrefix(license, prefix) {
+			return true
+		}
+	}
+	return false
+}
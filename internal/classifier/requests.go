@@ -0,0 +1,83 @@
+// Package classifier - Runtime evidence from access logs and usage beacons
+//
+// Access logs and beacons name URL paths, not repo paths:
+// public/images/logo.png is served as /images/logo.png, and bundled assets are
+// renamed to /assets/logo.8f3a2b1c.png. A path counts toward an asset when it
+// is a suffix of the asset's path, or when its file name carries a content
+// hash and matches the asset's file name once the hash is removed.
+//
+// Request counts are recorded as a signal only. Beacons report what actually
+// rendered, so a Used asset no beacon reported is downgraded to Potentially
+// Unused: the code mentions it, but no user has seen it.
+package classifier
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// runtimePath is one runtime URL path, grouped by normalized file name
+type runtimePath struct {
+	path   string
+	hashed bool
+	count  int
+}
+
+// runtimeIndex maps URL path counts onto asset paths
+type runtimeIndex map[string][]runtimePath
+
+// newRuntimeIndex indexes counts keyed by URL path without the leading slash
+func newRuntimeIndex(counts map[string]int) runtimeIndex {
+	index := make(runtimeIndex)
+	for p, count := range counts {
+		lower := strings.ToLower(p)
+		base := path.Base(lower)
+		name := utils.StripContentHash(base)
+		index[name] = append(index[name], runtimePath{path: lower, hashed: name != base, count: count})
+	}
+	return index
+}
+
+// count returns the total count of the URL paths that serve an asset
+func (index runtimeIndex) count(asset models.AssetFile) int {
+	relPath := strings.ToLower(filepath.ToSlash(asset.RelativePath))
+	total := 0
+	for _, p := range index[path.Base(relPath)] {
+		if p.hashed || relPath == p.path || strings.HasSuffix(relPath, "/"+p.path) {
+			total += p.count
+		}
+	}
+	return total
+}
+
+// ApplyRequestCounts sets Requests on each asset and flags the ones no
+// request reached as NeverRequested. requests is keyed by URL path without
+// the leading slash (see usage.Requests).
+func ApplyRequestCounts(assets []models.AssetFile, requests map[string]int) []models.AssetFile {
+	index := newRuntimeIndex(requests)
+	for i := range assets {
+		assets[i].Requests = index.count(assets[i])
+		assets[i].NeverRequested = assets[i].Requests == 0
+	}
+	return assets
+}
+
+// ApplyRenderCounts sets Renders on each asset from usage beacons, flags the
+// ones never rendered, and downgrades never-rendered Used assets to
+// Potentially Unused
+func ApplyRenderCounts(assets []models.AssetFile, renders map[string]int) []models.AssetFile {
+	index := newRuntimeIndex(renders)
+	for i := range assets {
+		assets[i].Renders = index.count(assets[i])
+		assets[i].NeverRendered = assets[i].Renders == 0
+		if assets[i].NeverRendered && assets[i].Status == models.StatusUsed {
+			assets[i].Status = models.StatusPotentiallyUnused
+			assets[i].ReviewReasons = append(assets[i].ReviewReasons, "never rendered according to usage beacons")
+		}
+	}
+	return assets
+}
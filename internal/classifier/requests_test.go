@@ -0,0 +1,72 @@
+package classifier
+
+import (
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestApplyRequestCounts(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "public/images/logo.png"},
+		{RelativePath: "src/assets/hero.webp"},
+		{RelativePath: "public/images/old.png"},
+		{RelativePath: "public/icons/logo.png"},
+	}
+	requests := map[string]int{
+		"images/logo.png":           5,
+		"assets/hero.8f3a2b1c.webp": 3,
+		"images/Old.png.bak":        1,
+	}
+
+	assets = ApplyRequestCounts(assets, requests)
+
+	tests := []struct {
+		path     string
+		requests int
+	}{
+		{"public/images/logo.png", 5},
+		{"src/assets/hero.webp", 3}, // bundled under a hashed name
+		{"public/images/old.png", 0},
+		{"public/icons/logo.png", 0}, // same name, different directory
+	}
+	for i, tt := range tests {
+		if assets[i].Requests != tt.requests {
+			t.Errorf("%s: Requests = %d, want %d", tt.path, assets[i].Requests, tt.requests)
+		}
+		if assets[i].NeverRequested != (tt.requests == 0) {
+			t.Errorf("%s: NeverRequested = %v, want %v", tt.path, assets[i].NeverRequested, tt.requests == 0)
+		}
+	}
+}
+
+func TestApplyRenderCounts(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "public/images/logo.png", Status: models.StatusUsed},
+		{RelativePath: "public/images/promo.png", Status: models.StatusUsed},
+		{RelativePath: "public/favicon.ico", Status: models.StatusConventional},
+		{RelativePath: "public/images/old.png", Status: models.StatusUnused},
+	}
+	renders := map[string]int{"images/logo.png": 2}
+
+	assets = ApplyRenderCounts(assets, renders)
+
+	want := []struct {
+		renders int
+		status  models.AssetStatus
+	}{
+		{2, models.StatusUsed},
+		{0, models.StatusPotentiallyUnused}, // referenced, but never rendered
+		{0, models.StatusConventional},
+		{0, models.StatusUnused},
+	}
+	for i, w := range want {
+		if assets[i].Renders != w.renders || assets[i].Status != w.status {
+			t.Errorf("%s: Renders = %d, Status = %v; want %d, %v",
+				assets[i].RelativePath, assets[i].Renders, assets[i].Status, w.renders, w.status)
+		}
+		if assets[i].NeverRendered != (w.renders == 0) {
+			t.Errorf("%s: NeverRendered = %v, want %v", assets[i].RelativePath, assets[i].NeverRendered, w.renders == 0)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package classifier - Review reasons
+//
+// Assets classified Needs Manual Review or Potentially Unused carry the
+// evidence behind their status ("dynamic template literal in src/x.ts:10",
+// "reference inside comment in src/y.ts:3"), so reviewers know what to check.
+package classifier
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// maxReviewReasons caps the reasons listed per asset; the rest are counted
+const maxReviewReasons = 5
+
+// refTypeNames describe reference types in review reasons
+var refTypeNames = map[models.ReferenceType]string{
+	models.RefTypeImport:          "import",
+	models.RefTypeStringLiteral:   "string literal",
+	models.RefTypeTemplateLiteral: "template literal",
+	models.RefTypeCSSUrl:          "CSS url()",
+	models.RefTypeHTMLAttribute:   "HTML attribute",
+	models.RefTypeConstant:        "constant",
+	models.RefTypeFunctionCall:    "function call",
+	models.RefTypeConfig:          "config entry",
+}
+
+// ReviewReasons explains why an asset needs review: its dynamic, comment,
+// and dead code references, a match on its file name alone, or an
+// extension-less file no reference names. Assets with other statuses have none.
+func ReviewReasons(asset *models.AssetFile) []string {
+	if asset.Status != models.StatusNeedsManualReview && asset.Status != models.StatusPotentiallyUnused {
+		return nil
+	}
+	if len(asset.References) == 0 {
+		if asset.SniffedType != "" {
+			return []string{fmt.Sprintf("extension-less file detected as %s; no reference names it", asset.SniffedType)}
+		}
+		return nil
+	}
+
+	var reasons []string
+	extra := 0
+	add := func(reason string) {
+		if len(reasons) < maxReviewReasons {
+			reasons = append(reasons, reason)
+		} else {
+			extra++
+		}
+	}
+
+	active, basenameOnly := 0, true
+	for _, ref := range asset.References {
+		if ref.FromUnreachable {
+			continue
+		}
+		location := referenceLocation(asset, ref)
+		switch {
+		case ref.IsDeadCode:
+			add("reference in dead code at " + location)
+		case ref.IsComment:
+			add("reference inside comment at " + location)
+		case ref.IsDynamic:
+			add(fmt.Sprintf("dynamic %s in %s", refTypeName(ref.Type), location))
+		}
+		if !ref.IsComment && !ref.IsDeadCode {
+			active++
+			basenameOnly = basenameOnly && !strings.ContainsAny(ref.MatchedText, `/\`)
+		}
+	}
+	if active > 0 && basenameOnly && strings.ContainsAny(asset.RelativePath, `/\`) {
+		add(fmt.Sprintf("only basename match (%s)", asset.Name))
+	}
+
+	if extra > 0 {
+		reasons = append(reasons, fmt.Sprintf("and %d more", extra))
+	}
+	return reasons
+}
+
+// refTypeName describes a reference type, falling back to its identifier
+func refTypeName(t models.ReferenceType) string {
+	if name, ok := refTypeNames[t]; ok {
+		return name
+	}
+	return t.String()
+}
+
+// referenceLocation formats file:line of a reference
+func referenceLocation(asset *models.AssetFile, ref *models.Reference) string {
+	return fmt.Sprintf("%s:%d", relativeSource(asset, ref.SourceFile), ref.LineNumber)
+}
+
+// relativeSource makes a source file relative to the project root the
+// asset's paths share, when it lies inside it
+func relativeSource(asset *models.AssetFile, source string) string {
+	if asset.RelativePath != "" && strings.HasSuffix(asset.Path, asset.RelativePath) {
+		root := strings.TrimSuffix(asset.Path, asset.RelativePath)
+		if rel, err := filepath.Rel(root, source); err == nil && !strings.HasPrefix(rel, "..") {
+			source = rel
+		}
+	}
+	return filepath.ToSlash(source)
+}
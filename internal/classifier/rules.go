@@ -0,0 +1,69 @@
+// Package classifier - Status rules
+//
+// Configured rules encode project policy on top of the built-in
+// classification, e.g. "anything under assets/brand/ is never deletable".
+// The first rule whose conditions an asset meets sets its status and/or
+// severity; later rules don't apply to it.
+package classifier
+
+import (
+	"path/filepath"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// ApplyStatusRules applies the first matching rule to each asset, recording
+// its label on the asset. Rules are expected to be validated already; ones
+// with invalid values are skipped.
+func ApplyStatusRules(assets []models.AssetFile, rules []models.StatusRule) []models.AssetFile {
+	if len(rules) == 0 {
+		return assets
+	}
+
+	for i := range assets {
+		relPath := filepath.ToSlash(assets[i].RelativePath)
+		for _, rule := range rules {
+			if !ruleMatches(rule, &assets[i], relPath) {
+				continue
+			}
+			if rule.SetStatus != "" {
+				if status, err := models.ParseAssetStatus(rule.SetStatus); err == nil {
+					assets[i].Status = status
+					if status == models.StatusNeedsManualReview || status == models.StatusPotentiallyUnused {
+						assets[i].ReviewReasons = append(assets[i].ReviewReasons, "status set by rule "+rule.Label())
+					} else {
+						assets[i].ReviewReasons = nil
+					}
+				}
+			}
+			if rule.Severity != "" {
+				if severity, err := models.ParseSeverity(rule.Severity); err == nil {
+					assets[i].Severity = severity
+				}
+			}
+			assets[i].Rule = rule.Label()
+			break
+		}
+	}
+
+	return assets
+}
+
+// ruleMatches reports whether an asset meets every condition a rule sets
+func ruleMatches(rule models.StatusRule, asset *models.AssetFile, relPath string) bool {
+	if rule.Path != "" && !utils.MatchGlob(rule.Path, relPath) {
+		return false
+	}
+	if rule.Status != "" {
+		status, err := models.ParseAssetStatus(rule.Status)
+		if err != nil || status != asset.Status {
+			return false
+		}
+	}
+	if matched, err := rule.MatchRefCount(asset.RefCount); err != nil || !matched {
+		return false
+	}
+	matched, err := rule.MatchUsageConfidence(asset.UsageConfidence)
+	return err == nil && matched
+}
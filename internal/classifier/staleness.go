@@ -0,0 +1,60 @@
+// Package classifier - Staleness scoring
+//
+// Combines the last time an asset was touched with its usage status.
+// An unused asset untouched for years is a far safer deletion candidate
+// than one added last week.
+package classifier
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+const hoursPerYear = 24 * 365
+
+// statusStalenessWeight scales the staleness score by how likely an asset is unused
+var statusStalenessWeight = map[models.AssetStatus]float64{
+	models.StatusUsed:               0,
+	models.StatusUnused:             1.0,
+	models.StatusPotentiallyUnused:  0.5,
+	models.StatusNeedsManualReview:  0.25,
+	models.StatusKept:               0,
+	models.StatusConventional:       0,
+	models.StatusTransitivelyUnused: 1.0,
+}
+
+// ScoreStaleness sets LastTouched and StalenessScore on each asset.
+// lastCommits maps slash-separated relative paths to their last commit time;
+// assets missing from it (or a nil map) fall back to filesystem ModTime.
+// The score is years since last touch, weighted by status (0 for used assets).
+func ScoreStaleness(assets []models.AssetFile, lastCommits map[string]time.Time, now time.Time) []models.AssetFile {
+	for i := range assets {
+		touched := assets[i].ModTime
+		if t, ok := lastCommits[filepath.ToSlash(assets[i].RelativePath)]; ok {
+			touched = t
+		}
+
+		assets[i].LastTouched = touched
+		assets[i].StalenessScore = StalenessScore(assets[i].Status, touched, now)
+	}
+	return assets
+}
+
+// StalenessScore computes the staleness score for a single asset
+func StalenessScore(status models.AssetStatus, lastTouched, now time.Time) float64 {
+	if lastTouched.IsZero() || lastTouched.After(now) {
+		return 0
+	}
+	years := now.Sub(lastTouched).Hours() / hoursPerYear
+	return years * statusStalenessWeight[status]
+}
+
+// AgeInDays returns how many whole days have passed since the asset was last touched
+func AgeInDays(asset *models.AssetFile, now time.Time) int {
+	if asset.LastTouched.IsZero() {
+		return 0
+	}
+	return int(now.Sub(asset.LastTouched).Hours() / 24)
+}
@@ -0,0 +1,54 @@
+package classifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestScoreStaleness(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	twoYearsAgo := now.AddDate(-2, 0, 0)
+	lastWeek := now.AddDate(0, 0, -7)
+
+	assets := []models.AssetFile{
+		{RelativePath: "assets/old.png", Status: models.StatusUnused},
+		{RelativePath: "assets/new.png", Status: models.StatusUnused, ModTime: lastWeek},
+		{RelativePath: "assets/used.png", Status: models.StatusUsed},
+	}
+	lastCommits := map[string]time.Time{
+		"assets/old.png":  twoYearsAgo,
+		"assets/used.png": twoYearsAgo,
+	}
+
+	assets = ScoreStaleness(assets, lastCommits, now)
+
+	if !assets[0].LastTouched.Equal(twoYearsAgo) {
+		t.Errorf("Expected git commit time for old.png, got %v", assets[0].LastTouched)
+	}
+	if !assets[1].LastTouched.Equal(lastWeek) {
+		t.Errorf("Expected ModTime fallback for new.png, got %v", assets[1].LastTouched)
+	}
+	if assets[0].StalenessScore < 1.9 || assets[0].StalenessScore > 2.1 {
+		t.Errorf("Expected ~2.0 staleness for old unused asset, got %f", assets[0].StalenessScore)
+	}
+	if assets[0].StalenessScore <= assets[1].StalenessScore {
+		t.Error("Expected old asset to be staler than new asset")
+	}
+	if assets[2].StalenessScore != 0 {
+		t.Errorf("Expected 0 staleness for used asset, got %f", assets[2].StalenessScore)
+	}
+}
+
+func TestAgeInDays(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	asset := &models.AssetFile{LastTouched: now.AddDate(0, 0, -400)}
+
+	if days := AgeInDays(asset, now); days != 400 {
+		t.Errorf("AgeInDays() = %d, want 400", days)
+	}
+	if days := AgeInDays(&models.AssetFile{}, now); days != 0 {
+		t.Errorf("AgeInDays() for zero time = %d, want 0", days)
+	}
+}
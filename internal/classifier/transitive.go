@@ -0,0 +1,124 @@
+// Package classifier - Transitive unused propagation
+//
+// An image referenced only from an orphaned stylesheet, or only from a
+// Lottie animation nothing plays, is as unused as one nothing references.
+// Such assets are marked Transitively Unused with the chain of unused files
+// that references them.
+package classifier
+
+import (
+	"sort"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// ApplyTransitiveUnused marks Transitively Unused the assets no live file
+// reaches: every active reference comes from unused files, whether assets
+// that are themselves unused, source files no entry point reaches, or other
+// assets referenced the same way, as with two orphaned stylesheets importing
+// each other. unreachableChains maps those source files to their chain of
+// importers (see ModuleGraph.UnreachableChains); it may be nil. Kept and
+// Conventional assets are never changed, and vouch for what they
+// reference, so keep rules and keep_paths should run first; assets a status
+// rule matched keep the status the rule left them with.
+func ApplyTransitiveUnused(assets []models.AssetFile, unreachableChains map[string][]string) []models.AssetFile {
+	byPath := make(map[string]int, len(assets))
+	for i := range assets {
+		byPath[assets[i].Path] = i
+	}
+
+	// Walk from the live files: reachable source files, and assets whose
+	// status this never changes and isn't unused. A Used candidate a live
+	// file references is live in turn; an Unused one stays a dead end.
+	candidate := func(i int) bool {
+		s := assets[i].Status
+		return (s == models.StatusUsed || s == models.StatusUnused) && assets[i].Rule == ""
+	}
+	sources := make([][]string, len(assets))
+	referencedBy := make(map[int][]int) // asset index to candidates it references
+	live := make([]bool, len(assets))
+	var queue []int
+	for i := range assets {
+		if !candidate(i) {
+			live[i] = !assets[i].Status.IsUnused()
+			continue
+		}
+		for _, ref := range assets[i].References {
+			if ref.IsComment || ref.IsDeadCode {
+				continue
+			}
+			j, isAsset := byPath[ref.SourceFile]
+			if j == i && isAsset {
+				continue
+			}
+			sources[i] = append(sources[i], ref.SourceFile)
+			if isAsset {
+				referencedBy[j] = append(referencedBy[j], i)
+			} else if !ref.FromUnreachable && !live[i] {
+				live[i] = true
+				queue = append(queue, i)
+			}
+		}
+		sort.Strings(sources[i])
+	}
+	for i := range assets {
+		if !candidate(i) && live[i] {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		j := queue[0]
+		queue = queue[1:]
+		if candidate(j) && assets[j].Status != models.StatusUsed {
+			continue
+		}
+		for _, i := range referencedBy[j] {
+			if !live[i] {
+				live[i] = true
+				queue = append(queue, i)
+			}
+		}
+	}
+
+	var marked []int
+	for i := range assets {
+		if candidate(i) && !live[i] && len(sources[i]) > 0 {
+			marked = append(marked, i)
+		}
+	}
+	for _, i := range marked {
+		assets[i].Status = models.StatusTransitivelyUnused
+		assets[i].ReviewReasons = nil
+	}
+	for _, i := range marked {
+		assets[i].UnusedChain = unusedChain(assets, i, sources, byPath, unreachableChains)
+	}
+	return assets
+}
+
+// unusedChain follows assets[i]'s first source in path order back to an
+// unused file, returning the files passed on the way. A cycle of assets
+// referencing each other ends where it comes back around.
+func unusedChain(assets []models.AssetFile, i int, sources [][]string, byPath map[string]int, unreachableChains map[string][]string) []string {
+	var chain []string
+	seen := map[int]bool{i: true}
+	for current := i; ; {
+		source := sources[current][0]
+		j, isAsset := byPath[source]
+		if !isAsset {
+			if unreachable, ok := unreachableChains[source]; ok {
+				return append(chain, unreachable...)
+			}
+			return append(chain, relativeSource(&assets[current], source))
+		}
+		chain = append(chain, assets[j].RelativePath)
+		if seen[j] || len(sources[j]) == 0 || assets[j].Status != models.StatusTransitivelyUnused {
+			if !seen[j] {
+				chain = append(chain, assets[j].UnusedChain...)
+			}
+			return chain
+		}
+		seen[j] = true
+		current = j
+	}
+}
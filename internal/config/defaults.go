@@ -6,6 +6,10 @@ package config
 
 import "github.com/HabibPro1999/easyClean/internal/models"
 
+// DefaultHistoryLimit is how many earlier scans are kept per project when
+// history_limit is not configured
+const DefaultHistoryLimit = 10
+
 // DefaultConfig returns the default project configuration
 func DefaultConfig() *models.ProjectConfig {
 	return &models.ProjectConfig{
@@ -24,6 +28,12 @@ func DefaultConfig() *models.ProjectConfig {
 			".mp4", ".webm", ".mov", ".avi", ".mkv",
 			// Audio
 			".mp3", ".wav", ".ogg", ".m4a", ".flac",
+			// 3D models
+			".glb", ".gltf", ".obj", ".fbx",
+			// Animations
+			".lottie", ".riv",
+			// Documents
+			".pdf", ".doc", ".docx", ".xlsx", ".pptx",
 		},
 		ExcludePaths: []string{
 			"node_modules/",
@@ -34,6 +44,7 @@ func DefaultConfig() *models.ProjectConfig {
 			"vendor/",
 			".git/",
 			"__pycache__/",
+			".easyclean-quarantine/",
 			// Platform-specific assets (always exclude)
 			"android/app/src/main/res/",
 			"android/app/src/main/",
@@ -42,14 +53,29 @@ func DefaultConfig() *models.ProjectConfig {
 			"ios/",
 			"android/",
 		},
+		ConventionalFiles:     []string{},
+		KeepPaths:             []string{},
 		ConstantFiles:         []string{},
 		BasePathVars:          []string{},
 		CustomPatterns:        []string{},
+		ContentSources:        []string{},
+		PublicBaseURLs:        []string{},
+		Buckets:               []string{},
+		AccessLogs:            []string{},
+		UsageFiles:            []string{},
+		EntryPoints:           []string{},
+		LicenseFile:           DefaultLicenseFile,
 		FollowSymlinks:        false,
+		IncludeHidden:         false,
+		ScanDotfiles:          false,
 		AutoDetectProjectType: true,
 		ProjectType:           models.ProjectTypeUnknown,
 		MaxWorkers:            0, // Auto-detect
 		MemoryLimit:           0, // No limit
+		MaxDepth:              0, // No limit
+		MaxFiles:              0, // No limit
+		HistoryLimit:          DefaultHistoryLimit,
+		HistoryMaxAgeDays:     0, // No age limit
 		Verbose:               false,
 		ShowProgress:          true,
 		ColorOutput:           true,
@@ -68,6 +94,23 @@ var projectAssetPaths = map[models.ProjectType][]string{
 	models.ProjectTypeAndroid:     {"res/drawable/", "res/raw/", "assets/"},
 	models.ProjectTypeGo:          {"assets/", "static/", "web/"},
 	models.ProjectTypeRust:        {"assets/", "static/", "resources/"},
+	// Hugo page bundles keep a page's images next to its index.md
+	models.ProjectTypeHugo:     {"static/", "assets/", "content/"},
+	models.ProjectTypeJekyll:   {"assets/", "images/", "img/"},
+	models.ProjectTypeEleventy: {"assets/", "img/", "images/", "src/assets/", "src/img/", "public/"},
+	// A theme or plugin repository, or a wp-content directory holding them
+	models.ProjectTypeWordPress: {"assets/", "images/", "img/", "fonts/", "themes/", "plugins/", "wp-content/themes/", "wp-content/plugins/"},
+}
+
+// projectExcludePaths maps project types to paths they exclude on top of the
+// defaults: build output written into the project, which names every asset
+// it was built from, and files the code never references
+var projectExcludePaths = map[models.ProjectType][]string{
+	models.ProjectTypeHugo:     {"public/", "resources/_gen/"},
+	models.ProjectTypeJekyll:   {"_site/", ".jekyll-cache/"},
+	models.ProjectTypeEleventy: {"_site/"},
+	// Uploads are referenced from the database, never from the code
+	models.ProjectTypeWordPress: {"uploads/", "wp-content/uploads/", "wp-admin/", "wp-includes/"},
 }
 
 // defaultAssetPaths is the fallback for unknown project types
@@ -81,6 +124,12 @@ func DefaultAssetPathsForProjectType(pt models.ProjectType) []string {
 	return defaultAssetPaths
 }
 
+// ExcludePathsForProjectType returns the extra paths excluded for a project
+// type, on top of the default excludes
+func ExcludePathsForProjectType(pt models.ProjectType) []string {
+	return projectExcludePaths[pt]
+}
+
 // baseExtensions are the common asset extensions used by most project types
 var baseExtensions = []string{
 	".jpg", ".jpeg", ".png", ".gif", ".svg", ".webp",
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/spf13/viper"
+)
+
+// DefaultLicenseFile is the license mapping file looked up in the project root
+const DefaultLicenseFile = "asset-licenses.yaml"
+
+// LoadLicenses loads license rules from an asset-licenses.yaml file.
+// Returns nil (and no error) when the file does not exist.
+//
+// Format:
+//
+//	licenses:
+//	  - path: "assets/icons/**"
+//	    license: CC-BY-4.0
+//	    attribution: "Icons by Jane Doe"
+func LoadLicenses(path string) ([]models.LicenseRule, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read license file: %w", err)
+	}
+
+	var file struct {
+		Licenses []models.LicenseRule `mapstructure:"licenses"`
+	}
+	if err := v.Unmarshal(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse license file: %w", err)
+	}
+
+	return file.Licenses, nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadLicenses_NoFile(t *testing.T) {
+	rules, err := LoadLicenses(filepath.Join(t.TempDir(), DefaultLicenseFile))
+
+	if err != nil {
+		t.Fatalf("LoadLicenses() should not error on missing file: %v", err)
+	}
+	if rules != nil {
+		t.Errorf("LoadLicenses() = %v, want nil", rules)
+	}
+}
+
+func TestLoadLicenses_WithFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultLicenseFile)
+
+	content := `licenses:
+  - path: "assets/icons/**"
+    license: CC-BY-4.0
+    attribution: "Icons by Jane Doe"
+  - path: "assets/photos/*.jpg"
+    license: CC0-1.0
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write license file: %v", err)
+	}
+
+	rules, err := LoadLicenses(path)
+	if err != nil {
+		t.Fatalf("LoadLicenses() failed: %v", err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf("LoadLicenses() returned %d rules, want 2", len(rules))
+	}
+	if rules[0].Path != "assets/icons/**" || rules[0].License != "CC-BY-4.0" || rules[0].Attribution != "Icons by Jane Doe" {
+		t.Errorf("rules[0] = %+v, unexpected value", rules[0])
+	}
+	if rules[1].License != "CC0-1.0" {
+		t.Errorf("rules[1].License = %q, want %q", rules[1].License, "CC0-1.0")
+	}
+}
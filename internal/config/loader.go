@@ -4,13 +4,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/parser"
 	"github.com/spf13/viper"
 )
 
 // LoadConfig loads configuration from file or returns defaults
 func LoadConfig(configPath string) (*models.ProjectConfig, error) {
+	return LoadConfigProfile(configPath, "")
+}
+
+// LoadConfigProfile loads configuration like LoadConfig, with the settings of
+// a named entry under profiles: applied over the top-level ones
+func LoadConfigProfile(configPath, profile string) (*models.ProjectConfig, error) {
 	// Check if config file exists
 	if configPath == "" {
 		configPath = ".unusedassets.yaml"
@@ -18,6 +28,9 @@ func LoadConfig(configPath string) (*models.ProjectConfig, error) {
 
 	// If file doesn't exist, return defaults
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if profile != "" {
+			return nil, fmt.Errorf("profile %q not found: %s does not exist", profile, configPath)
+		}
 		return DefaultConfig(), nil
 	}
 
@@ -31,11 +44,18 @@ func LoadConfig(configPath string) (*models.ProjectConfig, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	if profile != "" {
+		if err := applyProfile(v, profile); err != nil {
+			return nil, err
+		}
+	}
+
 	// Start with empty config and unmarshal from file
 	cfg := &models.ProjectConfig{}
 	if err := v.Unmarshal(cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	cfg.Profile = profile
 
 	// Apply defaults for fields not specified in config
 	if len(cfg.AssetPaths) == 0 {
@@ -47,7 +67,97 @@ func LoadConfig(configPath string) (*models.ProjectConfig, error) {
 	if len(cfg.ExcludePaths) == 0 {
 		cfg.ExcludePaths = DefaultConfig().ExcludePaths
 	}
+	if cfg.LicenseFile == "" {
+		cfg.LicenseFile = DefaultLicenseFile
+	}
+	if !v.IsSet("history_limit") {
+		cfg.HistoryLimit = DefaultHistoryLimit
+	}
+	if cfg.MaxDepth < 0 || cfg.MaxFiles < 0 {
+		return nil, fmt.Errorf("max_depth and max_files must not be negative")
+	}
+	if cfg.IOThrottle.MaxConcurrentReads < 0 || cfg.IOThrottle.DirectoryPauseMs < 0 {
+		return nil, fmt.Errorf("io_throttle values must not be negative")
+	}
+	if cfg.HistoryLimit < 0 || cfg.HistoryMaxAgeDays < 0 {
+		return nil, fmt.Errorf("history_limit and history_max_age_days must not be negative")
+	}
+	if err := cfg.ValidateSeverity(); err != nil {
+		return nil, fmt.Errorf("invalid severity config: %w", err)
+	}
+	if err := cfg.ValidateStatusRules(); err != nil {
+		return nil, fmt.Errorf("invalid rules config: %w", err)
+	}
+	if err := cfg.ValidateCategories(); err != nil {
+		return nil, fmt.Errorf("invalid categories config: %w", err)
+	}
+	for _, exts := range cfg.Categories {
+		for _, ext := range exts {
+			if !slices.Contains(cfg.Extensions, ext) {
+				cfg.Extensions = append(cfg.Extensions, ext)
+			}
+		}
+	}
+	if _, err := parser.CompileCustomPatterns(cfg.CustomPatterns); err != nil {
+		return nil, fmt.Errorf("invalid custom_patterns config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// applyProfile merges the settings of profiles.<name> over the top-level
+// config; maps such as severity merge key by key, lists are replaced
+func applyProfile(v *viper.Viper, name string) error {
+	profiles := v.GetStringMap("profiles")
+	settings, ok := profiles[strings.ToLower(name)].(map[string]any)
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return fmt.Errorf("unknown profile %q: no profiles configured", name)
+		}
+		return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to apply profile %q: %w", name, err)
+	}
+	return nil
+}
+
+// dirConfigKeys are the settings a nested .unusedassets.yaml may override
+var dirConfigKeys = map[string]bool{"extensions": true, "exclude_paths": true, "keep_paths": true}
+
+// LoadDirConfig reads a .unusedassets.yaml nested below the project root.
+// Only extensions, exclude_paths, and keep_paths apply to a subtree; any
+// other setting is an error rather than silently ignored.
+func LoadDirConfig(configPath string) (*models.DirConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
 
+	var unsupported []string
+	for _, key := range v.AllKeys() {
+		top, _, _ := strings.Cut(key, ".")
+		if !dirConfigKeys[top] && !slices.Contains(unsupported, top) {
+			unsupported = append(unsupported, top)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return nil, fmt.Errorf("%s: %s cannot be set per directory (only extensions, exclude_paths, keep_paths)",
+			configPath, strings.Join(unsupported, ", "))
+	}
+
+	cfg := &models.DirConfig{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", configPath, err)
+	}
 	return cfg, nil
 }
 
@@ -57,24 +167,11 @@ func SaveConfig(cfg *models.ProjectConfig, configPath string) error {
 		configPath = ".unusedassets.yaml"
 	}
 
-	v := viper.New()
-
-	// Set all config values
-	v.Set("asset_paths", cfg.AssetPaths)
-	v.Set("extensions", cfg.Extensions)
-	v.Set("exclude_paths", cfg.ExcludePaths)
-	v.Set("constant_files", cfg.ConstantFiles)
-	v.Set("base_path_vars", cfg.BasePathVars)
-	v.Set("custom_patterns", cfg.CustomPatterns)
-	v.Set("follow_symlinks", cfg.FollowSymlinks)
-	v.Set("auto_detect_project_type", cfg.AutoDetectProjectType)
-	v.Set("max_workers", cfg.MaxWorkers)
-	v.Set("memory_limit", cfg.MemoryLimit)
-	v.Set("show_progress", cfg.ShowProgress)
-	v.Set("color_output", cfg.ColorOutput)
-
-	// Write to file
-	return v.WriteConfigAs(configPath)
+	data, err := MarshalConfig(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configPath, data, 0644)
 }
 
 // ConfigExists checks if a config file exists
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -62,6 +63,113 @@ max_workers: 16
 	}
 }
 
+func TestLoadConfig_HistoryLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".unusedassets.yaml")
+
+	for content, want := range map[string]int{
+		"max_workers: 4\n":    DefaultHistoryLimit, // unset keeps the default
+		"history_limit: 0\n":  0,
+		"history_limit: 25\n": 25,
+	} {
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+		cfg, err := LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("LoadConfig(%q) failed: %v", content, err)
+		}
+		if cfg.HistoryLimit != want {
+			t.Errorf("LoadConfig(%q).HistoryLimit = %d, want %d", content, cfg.HistoryLimit, want)
+		}
+	}
+
+	if err := os.WriteFile(configPath, []byte("history_max_age_days: -1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected error for negative history_max_age_days, got nil")
+	}
+}
+
+func TestLoadConfigProfile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".unusedassets.yaml")
+
+	configContent := `max_depth: 20
+severity:
+  unused: error
+  needs_review: info
+profiles:
+  quick:
+    regex_only: true
+    max_depth: 6
+  deep:
+    reachability_analysis: true
+    dead_code_analysis: true
+    severity:
+      needs_review: warning
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	base, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+	if base.RegexOnly || base.MaxDepth != 20 || base.Profile != "" {
+		t.Errorf("LoadConfig() applied a profile: %+v", base)
+	}
+
+	quick, err := LoadConfigProfile(configPath, "quick")
+	if err != nil {
+		t.Fatalf("LoadConfigProfile(quick) failed: %v", err)
+	}
+	if !quick.RegexOnly || quick.MaxDepth != 6 || quick.Profile != "quick" {
+		t.Errorf("quick profile = regex_only %v, max_depth %d, profile %q", quick.RegexOnly, quick.MaxDepth, quick.Profile)
+	}
+
+	deep, err := LoadConfigProfile(configPath, "deep")
+	if err != nil {
+		t.Fatalf("LoadConfigProfile(deep) failed: %v", err)
+	}
+	if !deep.ReachabilityAnalysis || !deep.DeadCodeAnalysis || deep.MaxDepth != 20 {
+		t.Errorf("deep profile = %+v", deep)
+	}
+	// Maps merge key by key
+	if deep.Severity["needs_review"] != "warning" || deep.Severity["unused"] != "error" {
+		t.Errorf("deep severity = %v, want needs_review overridden and unused kept", deep.Severity)
+	}
+
+	if _, err := LoadConfigProfile(configPath, "thorough"); err == nil || !strings.Contains(err.Error(), "deep, quick") {
+		t.Errorf("LoadConfigProfile(unknown) error = %v, want the available profiles listed", err)
+	}
+}
+
+func TestLoadDirConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".unusedassets.yaml")
+
+	if err := os.WriteFile(configPath, []byte("extensions: [\".png\"]\nkeep_paths: [\"icons/**\"]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	dirCfg, err := LoadDirConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadDirConfig() failed: %v", err)
+	}
+	if len(dirCfg.Extensions) != 1 || len(dirCfg.KeepPaths) != 1 || len(dirCfg.ExcludePaths) != 0 {
+		t.Errorf("LoadDirConfig() = %+v", dirCfg)
+	}
+
+	if err := os.WriteFile(configPath, []byte("max_depth: 3\nseverity:\n  unused: error\n  needs_review: info\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if _, err := LoadDirConfig(configPath); err == nil || !strings.Contains(err.Error(), "max_depth, severity cannot be set per directory") {
+		t.Errorf("LoadDirConfig() with project settings error = %v", err)
+	}
+}
+
 func TestLoadConfig_InvalidYAML(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, ".unusedassets.yaml")
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// configField is a documented key in the generated YAML
+type configField struct {
+	key     string
+	comment string
+	value   func(cfg *models.ProjectConfig) any
+}
+
+// configSection groups related keys under a heading
+type configSection struct {
+	title  string
+	fields []configField
+}
+
+// configLayout defines the key order and documentation of generated config files
+var configLayout = []configSection{
+	{"Asset discovery", []configField{
+		{"asset_paths", "Directories containing asset files", func(c *models.ProjectConfig) any { return c.AssetPaths }},
+		{"extensions", "File extensions treated as assets", func(c *models.ProjectConfig) any { return c.Extensions }},
+		{"exclude_paths", "Paths and patterns skipped during scanning", func(c *models.ProjectConfig) any { return c.ExcludePaths }},
+		{"categories", "Extra extensions per category (model, animation, document, ...)", func(c *models.ProjectConfig) any { return c.Categories }},
+		{"sniff_content", "Detect extension-less assets from their magic bytes", func(c *models.ProjectConfig) any { return c.SniffContent }},
+		{"conventional_files", "Extra globs for assets used by well-known path (robots.txt, og-image.png)", func(c *models.ProjectConfig) any { return c.ConventionalFiles }},
+		{"keep_paths", "Globs for assets never reported unused or deleted", func(c *models.ProjectConfig) any { return c.KeepPaths }},
+		{"discover_asset_paths", "Use directories holding many assets instead of conventions", func(c *models.ProjectConfig) any { return c.DiscoverAssetPaths }},
+		{"discovery_min_files", "Asset files a directory needs to be discovered (0 = default)", func(c *models.ProjectConfig) any { return c.DiscoveryMinFiles }},
+	}},
+	{"Reference detection", []configField{
+		{"constant_files", "Files defining asset path constants", func(c *models.ProjectConfig) any { return c.ConstantFiles }},
+		{"base_path_vars", "Variables used as asset base paths (e.g. PUBLIC_URL)", func(c *models.ProjectConfig) any { return c.BasePathVars }},
+		{"custom_patterns", "Extra regex patterns for asset references", func(c *models.ProjectConfig) any { return c.CustomPatterns }},
+		{"content_sources", "CMS content dirs/globs whose front matter and data fields name assets", func(c *models.ProjectConfig) any { return c.ContentSources }},
+		{"email_templates", "Scan .mjml files and email-only image attributes", func(c *models.ProjectConfig) any { return c.EmailTemplates }},
+		{"public_base_urls", "URLs public assets are served from, for absolute references", func(c *models.ProjectConfig) any { return c.PublicBaseURLs }},
+		{"buckets", "Object storage prefixes to inventory with the bucket command", func(c *models.ProjectConfig) any { return c.Buckets }},
+		{"access_logs", "Access logs whose request counts flag assets never requested in production", func(c *models.ProjectConfig) any { return c.AccessLogs }},
+		{"usage_files", "Runtime usage beacon files; used assets never rendered become potentially unused", func(c *models.ProjectConfig) any { return c.UsageFiles }},
+		{"reachability_analysis", "Treat assets used only by files unreachable from entry points as unused", func(c *models.ProjectConfig) any { return c.ReachabilityAnalysis }},
+		{"entry_points", "Module graph roots for reachability (empty = framework defaults)", func(c *models.ProjectConfig) any { return c.EntryPoints }},
+		{"regex_only", "Skip AST parsing and match references with regex patterns only (faster)", func(c *models.ProjectConfig) any { return c.RegexOnly }},
+		{"dead_code_analysis", "Ignore references inside unreachable code (if (false), unused Go funcs, unread Dart constants)", func(c *models.ProjectConfig) any { return c.DeadCodeAnalysis }},
+	}},
+	{"Behavior", []configField{
+		{"follow_symlinks", "Follow symbolic links during scan", func(c *models.ProjectConfig) any { return c.FollowSymlinks }},
+		{"include_hidden", "Walk dot-directories (.well-known and hidden asset paths always are)", func(c *models.ProjectConfig) any { return c.IncludeHidden }},
+		{"scan_dotfiles", "Treat dot-prefixed files as assets and source files", func(c *models.ProjectConfig) any { return c.ScanDotfiles }},
+		{"auto_detect_project_type", "Pick asset paths from the detected project type", func(c *models.ProjectConfig) any { return c.AutoDetectProjectType }},
+		{"project_type", "Project type override (0 = unknown/auto)", func(c *models.ProjectConfig) any { return int(c.ProjectType) }},
+	}},
+	{"Performance", []configField{
+		{"max_workers", "Concurrent workers (0 = auto-detect CPU cores)", func(c *models.ProjectConfig) any { return c.MaxWorkers }},
+		{"memory_limit", "Memory limit in bytes (0 = no limit)", func(c *models.ProjectConfig) any { return c.MemoryLimit }},
+		{"max_depth", "Directory levels below the root to walk (0 = no limit)", func(c *models.ProjectConfig) any { return c.MaxDepth }},
+		{"max_files", "Files one walk visits before stopping (0 = no limit)", func(c *models.ProjectConfig) any { return c.MaxFiles }},
+		{"io_throttle", "Throttle scans: max_concurrent_reads, directory_pause_ms (0 = no limit)", func(c *models.ProjectConfig) any { return c.IOThrottle }},
+		{"compress_cache", "Gzip cached scan results (recommended for 100k+ assets)", func(c *models.ProjectConfig) any { return c.CompressCache }},
+		{"history_limit", "Earlier scans kept per project for review and diffs (0 = none)", func(c *models.ProjectConfig) any { return c.HistoryLimit }},
+		{"history_max_age_days", "Prune scans older than N days from history (0 = no age limit)", func(c *models.ProjectConfig) any { return c.HistoryMaxAgeDays }},
+		{"cache_dir", "Cache directory for scan results, relative to this file (empty = user cache)", func(c *models.ProjectConfig) any { return c.CacheDir }},
+	}},
+	{"Reporting", []configField{
+		{"license_file", "Asset license mapping, relative to the project root", func(c *models.ProjectConfig) any { return c.LicenseFile }},
+		{"privacy_scan", "Flag images with EXIF GPS coordinates or camera serials", func(c *models.ProjectConfig) any { return c.PrivacyScan }},
+		{"severity", "Severity per classification: off, info, warning, error", func(c *models.ProjectConfig) any { return c.Severity }},
+		{"rules", "Status/severity overrides: path, status, ref_count conditions; set_status, severity actions", func(c *models.ProjectConfig) any { return c.StatusRules }},
+		{"features", "Feature/team name to path glob, for per-feature reports", func(c *models.ProjectConfig) any { return c.Features }},
+	}},
+	{"Output", []configField{
+		{"verbose", "Enable verbose logging", func(c *models.ProjectConfig) any { return c.Verbose }},
+		{"show_progress", "Show progress bar during scan", func(c *models.ProjectConfig) any { return c.ShowProgress }},
+		{"color_output", "Enable colored output", func(c *models.ProjectConfig) any { return c.ColorOutput }},
+	}},
+}
+
+// MarshalConfig renders configuration as commented YAML with a stable key order.
+// Empty maps, rule lists, and throttles are omitted; every other key is always written.
+func MarshalConfig(cfg *models.ProjectConfig) ([]byte, error) {
+	var sb strings.Builder
+
+	sb.WriteString("# easyClean configuration\n")
+	if cfg.ProjectType != models.ProjectTypeUnknown {
+		sb.WriteString(fmt.Sprintf("# Generated for project type: %s\n", cfg.ProjectType))
+	}
+
+	for _, section := range configLayout {
+		sb.WriteString(fmt.Sprintf("\n# --- %s ---\n", section.title))
+
+		for _, field := range section.fields {
+			value := field.value(cfg)
+			if m, ok := value.(map[string]string); ok && len(m) == 0 {
+				continue
+			}
+			if m, ok := value.(map[string][]string); ok && len(m) == 0 {
+				continue
+			}
+			if rules, ok := value.([]models.StatusRule); ok && len(rules) == 0 {
+				continue
+			}
+			if throttle, ok := value.(models.IOThrottle); ok && throttle.IsZero() {
+				continue
+			}
+
+			sb.WriteString(fmt.Sprintf("\n# %s\n", field.comment))
+			if err := writeYAMLValue(&sb, field.key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return []byte(sb.String()), nil
+}
+
+// writeYAMLValue writes "key: value" for scalars, lists, string maps, rules,
+// and throttles
+func writeYAMLValue(sb *strings.Builder, key string, value any) error {
+	switch v := value.(type) {
+	case []models.StatusRule:
+		sb.WriteString(key + ":\n")
+		for _, rule := range v {
+			prefix := "  - "
+			for _, field := range [][2]string{
+				{"name", rule.Name}, {"path", rule.Path}, {"status", rule.Status}, {"ref_count", rule.RefCount},
+				{"set_status", rule.SetStatus}, {"severity", rule.Severity},
+			} {
+				if field[1] == "" {
+					continue
+				}
+				sb.WriteString(prefix + field[0] + ": " + quoteYAML(field[1]) + "\n")
+				prefix = "    "
+			}
+		}
+	case models.IOThrottle:
+		sb.WriteString(key + ":\n")
+		sb.WriteString("  max_concurrent_reads: " + strconv.Itoa(v.MaxConcurrentReads) + "\n")
+		sb.WriteString("  directory_pause_ms: " + strconv.Itoa(v.DirectoryPauseMs) + "\n")
+	case []string:
+		if len(v) == 0 {
+			sb.WriteString(key + ": []\n")
+			return nil
+		}
+		sb.WriteString(key + ":\n")
+		for _, item := range v {
+			sb.WriteString("  - " + quoteYAML(item) + "\n")
+		}
+	case map[string]string:
+		sb.WriteString(key + ":\n")
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sb.WriteString("  " + quoteYAML(k) + ": " + quoteYAML(v[k]) + "\n")
+		}
+	case map[string][]string:
+		sb.WriteString(key + ":\n")
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			items := make([]string, len(v[k]))
+			for i, item := range v[k] {
+				items[i] = quoteYAML(item)
+			}
+			sb.WriteString("  " + quoteYAML(k) + ": [" + strings.Join(items, ", ") + "]\n")
+		}
+	case string:
+		sb.WriteString(key + ": " + quoteYAML(v) + "\n")
+	case bool:
+		sb.WriteString(key + ": " + strconv.FormatBool(v) + "\n")
+	case int:
+		sb.WriteString(key + ": " + strconv.Itoa(v) + "\n")
+	case int64:
+		sb.WriteString(key + ": " + strconv.FormatInt(v, 10) + "\n")
+	default:
+		return fmt.Errorf("unsupported config value for %s: %T", key, value)
+	}
+	return nil
+}
+
+// yamlReserved are plain scalars YAML would not read back as strings
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+	"null": true, "~": true, "y": true, "n": true,
+}
+
+// quoteYAML double-quotes a string when it would not round-trip as a plain scalar
+func quoteYAML(s string) string {
+	if s == "" || yamlReserved[strings.ToLower(s)] || s != strings.TrimSpace(s) {
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") || strings.ContainsAny(s, "#:\\\"") {
+		return strconv.Quote(s)
+	}
+	return s
+}
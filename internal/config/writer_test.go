@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestMarshalConfig_RoundTrip(t *testing.T) {
+	original := DefaultConfig()
+	original.ProjectType = models.ProjectTypeWebReact
+	original.ConstantFiles = []string{"src/constants/assets.ts"}
+	original.CustomPatterns = []string{`asset\("([^"]+)"\)`, "logo: (\\w+\\.png)"}
+	original.MaxWorkers = 8
+	original.MemoryLimit = 1 << 30
+	original.CompressCache = true
+	original.IOThrottle = models.IOThrottle{MaxConcurrentReads: 2, DirectoryPauseMs: 5}
+	original.PrivacyScan = true
+	original.DiscoveryMinFiles = 3
+	original.Severity = map[string]string{"unused": "warning", "needs_review": "off"}
+	original.Features = map[string]string{"checkout": "src/checkout/**"}
+	original.KeepPaths = []string{"assets/brand/**"}
+	original.StatusRules = []models.StatusRule{
+		{Name: "brand assets are never deletable", Path: "assets/brand/**", SetStatus: "kept"},
+		{Path: "assets/legacy/**", RefCount: ">= 1", Severity: "off"},
+	}
+
+	path := filepath.Join(t.TempDir(), ".unusedassets.yaml")
+	if err := SaveConfig(original, path); err != nil {
+		t.Fatalf("SaveConfig() failed: %v", err)
+	}
+
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded, original) {
+		t.Errorf("round trip mismatch\n got: %+v\nwant: %+v", loaded, original)
+	}
+}
+
+func TestMarshalConfig_StableAndCommented(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Features = map[string]string{"b": "src/b/**", "a": "src/a/**"}
+
+	first, err := MarshalConfig(cfg)
+	if err != nil {
+		t.Fatalf("MarshalConfig() failed: %v", err)
+	}
+	second, _ := MarshalConfig(cfg)
+	if string(first) != string(second) {
+		t.Error("MarshalConfig() output is not stable across calls")
+	}
+
+	out := string(first)
+	if !strings.Contains(out, "# Directories containing asset files\nasset_paths:") {
+		t.Error("MarshalConfig() should document asset_paths")
+	}
+	if strings.Index(out, "asset_paths:") > strings.Index(out, "color_output:") {
+		t.Error("MarshalConfig() should keep asset_paths before color_output")
+	}
+	if strings.Index(out, "  a: ") > strings.Index(out, "  b: ") {
+		t.Error("MarshalConfig() should sort map keys")
+	}
+	if strings.Contains(out, "severity:") {
+		t.Error("MarshalConfig() should omit empty maps")
+	}
+}
+
+func TestQuoteYAML(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"assets/", "assets/"},
+		{".png", ".png"},
+		{"*.png", `"*.png"`},
+		{"off", `"off"`},
+		{"123", `"123"`},
+		{"", `""`},
+		{"a: b", `"a: b"`},
+		{" padded", `" padded"`},
+	}
+
+	for _, tt := range tests {
+		if got := quoteYAML(tt.in); got != tt.want {
+			t.Errorf("quoteYAML(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSaveConfig_WritesComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".unusedassets.yaml")
+	if err := SaveConfig(DefaultConfig(), path); err != nil {
+		t.Fatalf("SaveConfig() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# easyClean configuration") {
+		t.Errorf("SaveConfig() output should start with a header comment, got %q", string(data)[:40])
+	}
+}
@@ -0,0 +1,414 @@
+// Package daemon serves scan results to IDE plugins over JSON-RPC.
+//
+// It is a simpler alternative to the language server for plugins that want
+// the data rather than diagnostics: one newline-delimited JSON-RPC 2.0
+// message per line on a unix socket. Methods:
+// - scan {force}: the current scan result, rescanning first if files changed
+// - why {asset}: references to an asset
+// - uses {source}: assets a source file references
+// - delete {paths, dry_run, force}: delete unused assets; an incomplete scan
+//   is refused without force
+// - didChangeFiles {paths} (notification): the editor saved or changed files
+// - shutdown: stop the daemon
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeServerError    = -32000
+)
+
+// maxMessageSize caps one request line
+const maxMessageSize = 4 * 1024 * 1024
+
+// Options configure a daemon for one project
+type Options struct {
+	Root string
+
+	// Scan scans the project
+	Scan func() (*models.ScanResult, error)
+
+	// Watches reports whether a changed file can affect the scan (an asset
+	// or source file outside excluded paths); nil watches everything
+	Watches func(path string) bool
+
+	// Debounce is how long to wait after the last change before rescanning
+	Debounce time.Duration
+}
+
+// Daemon keeps a project's scan result current as the editor reports changes
+type Daemon struct {
+	opts Options
+
+	scanMu sync.Mutex // serializes scans
+
+	mu      sync.Mutex
+	result  *models.ScanResult
+	index   *models.ReferenceIndex
+	changes int // bumped on every relevant change
+	scanned int // value of changes the current result reflects
+	timer   *time.Timer
+
+	listener net.Listener
+	closed   bool
+}
+
+// New returns a daemon for a project; nothing is scanned until asked
+func New(opts Options) *Daemon {
+	return &Daemon{opts: opts, scanned: -1}
+}
+
+type request struct {
+	ID     *json.RawMessage `json:"id"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+}
+
+type response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcError is a handler failure with its JSON-RPC error code
+type rpcError struct {
+	code int
+	err  error
+}
+
+func (e *rpcError) Error() string { return e.err.Error() }
+
+func invalidParams(err error) error {
+	return &rpcError{code: codeInvalidParams, err: err}
+}
+
+// DeleteResult reports what a delete request removed
+type DeleteResult struct {
+	Deleted    []string `json:"deleted"`
+	FreedBytes int64    `json:"freed_bytes"`
+	Errors     []string `json:"errors,omitempty"`
+	DryRun     bool     `json:"dry_run,omitempty"`
+}
+
+// Serve accepts connections until shutdown is requested or the listener is
+// closed
+func (d *Daemon) Serve(listener net.Listener) error {
+	d.mu.Lock()
+	d.listener = listener
+	d.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			d.mu.Lock()
+			closed := d.closed
+			d.mu.Unlock()
+			if closed || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go d.serveConn(conn)
+	}
+}
+
+// Close stops accepting connections and any pending rescan
+func (d *Daemon) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closed = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	if d.listener != nil {
+		return d.listener.Close()
+	}
+	return nil
+}
+
+func (d *Daemon) serveConn(conn io.ReadWriteCloser) {
+	defer conn.Close()
+
+	var writeMu sync.Mutex
+	write := func(resp *response) {
+		resp.JSONRPC = "2.0"
+		line, _ := json.Marshal(resp)
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		conn.Write(append(line, '\n'))
+	}
+
+	lines := bufio.NewScanner(conn)
+	lines.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for lines.Scan() {
+		if len(lines.Bytes()) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(lines.Bytes(), &req); err != nil {
+			write(&response{Error: &responseError{Code: codeParseError, Message: "invalid JSON"}})
+			continue
+		}
+
+		result, err := d.handle(req.Method, req.Params)
+		if req.ID == nil {
+			continue // notifications get no reply
+		}
+		if err != nil {
+			code := codeServerError
+			var rerr *rpcError
+			if errors.As(err, &rerr) {
+				code = rerr.code
+			}
+			write(&response{ID: req.ID, Error: &responseError{Code: code, Message: err.Error()}})
+			continue
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			write(&response{ID: req.ID, Error: &responseError{Code: codeServerError, Message: err.Error()}})
+			continue
+		}
+		write(&response{ID: req.ID, Result: data})
+
+		if req.Method == "shutdown" {
+			d.Close()
+			return
+		}
+	}
+}
+
+func (d *Daemon) handle(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "scan":
+		var p struct {
+			Force bool `json:"force"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Force {
+			d.markChanged()
+		}
+		result, _, err := d.current()
+		return result, err
+
+	case "why":
+		var p struct {
+			Asset string `json:"asset"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Asset == "" {
+			return nil, invalidParams(fmt.Errorf("missing asset"))
+		}
+		_, index, err := d.current()
+		if err != nil {
+			return nil, err
+		}
+		refs, known := index.Why(p.Asset)
+		if !known {
+			return nil, invalidParams(fmt.Errorf("%s is not an asset in the scan results", p.Asset))
+		}
+		if refs == nil {
+			refs = []models.IndexedReference{}
+		}
+		return refs, nil
+
+	case "uses":
+		var p struct {
+			Source string `json:"source"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		_, index, err := d.current()
+		if err != nil {
+			return nil, err
+		}
+		assets := index.Uses(p.Source)
+		if assets == nil {
+			assets = []string{}
+		}
+		return assets, nil
+
+	case "delete":
+		var p struct {
+			Paths  []string `json:"paths"`
+			DryRun bool     `json:"dry_run"`
+			Force  bool     `json:"force"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return d.delete(p.Paths, p.DryRun, p.Force)
+
+	case "didChangeFiles":
+		var p struct {
+			Paths []string `json:"paths"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		d.filesChanged(p.Paths)
+		return nil, nil
+
+	case "shutdown":
+		return nil, nil
+
+	default:
+		return nil, &rpcError{code: codeMethodNotFound, err: fmt.Errorf("method not supported: %s", method)}
+	}
+}
+
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return invalidParams(fmt.Errorf("invalid params: %w", err))
+	}
+	return nil
+}
+
+// current returns an up-to-date scan result, rescanning when files changed
+// since the last scan
+func (d *Daemon) current() (*models.ScanResult, *models.ReferenceIndex, error) {
+	d.mu.Lock()
+	if d.result != nil && d.scanned == d.changes {
+		result, index := d.result, d.index
+		d.mu.Unlock()
+		return result, index, nil
+	}
+	d.mu.Unlock()
+
+	if err := d.rescan(); err != nil {
+		return nil, nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.result, d.index, nil
+}
+
+// rescan scans the project unless a concurrent scan already covered the
+// latest changes
+func (d *Daemon) rescan() error {
+	d.scanMu.Lock()
+	defer d.scanMu.Unlock()
+
+	d.mu.Lock()
+	generation := d.changes
+	upToDate := d.result != nil && d.scanned == generation
+	d.mu.Unlock()
+	if upToDate {
+		return nil
+	}
+
+	result, err := d.opts.Scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+	index := models.BuildReferenceIndex(result)
+
+	d.mu.Lock()
+	d.result, d.index, d.scanned = result, index, generation
+	d.mu.Unlock()
+	return nil
+}
+
+// filesChanged records edits the editor reported and schedules a rescan once
+// they settle; files the scan never reads are ignored
+func (d *Daemon) filesChanged(paths []string) {
+	for _, path := range paths {
+		if d.opts.Watches == nil || d.opts.Watches(path) {
+			d.markChanged()
+			return
+		}
+	}
+}
+
+func (d *Daemon) markChanged() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.changes++
+	if d.closed {
+		return
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.AfterFunc(d.opts.Debounce, func() { d.rescan() })
+}
+
+// delete removes the unused assets among paths, confined to the project
+// root. Results of a scan that hit a traversal limit are refused unless
+// force is set, as assets referenced only from unread files look unused.
+func (d *Daemon) delete(paths []string, dryRun, force bool) (*DeleteResult, error) {
+	if len(paths) == 0 {
+		return nil, invalidParams(fmt.Errorf("missing paths"))
+	}
+	result, index, err := d.current()
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Warnings) > 0 && !dryRun && !force {
+		return nil, fmt.Errorf("refusing to delete assets of an incomplete scan (%s); pass force to delete anyway",
+			strings.Join(result.Warnings, "; "))
+	}
+
+	assets := make(map[string]models.AssetFile, len(result.UnusedAssets))
+	for _, asset := range result.UnusedAssets {
+		assets[asset.RelativePath] = asset
+	}
+
+	outcome := &DeleteResult{Deleted: []string{}, DryRun: dryRun}
+	for _, path := range paths {
+		asset, ok := assets[index.RelativePath(path)]
+		if !ok {
+			outcome.Errors = append(outcome.Errors, fmt.Sprintf("%s: not found in unused assets", path))
+			continue
+		}
+		resolved, err := utils.ResolveInRoot(d.opts.Root, asset.Path)
+		if err != nil {
+			outcome.Errors = append(outcome.Errors, fmt.Sprintf("%s: %v", path, err))
+			continue
+		}
+		if !dryRun {
+			if err := os.Remove(resolved); err != nil {
+				outcome.Errors = append(outcome.Errors, fmt.Sprintf("%s: %v", path, err))
+				continue
+			}
+		}
+		outcome.Deleted = append(outcome.Deleted, asset.RelativePath)
+		outcome.FreedBytes += asset.Size
+	}
+
+	if !dryRun && len(outcome.Deleted) > 0 {
+		d.markChanged()
+	}
+	return outcome, nil
+}
@@ -0,0 +1,292 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// client talks line-delimited JSON-RPC to a daemon over an in-memory pipe
+type client struct {
+	t     *testing.T
+	conn  net.Conn
+	lines *bufio.Scanner
+}
+
+func connect(t *testing.T, d *Daemon) *client {
+	t.Helper()
+	server, conn := net.Pipe()
+	go d.serveConn(server)
+	t.Cleanup(func() { conn.Close() })
+	return &client{t: t, conn: conn, lines: bufio.NewScanner(conn)}
+}
+
+func (c *client) send(msg string) {
+	c.t.Helper()
+	if _, err := c.conn.Write([]byte(msg + "\n")); err != nil {
+		c.t.Fatalf("write failed: %v", err)
+	}
+}
+
+func (c *client) call(msg string) map[string]any {
+	c.t.Helper()
+	c.send(msg)
+	if !c.lines.Scan() {
+		c.t.Fatalf("no response to %s: %v", msg, c.lines.Err())
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(c.lines.Bytes(), &resp); err != nil {
+		c.t.Fatalf("invalid response %q: %v", c.lines.Bytes(), err)
+	}
+	return resp
+}
+
+func errorCode(resp map[string]any) any {
+	if e, ok := resp["error"].(map[string]any); ok {
+		return e["code"]
+	}
+	return nil
+}
+
+// fakeProject counts scans and serves a fixed result
+type fakeProject struct {
+	mu     sync.Mutex
+	scans  int
+	result *models.ScanResult
+}
+
+func (p *fakeProject) scan() (*models.ScanResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.scans++
+	return p.result, nil
+}
+
+func (p *fakeProject) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.scans
+}
+
+func newTestProject(t *testing.T) (string, *fakeProject) {
+	t.Helper()
+	root := t.TempDir()
+	oldPath := filepath.Join(root, "assets", "old.png")
+	if err := os.MkdirAll(filepath.Dir(oldPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(oldPath, []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldAsset := models.AssetFile{Path: oldPath, RelativePath: "assets/old.png", Size: 3, Status: models.StatusUnused}
+	logo := models.AssetFile{
+		Path:         filepath.Join(root, "assets", "logo.png"),
+		RelativePath: "assets/logo.png",
+		Status:       models.StatusUsed,
+		References:   []*models.Reference{{SourceFile: filepath.Join(root, "src", "App.tsx"), LineNumber: 4}},
+	}
+	result := &models.ScanResult{
+		ProjectRoot:  root,
+		Assets:       []models.AssetFile{oldAsset, logo},
+		UnusedAssets: []models.AssetFile{oldAsset},
+	}
+	return root, &fakeProject{result: result}
+}
+
+func TestDaemonQueries(t *testing.T) {
+	root, project := newTestProject(t)
+	d := New(Options{Root: root, Scan: project.scan, Debounce: time.Hour})
+	c := connect(t, d)
+
+	resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"why","params":{"asset":"assets/logo.png"}}`)
+	refs, _ := resp["result"].([]any)
+	if len(refs) != 1 || refs[0].(map[string]any)["SourceFile"] != "src/App.tsx" {
+		t.Errorf("why = %v, want the reference from src/App.tsx", resp)
+	}
+
+	resp = c.call(`{"jsonrpc":"2.0","id":2,"method":"uses","params":{"source":"` + filepath.Join(root, "src", "App.tsx") + `"}}`)
+	if assets, _ := resp["result"].([]any); len(assets) != 1 || assets[0] != "assets/logo.png" {
+		t.Errorf("uses = %v, want [assets/logo.png]", resp)
+	}
+
+	resp = c.call(`{"jsonrpc":"2.0","id":3,"method":"why","params":{"asset":"assets/missing.png"}}`)
+	if errorCode(resp) != float64(codeInvalidParams) {
+		t.Errorf("why on an unknown asset = %v, want invalid params", resp)
+	}
+
+	resp = c.call(`{"jsonrpc":"2.0","id":4,"method":"scan"}`)
+	if result, _ := resp["result"].(map[string]any); result == nil {
+		t.Errorf("scan = %v, want a scan result", resp)
+	}
+	if project.count() != 1 {
+		t.Errorf("scanned %d times, want 1 (results reused until files change)", project.count())
+	}
+
+	resp = c.call(`{"jsonrpc":"2.0","id":5,"method":"hover"}`)
+	if errorCode(resp) != float64(codeMethodNotFound) {
+		t.Errorf("unknown method = %v, want method not found", resp)
+	}
+
+	resp = c.call(`not json`)
+	if errorCode(resp) != float64(codeParseError) {
+		t.Errorf("invalid JSON = %v, want parse error", resp)
+	}
+}
+
+func TestDaemonRescansAfterChanges(t *testing.T) {
+	root, project := newTestProject(t)
+	d := New(Options{
+		Root:     root,
+		Scan:     project.scan,
+		Watches:  func(path string) bool { return strings.HasSuffix(path, ".tsx") },
+		Debounce: time.Hour, // rescans only on the next request
+	})
+	c := connect(t, d)
+
+	c.call(`{"jsonrpc":"2.0","id":1,"method":"scan"}`)
+
+	// Notifications get no reply, so the next response answers the scan
+	c.send(`{"jsonrpc":"2.0","method":"didChangeFiles","params":{"paths":["README.md"]}}`)
+	c.call(`{"jsonrpc":"2.0","id":2,"method":"scan"}`)
+	if project.count() != 1 {
+		t.Errorf("scanned %d times, want 1 after an unwatched change", project.count())
+	}
+
+	c.send(`{"jsonrpc":"2.0","method":"didChangeFiles","params":{"paths":["src/App.tsx"]}}`)
+	c.call(`{"jsonrpc":"2.0","id":3,"method":"scan"}`)
+	if project.count() != 2 {
+		t.Errorf("scanned %d times, want 2 after a source change", project.count())
+	}
+
+	c.call(`{"jsonrpc":"2.0","id":4,"method":"scan","params":{"force":true}}`)
+	if project.count() != 3 {
+		t.Errorf("scanned %d times, want 3 after a forced scan", project.count())
+	}
+}
+
+func TestDaemonDebouncedRescan(t *testing.T) {
+	root, project := newTestProject(t)
+	d := New(Options{Root: root, Scan: project.scan, Debounce: 10 * time.Millisecond})
+	defer d.Close()
+
+	d.filesChanged([]string{"a.tsx"})
+	d.filesChanged([]string{"b.tsx"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for project.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if project.count() != 1 {
+		t.Errorf("scanned %d times, want 1 background scan for both changes", project.count())
+	}
+}
+
+func TestDaemonDelete(t *testing.T) {
+	root, project := newTestProject(t)
+	d := New(Options{Root: root, Scan: project.scan, Debounce: time.Hour})
+	c := connect(t, d)
+	oldPath := filepath.Join(root, "assets", "old.png")
+
+	resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"delete","params":{"paths":["assets/old.png"],"dry_run":true}}`)
+	result := resp["result"].(map[string]any)
+	if deleted := result["deleted"].([]any); len(deleted) != 1 || result["freed_bytes"] != float64(3) {
+		t.Errorf("dry run = %v, want old.png and 3 bytes", resp)
+	}
+	if _, err := os.Stat(oldPath); err != nil {
+		t.Fatalf("dry run removed the file: %v", err)
+	}
+
+	// Used assets and paths outside the unused list are refused
+	resp = c.call(`{"jsonrpc":"2.0","id":2,"method":"delete","params":{"paths":["assets/old.png","assets/logo.png","../etc/passwd"]}}`)
+	result = resp["result"].(map[string]any)
+	if deleted := result["deleted"].([]any); len(deleted) != 1 || deleted[0] != "assets/old.png" {
+		t.Errorf("delete = %v, want only old.png deleted", resp)
+	}
+	if errs := result["errors"].([]any); len(errs) != 2 {
+		t.Errorf("delete errors = %v, want 2", errs)
+	}
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("old.png still exists after delete: %v", err)
+	}
+
+	// The deletion invalidates the results, so the next query rescans
+	c.call(`{"jsonrpc":"2.0","id":3,"method":"scan"}`)
+	if project.count() != 2 {
+		t.Errorf("scanned %d times, want 2 after deleting", project.count())
+	}
+
+	resp = c.call(`{"jsonrpc":"2.0","id":4,"method":"delete","params":{}}`)
+	if errorCode(resp) != float64(codeInvalidParams) {
+		t.Errorf("delete without paths = %v, want invalid params", resp)
+	}
+}
+
+func TestDaemonDeleteIncompleteScan(t *testing.T) {
+	root, project := newTestProject(t)
+	project.result.Warnings = []string{"max_depth (3) reached; deeper directories were not scanned"}
+	d := New(Options{Root: root, Scan: project.scan, Debounce: time.Hour})
+	c := connect(t, d)
+	oldPath := filepath.Join(root, "assets", "old.png")
+
+	resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"delete","params":{"paths":["assets/old.png"]}}`)
+	if errorCode(resp) != float64(codeServerError) {
+		t.Errorf("delete from an incomplete scan = %v, want an error", resp)
+	}
+	if _, err := os.Stat(oldPath); err != nil {
+		t.Fatalf("refused delete removed the file: %v", err)
+	}
+
+	resp = c.call(`{"jsonrpc":"2.0","id":2,"method":"delete","params":{"paths":["assets/old.png"],"dry_run":true}}`)
+	if resp["error"] != nil {
+		t.Errorf("dry run of an incomplete scan = %v, want a result", resp)
+	}
+
+	resp = c.call(`{"jsonrpc":"2.0","id":3,"method":"delete","params":{"paths":["assets/old.png"],"force":true}}`)
+	if deleted, _ := resp["result"].(map[string]any)["deleted"].([]any); len(deleted) != 1 {
+		t.Errorf("forced delete = %v, want old.png deleted", resp)
+	}
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("old.png still exists after a forced delete: %v", err)
+	}
+}
+
+func TestDaemonServeShutdown(t *testing.T) {
+	root, project := newTestProject(t)
+	d := New(Options{Root: root, Scan: project.scan})
+
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "d.sock"))
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- d.Serve(listener) }()
+
+	conn, err := net.Dial("unix", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	c := &client{t: t, conn: conn, lines: bufio.NewScanner(conn)}
+	if resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"shutdown"}`); resp["error"] != nil {
+		t.Errorf("shutdown = %v", resp)
+	}
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Serve() did not return after shutdown")
+	}
+}
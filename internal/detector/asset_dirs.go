@@ -0,0 +1,146 @@
+package detector
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultDiscoveryMinFiles is the asset count a directory needs to be proposed
+const DefaultDiscoveryMinFiles = 5
+
+// dominantChildShare is the share of a directory's assets a single child must
+// hold for discovery to propose the child instead (e.g. src/ -> src/assets/)
+const dominantChildShare = 0.9
+
+// AssetDirCandidate is a directory proposed as an asset path
+type AssetDirCandidate struct {
+	Path  string // slash-separated, relative to root, with trailing slash
+	Count int    // asset files in the directory and its subdirectories
+}
+
+// DiscoverAssetDirs walks root counting files with asset extensions per
+// directory and proposes directories holding at least minFiles of them.
+// Hidden and excluded directories are skipped. When one child directory
+// holds nearly all of a candidate's assets, the child is proposed instead.
+func DiscoverAssetDirs(root string, extensions, exclude []string, minFiles int) []AssetDirCandidate {
+	if minFiles <= 0 {
+		minFiles = DefaultDiscoveryMinFiles
+	}
+
+	extSet := make(map[string]bool, len(extensions))
+	for _, ext := range extensions {
+		extSet[strings.ToLower(ext)] = true
+	}
+
+	// subtree[dir] counts assets in dir and below; children tracks the tree
+	subtree := make(map[string]int)
+	children := make(map[string]map[string]bool)
+
+	filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(root, p)
+		if relErr != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel != "." && isSkippedDir(rel, d.Name(), exclude) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !extSet[strings.ToLower(filepath.Ext(p))] {
+			return nil
+		}
+
+		// Credit the file to every ancestor directory below root
+		for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+			subtree[dir]++
+			parent := path.Dir(dir)
+			if children[parent] == nil {
+				children[parent] = make(map[string]bool)
+			}
+			children[parent][dir] = true
+		}
+		return nil
+	})
+
+	var candidates []AssetDirCandidate
+	var visit func(dir string)
+	visit = func(dir string) {
+		for _, child := range sortedKeys(children[dir]) {
+			count := subtree[child]
+			if count < minFiles {
+				continue
+			}
+			if dominant := dominantChild(child, count, children, subtree); dominant != "" {
+				visit(child)
+				continue
+			}
+			candidates = append(candidates, AssetDirCandidate{Path: child + "/", Count: count})
+		}
+	}
+	visit(".")
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Count != candidates[j].Count {
+			return candidates[i].Count > candidates[j].Count
+		}
+		return candidates[i].Path < candidates[j].Path
+	})
+	return candidates
+}
+
+// DiscoverAssetPaths returns just the paths of DiscoverAssetDirs candidates
+func DiscoverAssetPaths(root string, extensions, exclude []string, minFiles int) []string {
+	var paths []string
+	for _, c := range DiscoverAssetDirs(root, extensions, exclude, minFiles) {
+		paths = append(paths, c.Path)
+	}
+	return paths
+}
+
+// dominantChild returns the child of dir holding nearly all of its assets, if any
+func dominantChild(dir string, total int, children map[string]map[string]bool, subtree map[string]int) string {
+	for child := range children[dir] {
+		if float64(subtree[child]) >= dominantChildShare*float64(total) {
+			return child
+		}
+	}
+	return ""
+}
+
+// isSkippedDir reports whether discovery should skip a directory
+func isSkippedDir(rel, name string, exclude []string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+	for _, pattern := range exclude {
+		trimmed := strings.TrimSuffix(pattern, "/")
+		if name == path.Base(trimmed) || rel == trimmed {
+			return true
+		}
+		if matched, _ := path.Match(trimmed, rel); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package detector
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverAssetDirs(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeAssets := func(dir string, count int, ext string) {
+		for i := 0; i < count; i++ {
+			writeFile(t, filepath.Join(tmpDir, dir, fmt.Sprintf("file%d%s", i, ext)), "x")
+		}
+	}
+
+	writeAssets("src/assets/images", 8, ".png")
+	writeAssets("src/assets/fonts", 3, ".woff2")
+	writeAssets("src", 1, ".svg")
+	writeAssets("public", 6, ".jpg")
+	writeAssets("docs", 2, ".png")              // below threshold
+	writeAssets("node_modules/pkg", 20, ".png") // excluded
+	writeAssets(".cache", 20, ".png")           // hidden
+	writeAssets("lib", 10, ".js")               // not assets
+
+	extensions := []string{".png", ".jpg", ".svg", ".woff2"}
+	exclude := []string{"node_modules/"}
+
+	got := DiscoverAssetDirs(tmpDir, extensions, exclude, 5)
+	want := []AssetDirCandidate{
+		{Path: "src/assets/", Count: 11},
+		{Path: "public/", Count: 6},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiscoverAssetDirs() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiscoverAssetDirs_NoAssets(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile(t, filepath.Join(tmpDir, "src", "main.go"), "package main")
+
+	if got := DiscoverAssetDirs(tmpDir, []string{".png"}, nil, 5); len(got) != 0 {
+		t.Errorf("DiscoverAssetDirs() = %+v, want none", got)
+	}
+}
+
+func TestDiscoverAssetPaths_DefaultThreshold(t *testing.T) {
+	tmpDir := t.TempDir()
+	for i := 0; i < DefaultDiscoveryMinFiles; i++ {
+		writeFile(t, filepath.Join(tmpDir, "art", fmt.Sprintf("a%d.png", i)), "x")
+	}
+	writeFile(t, filepath.Join(tmpDir, "misc", "one.png"), "x")
+
+	got := DiscoverAssetPaths(tmpDir, []string{".png"}, nil, 0)
+	if !reflect.DeepEqual(got, []string{"art/"}) {
+		t.Errorf("DiscoverAssetPaths() = %v, want [art/]", got)
+	}
+}
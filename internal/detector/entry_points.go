@@ -0,0 +1,189 @@
+package detector
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// toolEntryPoints are files run by tooling rather than imported: tests,
+// stories, and build configs
+var toolEntryPoints = []string{
+	"**/*.test.*",
+	"**/*.spec.*",
+	"**/*.stories.*",
+	"**/__tests__/**",
+	"*.config.*",
+}
+
+// DefaultEntryPoints returns entry point globs for a project type, relative
+// to the project root. Files matching them are the roots of the module graph.
+func DefaultEntryPoints(projectType models.ProjectType) []string {
+	var entries []string
+
+	switch projectType {
+	case models.ProjectTypeWebReact:
+		entries = []string{
+			"src/index.*", "src/main.*", "index.html", "public/index.html",
+			// Next.js routes are loaded by the framework
+			"pages/**", "src/pages/**", "app/**", "middleware.*",
+		}
+	case models.ProjectTypeReactNative:
+		entries = []string{"index.*", "App.*", "app/**"}
+	case models.ProjectTypeWebVue:
+		entries = []string{
+			"src/main.*", "index.html", "public/index.html",
+			// Nuxt routes, layouts, and auto-imported components
+			"app.vue", "pages/**", "layouts/**", "components/**", "plugins/**", "middleware/**",
+		}
+	case models.ProjectTypeWebAngular:
+		entries = []string{"src/main.ts", "src/index.html", "src/polyfills.ts"}
+	case models.ProjectTypeWebSvelte:
+		entries = []string{"src/main.*", "index.html", "src/app.html", "src/routes/**", "src/hooks.*"}
+	case models.ProjectTypeFlutter:
+		entries = []string{"lib/main.dart", "lib/main_*.dart", "test/**", "integration_test/**"}
+	case models.ProjectTypeHugo:
+		// Every content page is rendered through the layouts
+		entries = []string{"content/**", "layouts/**", "themes/*/layouts/**", "archetypes/**", "data/**", "hugo.*", "config.*"}
+	case models.ProjectTypeJekyll:
+		entries = []string{"**/*.md", "**/*.markdown", "**/*.html", "_layouts/**", "_includes/**", "_data/**", "_config.yml"}
+	case models.ProjectTypeWordPress:
+		// WordPress loads templates by name through the template hierarchy
+		entries = []string{"**/*.php", "style.css", "theme.json", "**/block.json"}
+	case models.ProjectTypeEleventy:
+		entries = []string{
+			"**/*.md", "**/*.njk", "**/*.liquid", "**/*.html", "**/*.11ty.js",
+			"_includes/**", "_data/**", "src/_includes/**", "src/_data/**", ".eleventy.js", "eleventy.config.*",
+		}
+	default:
+		entries = []string{"index.*", "src/index.*", "src/main.*", "public/index.html"}
+	}
+
+	return append(entries, toolEntryPoints...)
+}
+
+// EntryPoints returns the module graph roots for a project: the configured
+// globs when there are any, otherwise package.json entries plus the defaults
+// for the project type
+func EntryPoints(root string, projectType models.ProjectType, configured []string) []string {
+	if len(configured) > 0 {
+		return configured
+	}
+	entries := append(PackageEntryPoints(root), StylesheetEntryPoints(root)...)
+	return append(entries, DefaultEntryPoints(projectType)...)
+}
+
+// styleSourceExtensions are stylesheet languages compiled to CSS by tooling
+var styleSourceExtensions = map[string]bool{".scss": true, ".sass": true, ".less": true}
+
+// StylesheetEntryPoints returns the stylesheets build tooling compiles on its
+// own rather than through an import: the "styles" of angular.json and the
+// inputs of sass and lessc commands in package.json scripts. Sass directory
+// mode (sass scss:css) compiles every stylesheet in the input directory.
+func StylesheetEntryPoints(root string) []string {
+	seen := make(map[string]bool)
+	add := func(entry string) {
+		seen[path.Clean(strings.TrimPrefix(filepath.ToSlash(entry), "./"))] = true
+	}
+
+	for _, style := range angularStyles(root) {
+		add(style)
+	}
+	if pkg, err := readPackageJSON(filepath.Join(root, "package.json")); err == nil {
+		for _, script := range pkg.Scripts {
+			if !strings.Contains(script, "sass") && !strings.Contains(script, "lessc") {
+				continue
+			}
+			for _, arg := range strings.Fields(script) {
+				arg = strings.Trim(arg, `'"`)
+				if strings.HasPrefix(arg, "-") {
+					continue
+				}
+				// sass in.scss:out.css and sass in_dir:out_dir
+				if in, _, ok := strings.Cut(arg, ":"); ok && in != "" {
+					if path.Ext(in) == "" {
+						add(path.Join(in, "**/*.scss"))
+						add(path.Join(in, "**/*.sass"))
+						continue
+					}
+					arg = in
+				}
+				if styleSourceExtensions[strings.ToLower(path.Ext(arg))] {
+					add(arg)
+				}
+			}
+		}
+	}
+
+	entries := make([]string, 0, len(seen))
+	for entry := range seen {
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// angularStyles returns the global stylesheets of every project in
+// angular.json; entries are paths or {"input": path} objects
+func angularStyles(root string) []string {
+	data, err := os.ReadFile(filepath.Join(root, "angular.json"))
+	if err != nil {
+		return nil
+	}
+	var workspace struct {
+		Projects map[string]struct {
+			Architect map[string]struct {
+				Options struct {
+					Styles []json.RawMessage `json:"styles"`
+				} `json:"options"`
+			} `json:"architect"`
+		} `json:"projects"`
+	}
+	if err := json.Unmarshal(data, &workspace); err != nil {
+		return nil
+	}
+
+	var styles []string
+	for _, project := range workspace.Projects {
+		for _, target := range project.Architect {
+			for _, raw := range target.Options.Styles {
+				var style string
+				if json.Unmarshal(raw, &style) != nil {
+					var entry struct {
+						Input string `json:"input"`
+					}
+					if json.Unmarshal(raw, &entry) != nil {
+						continue
+					}
+					style = entry.Input
+				}
+				if style != "" {
+					styles = append(styles, style)
+				}
+			}
+		}
+	}
+	return styles
+}
+
+// PackageEntryPoints returns the main, module, and source files declared in
+// the project's package.json, relative to root
+func PackageEntryPoints(root string) []string {
+	pkg, err := readPackageJSON(filepath.Join(root, "package.json"))
+	if err != nil {
+		return nil
+	}
+
+	var entries []string
+	for _, entry := range []string{pkg.Main, pkg.Module, pkg.Source} {
+		if entry != "" {
+			entries = append(entries, path.Clean(filepath.ToSlash(entry)))
+		}
+	}
+	return entries
+}
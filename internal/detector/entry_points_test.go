@@ -0,0 +1,96 @@
+package detector
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestPackageEntryPoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile(t, filepath.Join(tmpDir, "package.json"), `{"main": "./dist/index.js", "module": "src/index.ts", "dependencies": {"react": "18"}}`)
+
+	got := PackageEntryPoints(tmpDir)
+	expected := []string{"dist/index.js", "src/index.ts"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("PackageEntryPoints() = %v, want %v", got, expected)
+	}
+
+	if got := PackageEntryPoints(t.TempDir()); got != nil {
+		t.Errorf("PackageEntryPoints() without package.json = %v, want nil", got)
+	}
+}
+
+func TestStylesheetEntryPoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile(t, filepath.Join(tmpDir, "angular.json"), `{"projects": {"app": {"architect": {
+		"build": {"options": {"styles": ["src/styles.scss", {"input": "src/theme/dark.scss", "bundleName": "dark"}]}},
+		"test": {"options": {"styles": ["src/styles.scss"]}}
+	}}}}`)
+	writeFile(t, filepath.Join(tmpDir, "package.json"), `{"scripts": {
+		"build:css": "sass --no-source-map scss/main.scss:public/main.css && lessc ./less/print.less public/print.css",
+		"watch:css": "sass --watch themes:public/themes",
+		"build": "vite build"
+	}}`)
+
+	got := StylesheetEntryPoints(tmpDir)
+	expected := []string{
+		"less/print.less",
+		"scss/main.scss",
+		"src/styles.scss",
+		"src/theme/dark.scss",
+		"themes/**/*.sass",
+		"themes/**/*.scss",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("StylesheetEntryPoints() = %v, want %v", got, expected)
+	}
+
+	if got := StylesheetEntryPoints(t.TempDir()); len(got) != 0 {
+		t.Errorf("StylesheetEntryPoints() without build config = %v, want none", got)
+	}
+}
+
+func TestDefaultEntryPoints(t *testing.T) {
+	tests := []struct {
+		projectType models.ProjectType
+		contains    string
+	}{
+		{models.ProjectTypeWebReact, "pages/**"},
+		{models.ProjectTypeWebAngular, "src/main.ts"},
+		{models.ProjectTypeFlutter, "lib/main.dart"},
+		{models.ProjectTypeUnknown, "**/*.test.*"},
+	}
+
+	for _, tt := range tests {
+		found := false
+		for _, entry := range DefaultEntryPoints(tt.projectType) {
+			if entry == tt.contains {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("DefaultEntryPoints(%s) missing %s", tt.projectType, tt.contains)
+		}
+	}
+}
+
+func TestEntryPoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile(t, filepath.Join(tmpDir, "package.json"), `{"main": "lib/index.js"}`)
+
+	configured := []string{"src/main.tsx", "src/sw.ts"}
+	if got := EntryPoints(tmpDir, models.ProjectTypeWebReact, configured); !reflect.DeepEqual(got, configured) {
+		t.Errorf("EntryPoints() with configured entries = %v, want %v", got, configured)
+	}
+
+	got := EntryPoints(tmpDir, models.ProjectTypeWebReact, nil)
+	if len(got) == 0 || got[0] != "lib/index.js" {
+		t.Errorf("EntryPoints() = %v, want package.json main first", got)
+	}
+	if len(got) != 1+len(DefaultEntryPoints(models.ProjectTypeWebReact)) {
+		t.Errorf("EntryPoints() = %v, want package.json entries plus framework defaults", got)
+	}
+}
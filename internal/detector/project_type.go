@@ -1,12 +1,15 @@
 // Package detector identifies project types by inspecting filesystem markers.
 //
 // It supports detection of 10+ project types including React, Vue, Flutter,
-// iOS, Android, Go, and Rust by looking for characteristic files like
-// package.json, pubspec.yaml, go.mod, etc.
+// iOS, Android, Go, Rust, WordPress themes and plugins, and the Hugo, Jekyll,
+// and Eleventy static site generators by looking for characteristic files
+// like package.json, pubspec.yaml, go.mod, hugo.toml, etc.
 package detector
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -15,12 +18,25 @@ import (
 
 // PackageJSON represents a minimal package.json structure for detection
 type PackageJSON struct {
+	Main            string            `json:"main"`
+	Module          string            `json:"module"`
+	Source          string            `json:"source"`
+	Scripts         map[string]string `json:"scripts"`
 	Dependencies    map[string]string `json:"dependencies"`
 	DevDependencies map[string]string `json:"devDependencies"`
 }
 
 // DetectProjectType attempts to detect the project type from filesystem markers
 func DetectProjectType(root string) models.ProjectType {
+	// Static site generators and WordPress come first: their sites often
+	// carry a package.json for CSS tooling
+	if pt := detectStaticSiteGenerator(root); pt != models.ProjectTypeUnknown {
+		return pt
+	}
+	if isWordPress(root) {
+		return models.ProjectTypeWordPress
+	}
+
 	// Check for package.json (JavaScript/TypeScript projects)
 	if pkg, err := readPackageJSON(filepath.Join(root, "package.json")); err == nil {
 		return detectFromPackageJSON(pkg)
@@ -56,6 +72,89 @@ func DetectProjectType(root string) models.ProjectType {
 	return models.ProjectTypeUnknown
 }
 
+// hugoConfigs are Hugo's site configuration files; config.* is the name
+// older sites use, which only counts alongside Hugo's directory layout
+var hugoConfigs = []string{"hugo.toml", "hugo.yaml", "hugo.yml", "hugo.json"}
+
+// eleventyConfigs are Eleventy's configuration files
+var eleventyConfigs = []string{
+	".eleventy.js", "eleventy.config.js", "eleventy.config.mjs", "eleventy.config.cjs", "eleventy.config.ts",
+}
+
+// detectStaticSiteGenerator recognizes Hugo, Jekyll, and Eleventy sites
+func detectStaticSiteGenerator(root string) models.ProjectType {
+	for _, name := range hugoConfigs {
+		if fileExists(filepath.Join(root, name)) {
+			return models.ProjectTypeHugo
+		}
+	}
+	if (fileExists(filepath.Join(root, "config.toml")) || fileExists(filepath.Join(root, "config.yaml"))) &&
+		(dirExists(filepath.Join(root, "archetypes")) || dirExists(filepath.Join(root, "themes"))) {
+		return models.ProjectTypeHugo
+	}
+
+	if fileExists(filepath.Join(root, "_config.yml")) || fileExists(filepath.Join(root, "_config.yaml")) {
+		return models.ProjectTypeJekyll
+	}
+
+	for _, name := range eleventyConfigs {
+		if fileExists(filepath.Join(root, name)) {
+			return models.ProjectTypeEleventy
+		}
+	}
+	if pkg, err := readPackageJSON(filepath.Join(root, "package.json")); err == nil {
+		if _, ok := pkg.Dependencies["@11ty/eleventy"]; ok {
+			return models.ProjectTypeEleventy
+		}
+		if _, ok := pkg.DevDependencies["@11ty/eleventy"]; ok {
+			return models.ProjectTypeEleventy
+		}
+	}
+
+	return models.ProjectTypeUnknown
+}
+
+// wpHeaderSize is how much of a file is searched for a WordPress file header
+const wpHeaderSize = 8192
+
+// isWordPress recognizes a WordPress theme (style.css with a Theme Name
+// header), a plugin (a PHP file with a Plugin Name header), or a wp-content
+// directory
+func isWordPress(root string) bool {
+	if dirExists(filepath.Join(root, "wp-content")) {
+		return true
+	}
+	if hasFileHeader(filepath.Join(root, "style.css"), "Theme Name:") {
+		return true
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".php" &&
+			hasFileHeader(filepath.Join(root, entry.Name()), "Plugin Name:") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFileHeader reports whether the start of a file contains a WordPress
+// header field such as "Theme Name:"
+func hasFileHeader(path, field string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	head := make([]byte, wpHeaderSize)
+	n, _ := io.ReadFull(f, head)
+	return bytes.Contains(head[:n], []byte(field))
+}
+
 // detectFromPackageJSON determines project type from package.json dependencies
 func detectFromPackageJSON(pkg *PackageJSON) models.ProjectType {
 	allDeps := make(map[string]bool)
@@ -118,6 +217,12 @@ func fileExists(path string) bool {
 	return !info.IsDir()
 }
 
+// dirExists checks if a directory exists at the given path
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
 // hasXcodeProject checks if there's an .xcodeproj directory in the root
 func hasXcodeProject(root string) bool {
 	entries, err := os.ReadDir(root)
@@ -178,6 +178,63 @@ version = "0.1.0"
 	}
 }
 
+func TestDetectProjectType_StaticSiteGenerators(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected models.ProjectType
+	}{
+		{"hugo.toml", map[string]string{"hugo.toml": "baseURL = '/'\n"}, models.ProjectTypeHugo},
+		{"legacy Hugo config", map[string]string{"config.toml": "baseURL = '/'\n", "archetypes/default.md": "---\n---\n"}, models.ProjectTypeHugo},
+		{"Hugo with package.json", map[string]string{"hugo.yaml": "baseURL: /\n", "package.json": `{"dependencies": {"react": "^18.0.0"}}`}, models.ProjectTypeHugo},
+		{"Jekyll", map[string]string{"_config.yml": "title: Blog\n"}, models.ProjectTypeJekyll},
+		{"Eleventy config", map[string]string{"eleventy.config.js": "export default function () {}\n"}, models.ProjectTypeEleventy},
+		{"Eleventy dependency", map[string]string{"package.json": `{"devDependencies": {"@11ty/eleventy": "^3.0.0"}}`}, models.ProjectTypeEleventy},
+		{"config.toml alone", map[string]string{"config.toml": "[settings]\n"}, models.ProjectTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		tmpDir := t.TempDir()
+		for name, content := range tt.files {
+			if err := os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, name)), 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(tmpDir, name), content)
+		}
+
+		if got := DetectProjectType(tmpDir); got != tt.expected {
+			t.Errorf("%s: DetectProjectType() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestDetectProjectType_WordPress(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected models.ProjectType
+	}{
+		{"theme", map[string]string{"style.css": "/*\nTheme Name: Acme\nVersion: 1.0\n*/\n", "package.json": `{"dependencies": {"react": "^18.0.0"}}`}, models.ProjectTypeWordPress},
+		{"plugin", map[string]string{"gallery.php": "<?php\n/**\n * Plugin Name: Gallery\n */\n"}, models.ProjectTypeWordPress},
+		{"wp-content", map[string]string{"wp-content/themes/acme/style.css": "/* Theme Name: Acme */\n"}, models.ProjectTypeWordPress},
+		{"plain stylesheet", map[string]string{"style.css": "body { margin: 0; }\n", "index.php": "<?php echo 'hi';\n"}, models.ProjectTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		tmpDir := t.TempDir()
+		for name, content := range tt.files {
+			if err := os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, name)), 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(tmpDir, name), content)
+		}
+
+		if got := DetectProjectType(tmpDir); got != tt.expected {
+			t.Errorf("%s: DetectProjectType() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
 func TestDetectProjectType_Unknown(t *testing.T) {
 	tmpDir := t.TempDir()
 
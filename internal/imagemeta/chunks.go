@@ -0,0 +1,142 @@
+package imagemeta
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// pngColorProfile walks PNG chunks up to the first IDAT looking for sRGB or iCCP
+func pngColorProfile(r io.Reader) string {
+	br := bufio.NewReader(r)
+	if _, err := br.Discard(8); err != nil { // PNG signature
+		return ""
+	}
+
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil {
+			return ""
+		}
+		length := binary.BigEndian.Uint32(header[0:4])
+
+		switch string(header[4:8]) {
+		case "sRGB":
+			return ProfileSRGB
+		case "iCCP":
+			return ProfileICC
+		case "IDAT", "IEND":
+			return ""
+		}
+
+		// Skip chunk data and CRC
+		if _, err := br.Discard(int(length) + 4); err != nil {
+			return ""
+		}
+	}
+}
+
+// iccMarker identifies an ICC profile inside a JPEG APP2 segment
+var iccMarker = []byte("ICC_PROFILE\x00")
+
+// jpegColorProfile walks JPEG markers up to start-of-scan looking for an ICC APP2 segment
+func jpegColorProfile(r io.Reader) string {
+	br := bufio.NewReader(r)
+	if _, err := br.Discard(2); err != nil { // SOI
+		return ""
+	}
+
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil || header[0] != 0xFF {
+			return ""
+		}
+		marker := header[1]
+		length := int(binary.BigEndian.Uint16(header[2:4])) - 2
+		if marker == 0xDA || length < 0 { // start of scan: no more metadata
+			return ""
+		}
+
+		if marker == 0xE2 && length >= len(iccMarker) {
+			segment := make([]byte, length)
+			if _, err := io.ReadFull(br, segment); err != nil {
+				return ""
+			}
+			if bytes.HasPrefix(segment, iccMarker) {
+				return ProfileICC
+			}
+			continue
+		}
+
+		if _, err := br.Discard(length); err != nil {
+			return ""
+		}
+	}
+}
+
+// gifFrameCount counts image descriptors by skipping over data sub-blocks
+// without LZW-decoding any pixels
+func gifFrameCount(r io.Reader) int {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, 13) // signature + logical screen descriptor
+	if _, err := io.ReadFull(br, header); err != nil {
+		return 1
+	}
+	if header[10]&0x80 != 0 { // global color table
+		if _, err := br.Discard(3 << ((header[10] & 0x07) + 1)); err != nil {
+			return 1
+		}
+	}
+
+	frames := 0
+	for {
+		block, err := br.ReadByte()
+		if err != nil {
+			break
+		}
+
+		switch block {
+		case 0x21: // extension: label byte then sub-blocks
+			if _, err := br.Discard(1); err != nil || skipSubBlocks(br) != nil {
+				return max(frames, 1)
+			}
+		case 0x2C: // image descriptor
+			frames++
+			desc := make([]byte, 9)
+			if _, err := io.ReadFull(br, desc); err != nil {
+				return max(frames, 1)
+			}
+			if desc[8]&0x80 != 0 { // local color table
+				if _, err := br.Discard(3 << ((desc[8] & 0x07) + 1)); err != nil {
+					return max(frames, 1)
+				}
+			}
+			// LZW minimum code size, then image data sub-blocks
+			if _, err := br.Discard(1); err != nil || skipSubBlocks(br) != nil {
+				return max(frames, 1)
+			}
+		default: // 0x3B trailer or garbage
+			return max(frames, 1)
+		}
+	}
+
+	return max(frames, 1)
+}
+
+// skipSubBlocks skips a sequence of GIF data sub-blocks ending with a zero-length block
+func skipSubBlocks(br *bufio.Reader) error {
+	for {
+		size, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if size == 0 {
+			return nil
+		}
+		if _, err := br.Discard(int(size)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package imagemeta
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Privacy flags reported by CheckPrivacy
+const (
+	PrivacyGPS          = "gps"
+	PrivacyCameraSerial = "camera_serial"
+)
+
+// EXIF tags relevant to privacy
+const (
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagGPSLatitude        = 0x0002
+	tagGPSLongitude       = 0x0004
+	tagBodySerialNumber   = 0xA431
+	tagLensSerialNumber   = 0xA435
+	tagCameraSerialNumber = 0xC62F
+)
+
+// maxExifSize guards against corrupt chunk lengths
+const maxExifSize = 1 << 20
+
+// exifHeader prefixes the TIFF payload in a JPEG APP1 segment
+var exifHeader = []byte("Exif\x00\x00")
+
+// CheckPrivacy returns privacy flags (GPS coordinates, camera serials) found in
+// the EXIF metadata of a JPEG or PNG file. Files without EXIF return nil.
+func CheckPrivacy(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var tiff []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		tiff = jpegExif(file)
+	case ".png":
+		tiff = pngExif(file)
+	default:
+		return nil, nil
+	}
+
+	if tiff == nil {
+		return nil, nil
+	}
+	return exifPrivacyFlags(tiff), nil
+}
+
+// jpegExif returns the TIFF payload of the JPEG APP1 Exif segment
+func jpegExif(r io.Reader) []byte {
+	br := bufio.NewReader(r)
+	if _, err := br.Discard(2); err != nil {
+		return nil
+	}
+
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil || header[0] != 0xFF {
+			return nil
+		}
+		marker := header[1]
+		length := int(binary.BigEndian.Uint16(header[2:4])) - 2
+		if marker == 0xDA || length < 0 {
+			return nil
+		}
+
+		if marker == 0xE1 && length > len(exifHeader) {
+			segment := make([]byte, length)
+			if _, err := io.ReadFull(br, segment); err != nil {
+				return nil
+			}
+			if bytes.HasPrefix(segment, exifHeader) {
+				return segment[len(exifHeader):]
+			}
+			continue
+		}
+
+		if _, err := br.Discard(length); err != nil {
+			return nil
+		}
+	}
+}
+
+// pngExif returns the TIFF payload of the PNG eXIf chunk
+func pngExif(r io.Reader) []byte {
+	br := bufio.NewReader(r)
+	if _, err := br.Discard(8); err != nil {
+		return nil
+	}
+
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(br, header); err != nil {
+			return nil
+		}
+		length := binary.BigEndian.Uint32(header[0:4])
+
+		switch string(header[4:8]) {
+		case "eXIf":
+			if length > maxExifSize {
+				return nil
+			}
+			data := make([]byte, length)
+			if _, err := io.ReadFull(br, data); err != nil {
+				return nil
+			}
+			return data
+		case "IEND":
+			return nil
+		}
+
+		if _, err := br.Discard(int(length) + 4); err != nil {
+			return nil
+		}
+	}
+}
+
+// exifPrivacyFlags inspects IFD0, the Exif IFD, and the GPS IFD of a TIFF payload
+func exifPrivacyFlags(tiff []byte) []string {
+	if len(tiff) < 8 {
+		return nil
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil
+	}
+
+	ifd0 := readIFD(tiff, order, order.Uint32(tiff[4:8]))
+
+	var flags []string
+	if gpsOffset, ok := ifd0[tagGPSIFD]; ok {
+		gps := readIFD(tiff, order, gpsOffset)
+		_, hasLat := gps[tagGPSLatitude]
+		_, hasLon := gps[tagGPSLongitude]
+		if hasLat || hasLon {
+			flags = append(flags, PrivacyGPS)
+		}
+	}
+
+	_, hasSerial := ifd0[tagCameraSerialNumber]
+	if exifOffset, ok := ifd0[tagExifIFD]; ok {
+		exif := readIFD(tiff, order, exifOffset)
+		_, body := exif[tagBodySerialNumber]
+		_, lens := exif[tagLensSerialNumber]
+		hasSerial = hasSerial || body || lens
+	}
+	if hasSerial {
+		flags = append(flags, PrivacyCameraSerial)
+	}
+
+	return flags
+}
+
+// readIFD reads an IFD's entries as tag → value/offset field
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32) map[uint16]uint32 {
+	entries := make(map[uint16]uint32)
+	if int(offset)+2 > len(tiff) {
+		return entries
+	}
+
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	pos := int(offset) + 2
+	for i := 0; i < count && pos+12 <= len(tiff); i++ {
+		tag := order.Uint16(tiff[pos : pos+2])
+		entries[tag] = order.Uint32(tiff[pos+8 : pos+12])
+		pos += 12
+	}
+	return entries
+}
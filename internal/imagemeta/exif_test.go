@@ -0,0 +1,95 @@
+package imagemeta
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildTIFF creates a little-endian TIFF payload with IFD0 pointing to a GPS IFD
+// (with latitude) and an Exif IFD (with body serial number) as requested
+func buildTIFF(withGPS, withSerial bool) []byte {
+	le := binary.LittleEndian
+	tiff := []byte("II\x2a\x00\x08\x00\x00\x00")
+
+	var ifd0 [][2]uint32
+	gpsOffset := uint32(8 + 2 + 2*12 + 4)
+	exifOffset := gpsOffset + 2 + 12 + 4
+	if withGPS {
+		ifd0 = append(ifd0, [2]uint32{tagGPSIFD, gpsOffset})
+	}
+	if withSerial {
+		ifd0 = append(ifd0, [2]uint32{tagExifIFD, exifOffset})
+	}
+	for len(ifd0) < 2 {
+		ifd0 = append(ifd0, [2]uint32{0x010F, 0}) // Make (padding entry)
+	}
+
+	writeIFD := func(entries [][2]uint32) {
+		tiff = le.AppendUint16(tiff, uint16(len(entries)))
+		for _, e := range entries {
+			tiff = le.AppendUint16(tiff, uint16(e[0]))
+			tiff = le.AppendUint16(tiff, 4) // LONG
+			tiff = le.AppendUint32(tiff, 1)
+			tiff = le.AppendUint32(tiff, e[1])
+		}
+		tiff = le.AppendUint32(tiff, 0) // next IFD
+	}
+
+	writeIFD(ifd0)
+	writeIFD([][2]uint32{{tagGPSLatitude, 0}})
+	writeIFD([][2]uint32{{tagBodySerialNumber, 0}})
+	return tiff
+}
+
+func TestExifPrivacyFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		withGPS    bool
+		withSerial bool
+		expected   []string
+	}{
+		{"no sensitive metadata", false, false, nil},
+		{"GPS only", true, false, []string{PrivacyGPS}},
+		{"serial only", false, true, []string{PrivacyCameraSerial}},
+		{"GPS and serial", true, true, []string{PrivacyGPS, PrivacyCameraSerial}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := exifPrivacyFlags(buildTIFF(tt.withGPS, tt.withSerial))
+			if len(flags) != len(tt.expected) {
+				t.Fatalf("exifPrivacyFlags() = %v, want %v", flags, tt.expected)
+			}
+			for i := range flags {
+				if flags[i] != tt.expected[i] {
+					t.Errorf("exifPrivacyFlags()[%d] = %q, want %q", i, flags[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPrivacy_JPEG(t *testing.T) {
+	tiff := buildTIFF(true, false)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	jpeg := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	jpeg = binary.BigEndian.AppendUint16(jpeg, uint16(len(segment)+2))
+	jpeg = append(jpeg, segment...)
+	jpeg = append(jpeg, 0xFF, 0xDA, 0x00, 0x02)
+
+	path := filepath.Join(t.TempDir(), "photo.jpg")
+	if err := os.WriteFile(path, jpeg, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	flags, err := CheckPrivacy(path)
+	if err != nil {
+		t.Fatalf("CheckPrivacy() failed: %v", err)
+	}
+	if len(flags) != 1 || flags[0] != PrivacyGPS {
+		t.Errorf("CheckPrivacy() = %v, want [gps]", flags)
+	}
+}
@@ -0,0 +1,83 @@
+// Package imagemeta extracts image metadata by decoding file headers only.
+//
+// Supports PNG, JPEG, and GIF via the standard library decoders, plus WebP
+// via manual RIFF header parsing. Pixel data is never decoded, so this is
+// cheap enough to run on every image asset in a scan.
+//
+// Beyond dimensions it reports:
+// - Frame count for animated GIFs
+// - Embedded color profile (sRGB chunk or ICC profile)
+// - Privacy-sensitive EXIF fields (GPS coordinates, camera serials)
+package imagemeta
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF header decoder
+	_ "image/jpeg" // register JPEG header decoder
+	_ "image/png"  // register PNG header decoder
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Color profile values reported in Info.ColorProfile
+const (
+	ProfileSRGB = "sRGB"
+	ProfileICC  = "ICC"
+)
+
+// Info holds header-level image metadata
+type Info struct {
+	Width        int
+	Height       int
+	Format       string
+	Frames       int
+	ColorProfile string
+}
+
+// Decode reads image dimensions, format, frame count, and color profile
+func Decode(path string) (*Info, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".webp") {
+		return decodeWebP(file)
+	}
+
+	cfg, format, err := image.DecodeConfig(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image header: %w", err)
+	}
+
+	info := &Info{Width: cfg.Width, Height: cfg.Height, Format: format, Frames: 1}
+
+	// Extras are best-effort: a malformed tail should not discard dimensions
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return info, nil
+	}
+
+	switch format {
+	case "png":
+		info.ColorProfile = pngColorProfile(file)
+	case "jpeg":
+		info.ColorProfile = jpegColorProfile(file)
+	case "gif":
+		info.Frames = gifFrameCount(file)
+	}
+
+	return info, nil
+}
+
+// Supported reports whether Decode can read metadata for the extension
+func Supported(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package imagemeta
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecode_PNG(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logo.png")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if err := png.Encode(file, image.NewRGBA(image.Rect(0, 0, 40, 20))); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+	file.Close()
+
+	info, err := Decode(path)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if info.Width != 40 || info.Height != 20 || info.Format != "png" {
+		t.Errorf("Decode() = %dx%d %s, want 40x20 png", info.Width, info.Height, info.Format)
+	}
+}
+
+func TestDecode_WebP(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+		width  int
+		height int
+	}{
+		{
+			name: "VP8X extended",
+			header: append([]byte("RIFF\x00\x00\x00\x00WEBPVP8X\x0a\x00\x00\x00"),
+				0, 0, 0, 0, 99, 0, 0, 49, 0, 0),
+			width:  100,
+			height: 50,
+		},
+		{
+			name: "VP8L lossless",
+			// width-1 = 99, height-1 = 49 → bits = 99 | 49<<14
+			header: append([]byte("RIFF\x00\x00\x00\x00WEBPVP8L\x0a\x00\x00\x00\x2f"),
+				0x63, 0x40, 0x0c, 0x00, 0, 0, 0, 0, 0),
+			width:  100,
+			height: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "image.webp")
+			if err := os.WriteFile(path, tt.header, 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			info, err := Decode(path)
+			if err != nil {
+				t.Fatalf("Decode() failed: %v", err)
+			}
+			if info.Width != tt.width || info.Height != tt.height {
+				t.Errorf("Decode() = %dx%d, want %dx%d", info.Width, info.Height, tt.width, tt.height)
+			}
+		})
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.png")
+	if err := os.WriteFile(path, []byte("not an image"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if _, err := Decode(path); err == nil {
+		t.Error("Expected error for invalid image, got nil")
+	}
+}
+
+func TestDecode_AnimatedGIF(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	anim := &gif.GIF{}
+	for i := 0; i < 3; i++ {
+		anim.Image = append(anim.Image, image.NewPaletted(image.Rect(0, 0, 8, 8), palette))
+		anim.Delay = append(anim.Delay, 10)
+	}
+
+	path := filepath.Join(t.TempDir(), "spinner.gif")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if err := gif.EncodeAll(file, anim); err != nil {
+		t.Fatalf("Failed to encode GIF: %v", err)
+	}
+	file.Close()
+
+	info, err := Decode(path)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if info.Frames != 3 {
+		t.Errorf("Decode() frames = %d, want 3", info.Frames)
+	}
+	if info.Width != 8 || info.Height != 8 {
+		t.Errorf("Decode() = %dx%d, want 8x8", info.Width, info.Height)
+	}
+}
+
+func TestPNGColorProfile(t *testing.T) {
+	// Signature, then a zero-length sRGB chunk (length + type + CRC)
+	data := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x01sRGB\x00\x00\x00\x00\x00")
+	if profile := pngColorProfile(bytes.NewReader(data)); profile != ProfileSRGB {
+		t.Errorf("pngColorProfile() = %q, want %q", profile, ProfileSRGB)
+	}
+
+	noProfile := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x00IDAT\x00\x00\x00\x00")
+	if profile := pngColorProfile(bytes.NewReader(noProfile)); profile != "" {
+		t.Errorf("pngColorProfile() = %q, want empty", profile)
+	}
+}
@@ -0,0 +1,53 @@
+package imagemeta
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// webpICCFlag marks an embedded ICC profile in the VP8X flags byte
+const webpICCFlag = 0x20
+
+// decodeWebP parses the RIFF container header of a WebP file.
+// Handles lossy (VP8), lossless (VP8L), and extended (VP8X) formats.
+func decodeWebP(r io.Reader) (*Info, error) {
+	header := make([]byte, 30)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read WebP header: %w", err)
+	}
+
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("not a WebP file")
+	}
+
+	info := &Info{Format: "webp", Frames: 1}
+
+	switch string(header[12:16]) {
+	case "VP8 ":
+		// Frame header: 3-byte tag, 3-byte start code, then 14-bit width/height
+		info.Width = int(binary.LittleEndian.Uint16(header[26:28]) & 0x3fff)
+		info.Height = int(binary.LittleEndian.Uint16(header[28:30]) & 0x3fff)
+	case "VP8L":
+		// 1-byte signature, then 14-bit width-1 and height-1 packed in 4 bytes
+		bits := binary.LittleEndian.Uint32(header[21:25])
+		info.Width = int(bits&0x3fff) + 1
+		info.Height = int((bits>>14)&0x3fff) + 1
+	case "VP8X":
+		// 4 bytes flags, then 24-bit canvas width-1 and height-1
+		if header[20]&webpICCFlag != 0 {
+			info.ColorProfile = ProfileICC
+		}
+		info.Width = int(uint24(header[24:27])) + 1
+		info.Height = int(uint24(header[27:30])) + 1
+	default:
+		return nil, fmt.Errorf("unsupported WebP chunk %q", header[12:16])
+	}
+
+	return info, nil
+}
+
+// uint24 decodes a 3-byte little-endian integer
+func uint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
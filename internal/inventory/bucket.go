@@ -0,0 +1,112 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Bucket is an object storage prefix: s3://bucket/prefix or gs://bucket/prefix
+type Bucket struct {
+	Scheme string // "s3" or "gs"
+	Name   string
+	Prefix string // "" or ending in "/"
+}
+
+// ParseBucket parses an s3:// or gs:// URL
+func ParseBucket(url string) (Bucket, error) {
+	scheme, rest, ok := strings.Cut(url, "://")
+	if !ok || (scheme != "s3" && scheme != "gs") {
+		return Bucket{}, fmt.Errorf("invalid bucket %q: want s3://bucket/prefix or gs://bucket/prefix", url)
+	}
+	name, prefix, _ := strings.Cut(rest, "/")
+	if name == "" {
+		return Bucket{}, fmt.Errorf("invalid bucket %q: missing bucket name", url)
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return Bucket{Scheme: scheme, Name: name, Prefix: prefix}, nil
+}
+
+// String returns the bucket URL
+func (b Bucket) String() string {
+	return b.Scheme + "://" + b.Name + "/" + b.Prefix
+}
+
+// ListBucket lists the asset files below a bucket prefix, relative to it,
+// using the aws or gcloud CLI and whatever credentials it is configured with
+func ListBucket(b Bucket, extensions []string) ([]Object, error) {
+	var cmd *exec.Cmd
+	if b.Scheme == "s3" {
+		cmd = exec.Command("aws", "s3api", "list-objects-v2", "--bucket", b.Name, "--prefix", b.Prefix,
+			"--query", "Contents[].{Key: Key, Size: Size}", "--output", "json")
+	} else {
+		cmd = exec.Command("gcloud", "storage", "objects", "list", "gs://"+b.Name+"/"+b.Prefix+"**",
+			"--format", "json(name,size)")
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to list %s: %s", b, msg)
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", b, err)
+	}
+
+	listed, err := parseListing(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse listing of %s: %w", b, err)
+	}
+
+	var objects []Object
+	for _, obj := range listed {
+		name := strings.TrimPrefix(obj.Path, b.Prefix)
+		if name == "" || strings.HasSuffix(name, "/") || !hasExtension(name, extensions) {
+			continue
+		}
+		objects = append(objects, Object{Path: name, Size: obj.Size})
+	}
+	sortObjects(objects)
+	return objects, nil
+}
+
+// listedObject is one entry of an aws (Key, Size) or gcloud (name, size)
+// JSON listing; gcloud reports sizes as strings
+type listedObject struct {
+	Key  string          `json:"Key"`
+	Name string          `json:"name"`
+	Size json.RawMessage `json:"Size"`
+	Len  json.RawMessage `json:"size"`
+}
+
+// parseListing parses the JSON array either CLI prints into objects keyed by
+// their full object name
+func parseListing(data []byte) ([]Object, error) {
+	data = bytes.TrimSpace(data)
+	// aws prints null for an empty prefix, gcloud prints nothing
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var listed []listedObject
+	if err := json.Unmarshal(data, &listed); err != nil {
+		return nil, err
+	}
+
+	objects := make([]Object, 0, len(listed))
+	for _, l := range listed {
+		raw := l.Size
+		if len(raw) == 0 {
+			raw = l.Len
+		}
+		size, _ := strconv.ParseInt(strings.Trim(string(raw), `"`), 10, 64)
+		objects = append(objects, Object{Path: l.Key + l.Name, Size: size})
+	}
+	return objects, nil
+}
@@ -0,0 +1,72 @@
+package inventory
+
+import "testing"
+
+func TestParseBucket(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    Bucket
+		wantErr bool
+	}{
+		{"s3://assets/images", Bucket{Scheme: "s3", Name: "assets", Prefix: "images/"}, false},
+		{"gs://assets/", Bucket{Scheme: "gs", Name: "assets"}, false},
+		{"gs://assets", Bucket{Scheme: "gs", Name: "assets"}, false},
+		{"https://assets.example.com/", Bucket{}, true},
+		{"s3:///images", Bucket{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBucket(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseBucket(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseBucket(%q) = %+v, want %+v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestParseListing(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []Object
+	}{
+		{"aws", `[{"Key": "images/a.png", "Size": 12}]`, []Object{{Path: "images/a.png", Size: 12}}},
+		{"gcloud", `[{"name": "images/b.png", "size": "34"}]`, []Object{{Path: "images/b.png", Size: 34}}},
+		{"aws empty", "null\n", nil},
+		{"gcloud empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseListing([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parseListing() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseListing() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseListing()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUnmentioned(t *testing.T) {
+	objects := []Object{
+		{Path: "images/Hero@2x.webp"},
+		{Path: "images/logo.0123abcd.png"},
+		{Path: "images/orphan.png"},
+	}
+	mentioned := map[string]bool{"hero@2x.webp": true, "logo.png": true}
+
+	got := Unmentioned(objects, mentioned)
+	if want := []string{"images/orphan.png"}; !equal(paths(got), want) {
+		t.Errorf("Unmentioned() = %v, want %v", paths(got), want)
+	}
+}
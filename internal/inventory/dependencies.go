@@ -0,0 +1,193 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ManifestCandidates are the build manifests looked for, relative to the
+// project root, when none is given
+var ManifestCandidates = []string{
+	"dist/.vite/manifest.json",
+	"dist/manifest.json",
+	"build/.vite/manifest.json",
+	"stats.json",
+	"dist/stats.json",
+	"build/stats.json",
+}
+
+// DependencyAsset is an asset from node_modules that a build bundled
+type DependencyAsset struct {
+	Package string `json:"package"`
+	Source  string `json:"source"`           // slash-separated, relative to the project root
+	Output  string `json:"output,omitempty"` // emitted file, when the manifest names it
+	Size    int64  `json:"size_bytes"`
+}
+
+// PackageWeight totals the bundled assets of one package
+type PackageWeight struct {
+	Package string            `json:"package"`
+	Assets  []DependencyAsset `json:"assets"`
+	Size    int64             `json:"size_bytes"`
+}
+
+// FindManifest returns the first of ManifestCandidates present in root
+func FindManifest(root string) (string, bool) {
+	for _, candidate := range ManifestCandidates {
+		p := filepath.Join(root, filepath.FromSlash(candidate))
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// ReadDependencyAssets lists the node_modules assets a build manifest shows
+// were bundled: a Vite manifest (build.manifest) or webpack stats
+// (webpack --json). Sizes are those of the source files under root, falling
+// back to the size the manifest reports.
+func ReadDependencyAssets(manifestPath, root string, extensions []string) ([]DependencyAsset, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build manifest: %w", err)
+	}
+	sources, err := parseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse build manifest %s: %w", manifestPath, err)
+	}
+
+	seen := make(map[string]bool)
+	var assets []DependencyAsset
+	for _, src := range sources {
+		source := strings.TrimPrefix(path.Clean(strings.ReplaceAll(src.source, "\\", "/")), "./")
+		pkg := PackageName(source)
+		if pkg == "" || seen[source] || !hasExtension(source, extensions) {
+			continue
+		}
+		seen[source] = true
+
+		size := src.size
+		if info, err := os.Stat(filepath.Join(root, filepath.FromSlash(source))); err == nil {
+			size = info.Size()
+		}
+		assets = append(assets, DependencyAsset{Package: pkg, Source: source, Output: src.output, Size: size})
+	}
+	return assets, nil
+}
+
+// manifestSource is one bundled module named by a manifest
+type manifestSource struct {
+	source string
+	output string
+	size   int64
+}
+
+// parseManifest reads the modules a Vite manifest or webpack stats file lists
+func parseManifest(data []byte) ([]manifestSource, error) {
+	var stats struct {
+		Assets []struct {
+			Name string `json:"name"`
+			Size int64  `json:"size"`
+			Info struct {
+				SourceFilename string `json:"sourceFilename"`
+			} `json:"info"`
+		} `json:"assets"`
+		Modules []struct {
+			Name string `json:"name"`
+			Size int64  `json:"size"`
+		} `json:"modules"`
+	}
+	if err := json.Unmarshal(data, &stats); err == nil && (len(stats.Assets) > 0 || len(stats.Modules) > 0) {
+		var sources []manifestSource
+		for _, asset := range stats.Assets {
+			if asset.Info.SourceFilename != "" {
+				sources = append(sources, manifestSource{source: asset.Info.SourceFilename, output: asset.Name, size: asset.Size})
+			}
+		}
+		for _, module := range stats.Modules {
+			// Loader chains look like "css-loader!./node_modules/x/y.css"
+			name := module.Name[strings.LastIndex(module.Name, "!")+1:]
+			sources = append(sources, manifestSource{source: name, size: module.Size})
+		}
+		return sources, nil
+	}
+
+	// Vite: source path -> chunk, where imported assets are chunks of their own
+	var vite map[string]struct {
+		File string `json:"file"`
+		Src  string `json:"src"`
+	}
+	if err := json.Unmarshal(data, &vite); err != nil {
+		return nil, err
+	}
+	sources := make([]manifestSource, 0, len(vite))
+	for key, chunk := range vite {
+		source := chunk.Src
+		if source == "" {
+			source = key
+		}
+		sources = append(sources, manifestSource{source: source, output: chunk.File})
+	}
+	return sources, nil
+}
+
+// PackageName returns the npm package a node_modules path belongs to, or ""
+// outside node_modules. The innermost node_modules wins, so nested and pnpm
+// paths (node_modules/.pnpm/x@1.0.0/node_modules/x/...) name the real package.
+func PackageName(p string) string {
+	parts := strings.Split(p, "/")
+	last := -1
+	for i, part := range parts {
+		if part == "node_modules" {
+			last = i
+		}
+	}
+	if last < 0 || last+1 >= len(parts)-1 {
+		return ""
+	}
+	name := parts[last+1]
+	if strings.HasPrefix(name, "@") {
+		if last+2 >= len(parts)-1 {
+			return ""
+		}
+		name += "/" + parts[last+2]
+	}
+	return name
+}
+
+// GroupByPackage totals dependency assets per package, heaviest first
+func GroupByPackage(assets []DependencyAsset) []PackageWeight {
+	byPackage := make(map[string]*PackageWeight)
+	for _, asset := range assets {
+		group := byPackage[asset.Package]
+		if group == nil {
+			group = &PackageWeight{Package: asset.Package}
+			byPackage[asset.Package] = group
+		}
+		group.Assets = append(group.Assets, asset)
+		group.Size += asset.Size
+	}
+
+	groups := make([]PackageWeight, 0, len(byPackage))
+	for _, group := range byPackage {
+		sort.Slice(group.Assets, func(i, j int) bool {
+			if group.Assets[i].Size != group.Assets[j].Size {
+				return group.Assets[i].Size > group.Assets[j].Size
+			}
+			return group.Assets[i].Source < group.Assets[j].Source
+		})
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Size != groups[j].Size {
+			return groups[i].Size > groups[j].Size
+		}
+		return groups[i].Package < groups[j].Package
+	})
+	return groups
+}
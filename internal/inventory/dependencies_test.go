@@ -0,0 +1,105 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"node_modules/katex/dist/fonts/KaTeX_Main.woff2":              "katex",
+		"node_modules/@fontsource/inter/files/inter-latin-400.woff2":  "@fontsource/inter",
+		"node_modules/.pnpm/leaflet@1.9.4/node_modules/leaflet/a.png": "leaflet",
+		"node_modules/a/node_modules/b/img/icon.svg":                  "b",
+		"src/assets/logo.png":          "",
+		"node_modules/stray.png":       "",
+		"node_modules/@scope/file.png": "",
+	}
+	for p, want := range tests {
+		if got := PackageName(p); got != want {
+			t.Errorf("PackageName(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, root, name string, size int) {
+	t.Helper()
+	p := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadDependencyAssets_Vite(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "node_modules/katex/dist/fonts/KaTeX_Main.woff2", 3000)
+	writeFile(t, root, "node_modules/leaflet/dist/images/marker.png", 1000)
+	writeFile(t, root, "dist/.vite/manifest.json", 0)
+	manifest := `{
+  "index.html": {"file": "assets/index-4f2a.js", "src": "index.html", "isEntry": true},
+  "node_modules/katex/dist/fonts/KaTeX_Main.woff2": {"file": "assets/KaTeX_Main-9c1e.woff2", "src": "node_modules/katex/dist/fonts/KaTeX_Main.woff2"},
+  "node_modules/leaflet/dist/images/marker.png": {"file": "assets/marker-77aa.png"},
+  "node_modules/leaflet/dist/leaflet.js": {"file": "assets/leaflet-1b2c.js"},
+  "src/logo.png": {"file": "assets/logo-0d0d.png"}
+}`
+	manifestPath := filepath.Join(root, "dist", ".vite", "manifest.json")
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	found, ok := FindManifest(root)
+	if !ok || found != manifestPath {
+		t.Fatalf("FindManifest() = %q, %v; want %q", found, ok, manifestPath)
+	}
+
+	assets, err := ReadDependencyAssets(manifestPath, root, []string{".png", ".woff2"})
+	if err != nil {
+		t.Fatalf("ReadDependencyAssets() error = %v", err)
+	}
+	groups := GroupByPackage(assets)
+	if len(groups) != 2 {
+		t.Fatalf("GroupByPackage() = %+v, want katex and leaflet", groups)
+	}
+	if groups[0].Package != "katex" || groups[0].Size != 3000 || groups[0].Assets[0].Output != "assets/KaTeX_Main-9c1e.woff2" {
+		t.Errorf("heaviest package = %+v, want katex with 3000 bytes", groups[0])
+	}
+	if groups[1].Package != "leaflet" || len(groups[1].Assets) != 1 {
+		t.Errorf("second package = %+v, want leaflet's marker.png only (no JS)", groups[1])
+	}
+}
+
+func TestReadDependencyAssets_WebpackStats(t *testing.T) {
+	root := t.TempDir()
+	stats := `{
+  "assets": [
+    {"name": "static/media/roboto.woff2", "size": 64000, "info": {"sourceFilename": "node_modules/roboto-font/roboto.woff2"}},
+    {"name": "main.js", "size": 120000, "info": {}}
+  ],
+  "modules": [
+    {"name": "./node_modules/slick-carousel/slick/ajax-loader.gif", "size": 80},
+    {"name": "css-loader!./node_modules/slick-carousel/slick/slick.css", "size": 900},
+    {"name": "./src/hero.png", "size": 80}
+  ]
+}`
+	manifestPath := filepath.Join(root, "stats.json")
+	if err := os.WriteFile(manifestPath, []byte(stats), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, root, "node_modules/slick-carousel/slick/ajax-loader.gif", 4000)
+
+	assets, err := ReadDependencyAssets(manifestPath, root, []string{".gif", ".woff2"})
+	if err != nil {
+		t.Fatalf("ReadDependencyAssets() error = %v", err)
+	}
+	groups := GroupByPackage(assets)
+	if len(groups) != 2 || groups[0].Package != "roboto-font" || groups[0].Size != 64000 {
+		t.Fatalf("GroupByPackage() = %+v, want roboto-font (64000, from stats) first", groups)
+	}
+	if groups[1].Package != "slick-carousel" || groups[1].Size != 4000 {
+		t.Errorf("slick-carousel = %+v, want the 4000-byte source file size", groups[1])
+	}
+}
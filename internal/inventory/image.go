@@ -0,0 +1,204 @@
+package inventory
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// whiteoutPrefix marks a file deleted by a later image layer
+const whiteoutPrefix = ".wh."
+
+// opaqueWhiteout marks a directory whose lower-layer contents were replaced
+const opaqueWhiteout = ".wh..wh..opq"
+
+// ReadImage lists the asset files in a container image or build output.
+// source is a directory, a docker save or OCI layout tarball, or a plain
+// (optionally gzipped) tarball of a filesystem such as docker export writes.
+// Only files below root (e.g. usr/share/nginx/html) are listed, relative to it.
+func ReadImage(source, root string, extensions []string) ([]Object, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	files := make(map[string]int64)
+	if info.IsDir() {
+		err = readDir(source, files)
+	} else {
+		err = readArchive(source, files)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	root = cleanImagePath(root)
+	var objects []Object
+	for name, size := range files {
+		if root != "" {
+			if !strings.HasPrefix(name, root+"/") {
+				continue
+			}
+			name = strings.TrimPrefix(name, root+"/")
+		}
+		if hasExtension(name, extensions) {
+			objects = append(objects, Object{Path: name, Size: size})
+		}
+	}
+	sortObjects(objects)
+	return objects, nil
+}
+
+// readDir records every regular file below dir
+func readDir(dir string, files map[string]int64) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = info.Size()
+		return nil
+	})
+}
+
+// readArchive records the files in an image tarball. Entries that are
+// themselves tarballs are image layers, applied in archive order; when there
+// are none, the archive is a filesystem and its own entries are recorded.
+func readArchive(source string, files map[string]int64) error {
+	f, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+	defer f.Close()
+
+	r, err := maybeGunzip(bufio.NewReader(f))
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+
+	plain := make(map[string]int64)
+	layers := 0
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read image %s: %w", source, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		br := bufio.NewReader(tr)
+		layer, isLayer, err := openLayer(br)
+		if err != nil {
+			return fmt.Errorf("failed to read image layer %s: %w", hdr.Name, err)
+		}
+		if !isLayer {
+			applyEntry(plain, hdr)
+			continue
+		}
+		layers++
+		if err := applyLayer(files, tar.NewReader(layer)); err != nil {
+			return fmt.Errorf("failed to read image layer %s: %w", hdr.Name, err)
+		}
+	}
+
+	if layers == 0 {
+		for name, size := range plain {
+			files[name] = size
+		}
+	}
+	return nil
+}
+
+// applyLayer adds a layer's files to files, honoring whiteouts
+func applyLayer(files map[string]int64, tr *tar.Reader) error {
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		applyEntry(files, hdr)
+	}
+}
+
+// applyEntry records a regular file, or deletes what a whiteout hides
+func applyEntry(files map[string]int64, hdr *tar.Header) {
+	name := cleanImagePath(hdr.Name)
+	dir, base := path.Split(name)
+	dir = strings.TrimSuffix(dir, "/")
+
+	switch {
+	case base == opaqueWhiteout:
+		removeTree(files, dir)
+	case strings.HasPrefix(base, whiteoutPrefix):
+		removeTree(files, path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)))
+	case hdr.Typeflag == tar.TypeReg:
+		files[name] = hdr.Size
+	}
+}
+
+// removeTree deletes name and everything below it; "" clears all files
+func removeTree(files map[string]int64, name string) {
+	for file := range files {
+		if name == "" || file == name || strings.HasPrefix(file, name+"/") {
+			delete(files, file)
+		}
+	}
+}
+
+// openLayer reports whether br holds a (possibly gzipped) tarball and, if so,
+// returns a reader over the uncompressed tar stream
+func openLayer(br *bufio.Reader) (io.Reader, bool, error) {
+	r, err := maybeGunzip(br)
+	if err != nil {
+		// Not actually gzip data; a plain file starting with the magic bytes
+		return nil, false, nil
+	}
+	lbr := bufio.NewReaderSize(r, 1024)
+	header, _ := lbr.Peek(512)
+	if len(header) < 512 || !bytes.Equal(header[257:262], []byte("ustar")) {
+		return nil, false, nil
+	}
+	return lbr, true, nil
+}
+
+// maybeGunzip returns a decompressing reader when br starts with the gzip
+// magic number, or br itself
+func maybeGunzip(br *bufio.Reader) (io.Reader, error) {
+	magic, _ := br.Peek(2)
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+// cleanImagePath makes a tar entry name relative and slash-separated
+func cleanImagePath(name string) string {
+	name = path.Clean("/" + filepath.ToSlash(name))
+	return strings.TrimPrefix(name, "/")
+}
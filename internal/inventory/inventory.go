@@ -0,0 +1,98 @@
+// Package inventory lists assets shipped outside the source tree and matches
+// them against a source scan.
+//
+// Source-level analysis only sees assets that live in the repository. Build
+// steps copy in more (node_modules, generated sprites, stale build output), so
+// an inventory of what actually ships finds bloat the scan cannot:
+// - Container images saved with docker save or as an OCI layout tarball
+// - Build output directories (dist/, build/, public/)
+// - Object storage buckets (S3, GCS) holding assets the code links to by URL
+// - Dependency assets a bundler pulled in from node_modules, per its manifest
+package inventory
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// Object is one file in an inventory
+type Object struct {
+	Path string `json:"path"` // slash-separated, relative to the inventory root
+	Size int64  `json:"size_bytes"`
+}
+
+// Unreferenced returns the objects no asset the scan considers referenced
+// could have produced. Objects are matched by file name, ignoring case and
+// bundler content hashes, so a name shared by a used and an unused asset
+// counts as referenced.
+func Unreferenced(objects []Object, result *models.ScanResult) []Object {
+	referenced := make(map[string]bool)
+	for _, asset := range result.Assets {
+		switch asset.Status {
+		case models.StatusUnused, models.StatusTransitivelyUnused, models.StatusPotentiallyUnused:
+			continue
+		}
+		referenced[normalizeName(path.Base(asset.RelativePath))] = true
+	}
+
+	var unreferenced []Object
+	for _, obj := range objects {
+		if !referenced[normalizeName(path.Base(obj.Path))] {
+			unreferenced = append(unreferenced, obj)
+		}
+	}
+	return unreferenced
+}
+
+// Unmentioned returns the objects whose file name, ignoring case and content
+// hashes, is not in mentioned (see scanner.MentionedFileNames)
+func Unmentioned(objects []Object, mentioned map[string]bool) []Object {
+	names := make(map[string]bool, len(mentioned))
+	for name := range mentioned {
+		names[normalizeName(name)] = true
+	}
+
+	var unmentioned []Object
+	for _, obj := range objects {
+		if !names[normalizeName(path.Base(obj.Path))] {
+			unmentioned = append(unmentioned, obj)
+		}
+	}
+	return unmentioned
+}
+
+// TotalSize returns the combined size of objects
+func TotalSize(objects []Object) int64 {
+	var total int64
+	for _, obj := range objects {
+		total += obj.Size
+	}
+	return total
+}
+
+// normalizeName lowercases a file name and drops its content hash
+func normalizeName(name string) string {
+	return utils.StripContentHash(strings.ToLower(name))
+}
+
+// hasExtension reports whether name ends with one of extensions
+func hasExtension(name string, extensions []string) bool {
+	name = strings.ToLower(name)
+	for _, ext := range extensions {
+		if strings.HasSuffix(name, strings.ToLower(ext)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortObjects orders objects by path
+func sortObjects(objects []Object) {
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Path < objects[j].Path
+	})
+}
@@ -0,0 +1,177 @@
+package inventory
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// tarFile is a regular file written by buildTar
+type tarFile struct {
+	name string
+	data []byte
+}
+
+// buildTar returns a tarball of files, gzipped when compress is set
+func buildTar(t *testing.T, compress bool, files ...tarFile) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var gz *gzip.Writer
+	tw := tar.NewWriter(&buf)
+	if compress {
+		gz = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(gz)
+	}
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func writeArchive(t *testing.T, data []byte) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "image.tar")
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func paths(objects []Object) []string {
+	var out []string
+	for _, obj := range objects {
+		out = append(out, obj.Path)
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var imageExtensions = []string{".png", ".svg", ".woff2"}
+
+func TestReadImage_DockerSave(t *testing.T) {
+	base := buildTar(t, false,
+		tarFile{"usr/share/nginx/html/logo.png", []byte("png")},
+		tarFile{"usr/share/nginx/html/old/banner.png", []byte("png")},
+		tarFile{"usr/share/nginx/html/removed.svg", []byte("<svg/>")},
+		tarFile{"etc/nginx/nginx.conf", []byte("conf")},
+		tarFile{"usr/lib/icon.png", []byte("png")},
+	)
+	top := buildTar(t, true,
+		tarFile{"usr/share/nginx/html/.wh.removed.svg", nil},
+		tarFile{"usr/share/nginx/html/old/.wh..wh..opq", nil},
+		tarFile{"usr/share/nginx/html/fonts/inter.woff2", []byte("woff2!")},
+	)
+	image := writeArchive(t, buildTar(t, false,
+		tarFile{"manifest.json", []byte(`[{"Layers":["base/layer.tar","top/layer.tar"]}]`)},
+		tarFile{"base/layer.tar", base},
+		tarFile{"top/layer.tar", top},
+	))
+
+	objects, err := ReadImage(image, "/usr/share/nginx/html/", imageExtensions)
+	if err != nil {
+		t.Fatalf("ReadImage() error = %v", err)
+	}
+	want := []string{"fonts/inter.woff2", "logo.png"}
+	if got := paths(objects); !equal(got, want) {
+		t.Errorf("ReadImage() = %v, want %v", got, want)
+	}
+	if objects[0].Size != 6 {
+		t.Errorf("ReadImage() size = %d, want 6", objects[0].Size)
+	}
+
+	all, err := ReadImage(image, "", imageExtensions)
+	if err != nil {
+		t.Fatalf("ReadImage() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("ReadImage() without root = %v, want 3 files", paths(all))
+	}
+}
+
+func TestReadImage_FilesystemTarball(t *testing.T) {
+	image := writeArchive(t, buildTar(t, true,
+		tarFile{"./app/static/a.png", []byte("png")},
+		tarFile{"./app/static/b.txt", []byte("txt")},
+	))
+
+	objects, err := ReadImage(image, "app", imageExtensions)
+	if err != nil {
+		t.Fatalf("ReadImage() error = %v", err)
+	}
+	if got, want := paths(objects), []string{"static/a.png"}; !equal(got, want) {
+		t.Errorf("ReadImage() = %v, want %v", got, want)
+	}
+}
+
+func TestReadImage_Directory(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"assets/a.png", "assets/b.PNG", "index.html"} {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects, err := ReadImage(dir, "", imageExtensions)
+	if err != nil {
+		t.Fatalf("ReadImage() error = %v", err)
+	}
+	if got, want := paths(objects), []string{"assets/a.png", "assets/b.PNG"}; !equal(got, want) {
+		t.Errorf("ReadImage() = %v, want %v", got, want)
+	}
+}
+
+func TestUnreferenced(t *testing.T) {
+	result := &models.ScanResult{Assets: []models.AssetFile{
+		{RelativePath: "src/assets/logo.png", Status: models.StatusUsed},
+		{RelativePath: "public/favicon.ico", Status: models.StatusConventional},
+		{RelativePath: "src/assets/old.png", Status: models.StatusUnused},
+	}}
+	objects := []Object{
+		{Path: "assets/logo.8f3a2b1c.png", Size: 10},
+		{Path: "favicon.ico", Size: 5},
+		{Path: "assets/old-0123abcd.png", Size: 7},
+		{Path: "vendor/leaflet/marker.png", Size: 3},
+	}
+
+	got := Unreferenced(objects, result)
+	want := []string{"assets/old-0123abcd.png", "vendor/leaflet/marker.png"}
+	if !equal(paths(got), want) {
+		t.Errorf("Unreferenced() = %v, want %v", paths(got), want)
+	}
+	if size := TotalSize(got); size != 10 {
+		t.Errorf("TotalSize() = %d, want 10", size)
+	}
+}
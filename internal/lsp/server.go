@@ -0,0 +1,230 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// Analyzer runs the scans behind the server; the CLI wires it to the scanner
+type Analyzer interface {
+	// Scan scans the project rooted at root
+	Scan(root string) (*models.ScanResult, error)
+
+	// MissingReferences returns a source file's references to assets that
+	// don't exist
+	MissingReferences(root, file string) ([]*models.Reference, error)
+}
+
+// Server is a Language Server publishing scan results to one editor
+type Server struct {
+	analyzer Analyzer
+
+	writeMu sync.Mutex
+	out     io.Writer
+
+	root      string
+	published map[string]bool // asset URIs currently carrying diagnostics
+}
+
+// NewServer returns a server writing its messages to out
+func NewServer(analyzer Analyzer, out io.Writer) *Server {
+	return &Server{analyzer: analyzer, out: out, published: make(map[string]bool)}
+}
+
+// Serve handles the messages read from in until the client sends exit or
+// closes the stream
+func (s *Server) Serve(in io.Reader) error {
+	r := bufio.NewReader(in)
+	for {
+		body, err := readMessage(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			s.replyError(nil, codeParseError, "invalid JSON")
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		s.handle(&req)
+	}
+}
+
+func (s *Server) handle(req *request) {
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		json.Unmarshal(req.Params, &params)
+		s.root = params.RootPath
+		if params.RootURI != "" {
+			if root, err := uriToPath(params.RootURI); err == nil {
+				s.root = root
+			}
+		}
+		if s.root == "" {
+			s.root, _ = os.Getwd()
+		}
+		s.reply(req.ID, map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": map[string]any{"save": true},
+				"codeLensProvider": map[string]any{"resolveProvider": false},
+			},
+			"serverInfo": map[string]string{"name": "easyClean"},
+		})
+
+	case "initialized", "textDocument/didSave":
+		// Any saved file can change which assets are used
+		s.publishScan()
+
+	case "textDocument/codeLens":
+		var params textDocumentParams
+		json.Unmarshal(req.Params, &params)
+		lenses, err := s.codeLenses(params.TextDocument.URI)
+		if err != nil {
+			s.replyError(req.ID, codeInternalError, err.Error())
+			return
+		}
+		s.reply(req.ID, lenses)
+
+	case "shutdown":
+		s.reply(req.ID, nil)
+
+	default:
+		// Unknown notifications are ignored; unknown requests must be answered
+		if req.ID != nil {
+			s.replyError(req.ID, codeMethodNotFound, "method not supported: "+req.Method)
+		}
+	}
+}
+
+// publishScan rescans the project and replaces the diagnostics on asset files
+func (s *Server) publishScan() {
+	result, err := s.analyzer.Scan(s.root)
+	if err != nil {
+		s.notify("window/showMessage", showMessageParams{Type: 1, Message: "easyClean scan failed: " + err.Error()})
+		return
+	}
+
+	current := make(map[string]bool)
+	for _, asset := range result.Assets {
+		diagnostic, ok := assetDiagnostic(asset)
+		if !ok {
+			continue
+		}
+		uri := pathToURI(asset.Path)
+		current[uri] = true
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{diagnostic}})
+	}
+
+	// Clear assets that are used again
+	for uri := range s.published {
+		if !current[uri] {
+			s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{}})
+		}
+	}
+	s.published = current
+}
+
+// assetDiagnostic describes an unused or potentially unused asset, at the
+// severity configured for its status
+func assetDiagnostic(asset models.AssetFile) (Diagnostic, bool) {
+	var message string
+	switch asset.Status {
+	case models.StatusUnused:
+		message = "Unused asset: nothing in the project references it"
+	case models.StatusTransitivelyUnused:
+		message = "Transitively unused asset: only unused files reference it (" + strings.Join(asset.UnusedChain, " ← ") + ")"
+	case models.StatusPotentiallyUnused:
+		message = "Potentially unused asset: review its references before deleting"
+	default:
+		return Diagnostic{}, false
+	}
+
+	severity := SeverityWarning
+	switch asset.Severity {
+	case models.SeverityOff:
+		return Diagnostic{}, false
+	case models.SeverityError:
+		severity = SeverityError
+	case models.SeverityInfo:
+		severity = SeverityInformation
+	}
+
+	return Diagnostic{Severity: severity, Source: "easyClean", Message: message}, true
+}
+
+// codeLenses flags each reference in a source file to an asset that doesn't exist
+func (s *Server) codeLenses(uri string) ([]CodeLens, error) {
+	path, err := uriToPath(uri)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := s.analyzer.MissingReferences(s.root, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find references: %w", err)
+	}
+
+	// Columns are bytes, positions UTF-16 code units; without the file the
+	// line is taken to be ASCII
+	content, _ := os.ReadFile(path)
+	lines := strings.Split(string(content), "\n")
+
+	lenses := []CodeLens{}
+	for _, ref := range refs {
+		line := ref.LineNumber - 1
+		if line < 0 {
+			line = 0
+		}
+		pos := Position{Line: line}
+		if ref.Column > 0 {
+			pos.Character = ref.Column - 1
+			if line < len(lines) && ref.Column-1 <= len(lines[line]) {
+				pos.Character = len(utf16.Encode([]rune(lines[line][:ref.Column-1])))
+			}
+		}
+		lenses = append(lenses, CodeLens{
+			Range:   Range{Start: pos, End: pos},
+			Command: &Command{Title: "⚠ asset missing: " + ref.MatchedText},
+		})
+	}
+	return lenses, nil
+}
+
+func (s *Server) reply(id *json.RawMessage, result any) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		s.replyError(id, codeInternalError, err.Error())
+		return
+	}
+	s.write(&response{JSONRPC: "2.0", ID: id, Result: data})
+}
+
+func (s *Server) replyError(id *json.RawMessage, code int, message string) {
+	s.write(&response{JSONRPC: "2.0", ID: id, Error: &responseError{Code: code, Message: message}})
+}
+
+func (s *Server) notify(method string, params any) {
+	s.write(&notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) write(msg any) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	// A failed write means the client went away; Serve ends at the next read
+	writeMessage(s.out, msg)
+}
@@ -0,0 +1,174 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+type fakeAnalyzer struct {
+	scans   int
+	results []*models.ScanResult
+	missing []*models.Reference
+}
+
+func (a *fakeAnalyzer) Scan(root string) (*models.ScanResult, error) {
+	result := a.results[a.scans]
+	a.scans++
+	return result, nil
+}
+
+func (a *fakeAnalyzer) MissingReferences(root, file string) ([]*models.Reference, error) {
+	return a.missing, nil
+}
+
+func frame(t *testing.T, messages ...string) *bytes.Buffer {
+	t.Helper()
+	var in bytes.Buffer
+	for _, msg := range messages {
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+	}
+	return &in
+}
+
+// readAll decodes every framed message the server wrote
+func readAll(t *testing.T, out *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var messages []map[string]any
+	r := bufio.NewReader(out)
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			return messages
+		}
+		var msg map[string]any
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatalf("server wrote invalid JSON %q: %v", body, err)
+		}
+		messages = append(messages, msg)
+	}
+}
+
+func TestServer(t *testing.T) {
+	analyzer := &fakeAnalyzer{
+		results: []*models.ScanResult{
+			{Assets: []models.AssetFile{
+				{Path: "/project/assets/old.png", Status: models.StatusUnused, Severity: models.SeverityError},
+				{Path: "/project/assets/logo.png", Status: models.StatusUsed},
+				{Path: "/project/assets/quiet.png", Status: models.StatusUnused, Severity: models.SeverityOff},
+			}},
+			{Assets: []models.AssetFile{
+				{Path: "/project/assets/old.png", Status: models.StatusUsed},
+			}},
+		},
+		missing: []*models.Reference{{LineNumber: 3, MatchedText: "/images/hero.png"}},
+	}
+
+	in := frame(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"rootUri":"file:///project"}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/codeLens","params":{"textDocument":{"uri":"file:///project/src/App.tsx"}}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didSave","params":{"textDocument":{"uri":"file:///project/src/App.tsx"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"textDocument/hover","params":{}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+	var out bytes.Buffer
+	s := NewServer(analyzer, &out)
+	if err := s.Serve(in); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+	if s.root != "/project" {
+		t.Errorf("root = %q, want /project", s.root)
+	}
+
+	messages := readAll(t, &out)
+	if len(messages) != 6 {
+		t.Fatalf("server wrote %d messages, want 6: %v", len(messages), messages)
+	}
+
+	if caps, _ := messages[0]["result"].(map[string]any)["capabilities"].(map[string]any); caps["codeLensProvider"] == nil {
+		t.Errorf("initialize result = %v, want code lens capability", messages[0])
+	}
+
+	// Only the unused asset with a severity gets a diagnostic
+	params := messages[1]["params"].(map[string]any)
+	if messages[1]["method"] != "textDocument/publishDiagnostics" || params["uri"] != "file:///project/assets/old.png" {
+		t.Errorf("first scan published %v, want diagnostics for old.png", messages[1])
+	}
+	diagnostic := params["diagnostics"].([]any)[0].(map[string]any)
+	if diagnostic["severity"] != float64(SeverityError) {
+		t.Errorf("diagnostic severity = %v, want %d", diagnostic["severity"], SeverityError)
+	}
+
+	lens := messages[2]["result"].([]any)[0].(map[string]any)
+	line := lens["range"].(map[string]any)["start"].(map[string]any)["line"]
+	title := lens["command"].(map[string]any)["title"].(string)
+	if line != float64(2) || !strings.Contains(title, "asset missing: /images/hero.png") {
+		t.Errorf("code lens = %v, want asset missing on line 2", lens)
+	}
+
+	// After the rescan old.png is used again, so its diagnostics are cleared
+	params = messages[3]["params"].(map[string]any)
+	if params["uri"] != "file:///project/assets/old.png" || len(params["diagnostics"].([]any)) != 0 {
+		t.Errorf("rescan published %v, want cleared diagnostics for old.png", messages[3])
+	}
+
+	if code := messages[4]["error"].(map[string]any)["code"]; code != float64(codeMethodNotFound) {
+		t.Errorf("unsupported request answered %v, want method not found", messages[4])
+	}
+	if result, ok := messages[5]["result"]; !ok || result != nil {
+		t.Errorf("shutdown answered %v, want a null result", messages[5])
+	}
+}
+
+func TestServer_CodeLensUTF16(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "App.tsx")
+	line := `const héro = "🎉"; img("/images/hero.png")`
+	if err := os.WriteFile(source, []byte("// header\n"+line+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	column := strings.Index(line, "/images") + 1
+	analyzer := &fakeAnalyzer{
+		results: []*models.ScanResult{{}},
+		missing: []*models.Reference{{LineNumber: 2, Column: column, MatchedText: "/images/hero.png"}},
+	}
+
+	in := frame(t,
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/codeLens","params":{"textDocument":{"uri":"`+pathToURI(source)+`"}}}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+	var out bytes.Buffer
+	if err := NewServer(analyzer, &out).Serve(in); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	messages := readAll(t, &out)
+	lens := messages[0]["result"].([]any)[0].(map[string]any)
+	start := lens["range"].(map[string]any)["start"].(map[string]any)
+	// é is one UTF-16 unit but two bytes, 🎉 two units but four bytes, so
+	// the path starts at unit 24 rather than byte 27
+	if start["line"] != float64(1) || start["character"] != float64(24) {
+		t.Errorf("code lens start = %v, want line 1 character 24", start)
+	}
+}
+
+func TestURIConversion(t *testing.T) {
+	uri := pathToURI("/project/assets/hero image.png")
+	if uri != "file:///project/assets/hero%20image.png" {
+		t.Errorf("pathToURI() = %q", uri)
+	}
+	if path, err := uriToPath(uri); err != nil || path != "/project/assets/hero image.png" {
+		t.Errorf("uriToPath(%q) = %q, %v", uri, path, err)
+	}
+	if _, err := uriToPath("untitled:Untitled-1"); err == nil {
+		t.Error("uriToPath() should reject non-file URIs")
+	}
+}
@@ -9,6 +9,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -21,17 +23,27 @@ const (
 	CategoryVideo
 	CategoryAudio
 	CategoryOther
+	// Added after Other so saved scan results keep their numbering
+	CategoryModel
+	CategoryAnimation
+	CategoryDocument
 )
 
+// categoryNames are the names of the categories, in order
+var categoryNames = [...]string{
+	"Image",
+	"Font",
+	"Video",
+	"Audio",
+	"Other",
+	"Model",
+	"Animation",
+	"Document",
+}
+
 // String returns the string representation of AssetCategory
 func (ac AssetCategory) String() string {
-	return [...]string{
-		"Image",
-		"Font",
-		"Video",
-		"Audio",
-		"Other",
-	}[ac]
+	return categoryNames[ac]
 }
 
 // AssetStatus represents the usage status of an asset
@@ -42,6 +54,9 @@ const (
 	StatusUnused
 	StatusPotentiallyUnused
 	StatusNeedsManualReview
+	StatusKept               // Explicitly kept via easyclean:keep annotation or .easycleankeep file
+	StatusConventional       // Requested by well-known path (favicon.ico, robots.txt, .well-known/) without a reference
+	StatusTransitivelyUnused // Referenced only by unused assets or source files no entry point reaches
 )
 
 // String returns the string representation of AssetStatus
@@ -51,9 +66,18 @@ func (as AssetStatus) String() string {
 		"Unused",
 		"PotentiallyUnused",
 		"NeedsManualReview",
+		"Kept",
+		"Conventional",
+		"TransitivelyUnused",
 	}[as]
 }
 
+// IsUnused reports whether the status marks an asset as deletable:
+// Unused or TransitivelyUnused
+func (as AssetStatus) IsUnused() bool {
+	return as == StatusUnused || as == StatusTransitivelyUnused
+}
+
 // AssetFile represents a single asset file discovered in the project
 type AssetFile struct {
 	// Identity
@@ -61,12 +85,27 @@ type AssetFile struct {
 	RelativePath string `json:"relative_path"`
 	Name         string `json:"name"`
 	Extension    string `json:"extension"`
+	// SniffedType is the extension an extension-less file's content matched
+	// (e.g. ".png"), with sniff_content
+	SniffedType string `json:"sniffed_type,omitempty"`
+	// EmbeddedIn lists the Lottie animations that carry a copy of this image
+	// as a data URI, relative to the project root
+	EmbeddedIn []string `json:"embedded_in,omitempty"`
 
 	// Metadata
 	Size    int64     `json:"size_bytes"`
 	ModTime time.Time `json:"mod_time"`
 	Hash    string    `json:"hash,omitempty"`
 
+	// Image metadata (header-only decoding; zero for non-images)
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+	Frames       int    `json:"frames,omitempty"`
+	ColorProfile string `json:"color_profile,omitempty"`
+
+	// Privacy flags from EXIF metadata (e.g. "gps", "camera_serial")
+	PrivacyFlags []string `json:"privacy_flags,omitempty"`
+
 	// Classification
 	Category AssetCategory `json:"category"`
 	Status   AssetStatus   `json:"status"`
@@ -74,6 +113,79 @@ type AssetFile struct {
 	// Usage Information
 	References []*Reference `json:"references,omitempty"`
 	RefCount   int          `json:"reference_count"`
+	// UsageConfidence combines the confidence of the active references,
+	// each referencing file counting as independent evidence
+	UsageConfidence float32 `json:"usage_confidence,omitempty"`
+	// ReviewReasons explain a Needs Review or Potentially Unused status
+	// ("dynamic template literal in src/x.ts:10")
+	ReviewReasons []string `json:"review_reasons,omitempty"`
+	// UnusedChain lists, for a Transitively Unused asset, the unused files
+	// its references come from: the referencing file first, then what
+	// referenced that, ending at the file nothing uses
+	UnusedChain []string `json:"unused_chain,omitempty"`
+
+	// Ownership
+	Feature string `json:"feature,omitempty"`
+
+	// Severity of this asset's status per the configured rules
+	Severity Severity `json:"severity,omitempty"`
+	// Rule is the configured status rule that applied to this asset, if any
+	Rule string `json:"rule,omitempty"`
+	// KeptByConfig is set when a keep_paths glob made this asset Kept
+	KeptByConfig bool `json:"kept_by_config,omitempty"`
+
+	// Licensing (from asset-licenses.yaml)
+	License             string `json:"license,omitempty"`
+	Attribution         string `json:"attribution,omitempty"`
+	RequiresAttribution bool   `json:"requires_attribution,omitempty"`
+
+	// Staleness (last git commit touching the file, or ModTime outside git)
+	LastTouched    time.Time `json:"last_touched"`
+	StalenessScore float64   `json:"staleness_score"`
+
+	// Runtime evidence from access logs and usage beacons (only set when
+	// logs or usage files were given)
+	Requests       int  `json:"requests,omitempty"`
+	NeverRequested bool `json:"never_requested,omitempty"`
+	Renders        int  `json:"renders,omitempty"`
+	NeverRendered  bool `json:"never_rendered,omitempty"`
+}
+
+// OnlyDeadCodeReferences reports whether the asset has references and every
+// non-comment one lies in dead code
+func (a *AssetFile) OnlyDeadCodeReferences() bool {
+	deadCode := false
+	for _, ref := range a.References {
+		if ref.IsDeadCode {
+			deadCode = true
+		} else if !ref.IsComment {
+			return false
+		}
+	}
+	return deadCode
+}
+
+// OnlyUnreachableReferences reports whether the asset has references and every
+// non-comment one comes from a file no entry point reaches
+func (a *AssetFile) OnlyUnreachableReferences() bool {
+	unreachable := false
+	for _, ref := range a.References {
+		if ref.FromUnreachable {
+			unreachable = true
+		} else if !ref.IsComment {
+			return false
+		}
+	}
+	return unreachable
+}
+
+// TypeExtension returns the extension that determines the asset's type: its
+// own, or the sniffed one for an extension-less file
+func (a AssetFile) TypeExtension() string {
+	if a.Extension == "" {
+		return a.SniffedType
+	}
+	return a.Extension
 }
 
 // DetermineCategoryFromExtension returns the asset category based on file extension
@@ -91,6 +203,16 @@ func DetermineCategoryFromExtension(ext string) AssetCategory {
 	audioExts := map[string]bool{
 		".mp3": true, ".wav": true, ".ogg": true, ".m4a": true, ".flac": true,
 	}
+	modelExts := map[string]bool{
+		".glb": true, ".gltf": true, ".obj": true, ".fbx": true,
+	}
+	animationExts := map[string]bool{
+		".lottie": true, ".riv": true,
+	}
+	documentExts := map[string]bool{
+		".pdf": true, ".doc": true, ".docx": true, ".xls": true, ".xlsx": true,
+		".ppt": true, ".pptx": true, ".odt": true, ".ods": true, ".odp": true, ".epub": true,
+	}
 
 	if imageExts[ext] {
 		return CategoryImage
@@ -104,5 +226,47 @@ func DetermineCategoryFromExtension(ext string) AssetCategory {
 	if audioExts[ext] {
 		return CategoryAudio
 	}
+	if modelExts[ext] {
+		return CategoryModel
+	}
+	if animationExts[ext] {
+		return CategoryAnimation
+	}
+	if documentExts[ext] {
+		return CategoryDocument
+	}
 	return CategoryOther
 }
+
+// CategoryFor returns the category of an extension: the one the categories
+// config assigns it, else the built-in one
+func (cfg *ProjectConfig) CategoryFor(ext string) AssetCategory {
+	if cfg != nil {
+		for name, exts := range cfg.Categories {
+			for _, e := range exts {
+				if strings.EqualFold(e, ext) {
+					if category, err := ParseAssetCategory(name); err == nil {
+						return category
+					}
+				}
+			}
+		}
+	}
+	return DetermineCategoryFromExtension(ext)
+}
+
+// ValidateCategories checks that every configured category name is valid
+// and its extensions start with a dot
+func (cfg *ProjectConfig) ValidateCategories() error {
+	for name, exts := range cfg.Categories {
+		if _, err := ParseAssetCategory(name); err != nil {
+			return err
+		}
+		for _, ext := range exts {
+			if !strings.HasPrefix(ext, ".") {
+				return fmt.Errorf("extension %q of category %s must start with a dot", ext, name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// AssetFilter selects assets by status, category, size, path, and age, as
+// the review UI's bulk selection does. Zero-valued fields match everything.
+type AssetFilter struct {
+	Statuses   []AssetStatus
+	Categories []AssetCategory
+	MinSize    int64
+	MaxSize    int64
+	PathGlob   string        // matched against RelativePath; "**" spans directories
+	MinAge     time.Duration // time since the asset was last touched
+	MaxAge     time.Duration
+}
+
+// Match reports whether an asset passes every condition of the filter
+func (f AssetFilter) Match(asset AssetFile, now time.Time) bool {
+	if len(f.Statuses) > 0 && !containsStatus(f.Statuses, asset.Status) {
+		return false
+	}
+	if len(f.Categories) > 0 && !containsCategory(f.Categories, asset.Category) {
+		return false
+	}
+	if f.MinSize > 0 && asset.Size < f.MinSize {
+		return false
+	}
+	if f.MaxSize > 0 && asset.Size > f.MaxSize {
+		return false
+	}
+	if f.PathGlob != "" && !utils.MatchGlob(f.PathGlob, asset.RelativePath) {
+		return false
+	}
+	if f.MinAge > 0 || f.MaxAge > 0 {
+		touched := asset.LastTouched
+		if touched.IsZero() {
+			touched = asset.ModTime
+		}
+		age := now.Sub(touched)
+		if f.MinAge > 0 && age < f.MinAge {
+			return false
+		}
+		if f.MaxAge > 0 && age > f.MaxAge {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the assets matching the filter, in their original order
+func (f AssetFilter) Apply(assets []AssetFile, now time.Time) []AssetFile {
+	matched := []AssetFile{}
+	for _, asset := range assets {
+		if f.Match(asset, now) {
+			matched = append(matched, asset)
+		}
+	}
+	return matched
+}
+
+// ParseAssetCategory resolves a category name such as "image" or "font"
+// (case is ignored)
+func ParseAssetCategory(name string) (AssetCategory, error) {
+	for category := range AssetCategory(len(categoryNames)) {
+		if strings.EqualFold(category.String(), strings.TrimSpace(name)) {
+			return category, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown asset category %q", name)
+}
+
+func containsStatus(statuses []AssetStatus, status AssetStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func containsCategory(categories []AssetCategory, category AssetCategory) bool {
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
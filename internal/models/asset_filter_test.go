@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssetFilterApply(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	assets := []AssetFile{
+		{RelativePath: "src/checkout/hero.png", Category: CategoryImage, Status: StatusUnused, Size: 400_000, LastTouched: now.AddDate(-2, 0, 0)},
+		{RelativePath: "src/checkout/icons/cart.svg", Category: CategoryImage, Status: StatusPotentiallyUnused, Size: 2_000, LastTouched: now.AddDate(0, -1, 0)},
+		{RelativePath: "public/fonts/inter.woff2", Category: CategoryFont, Status: StatusUnused, Size: 90_000, ModTime: now.AddDate(-1, -6, 0)},
+		{RelativePath: "public/logo.png", Category: CategoryImage, Status: StatusUsed, Size: 10_000, LastTouched: now},
+	}
+
+	tests := []struct {
+		name   string
+		filter AssetFilter
+		want   []string
+	}{
+		{"empty matches all", AssetFilter{}, []string{"src/checkout/hero.png", "src/checkout/icons/cart.svg", "public/fonts/inter.woff2", "public/logo.png"}},
+		{"status", AssetFilter{Statuses: []AssetStatus{StatusUnused}}, []string{"src/checkout/hero.png", "public/fonts/inter.woff2"}},
+		{"category", AssetFilter{Categories: []AssetCategory{CategoryFont}}, []string{"public/fonts/inter.woff2"}},
+		{"size range", AssetFilter{MinSize: 5_000, MaxSize: 100_000}, []string{"public/fonts/inter.woff2", "public/logo.png"}},
+		{"path glob", AssetFilter{PathGlob: "src/checkout/**"}, []string{"src/checkout/hero.png", "src/checkout/icons/cart.svg"}},
+		// inter.woff2 has no git history and falls back to its ModTime
+		{"min age", AssetFilter{MinAge: 365 * 24 * time.Hour}, []string{"src/checkout/hero.png", "public/fonts/inter.woff2"}},
+		{"max age", AssetFilter{MaxAge: 90 * 24 * time.Hour}, []string{"src/checkout/icons/cart.svg", "public/logo.png"}},
+		{"combined", AssetFilter{Statuses: []AssetStatus{StatusUnused}, Categories: []AssetCategory{CategoryImage}, MinAge: 365 * 24 * time.Hour}, []string{"src/checkout/hero.png"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.Apply(assets, now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Apply() returned %d assets, want %v", len(got), tt.want)
+			}
+			for i, asset := range got {
+				if asset.RelativePath != tt.want[i] {
+					t.Errorf("Apply()[%d] = %s, want %s", i, asset.RelativePath, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseAssetCategory(t *testing.T) {
+	if got, err := ParseAssetCategory("font"); err != nil || got != CategoryFont {
+		t.Errorf("ParseAssetCategory(font) = %v, %v; want Font", got, err)
+	}
+	if got, err := ParseAssetCategory("Image"); err != nil || got != CategoryImage {
+		t.Errorf("ParseAssetCategory(Image) = %v, %v; want Image", got, err)
+	}
+	if got, err := ParseAssetCategory("document"); err != nil || got != CategoryDocument {
+		t.Errorf("ParseAssetCategory(document) = %v, %v; want Document", got, err)
+	}
+	if _, err := ParseAssetCategory("spreadsheet"); err == nil {
+		t.Error("ParseAssetCategory(spreadsheet) should fail")
+	}
+}
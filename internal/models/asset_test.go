@@ -20,6 +20,12 @@ func TestDetermineCategoryFromExtension(t *testing.T) {
 		{"WebM video", ".webm", CategoryVideo},
 		{"MP3 audio", ".mp3", CategoryAudio},
 		{"WAV audio", ".wav", CategoryAudio},
+		{"glTF binary model", ".glb", CategoryModel},
+		{"FBX model", ".fbx", CategoryModel},
+		{"Rive animation", ".riv", CategoryAnimation},
+		{"dotLottie animation", ".lottie", CategoryAnimation},
+		{"PDF document", ".pdf", CategoryDocument},
+		{"Word document", ".docx", CategoryDocument},
 		{"Unknown extension", ".xyz", CategoryOther},
 		{"No extension", "", CategoryOther},
 	}
@@ -34,6 +40,31 @@ func TestDetermineCategoryFromExtension(t *testing.T) {
 	}
 }
 
+func TestCategoryFor(t *testing.T) {
+	cfg := &ProjectConfig{Categories: map[string][]string{"model": {".usdz"}, "document": {".docx"}}}
+	tests := map[string]AssetCategory{
+		".usdz": CategoryModel,
+		".docx": CategoryDocument,
+		".glb":  CategoryModel,
+		".png":  CategoryImage,
+		".xyz":  CategoryOther,
+	}
+	for ext, want := range tests {
+		if got := cfg.CategoryFor(ext); got != want {
+			t.Errorf("CategoryFor(%q) = %v, want %v", ext, got, want)
+		}
+	}
+
+	if err := cfg.ValidateCategories(); err != nil {
+		t.Errorf("ValidateCategories() error = %v", err)
+	}
+	for _, bad := range []map[string][]string{{"spreadsheet": {".xlsx"}}, {"document": {"docx"}}} {
+		if err := (&ProjectConfig{Categories: bad}).ValidateCategories(); err == nil {
+			t.Errorf("ValidateCategories(%v) succeeded, want an error", bad)
+		}
+	}
+}
+
 func TestAssetCategoryString(t *testing.T) {
 	tests := []struct {
 		category AssetCategory
@@ -44,6 +75,9 @@ func TestAssetCategoryString(t *testing.T) {
 		{CategoryVideo, "Video"},
 		{CategoryAudio, "Audio"},
 		{CategoryOther, "Other"},
+		{CategoryModel, "Model"},
+		{CategoryAnimation, "Animation"},
+		{CategoryDocument, "Document"},
 	}
 
 	for _, tt := range tests {
@@ -65,6 +99,8 @@ func TestAssetStatusString(t *testing.T) {
 		{StatusUnused, "Unused"},
 		{StatusPotentiallyUnused, "PotentiallyUnused"},
 		{StatusNeedsManualReview, "NeedsManualReview"},
+		{StatusKept, "Kept"},
+		{StatusConventional, "Conventional"},
 	}
 
 	for _, tt := range tests {
@@ -76,3 +112,25 @@ func TestAssetStatusString(t *testing.T) {
 		})
 	}
 }
+
+func TestAssetOnlyUnreachableReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		refs     []*Reference
+		expected bool
+	}{
+		{"No references", nil, false},
+		{"Unreachable only", []*Reference{{FromUnreachable: true}}, true},
+		{"Unreachable and comment", []*Reference{{FromUnreachable: true}, {IsComment: true}}, true},
+		{"Unreachable and reachable", []*Reference{{FromUnreachable: true}, {}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asset := &AssetFile{References: tt.refs}
+			if got := asset.OnlyUnreachableReferences(); got != tt.expected {
+				t.Errorf("OnlyUnreachableReferences() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "strings"
+
+// bundleCompression is roughly how much of an asset's size remains once
+// packaged into an APK/IPA, which are zip archives: formats that are already
+// compressed are stored at about their own size, while text and raw formats
+// deflate well. Extensions not listed count at full size.
+var bundleCompression = map[string]float64{
+	// Already compressed
+	".png": 1.0, ".jpg": 1.0, ".jpeg": 1.0, ".gif": 1.0, ".webp": 1.0, ".avif": 1.0, ".heic": 1.0,
+	".woff": 1.0, ".woff2": 1.0,
+	".mp3": 1.0, ".m4a": 1.0, ".aac": 1.0, ".ogg": 1.0, ".opus": 1.0,
+	".mp4": 1.0, ".mov": 1.0, ".webm": 1.0, ".mkv": 1.0,
+	// Deflate well
+	".svg": 0.3, ".json": 0.25, ".xml": 0.3, ".txt": 0.4, ".csv": 0.35,
+	".ttf": 0.6, ".otf": 0.65, ".eot": 0.6,
+	".bmp": 0.3, ".tif": 0.5, ".tiff": 0.5, ".ico": 0.5, ".psd": 0.5,
+	".wav": 0.85, ".aiff": 0.85, ".flac": 1.0,
+}
+
+// BundleFormat names the app package a project type ships as ("APK", "IPA",
+// "APK/IPA"), or "" for projects not packaged as mobile apps
+func BundleFormat(pt ProjectType) string {
+	switch pt {
+	case ProjectTypeAndroid:
+		return "APK"
+	case ProjectTypeIOS:
+		return "IPA"
+	case ProjectTypeFlutter, ProjectTypeReactNative:
+		return "APK/IPA"
+	}
+	return ""
+}
+
+// EstimateBundleSize estimates how many bytes an asset of size bytes adds to
+// an app package after zip compression
+func EstimateBundleSize(size int64, ext string) int64 {
+	ratio, ok := bundleCompression[strings.ToLower(ext)]
+	if !ok {
+		ratio = 1.0
+	}
+	return int64(float64(size) * ratio)
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// ConfidenceBucket counts the references of one type and confidence found on
+// assets of one status
+type ConfidenceBucket struct {
+	Status     AssetStatus   `json:"status"`
+	Type       ReferenceType `json:"type"`
+	Confidence float32       `json:"confidence"`
+	References int           `json:"references"`
+	// Active references are not in comments, dead code, or unreachable files
+	Active int `json:"active"`
+	Assets int `json:"assets"`
+}
+
+// ConfidenceBreakdown groups every reference by the status of its asset,
+// its type, and its confidence (rounded to two decimals), ordered by status,
+// then type, then confidence from high to low
+func (sr *ScanResult) ConfidenceBreakdown() []ConfidenceBucket {
+	type bucketKey struct {
+		status     AssetStatus
+		refType    ReferenceType
+		confidence float32
+	}
+	buckets := make(map[bucketKey]*ConfidenceBucket)
+
+	for _, asset := range sr.Assets {
+		counted := make(map[bucketKey]bool)
+		for _, ref := range asset.References {
+			key := bucketKey{
+				status:     asset.Status,
+				refType:    ref.Type,
+				confidence: float32(math.Round(float64(ref.Confidence)*100) / 100),
+			}
+			bucket, ok := buckets[key]
+			if !ok {
+				bucket = &ConfidenceBucket{Status: key.status, Type: key.refType, Confidence: key.confidence}
+				buckets[key] = bucket
+			}
+			bucket.References++
+			if !ref.IsComment && !ref.IsDeadCode && !ref.FromUnreachable {
+				bucket.Active++
+			}
+			if !counted[key] {
+				counted[key] = true
+				bucket.Assets++
+			}
+		}
+	}
+
+	result := make([]ConfidenceBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, *bucket)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Confidence > b.Confidence
+	})
+	return result
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfidenceBreakdown(t *testing.T) {
+	result := &ScanResult{
+		Assets: []AssetFile{
+			{
+				Status: StatusUsed,
+				References: []*Reference{
+					{Type: RefTypeImport, Confidence: 1.0},
+					{Type: RefTypeImport, Confidence: 1.0, IsComment: true},
+					{Type: RefTypeStringLiteral, Confidence: 0.7},
+				},
+			},
+			{
+				Status: StatusUsed,
+				References: []*Reference{
+					{Type: RefTypeStringLiteral, Confidence: 0.69999},
+					{Type: RefTypeStringLiteral, Confidence: 0.9},
+				},
+			},
+			{
+				Status:     StatusNeedsManualReview,
+				References: []*Reference{{Type: RefTypeTemplateLiteral, Confidence: 0.6, IsDynamic: true}},
+			},
+			{Status: StatusUnused},
+		},
+	}
+
+	want := []ConfidenceBucket{
+		{Status: StatusUsed, Type: RefTypeImport, Confidence: 1.0, References: 2, Active: 1, Assets: 1},
+		{Status: StatusUsed, Type: RefTypeStringLiteral, Confidence: 0.9, References: 1, Active: 1, Assets: 1},
+		{Status: StatusUsed, Type: RefTypeStringLiteral, Confidence: 0.7, References: 2, Active: 2, Assets: 2},
+		{Status: StatusNeedsManualReview, Type: RefTypeTemplateLiteral, Confidence: 0.6, References: 1, Active: 1, Assets: 1},
+	}
+	if got := result.ConfidenceBreakdown(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfidenceBreakdown() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,8 @@
+package models
+
+// LicenseRule assigns license information to assets matching a path glob
+type LicenseRule struct {
+	Path        string `yaml:"path" json:"path" mapstructure:"path"`
+	License     string `yaml:"license" json:"license" mapstructure:"license"`
+	Attribution string `yaml:"attribution" json:"attribution,omitempty" mapstructure:"attribution"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// OptimizationKind identifies the type of optimization suggestion
+type OptimizationKind string
+
+const (
+	OptimizationFormat OptimizationKind = "format"
+	OptimizationResize OptimizationKind = "resize"
+)
+
+// OptimizationSuggestion is a potential size saving for a used asset
+type OptimizationSuggestion struct {
+	Path             string           `json:"path"`
+	Kind             OptimizationKind `json:"kind"`
+	Message          string           `json:"message"`
+	EstimatedSavings int64            `json:"estimated_savings_bytes"`
+}
@@ -15,6 +15,10 @@ const (
 	ProjectTypeAndroid
 	ProjectTypeGo
 	ProjectTypeRust
+	ProjectTypeHugo
+	ProjectTypeJekyll
+	ProjectTypeEleventy
+	ProjectTypeWordPress
 )
 
 // String returns the string representation of ProjectType
@@ -31,32 +35,158 @@ func (pt ProjectType) String() string {
 		"Android (Kotlin/Java)",
 		"Go",
 		"Rust",
+		"Hugo",
+		"Jekyll",
+		"Eleventy",
+		"WordPress",
 	}[pt]
 }
 
 // ProjectConfig holds the configuration for scanning behavior
 type ProjectConfig struct {
 	// Asset Discovery
-	AssetPaths   []string `yaml:"asset_paths" json:"asset_paths"`
-	Extensions   []string `yaml:"extensions" json:"extensions"`
-	ExcludePaths []string `yaml:"exclude_paths" json:"exclude_paths"`
+	AssetPaths   []string `yaml:"asset_paths" json:"asset_paths" mapstructure:"asset_paths"`
+	Extensions   []string `yaml:"extensions" json:"extensions" mapstructure:"extensions"`
+	ExcludePaths []string `yaml:"exclude_paths" json:"exclude_paths" mapstructure:"exclude_paths"`
+	// SniffContent detects the type of extension-less files in asset paths
+	// (hashed blobs, bare font files) from their magic bytes
+	SniffContent bool `yaml:"sniff_content" json:"sniff_content,omitempty" mapstructure:"sniff_content"`
+	// Categories maps a category name (model, animation, document, ...) to
+	// extra extensions assigned to it; they are scanned as assets too
+	Categories map[string][]string `yaml:"categories" json:"categories,omitempty" mapstructure:"categories"`
+	// ConventionalFiles are globs for assets requested by well-known path,
+	// added to the built-in list (favicon.ico, robots.txt, .well-known/, ...)
+	ConventionalFiles []string `yaml:"conventional_files" json:"conventional_files,omitempty" mapstructure:"conventional_files"`
+	// KeepPaths are globs for assets never reported unused or deleted; they
+	// are Kept like .easycleankeep matches but listed as kept by config
+	KeepPaths []string `yaml:"keep_paths" json:"keep_paths,omitempty" mapstructure:"keep_paths"`
 
 	// Reference Detection
-	ConstantFiles  []string `yaml:"constant_files" json:"constant_files"`
-	BasePathVars   []string `yaml:"base_path_vars" json:"base_path_vars"`
-	CustomPatterns []string `yaml:"custom_patterns" json:"custom_patterns"`
+	ConstantFiles  []string `yaml:"constant_files" json:"constant_files" mapstructure:"constant_files"`
+	BasePathVars   []string `yaml:"base_path_vars" json:"base_path_vars" mapstructure:"base_path_vars"`
+	CustomPatterns []string `yaml:"custom_patterns" json:"custom_patterns" mapstructure:"custom_patterns"`
+	// ContentSources are directories or globs of CMS content (Markdown with
+	// front matter, JSON/YAML/TOML data files) whose fields name assets
+	ContentSources []string `yaml:"content_sources" json:"content_sources,omitempty" mapstructure:"content_sources"`
+	// EmailTemplates scans .mjml files and email-only attributes
+	// (background-url, <td background>) in addition to the project's patterns
+	EmailTemplates bool `yaml:"email_templates" json:"email_templates,omitempty" mapstructure:"email_templates"`
+	// PublicBaseURLs are the URLs the project's public files are served from
+	// (e.g. https://cdn.example.com/); absolute references under them resolve
+	// to paths in the repo
+	PublicBaseURLs []string `yaml:"public_base_urls" json:"public_base_urls,omitempty" mapstructure:"public_base_urls"`
+	// Buckets are object storage prefixes (s3://bucket/prefix, gs://bucket/prefix)
+	// the bucket command inventories for assets no source file mentions
+	Buckets []string `yaml:"buckets" json:"buckets,omitempty" mapstructure:"buckets"`
+	// AccessLogs are CDN/web server access logs (or CSVs of requested paths)
+	// whose request counts are recorded on each asset; relative paths are
+	// resolved against the project root
+	AccessLogs []string `yaml:"access_logs" json:"access_logs,omitempty" mapstructure:"access_logs"`
+	// UsageFiles are JSON-lines files of runtime usage beacons (see the
+	// collect command); Used assets no beacon reported become Potentially
+	// Unused. Relative paths are resolved against the project root.
+	UsageFiles []string `yaml:"usage_files" json:"usage_files,omitempty" mapstructure:"usage_files"`
+	// DeadCodeAnalysis flags references inside unreachable code (if (false)
+	// blocks, unused unexported Go functions, Dart constants never read) so
+	// they don't count as usage
+	DeadCodeAnalysis bool `yaml:"dead_code_analysis" json:"dead_code_analysis,omitempty" mapstructure:"dead_code_analysis"`
+	// ReachabilityAnalysis builds the module import graph from entry points and
+	// treats assets referenced only by unreachable source files as unused
+	ReachabilityAnalysis bool `yaml:"reachability_analysis" json:"reachability_analysis,omitempty" mapstructure:"reachability_analysis"`
+	// EntryPoints are globs for the module graph roots; empty uses package.json
+	// and the conventions of the detected framework
+	EntryPoints []string `yaml:"entry_points" json:"entry_points,omitempty" mapstructure:"entry_points"`
+	// RegexOnly skips AST parsing of JavaScript/TypeScript and matches
+	// references with the regex patterns alone: faster, but misses some
+	// multi-line and computed imports
+	RegexOnly bool `yaml:"regex_only" json:"regex_only,omitempty" mapstructure:"regex_only"`
 
 	// Behavior
-	FollowSymlinks        bool        `yaml:"follow_symlinks" json:"follow_symlinks"`
-	AutoDetectProjectType bool        `yaml:"auto_detect_project_type" json:"auto_detect_project_type"`
-	ProjectType           ProjectType `yaml:"project_type" json:"project_type"`
+	FollowSymlinks        bool        `yaml:"follow_symlinks" json:"follow_symlinks" mapstructure:"follow_symlinks"`
+	AutoDetectProjectType bool        `yaml:"auto_detect_project_type" json:"auto_detect_project_type" mapstructure:"auto_detect_project_type"`
+	ProjectType           ProjectType `yaml:"project_type" json:"project_type" mapstructure:"project_type"`
+	// IncludeHidden walks dot-directories; otherwise only .well-known and
+	// dot-directories holding a configured asset path (.vitepress/public/) are
+	IncludeHidden bool `yaml:"include_hidden" json:"include_hidden,omitempty" mapstructure:"include_hidden"`
+	// ScanDotfiles treats dot-prefixed files as assets and source files
+	ScanDotfiles bool `yaml:"scan_dotfiles" json:"scan_dotfiles,omitempty" mapstructure:"scan_dotfiles"`
+	// DiscoverAssetPaths replaces conventional asset paths with directories
+	// found to hold at least DiscoveryMinFiles asset files (0 uses the default of 5)
+	DiscoverAssetPaths bool `yaml:"discover_asset_paths" json:"discover_asset_paths,omitempty" mapstructure:"discover_asset_paths"`
+	DiscoveryMinFiles  int  `yaml:"discovery_min_files" json:"discovery_min_files,omitempty" mapstructure:"discovery_min_files"`
 
 	// Performance
-	MaxWorkers  int   `yaml:"max_workers" json:"max_workers"`
-	MemoryLimit int64 `yaml:"memory_limit" json:"memory_limit"`
+	MaxWorkers  int   `yaml:"max_workers" json:"max_workers" mapstructure:"max_workers"`
+	MemoryLimit int64 `yaml:"memory_limit" json:"memory_limit" mapstructure:"memory_limit"`
+	// MaxDepth limits how many directory levels below the root are walked,
+	// and MaxFiles how many files one walk visits (0 = no limit)
+	MaxDepth int `yaml:"max_depth" json:"max_depth,omitempty" mapstructure:"max_depth"`
+	MaxFiles int `yaml:"max_files" json:"max_files,omitempty" mapstructure:"max_files"`
+	// IOThrottle slows scans down on laptops and shared CI nodes (see --nice)
+	IOThrottle IOThrottle `yaml:"io_throttle" json:"io_throttle" mapstructure:"io_throttle"`
+	// CompressCache gzips the cached scan results (smaller for huge projects)
+	CompressCache bool `yaml:"compress_cache" json:"compress_cache,omitempty" mapstructure:"compress_cache"`
+	// CacheDir replaces the user cache directory results are saved to,
+	// relative to the config file (EASYCLEAN_CACHE_DIR takes precedence)
+	CacheDir string `yaml:"cache_dir" json:"cache_dir,omitempty" mapstructure:"cache_dir"`
+	// HistoryLimit is how many earlier scans are kept per project (0 = none),
+	// and HistoryMaxAgeDays prunes older ones regardless (0 = no age limit)
+	HistoryLimit      int `yaml:"history_limit" json:"history_limit" mapstructure:"history_limit"`
+	HistoryMaxAgeDays int `yaml:"history_max_age_days" json:"history_max_age_days,omitempty" mapstructure:"history_max_age_days"`
+
+	// Reporting
+	// LicenseFile is the asset license mapping, relative to the project root
+	LicenseFile string `yaml:"license_file" json:"license_file,omitempty" mapstructure:"license_file"`
+
+	// PrivacyScan flags images carrying EXIF GPS coordinates or camera serials
+	PrivacyScan bool `yaml:"privacy_scan" json:"privacy_scan" mapstructure:"privacy_scan"`
+	// Severity maps a status name (unused, potentially_unused, needs_review, ...)
+	// to off, info, warning, or error; drives `check` exit codes and exporters
+	Severity map[string]string `yaml:"severity" json:"severity,omitempty" mapstructure:"severity"`
+	// StatusRules override the status and/or severity of assets matching a
+	// path glob, status, and reference count, in order; the first match wins
+	StatusRules []StatusRule `yaml:"rules" json:"rules,omitempty" mapstructure:"rules"`
+	// Features maps a feature/team name to a path glob (e.g. "src/checkout/**")
+	Features map[string]string `yaml:"features" json:"features,omitempty" mapstructure:"features"`
+
+	// Profile is the entry under profiles: the config was loaded with, if any
+	Profile string `yaml:"-" json:"profile,omitempty" mapstructure:"-"`
 
 	// Output
-	Verbose     bool `yaml:"verbose" json:"verbose"`
-	ShowProgress bool `yaml:"show_progress" json:"show_progress"`
-	ColorOutput bool `yaml:"color_output" json:"color_output"`
+	Verbose      bool `yaml:"verbose" json:"verbose" mapstructure:"verbose"`
+	ShowProgress bool `yaml:"show_progress" json:"show_progress" mapstructure:"show_progress"`
+	ColorOutput  bool `yaml:"color_output" json:"color_output" mapstructure:"color_output"`
+}
+
+// IOThrottle limits the disk and CPU a scan takes: MaxConcurrentReads caps
+// the files read at once and DirectoryPauseMs sleeps after each directory
+// (0 = no limit). One project's scan reads a file at a time, so the read cap
+// only bites where scans share a throttle, as the projects of --batch --nice do.
+type IOThrottle struct {
+	MaxConcurrentReads int `yaml:"max_concurrent_reads" json:"max_concurrent_reads,omitempty" mapstructure:"max_concurrent_reads"`
+	DirectoryPauseMs   int `yaml:"directory_pause_ms" json:"directory_pause_ms,omitempty" mapstructure:"directory_pause_ms"`
+}
+
+// NiceIOThrottle is the throttle --nice applies where io_throttle sets none
+var NiceIOThrottle = IOThrottle{MaxConcurrentReads: 1, DirectoryPauseMs: 10}
+
+// NetworkIOThrottle is the throttle applied where io_throttle sets none when
+// the project is on a network or slow filesystem: the pause between
+// directories spreads a scan's requests out so it doesn't swamp a mount
+// other machines share
+var NetworkIOThrottle = IOThrottle{MaxConcurrentReads: 4, DirectoryPauseMs: 5}
+
+// IsZero reports whether the throttle limits nothing
+func (t IOThrottle) IsZero() bool {
+	return t.MaxConcurrentReads == 0 && t.DirectoryPauseMs == 0
+}
+
+// DirConfig is a .unusedassets.yaml nested below the project root, overriding
+// the policy for its subtree: Extensions replace the inherited list, while
+// ExcludePaths and KeepPaths add to it, relative to Dir
+type DirConfig struct {
+	Dir          string   `yaml:"-" json:"dir" mapstructure:"-"` // slash-separated, relative to the project root
+	Extensions   []string `yaml:"extensions" json:"extensions,omitempty" mapstructure:"extensions"`
+	ExcludePaths []string `yaml:"exclude_paths" json:"exclude_paths,omitempty" mapstructure:"exclude_paths"`
+	KeepPaths    []string `yaml:"keep_paths" json:"keep_paths,omitempty" mapstructure:"keep_paths"`
 }
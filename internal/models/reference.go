@@ -33,7 +33,8 @@ type Reference struct {
 	// Location
 	SourceFile string `json:"source_file"`
 	LineNumber int    `json:"line_number"`
-	Column     int    `json:"column,omitempty"`
+	Column     int    `json:"column,omitempty"` // 1-based byte column of the matched text
+	Offset     int    `json:"offset,omitempty"` // byte offset of the matched text in the file
 
 	// Content
 	MatchedText string `json:"matched_text"`
@@ -47,4 +48,7 @@ type Reference struct {
 	IsComment  bool `json:"is_comment"`
 	IsDynamic  bool `json:"is_dynamic"`
 	IsDeadCode bool `json:"is_dead_code,omitempty"`
+
+	// FromUnreachable is set when the source file can't be reached from any entry point
+	FromUnreachable bool `json:"from_unreachable,omitempty"`
 }
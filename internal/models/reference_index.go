@@ -0,0 +1,117 @@
+package models
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+)
+
+// IndexedReference is a compact reference entry stored in the reference index
+type IndexedReference struct {
+	SourceFile      string // relative to project root
+	LineNumber      int
+	Column          int
+	Type            ReferenceType
+	Confidence      float32
+	IsComment       bool
+	IsDynamic       bool
+	IsDeadCode      bool
+	FromUnreachable bool
+}
+
+// ReferenceIndex is an inverted index of asset references, persisted in the
+// project cache so lookups don't require loading the full scan result
+type ReferenceIndex struct {
+	ProjectRoot string
+	// ByAsset maps an asset's relative path to the references pointing at it
+	ByAsset map[string][]IndexedReference
+	// BySource maps a source file's relative path to the assets it references
+	BySource map[string][]string
+	// Status records each asset's classification
+	Status map[string]AssetStatus
+}
+
+// BuildReferenceIndex builds an inverted reference index from a scan result
+func BuildReferenceIndex(result *ScanResult) *ReferenceIndex {
+	idx := &ReferenceIndex{
+		ProjectRoot: result.ProjectRoot,
+		ByAsset:     make(map[string][]IndexedReference, len(result.Assets)),
+		BySource:    make(map[string][]string),
+		Status:      make(map[string]AssetStatus, len(result.Assets)),
+	}
+
+	for _, asset := range result.Assets {
+		idx.Status[asset.RelativePath] = asset.Status
+
+		seenSources := make(map[string]bool)
+		for _, ref := range asset.References {
+			source := idx.RelativePath(ref.SourceFile)
+			idx.ByAsset[asset.RelativePath] = append(idx.ByAsset[asset.RelativePath], IndexedReference{
+				SourceFile:      source,
+				LineNumber:      ref.LineNumber,
+				Column:          ref.Column,
+				Type:            ref.Type,
+				Confidence:      ref.Confidence,
+				IsComment:       ref.IsComment,
+				IsDynamic:       ref.IsDynamic,
+				IsDeadCode:      ref.IsDeadCode,
+				FromUnreachable: ref.FromUnreachable,
+			})
+
+			if !seenSources[source] {
+				seenSources[source] = true
+				idx.BySource[source] = append(idx.BySource[source], asset.RelativePath)
+			}
+		}
+	}
+
+	for source := range idx.BySource {
+		sort.Strings(idx.BySource[source])
+	}
+
+	return idx
+}
+
+// RelativePath converts a path to the project-relative form used as index keys
+func (idx *ReferenceIndex) RelativePath(path string) string {
+	path = filepath.Clean(path)
+	if idx.ProjectRoot == "" || !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(idx.ProjectRoot, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Why returns the references to an asset and whether the asset is known
+func (idx *ReferenceIndex) Why(assetPath string) ([]IndexedReference, bool) {
+	key := idx.RelativePath(assetPath)
+	_, known := idx.Status[key]
+	return idx.ByAsset[key], known
+}
+
+// Uses returns the assets referenced by a source file
+func (idx *ReferenceIndex) Uses(sourcePath string) []string {
+	return idx.BySource[idx.RelativePath(sourcePath)]
+}
+
+// Encode writes the index to w in gob format
+func (idx *ReferenceIndex) Encode(w io.Writer) error {
+	if err := gob.NewEncoder(w).Encode(idx); err != nil {
+		return fmt.Errorf("failed to encode reference index: %w", err)
+	}
+	return nil
+}
+
+// DecodeReferenceIndex reads an index previously written by Encode
+func DecodeReferenceIndex(r io.Reader) (*ReferenceIndex, error) {
+	var idx ReferenceIndex
+	if err := gob.NewDecoder(r).Decode(&idx); err != nil {
+		return nil, fmt.Errorf("failed to decode reference index: %w", err)
+	}
+	return &idx, nil
+}
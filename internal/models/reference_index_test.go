@@ -0,0 +1,97 @@
+package models
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func sampleIndexResult() *ScanResult {
+	return &ScanResult{
+		ProjectRoot: "/project",
+		Assets: []AssetFile{
+			{
+				RelativePath: "assets/logo.png",
+				Status:       StatusUsed,
+				References: []*Reference{
+					{SourceFile: "/project/src/app.js", LineNumber: 3, Type: RefTypeImport, Confidence: 1},
+					{SourceFile: "/project/src/app.js", LineNumber: 9, Type: RefTypeStringLiteral, Confidence: 0.8},
+					{SourceFile: "/project/src/header.css", LineNumber: 1, Type: RefTypeCSSUrl, Confidence: 0.95},
+				},
+			},
+			{
+				RelativePath: "assets/icon.svg",
+				Status:       StatusUsed,
+				References: []*Reference{
+					{SourceFile: "/project/src/app.js", LineNumber: 4, Type: RefTypeImport, Confidence: 1},
+				},
+			},
+			{RelativePath: "assets/unused.png", Status: StatusUnused},
+		},
+	}
+}
+
+func TestReferenceIndex_Why(t *testing.T) {
+	idx := BuildReferenceIndex(sampleIndexResult())
+
+	tests := []struct {
+		path      string
+		wantRefs  int
+		wantKnown bool
+	}{
+		{"assets/logo.png", 3, true},
+		{"/project/assets/logo.png", 3, true},
+		{"./assets/icon.svg", 1, true},
+		{"assets/unused.png", 0, true},
+		{"assets/missing.png", 0, false},
+	}
+
+	for _, tt := range tests {
+		refs, known := idx.Why(tt.path)
+		if len(refs) != tt.wantRefs || known != tt.wantKnown {
+			t.Errorf("Why(%q) = %d refs, known %v; want %d, %v", tt.path, len(refs), known, tt.wantRefs, tt.wantKnown)
+		}
+	}
+
+	refs, _ := idx.Why("assets/logo.png")
+	if refs[0].SourceFile != "src/app.js" {
+		t.Errorf("Why() source = %q, want %q", refs[0].SourceFile, "src/app.js")
+	}
+}
+
+func TestReferenceIndex_Uses(t *testing.T) {
+	idx := BuildReferenceIndex(sampleIndexResult())
+
+	tests := []struct {
+		source string
+		want   []string
+	}{
+		{"src/app.js", []string{"assets/icon.svg", "assets/logo.png"}},
+		{"/project/src/header.css", []string{"assets/logo.png"}},
+		{"src/other.js", nil},
+	}
+
+	for _, tt := range tests {
+		if got := idx.Uses(tt.source); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Uses(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestReferenceIndex_EncodeDecode(t *testing.T) {
+	idx := BuildReferenceIndex(sampleIndexResult())
+
+	var buf bytes.Buffer
+	if err := idx.Encode(&buf); err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+
+	decoded, err := DecodeReferenceIndex(&buf)
+	if err != nil {
+		t.Fatalf("DecodeReferenceIndex() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, idx) {
+		t.Errorf("DecodeReferenceIndex() = %+v, want %+v", decoded, idx)
+	}
+}
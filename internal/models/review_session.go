@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Decision is a reviewer's verdict on an asset in the review UI
+type Decision string
+
+const (
+	DecisionDelete Decision = "delete" // selected for deletion
+	DecisionKeep   Decision = "keep"   // reviewed and kept
+)
+
+// ReviewSession is the in-progress state of a review, saved to the project
+// cache so a review can be paused and resumed
+type ReviewSession struct {
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Decisions by asset relative path
+	Decisions map[string]Decision `json:"decisions"`
+
+	// Filter is the query string of the last applied asset filter
+	Filter string `json:"filter,omitempty"`
+}
+
+// Prune drops invalid decisions and those for assets no longer in the scan
+// result, e.g. files deleted since the session was saved
+func (s *ReviewSession) Prune(result *ScanResult) {
+	known := make(map[string]bool, len(result.Assets))
+	for _, asset := range result.Assets {
+		known[asset.RelativePath] = true
+	}
+	for path, decision := range s.Decisions {
+		if !known[path] || (decision != DecisionDelete && decision != DecisionKeep) {
+			delete(s.Decisions, path)
+		}
+	}
+	if s.Decisions == nil {
+		s.Decisions = map[string]Decision{}
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestReviewSessionPrune(t *testing.T) {
+	result := &ScanResult{Assets: []AssetFile{
+		{RelativePath: "assets/a.png"},
+		{RelativePath: "assets/b.png"},
+	}}
+	session := &ReviewSession{Decisions: map[string]Decision{
+		"assets/a.png":    DecisionDelete,
+		"assets/b.png":    "maybe",
+		"assets/gone.png": DecisionKeep,
+	}}
+
+	session.Prune(result)
+
+	if len(session.Decisions) != 1 || session.Decisions["assets/a.png"] != DecisionDelete {
+		t.Errorf("Prune() left %v, want only assets/a.png: delete", session.Decisions)
+	}
+
+	empty := &ReviewSession{}
+	empty.Prune(result)
+	if empty.Decisions == nil {
+		t.Error("Prune() should initialize Decisions")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,9 +18,55 @@ type ScanStatistics struct {
 	UnusedSize             int64   `json:"unused_size_bytes"`
 	PotentiallyUnusedCount int     `json:"potentially_unused_count"`
 	NeedsReviewCount       int     `json:"needs_review_count"`
+	KeptCount              int     `json:"kept_count,omitempty"`
+	KeptByConfigCount      int     `json:"kept_by_config_count,omitempty"`
+	TransitiveUnusedCount  int     `json:"transitive_unused_count,omitempty"` // part of UnusedCount referenced only by unused files
+	ConventionalCount      int     `json:"conventional_count,omitempty"`
+	ErrorCount             int     `json:"error_count"`
+	WarningCount           int     `json:"warning_count"`
+	InfoCount              int     `json:"info_count"`
 	FilesScanned           int     `json:"files_scanned"`
+	BytesRead              int64   `json:"bytes_read"`
 	ReferencesFound        int     `json:"references_found"`
 	AvgScanSpeed           float64 `json:"avg_scan_speed_files_per_sec"`
+	PrivacyFlaggedCount    int     `json:"privacy_flagged_count,omitempty"`
+	EmbeddedCopyCount      int     `json:"embedded_copy_count,omitempty"` // images a Lottie file also embeds
+	UnlicensedCount        int     `json:"unlicensed_count,omitempty"`
+	DeadCodeOnlyCount      int     `json:"dead_code_only_count,omitempty"`
+	UnreachableOnlyCount   int     `json:"unreachable_only_count,omitempty"`
+	NeverRequestedCount    int     `json:"never_requested_count,omitempty"`
+	NeverRenderedCount     int     `json:"never_rendered_count,omitempty"`
+
+	// Estimated app package (APK/IPA) bytes the unused assets account for;
+	// only set for mobile project types
+	BundleFormat  string `json:"bundle_format,omitempty"`
+	BundleSavings int64  `json:"estimated_bundle_savings_bytes,omitempty"`
+
+	// Time spent in each scan phase
+	Phases PhaseDurations `json:"phase_durations_ms"`
+
+	// Per-feature breakdown (only populated when features are configured)
+	Features map[string]*GroupStatistics `json:"features,omitempty"`
+
+	// Breakdowns by asset category (Image, Font, ...) and lowercase extension
+	Categories map[string]*GroupStatistics `json:"categories,omitempty"`
+	Extensions map[string]*GroupStatistics `json:"extensions,omitempty"`
+}
+
+// PhaseDurations are the milliseconds a scan spent finding assets, finding
+// references, and classifying
+type PhaseDurations struct {
+	Discovery      int64 `json:"discovery"`
+	References     int64 `json:"references"`
+	Classification int64 `json:"classification"`
+}
+
+// GroupStatistics holds asset statistics for one feature, category, or extension
+type GroupStatistics struct {
+	TotalAssets int   `json:"total_assets"`
+	TotalSize   int64 `json:"total_size_bytes"`
+	UnusedCount int   `json:"unused_count"`
+	UnusedSize  int64 `json:"unused_size_bytes"`
 }
 
 // ScanResult represents the complete output of scanning a project
@@ -36,32 +83,116 @@ type ScanResult struct {
 	UnusedAssets            []AssetFile `json:"unused_assets,omitempty"`
 	PotentiallyUnusedAssets []AssetFile `json:"potentially_unused_assets,omitempty"`
 	NeedsReviewAssets       []AssetFile `json:"needs_review_assets,omitempty"`
+	KeptAssets              []AssetFile `json:"kept_assets,omitempty"`
+	KeptByConfigAssets      []AssetFile `json:"kept_by_config_assets,omitempty"`
+	ConventionalAssets      []AssetFile `json:"conventional_assets,omitempty"`
 
 	// Statistics
 	Stats ScanStatistics `json:"statistics"`
 
+	// LicensesTracked is set when an asset license file was loaded
+	LicensesTracked bool `json:"licenses_tracked,omitempty"`
+
+	// RequestsTracked is set when access logs supplied request counts
+	RequestsTracked bool `json:"requests_tracked,omitempty"`
+
+	// RendersTracked is set when usage beacons supplied render counts
+	RendersTracked bool `json:"renders_tracked,omitempty"`
+
+	// Optimization suggestions for used assets (only with --optimize)
+	Optimizations []OptimizationSuggestion `json:"optimizations,omitempty"`
+
+	// Reference counts by status, type, and confidence (only with --explain-confidence)
+	ConfidenceReport []ConfidenceBucket `json:"confidence_report,omitempty"`
+
+	// Warnings are traversal limits (max_depth, max_files) the scan hit,
+	// which leave results incomplete
+	Warnings []string `json:"warnings,omitempty"`
+
 	// Configuration
 	Config *ProjectConfig `json:"config,omitempty"`
 }
 
-// ComputeStatistics calculates all statistics from the Assets slice
+// ComputeStatistics calculates all statistics from the Assets slice.
+// Counters recorded while scanning (files scanned, bytes read, phase
+// durations) are kept.
 func (sr *ScanResult) ComputeStatistics() {
 	sr.Stats = ScanStatistics{
-		TotalAssets: len(sr.Assets),
+		TotalAssets:  len(sr.Assets),
+		FilesScanned: sr.Stats.FilesScanned,
+		BytesRead:    sr.Stats.BytesRead,
+		Phases:       sr.Stats.Phases,
+		BundleFormat: BundleFormat(sr.ProjectType),
 	}
 
+	bundleFormat := sr.Stats.BundleFormat
 	for _, asset := range sr.Assets {
 		sr.Stats.TotalSize += asset.Size
 		sr.Stats.ReferencesFound += len(asset.References)
 
 		switch asset.Status {
-		case StatusUnused:
+		case StatusUnused, StatusTransitivelyUnused:
 			sr.Stats.UnusedCount++
+			if asset.Status == StatusTransitivelyUnused {
+				sr.Stats.TransitiveUnusedCount++
+			}
 			sr.Stats.UnusedSize += asset.Size
+			if bundleFormat != "" {
+				sr.Stats.BundleSavings += EstimateBundleSize(asset.Size, asset.TypeExtension())
+			}
 		case StatusPotentiallyUnused:
 			sr.Stats.PotentiallyUnusedCount++
 		case StatusNeedsManualReview:
 			sr.Stats.NeedsReviewCount++
+		case StatusKept:
+			sr.Stats.KeptCount++
+			if asset.KeptByConfig {
+				sr.Stats.KeptByConfigCount++
+			}
+		case StatusConventional:
+			sr.Stats.ConventionalCount++
+		}
+
+		switch asset.Severity {
+		case SeverityError:
+			sr.Stats.ErrorCount++
+		case SeverityWarning:
+			sr.Stats.WarningCount++
+		case SeverityInfo:
+			sr.Stats.InfoCount++
+		}
+
+		if asset.Feature != "" {
+			addGroupStatistics(&sr.Stats.Features, asset.Feature, asset)
+		}
+		addGroupStatistics(&sr.Stats.Categories, asset.Category.String(), asset)
+		addGroupStatistics(&sr.Stats.Extensions, strings.ToLower(asset.TypeExtension()), asset)
+
+		if len(asset.PrivacyFlags) > 0 {
+			sr.Stats.PrivacyFlaggedCount++
+		}
+		if len(asset.EmbeddedIn) > 0 {
+			sr.Stats.EmbeddedCopyCount++
+		}
+
+		if sr.LicensesTracked && asset.License == "" {
+			sr.Stats.UnlicensedCount++
+		}
+
+		if asset.OnlyDeadCodeReferences() {
+			sr.Stats.DeadCodeOnlyCount++
+		}
+
+		if asset.OnlyUnreachableReferences() {
+			sr.Stats.UnreachableOnlyCount++
+		}
+
+		if asset.NeverRequested {
+			sr.Stats.NeverRequestedCount++
+		}
+
+		if asset.NeverRendered {
+			sr.Stats.NeverRenderedCount++
 		}
 	}
 
@@ -72,6 +203,48 @@ func (sr *ScanResult) ComputeStatistics() {
 	}
 }
 
+// addGroupStatistics accumulates an asset into the statistics of its group
+func addGroupStatistics(groups *map[string]*GroupStatistics, key string, asset AssetFile) {
+	if *groups == nil {
+		*groups = make(map[string]*GroupStatistics)
+	}
+
+	fs, ok := (*groups)[key]
+	if !ok {
+		fs = &GroupStatistics{}
+		(*groups)[key] = fs
+	}
+
+	fs.TotalAssets++
+	fs.TotalSize += asset.Size
+	if asset.Status.IsUnused() {
+		fs.UnusedCount++
+		fs.UnusedSize += asset.Size
+	}
+}
+
+// AssignSeverities sets each asset's severity from the config's severity
+// map, keeping severities a status rule already set
+func (sr *ScanResult) AssignSeverities() {
+	for i := range sr.Assets {
+		if sr.Assets[i].Rule != "" && sr.Assets[i].Severity != "" {
+			continue
+		}
+		sr.Assets[i].Severity = sr.Config.SeverityFor(sr.Assets[i].Status)
+	}
+}
+
+// MaxSeverity returns the highest severity among all assets
+func (sr *ScanResult) MaxSeverity() Severity {
+	highest := SeverityOff
+	for _, asset := range sr.Assets {
+		if asset.Severity.Rank() > highest.Rank() {
+			highest = asset.Severity
+		}
+	}
+	return highest
+}
+
 // FilterByStatus returns assets matching the given status
 func (sr *ScanResult) FilterByStatus(status AssetStatus) []AssetFile {
 	var filtered []AssetFile
@@ -86,9 +259,64 @@ func (sr *ScanResult) FilterByStatus(status AssetStatus) []AssetFile {
 // PopulateFilteredLists populates the filtered asset lists based on status
 func (sr *ScanResult) PopulateFilteredLists() {
 	sr.UsedAssets = sr.FilterByStatus(StatusUsed)
-	sr.UnusedAssets = sr.FilterByStatus(StatusUnused)
+	sr.UnusedAssets = nil
+	for _, asset := range sr.Assets {
+		if asset.Status.IsUnused() {
+			sr.UnusedAssets = append(sr.UnusedAssets, asset)
+		}
+	}
 	sr.PotentiallyUnusedAssets = sr.FilterByStatus(StatusPotentiallyUnused)
 	sr.NeedsReviewAssets = sr.FilterByStatus(StatusNeedsManualReview)
+	sr.KeptAssets = sr.FilterByStatus(StatusKept)
+	sr.KeptByConfigAssets = nil
+	for _, asset := range sr.KeptAssets {
+		if asset.KeptByConfig {
+			sr.KeptByConfigAssets = append(sr.KeptByConfigAssets, asset)
+		}
+	}
+	sr.ConventionalAssets = sr.FilterByStatus(StatusConventional)
+}
+
+// Sort keys accepted by SortAssets
+const (
+	SortByPath      = "path"
+	SortBySize      = "size"
+	SortByStaleness = "staleness"
+	SortByStatus    = "status"
+)
+
+// statusSortOrder puts actionable statuses first when sorting by status
+var statusSortOrder = map[AssetStatus]int{
+	StatusUnused:             0,
+	StatusTransitivelyUnused: 1,
+	StatusPotentiallyUnused:  2,
+	StatusNeedsManualReview:  3,
+	StatusUsed:               4,
+	StatusKept:               5,
+	StatusConventional:       6,
+}
+
+// SortAssets orders Assets by the given key (largest/stalest first for
+// size and staleness, unused first for status). Call before PopulateFilteredLists so the filtered
+// lists inherit the order. Unknown keys leave the order unchanged.
+func (sr *ScanResult) SortAssets(by string) {
+	var less func(a, b AssetFile) bool
+	switch by {
+	case SortByPath:
+		less = func(a, b AssetFile) bool { return a.RelativePath < b.RelativePath }
+	case SortBySize:
+		less = func(a, b AssetFile) bool { return a.Size > b.Size }
+	case SortByStaleness:
+		less = func(a, b AssetFile) bool { return a.StalenessScore > b.StalenessScore }
+	case SortByStatus:
+		less = func(a, b AssetFile) bool { return statusSortOrder[a.Status] < statusSortOrder[b.Status] }
+	default:
+		return
+	}
+
+	sort.SliceStable(sr.Assets, func(i, j int) bool {
+		return less(sr.Assets[i], sr.Assets[j])
+	})
 }
 
 // ToJSON exports the scan result as JSON
@@ -102,7 +330,7 @@ func (sr *ScanResult) ToCSV() (string, error) {
 	writer := csv.NewWriter(&builder)
 
 	// Write header
-	header := []string{"Status", "Path", "Size", "Category", "References", "ModTime"}
+	header := []string{"Status", "Path", "Size", "Category", "References", "ModTime", "Feature", "LastTouched", "StalenessScore", "Width", "Height", "Frames", "ColorProfile", "PrivacyFlags", "License", "Severity", "ReviewReasons", "UnusedChain"}
 	if err := writer.Write(header); err != nil {
 		return "", fmt.Errorf("failed to write CSV header: %w", err)
 	}
@@ -116,6 +344,18 @@ func (sr *ScanResult) ToCSV() (string, error) {
 			asset.Category.String(),
 			strconv.Itoa(asset.RefCount),
 			asset.ModTime.Format(time.RFC3339),
+			asset.Feature,
+			asset.LastTouched.Format(time.RFC3339),
+			strconv.FormatFloat(asset.StalenessScore, 'f', 2, 64),
+			strconv.Itoa(asset.Width),
+			strconv.Itoa(asset.Height),
+			strconv.Itoa(asset.Frames),
+			asset.ColorProfile,
+			strings.Join(asset.PrivacyFlags, ";"),
+			asset.License,
+			string(asset.Severity),
+			strings.Join(asset.ReviewReasons, ";"),
+			strings.Join(asset.UnusedChain, ";"),
 		}
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("failed to write CSV row: %w", err)
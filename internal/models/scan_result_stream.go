@@ -0,0 +1,228 @@
+package models
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// jsonField is a top-level ScanResult field, named by its json tag
+type jsonField struct {
+	key       string
+	index     int
+	omitEmpty bool
+}
+
+// scanResultFields are the fields of ScanResult in declaration order, read
+// from its struct tags so WriteJSON and ReadScanResult cover every field
+// json.Marshal would
+var scanResultFields = func() []jsonField {
+	t := reflect.TypeOf(ScanResult{})
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{
+			key:       name,
+			index:     i,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	return fields
+}()
+
+// scanResultKeys maps json keys to their ScanResult field index
+var scanResultKeys = func() map[string]int {
+	keys := make(map[string]int, len(scanResultFields))
+	for _, field := range scanResultFields {
+		keys[field.key] = field.index
+	}
+	return keys
+}()
+
+var assetSliceType = reflect.TypeOf([]AssetFile(nil))
+
+// isEmptyValue reports whether omitempty leaves v out, as encoding/json does
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Interface, reflect.Pointer,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return v.IsZero()
+	}
+	return false
+}
+
+// WriteJSON streams the scan result as compact JSON to w.
+// Asset slices are encoded one asset at a time so the full document is
+// never held in memory, which keeps caching 100k+ asset scans cheap.
+func (sr *ScanResult) WriteJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	v := reflect.ValueOf(sr).Elem()
+
+	if _, err := bw.WriteString("{"); err != nil {
+		return err
+	}
+
+	first := true
+	for _, field := range scanResultFields {
+		value := v.Field(field.index)
+		if field.omitEmpty && isEmptyValue(value) {
+			continue
+		}
+		if !first {
+			if _, err := bw.WriteString(","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		if _, err := fmt.Fprintf(bw, "%q:", field.key); err != nil {
+			return err
+		}
+
+		var err error
+		if assets, ok := value.Interface().([]AssetFile); ok {
+			err = writeAssetArray(bw, assets)
+		} else {
+			err = writeValue(bw, value.Interface())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", field.key, err)
+		}
+	}
+
+	if _, err := bw.WriteString("}\n"); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeAssetArray encodes assets as a JSON array, one element at a time
+func writeAssetArray(bw *bufio.Writer, assets []AssetFile) error {
+	if assets == nil {
+		_, err := bw.WriteString("null")
+		return err
+	}
+
+	if _, err := bw.WriteString("["); err != nil {
+		return err
+	}
+	for i := range assets {
+		if i > 0 {
+			if _, err := bw.WriteString(","); err != nil {
+				return err
+			}
+		}
+		if err := writeValue(bw, &assets[i]); err != nil {
+			return err
+		}
+	}
+	_, err := bw.WriteString("]")
+	return err
+}
+
+// writeValue marshals a single value and writes it to bw
+func writeValue(bw *bufio.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = bw.Write(data)
+	return err
+}
+
+// ReadScanResult decodes a scan result from r, streaming asset arrays
+// element by element. Accepts both WriteJSON and ToJSON output.
+func ReadScanResult(r io.Reader) (*ScanResult, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var result ScanResult
+	v := reflect.ValueOf(&result).Elem()
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected token %v", token)
+		}
+
+		index, known := scanResultKeys[key]
+		if !known {
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		} else if field := v.Field(index); field.Type() == assetSliceType {
+			var assets []AssetFile
+			assets, err = readAssetArray(dec)
+			field.Set(reflect.ValueOf(assets))
+		} else {
+			err = dec.Decode(field.Addr().Interface())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// readAssetArray decodes a JSON array of assets one element at a time
+func readAssetArray(dec *json.Decoder) ([]AssetFile, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("expected asset array, got %v", token)
+	}
+
+	assets := []AssetFile{}
+	for dec.More() {
+		var asset AssetFile
+		if err := dec.Decode(&asset); err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+
+	return assets, expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and checks it is the given delimiter
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %v, got %v", want, token)
+	}
+	return nil
+}
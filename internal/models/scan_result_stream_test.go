@@ -0,0 +1,131 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func sampleScanResult() *ScanResult {
+	result := &ScanResult{
+		Timestamp:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		ProjectRoot: "/project",
+		ProjectType: ProjectTypeWebReact,
+		Duration:    42,
+		Assets: []AssetFile{
+			{Path: "/project/a.png", RelativePath: "a.png", Size: 10, Status: StatusUnused},
+			{Path: "/project/b.png", RelativePath: "b.png", Size: 20, Status: StatusUsed},
+		},
+		LicensesTracked: true,
+		Warnings:        []string{"stopped at max_files (1000)"},
+		Config:          &ProjectConfig{MaxWorkers: 4},
+	}
+	result.ComputeStatistics()
+	result.PopulateFilteredLists()
+	return result
+}
+
+func TestWriteJSON_RoundTrip(t *testing.T) {
+	original := sampleScanResult()
+
+	var buf bytes.Buffer
+	if err := original.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+
+	decoded, err := ReadScanResult(&buf)
+	if err != nil {
+		t.Fatalf("ReadScanResult() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("ReadScanResult() = %+v, want %+v", decoded, original)
+	}
+}
+
+// fullScanResult sets every ScanResult field
+func fullScanResult() *ScanResult {
+	result := sampleScanResult()
+	result.Assets = append(result.Assets,
+		AssetFile{Path: "/project/c.png", RelativePath: "c.png", Status: StatusPotentiallyUnused},
+		AssetFile{Path: "/project/d.png", RelativePath: "d.png", Status: StatusNeedsManualReview},
+		AssetFile{Path: "/project/e.png", RelativePath: "e.png", Status: StatusKept, KeptByConfig: true},
+		AssetFile{Path: "/project/favicon.ico", RelativePath: "favicon.ico", Status: StatusConventional},
+	)
+	result.RequestsTracked = true
+	result.RendersTracked = true
+	result.Optimizations = []OptimizationSuggestion{{Path: "b.png", Kind: OptimizationFormat, EstimatedSavings: 5}}
+	result.ConfidenceReport = []ConfidenceBucket{{Status: StatusUsed, References: 1, Assets: 1}}
+	result.ComputeStatistics()
+	result.PopulateFilteredLists()
+	return result
+}
+
+func TestWriteJSON_MatchesMarshal(t *testing.T) {
+	original := fullScanResult()
+	value := reflect.ValueOf(original).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if value.Field(i).IsZero() {
+			t.Errorf("fullScanResult() leaves %s unset", value.Type().Field(i).Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := original.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+	marshaled, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	var streamed, want map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &streamed); err != nil {
+		t.Fatalf("WriteJSON() wrote invalid JSON: %v", err)
+	}
+	if err := json.Unmarshal(marshaled, &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(streamed, want) {
+		t.Errorf("WriteJSON() = %s, want json.Marshal output %s", buf.Bytes(), marshaled)
+	}
+
+	decoded, err := ReadScanResult(&buf)
+	if err != nil {
+		t.Fatalf("ReadScanResult() failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("ReadScanResult() = %+v, want %+v", decoded, original)
+	}
+}
+
+func TestReadScanResult_IndentedJSON(t *testing.T) {
+	original := sampleScanResult()
+
+	data, err := original.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() failed: %v", err)
+	}
+
+	decoded, err := ReadScanResult(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadScanResult() failed: %v", err)
+	}
+
+	if len(decoded.Assets) != 2 || decoded.Stats.TotalAssets != 2 || len(decoded.UnusedAssets) != 1 {
+		t.Errorf("ReadScanResult() decoded %d assets, %d total, %d unused; want 2, 2, 1",
+			len(decoded.Assets), decoded.Stats.TotalAssets, len(decoded.UnusedAssets))
+	}
+}
+
+func TestReadScanResult_Invalid(t *testing.T) {
+	inputs := []string{"", "[]", `{"assets": {}}`, `{"assets": [`}
+
+	for _, input := range inputs {
+		if _, err := ReadScanResult(bytes.NewReader([]byte(input))); err == nil {
+			t.Errorf("ReadScanResult(%q) expected error, got nil", input)
+		}
+	}
+}
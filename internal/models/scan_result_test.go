@@ -0,0 +1,102 @@
+package models
+
+import "testing"
+
+func TestComputeStatistics_KeepsScanCounters(t *testing.T) {
+	result := &ScanResult{
+		Duration: 2000,
+		Assets:   []AssetFile{{Status: StatusUnused}},
+		Stats: ScanStatistics{
+			FilesScanned: 40,
+			BytesRead:    1024,
+			Phases:       PhaseDurations{Discovery: 5, References: 30, Classification: 2},
+			UnusedCount:  7,
+		},
+	}
+
+	result.ComputeStatistics()
+
+	if result.Stats.FilesScanned != 40 || result.Stats.BytesRead != 1024 || result.Stats.Phases.References != 30 {
+		t.Errorf("scan counters = %+v, want them kept", result.Stats)
+	}
+	if result.Stats.UnusedCount != 1 {
+		t.Errorf("UnusedCount = %d, want 1 (recomputed)", result.Stats.UnusedCount)
+	}
+	if result.Stats.AvgScanSpeed != 20 {
+		t.Errorf("AvgScanSpeed = %v, want 20", result.Stats.AvgScanSpeed)
+	}
+}
+
+func TestComputeStatistics_Breakdowns(t *testing.T) {
+	result := &ScanResult{
+		Assets: []AssetFile{
+			{Category: CategoryImage, Extension: ".png", Size: 100, Status: StatusUnused},
+			{Category: CategoryImage, Extension: ".PNG", Size: 50, Status: StatusUsed},
+			{Category: CategoryImage, Extension: ".svg", Size: 10, Status: StatusUsed},
+			{Category: CategoryFont, Extension: ".woff2", Size: 300, Status: StatusUnused},
+		},
+	}
+
+	result.ComputeStatistics()
+
+	images := result.Stats.Categories["Image"]
+	if images == nil || images.TotalAssets != 3 || images.TotalSize != 160 || images.UnusedCount != 1 || images.UnusedSize != 100 {
+		t.Errorf("Categories[Image] = %+v, want 3 assets/160 bytes, 1 unused/100 bytes", images)
+	}
+	if fonts := result.Stats.Categories["Font"]; fonts == nil || fonts.UnusedSize != 300 {
+		t.Errorf("Categories[Font] = %+v, want 300 unused bytes", fonts)
+	}
+
+	png := result.Stats.Extensions[".png"]
+	if png == nil || png.TotalAssets != 2 || png.UnusedCount != 1 {
+		t.Errorf("Extensions[.png] = %+v, want 2 assets, 1 unused", png)
+	}
+	if len(result.Stats.Extensions) != 3 {
+		t.Errorf("len(Extensions) = %d, want 3", len(result.Stats.Extensions))
+	}
+	if result.Stats.Features != nil {
+		t.Errorf("Features = %v, want nil without configured features", result.Stats.Features)
+	}
+}
+
+func TestComputeStatistics_BundleSavings(t *testing.T) {
+	assets := []AssetFile{
+		{Extension: ".png", Size: 1000, Status: StatusUnused},
+		{Extension: ".SVG", Size: 1000, Status: StatusUnused},
+		{Extension: ".ttf", Size: 1000, Status: StatusUsed},
+	}
+
+	flutter := &ScanResult{ProjectType: ProjectTypeFlutter, Assets: assets}
+	flutter.ComputeStatistics()
+	if flutter.Stats.BundleFormat != "APK/IPA" || flutter.Stats.BundleSavings != 1300 {
+		t.Errorf("Flutter bundle savings = %s %d, want APK/IPA 1300 (png stored, svg deflated)",
+			flutter.Stats.BundleFormat, flutter.Stats.BundleSavings)
+	}
+
+	web := &ScanResult{ProjectType: ProjectTypeWebReact, Assets: assets}
+	web.ComputeStatistics()
+	if web.Stats.BundleFormat != "" || web.Stats.BundleSavings != 0 {
+		t.Errorf("React bundle savings = %q %d, want none for web projects", web.Stats.BundleFormat, web.Stats.BundleSavings)
+	}
+}
+
+func TestSortAssets_Status(t *testing.T) {
+	result := &ScanResult{
+		Assets: []AssetFile{
+			{RelativePath: "a.png", Status: StatusUsed},
+			{RelativePath: "b.png", Status: StatusNeedsManualReview},
+			{RelativePath: "c.png", Status: StatusUnused},
+			{RelativePath: "d.png", Status: StatusKept},
+			{RelativePath: "e.png", Status: StatusUnused},
+		},
+	}
+
+	result.SortAssets(SortByStatus)
+
+	want := []string{"c.png", "e.png", "b.png", "a.png", "d.png"}
+	for i, asset := range result.Assets {
+		if asset.RelativePath != want[i] {
+			t.Errorf("SortAssets(status)[%d] = %s, want %s", i, asset.RelativePath, want[i])
+		}
+	}
+}
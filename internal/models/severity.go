@@ -0,0 +1,93 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is the report/CI level a classification maps to
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// DefaultSeverities maps each status to its severity when not configured
+var DefaultSeverities = map[AssetStatus]Severity{
+	StatusUsed:               SeverityOff,
+	StatusUnused:             SeverityError,
+	StatusPotentiallyUnused:  SeverityWarning,
+	StatusNeedsManualReview:  SeverityInfo,
+	StatusKept:               SeverityOff,
+	StatusConventional:       SeverityOff,
+	StatusTransitivelyUnused: SeverityError,
+}
+
+// Rank orders severities from off (0) to error (3)
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityError:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ParseSeverity validates a severity name (case-insensitive)
+func ParseSeverity(name string) (Severity, error) {
+	switch s := Severity(strings.ToLower(strings.TrimSpace(name))); s {
+	case SeverityOff, SeverityInfo, SeverityWarning, SeverityError:
+		return s, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q (want off, info, warning, or error)", name)
+	}
+}
+
+// ParseAssetStatus resolves a status name such as "unused" or
+// "potentially_unused" (case and separators are ignored)
+func ParseAssetStatus(name string) (AssetStatus, error) {
+	normalized := strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(name))
+	for status := StatusUsed; status <= StatusTransitivelyUnused; status++ {
+		if strings.ToLower(status.String()) == normalized {
+			return status, nil
+		}
+	}
+	if normalized == "needsreview" {
+		return StatusNeedsManualReview, nil
+	}
+	return 0, fmt.Errorf("unknown asset status %q", name)
+}
+
+// SeverityFor returns the configured severity for a status, falling back to DefaultSeverities
+func (cfg *ProjectConfig) SeverityFor(status AssetStatus) Severity {
+	if cfg != nil {
+		for name, value := range cfg.Severity {
+			if s, err := ParseAssetStatus(name); err == nil && s == status {
+				if severity, err := ParseSeverity(value); err == nil {
+					return severity
+				}
+			}
+		}
+	}
+	return DefaultSeverities[status]
+}
+
+// ValidateSeverity checks that every configured status and severity name is valid
+func (cfg *ProjectConfig) ValidateSeverity() error {
+	for name, value := range cfg.Severity {
+		if _, err := ParseAssetStatus(name); err != nil {
+			return err
+		}
+		if _, err := ParseSeverity(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package models
+
+import "testing"
+
+func TestParseAssetStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    AssetStatus
+		wantErr bool
+	}{
+		{"unused", StatusUnused, false},
+		{"potentially_unused", StatusPotentiallyUnused, false},
+		{"PotentiallyUnused", StatusPotentiallyUnused, false},
+		{"needs_review", StatusNeedsManualReview, false},
+		{"needs-manual-review", StatusNeedsManualReview, false},
+		{"kept", StatusKept, false},
+		{"conventional", StatusConventional, false},
+		{"bogus", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAssetStatus(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAssetStatus(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseAssetStatus(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSeverityFor(t *testing.T) {
+	cfg := &ProjectConfig{Severity: map[string]string{
+		"unused":             "warning",
+		"potentially_unused": "OFF",
+	}}
+
+	tests := []struct {
+		status AssetStatus
+		want   Severity
+	}{
+		{StatusUnused, SeverityWarning},
+		{StatusPotentiallyUnused, SeverityOff},
+		{StatusNeedsManualReview, SeverityInfo},
+		{StatusUsed, SeverityOff},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.SeverityFor(tt.status); got != tt.want {
+			t.Errorf("SeverityFor(%v) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+
+	var nilCfg *ProjectConfig
+	if got := nilCfg.SeverityFor(StatusUnused); got != SeverityError {
+		t.Errorf("nil SeverityFor(Unused) = %q, want %q", got, SeverityError)
+	}
+}
+
+func TestValidateSeverity(t *testing.T) {
+	tests := []struct {
+		rules   map[string]string
+		wantErr bool
+	}{
+		{map[string]string{"unused": "info"}, false},
+		{map[string]string{"unused": "fatal"}, true},
+		{map[string]string{"missing": "error"}, true},
+	}
+
+	for _, tt := range tests {
+		cfg := &ProjectConfig{Severity: tt.rules}
+		if err := cfg.ValidateSeverity(); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateSeverity(%v) error = %v, wantErr %v", tt.rules, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScanResult_Severities(t *testing.T) {
+	result := &ScanResult{
+		Assets: []AssetFile{
+			{Status: StatusUsed},
+			{Status: StatusPotentiallyUnused},
+			{Status: StatusNeedsManualReview},
+		},
+	}
+
+	result.AssignSeverities()
+	result.ComputeStatistics()
+
+	if got := result.MaxSeverity(); got != SeverityWarning {
+		t.Errorf("MaxSeverity() = %q, want %q", got, SeverityWarning)
+	}
+	if result.Stats.WarningCount != 1 || result.Stats.InfoCount != 1 || result.Stats.ErrorCount != 0 {
+		t.Errorf("severity counts = %d/%d/%d errors/warnings/info, want 0/1/1",
+			result.Stats.ErrorCount, result.Stats.WarningCount, result.Stats.InfoCount)
+	}
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StatusRule is a policy applied after classification: assets matching every
+// condition it sets get its status and/or severity
+type StatusRule struct {
+	Name string `yaml:"name" json:"name,omitempty" mapstructure:"name"`
+
+	// Conditions; unset ones match every asset
+	Path     string `yaml:"path" json:"path,omitempty" mapstructure:"path"`                // glob on the relative path
+	Status   string `yaml:"status" json:"status,omitempty" mapstructure:"status"`          // current status
+	RefCount string `yaml:"ref_count" json:"ref_count,omitempty" mapstructure:"ref_count"` // "0", "== 0", ">= 2", ...
+	// UsageConfidence compares the combined confidence of the references: "< 0.8"
+	UsageConfidence string `yaml:"usage_confidence" json:"usage_confidence,omitempty" mapstructure:"usage_confidence"`
+
+	// Actions
+	SetStatus string `yaml:"set_status" json:"set_status,omitempty" mapstructure:"set_status"`
+	Severity  string `yaml:"severity" json:"severity,omitempty" mapstructure:"severity"`
+}
+
+// Label names a rule in output: its name, or its path glob
+func (r StatusRule) Label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Path
+}
+
+// MatchRefCount reports whether count satisfies the rule's ref_count
+// comparison; an empty comparison matches any count
+func (r StatusRule) MatchRefCount(count int) (bool, error) {
+	if strings.TrimSpace(r.RefCount) == "" {
+		return true, nil
+	}
+	op, operand := splitComparison(r.RefCount)
+	n, err := strconv.Atoi(operand)
+	if err != nil {
+		return false, fmt.Errorf("invalid ref_count %q (want e.g. 0, \"== 0\", \">= 2\")", r.RefCount)
+	}
+	return compare(op, float64(count), float64(n)), nil
+}
+
+// MatchUsageConfidence reports whether confidence satisfies the rule's
+// usage_confidence comparison; an empty comparison matches any confidence
+func (r StatusRule) MatchUsageConfidence(confidence float32) (bool, error) {
+	if strings.TrimSpace(r.UsageConfidence) == "" {
+		return true, nil
+	}
+	op, operand := splitComparison(r.UsageConfidence)
+	threshold, err := strconv.ParseFloat(operand, 32)
+	if err != nil || threshold < 0 || threshold > 1 {
+		return false, fmt.Errorf("invalid usage_confidence %q (want e.g. \"< 0.8\", between 0 and 1)", r.UsageConfidence)
+	}
+	// Compare at float32 precision so "== 0.7" matches a 0.7 confidence
+	return compare(op, float64(confidence), float64(float32(threshold))), nil
+}
+
+// splitComparison splits "op operand" into its operator, "==" when there is
+// none, and its trimmed operand
+func splitComparison(expr string) (string, string) {
+	expr = strings.TrimSpace(expr)
+	for _, candidate := range []string{"==", "!=", ">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(expr, candidate) {
+			return candidate, strings.TrimSpace(expr[len(candidate):])
+		}
+	}
+	return "==", expr
+}
+
+// compare applies a comparison operator
+func compare(op string, value, operand float64) bool {
+	switch op {
+	case "!=":
+		return value != operand
+	case ">=":
+		return value >= operand
+	case "<=":
+		return value <= operand
+	case ">":
+		return value > operand
+	case "<":
+		return value < operand
+	default:
+		return value == operand
+	}
+}
+
+// ValidateStatusRules checks that every rule has an action and that its
+// statuses, severity, and ref_count and usage_confidence comparisons are valid
+func (cfg *ProjectConfig) ValidateStatusRules() error {
+	for i, rule := range cfg.StatusRules {
+		if rule.SetStatus == "" && rule.Severity == "" {
+			return fmt.Errorf("rule %d (%s) sets neither set_status nor severity", i+1, rule.Label())
+		}
+		for _, status := range []string{rule.Status, rule.SetStatus} {
+			if status == "" {
+				continue
+			}
+			if _, err := ParseAssetStatus(status); err != nil {
+				return fmt.Errorf("rule %d (%s): %w", i+1, rule.Label(), err)
+			}
+		}
+		if rule.Severity != "" {
+			if _, err := ParseSeverity(rule.Severity); err != nil {
+				return fmt.Errorf("rule %d (%s): %w", i+1, rule.Label(), err)
+			}
+		}
+		if _, err := rule.MatchRefCount(0); err != nil {
+			return fmt.Errorf("rule %d (%s): %w", i+1, rule.Label(), err)
+		}
+		if _, err := rule.MatchUsageConfidence(0); err != nil {
+			return fmt.Errorf("rule %d (%s): %w", i+1, rule.Label(), err)
+		}
+	}
+	return nil
+}
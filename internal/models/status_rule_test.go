@@ -0,0 +1,86 @@
+package models
+
+import "testing"
+
+func TestStatusRule_MatchRefCount(t *testing.T) {
+	tests := []struct {
+		expr  string
+		count int
+		want  bool
+	}{
+		{"", 5, true},
+		{"0", 0, true},
+		{"== 0", 1, false},
+		{">= 2", 2, true},
+		{">2", 2, false},
+		{"< 3", 1, true},
+		{"!= 0", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := StatusRule{RefCount: tt.expr}.MatchRefCount(tt.count)
+		if err != nil || got != tt.want {
+			t.Errorf("MatchRefCount(%q, %d) = %v, %v, want %v", tt.expr, tt.count, got, err, tt.want)
+		}
+	}
+}
+
+func TestStatusRule_MatchUsageConfidence(t *testing.T) {
+	tests := []struct {
+		expr       string
+		confidence float32
+		want       bool
+	}{
+		{"", 0.3, true},
+		{"< 0.8", 0.7, true},
+		{"< 0.8", 0.91, false},
+		{">= 0.95", 0.95, true},
+		{"0.7", 0.7, true},
+	}
+	for _, tt := range tests {
+		got, err := StatusRule{UsageConfidence: tt.expr}.MatchUsageConfidence(tt.confidence)
+		if err != nil || got != tt.want {
+			t.Errorf("MatchUsageConfidence(%q, %v) = %v, %v, want %v", tt.expr, tt.confidence, got, err, tt.want)
+		}
+	}
+}
+
+func TestValidateStatusRules(t *testing.T) {
+	tests := []struct {
+		rule    StatusRule
+		wantErr bool
+	}{
+		{StatusRule{Path: "assets/brand/**", SetStatus: "kept"}, false},
+		{StatusRule{Status: "unused", RefCount: "0", Severity: "warning"}, false},
+		{StatusRule{Path: "assets/**"}, true},
+		{StatusRule{SetStatus: "deleted"}, true},
+		{StatusRule{Status: "gone", Severity: "info"}, true},
+		{StatusRule{Severity: "fatal"}, true},
+		{StatusRule{RefCount: "some", Severity: "info"}, true},
+		{StatusRule{UsageConfidence: "< 0.8", SetStatus: "needs_review"}, false},
+		{StatusRule{UsageConfidence: "< 80", SetStatus: "needs_review"}, true},
+	}
+	for _, tt := range tests {
+		cfg := &ProjectConfig{StatusRules: []StatusRule{tt.rule}}
+		if err := cfg.ValidateStatusRules(); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateStatusRules(%+v) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScanResult_RuleSeverities(t *testing.T) {
+	result := &ScanResult{
+		Assets: []AssetFile{
+			{Status: StatusUnused, Severity: SeverityInfo, Rule: "legacy"},
+			{Status: StatusKept, Rule: "brand"},
+		},
+	}
+
+	result.AssignSeverities()
+
+	if result.Assets[0].Severity != SeverityInfo {
+		t.Errorf("rule severity = %q, want it kept as %q", result.Assets[0].Severity, SeverityInfo)
+	}
+	if result.Assets[1].Severity != SeverityOff {
+		t.Errorf("rule status severity = %q, want %q", result.Assets[1].Severity, SeverityOff)
+	}
+}
@@ -0,0 +1,100 @@
+// Package optimizer suggests size optimizations for used image assets.
+//
+// It reports two kinds of suggestions:
+// - Format conversion (PNG/JPEG/GIF → WebP/AVIF) with estimated savings
+// - Oversized dimensions that exceed a configurable maximum edge length
+//
+// Savings are estimates based on typical compression ratios, not actual
+// re-encoding, so the pass stays fast and dependency-free.
+package optimizer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// DefaultMaxDimension is the longest edge (in pixels) above which an image is considered oversized
+const DefaultMaxDimension = 2048
+
+// minSavingsBytes filters out suggestions too small to be worth acting on
+const minSavingsBytes = 1024
+
+// conversionSavings holds typical size reductions when converting to modern formats
+var conversionSavings = map[string]struct {
+	target string
+	ratio  float64
+}{
+	".png":  {"WebP", 0.26},
+	".jpg":  {"AVIF", 0.50},
+	".jpeg": {"AVIF", 0.50},
+	".gif":  {"WebP", 0.64},
+	".bmp":  {"WebP", 0.90},
+}
+
+// Analyze returns optimization suggestions for used image assets
+func Analyze(assets []models.AssetFile, maxDimension int) []models.OptimizationSuggestion {
+	if maxDimension <= 0 {
+		maxDimension = DefaultMaxDimension
+	}
+
+	var suggestions []models.OptimizationSuggestion
+	for _, asset := range assets {
+		if asset.Status != models.StatusUsed || asset.Category != models.CategoryImage {
+			continue
+		}
+
+		if s, ok := suggestFormat(asset); ok {
+			suggestions = append(suggestions, s)
+		}
+		if s, ok := suggestResize(asset, maxDimension); ok {
+			suggestions = append(suggestions, s)
+		}
+	}
+
+	return suggestions
+}
+
+// suggestFormat suggests converting legacy formats to WebP/AVIF
+func suggestFormat(asset models.AssetFile) (models.OptimizationSuggestion, bool) {
+	conv, ok := conversionSavings[strings.ToLower(asset.Extension)]
+	if !ok {
+		return models.OptimizationSuggestion{}, false
+	}
+
+	savings := int64(float64(asset.Size) * conv.ratio)
+	if savings < minSavingsBytes {
+		return models.OptimizationSuggestion{}, false
+	}
+
+	return models.OptimizationSuggestion{
+		Path:             asset.RelativePath,
+		Kind:             models.OptimizationFormat,
+		Message:          fmt.Sprintf("convert %s to %s", strings.TrimPrefix(asset.Extension, "."), conv.target),
+		EstimatedSavings: savings,
+	}, true
+}
+
+// suggestResize suggests downscaling images whose longest edge exceeds maxDimension.
+// Relies on dimensions populated by the asset finder.
+func suggestResize(asset models.AssetFile, maxDimension int) (models.OptimizationSuggestion, bool) {
+	longest := max(asset.Width, asset.Height)
+	if longest <= maxDimension {
+		return models.OptimizationSuggestion{}, false
+	}
+
+	// File size scales roughly with pixel area
+	scale := float64(maxDimension) / float64(longest)
+	savings := int64(float64(asset.Size) * (1 - scale*scale))
+	if savings < minSavingsBytes {
+		return models.OptimizationSuggestion{}, false
+	}
+
+	return models.OptimizationSuggestion{
+		Path:             asset.RelativePath,
+		Kind:             models.OptimizationResize,
+		Message:          fmt.Sprintf("resize %dx%d to fit %dpx", asset.Width, asset.Height, maxDimension),
+		EstimatedSavings: savings,
+	}, true
+}
@@ -0,0 +1,50 @@
+package optimizer
+
+import (
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestAnalyze_FormatSuggestion(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "big.png", Path: "/nonexistent/big.png", Extension: ".png", Size: 100 * 1024, Category: models.CategoryImage, Status: models.StatusUsed},
+		{RelativePath: "unused.png", Extension: ".png", Size: 100 * 1024, Category: models.CategoryImage, Status: models.StatusUnused},
+		{RelativePath: "tiny.png", Extension: ".png", Size: 100, Category: models.CategoryImage, Status: models.StatusUsed},
+		{RelativePath: "modern.webp", Extension: ".webp", Size: 100 * 1024, Category: models.CategoryImage, Status: models.StatusUsed},
+	}
+
+	suggestions := Analyze(assets, 0)
+
+	if len(suggestions) != 1 {
+		t.Fatalf("Expected 1 suggestion, got %d: %+v", len(suggestions), suggestions)
+	}
+	if suggestions[0].Path != "big.png" || suggestions[0].Kind != models.OptimizationFormat {
+		t.Errorf("Unexpected suggestion: %+v", suggestions[0])
+	}
+	if suggestions[0].EstimatedSavings <= 0 {
+		t.Error("Expected positive estimated savings")
+	}
+}
+
+func TestAnalyze_ResizeSuggestion(t *testing.T) {
+	assets := []models.AssetFile{
+		{RelativePath: "huge.png", Extension: ".png", Size: 500 * 1024, Width: 400, Height: 200, Category: models.CategoryImage, Status: models.StatusUsed},
+		{RelativePath: "small.png", Extension: ".png", Size: 500 * 1024, Width: 80, Height: 80, Category: models.CategoryImage, Status: models.StatusUsed},
+	}
+
+	suggestions := Analyze(assets, 100)
+
+	resized := map[string]bool{}
+	for _, s := range suggestions {
+		if s.Kind == models.OptimizationResize {
+			resized[s.Path] = true
+		}
+	}
+	if !resized["huge.png"] {
+		t.Errorf("Expected resize suggestion for 400x200 image with max 100px, got %+v", suggestions)
+	}
+	if resized["small.png"] {
+		t.Error("Did not expect resize suggestion for 80x80 image")
+	}
+}
@@ -0,0 +1,53 @@
+// Package parser - Android-specific patterns
+//
+// Detects resource references in Kotlin, Java, and resource XML:
+// - Jetpack Compose painterResource(R.drawable.x) and vectorResource
+// - getDrawable, ContextCompat.getDrawable, and other R.<type>.<name> reads
+// - XML references like android:src="@drawable/x" and icon="@mipmap/x"
+//
+// References name a resource type and name (drawable/logo) rather than a
+// file; the scanner maps them to res/drawable*/logo.* in every qualifier.
+package parser
+
+import "regexp"
+
+var (
+	// painterResource(R.drawable.logo), painterResource(id = R.drawable.logo),
+	// ImageVector.vectorResource(R.drawable.ic_add)
+	AndroidComposeResourcePattern = regexp.MustCompile(`(?:painterResource|vectorResource)\s*\(\s*(?:id\s*=\s*)?R\.(drawable|mipmap)\.(\w+)`)
+
+	// getDrawable(R.drawable.x), ContextCompat.getDrawable(context, R.drawable.x),
+	// AppCompatResources.getDrawable(context, R.drawable.x)
+	AndroidGetDrawablePattern = regexp.MustCompile(`getDrawable\s*\(\s*(?:[\w.]+\s*,\s*)?R\.(drawable|mipmap)\.(\w+)`)
+
+	// Any other resource read: setImageResource(R.drawable.x), R.raw.intro, R.font.inter
+	AndroidResourcePattern = regexp.MustCompile(`\bR\.(drawable|mipmap|raw|font)\.(\w+)`)
+
+	// Resource XML: android:src="@drawable/x", app:srcCompat="@drawable/x",
+	// android:icon="@mipmap/ic_launcher" (framework @android:drawable/ excluded)
+	AndroidXMLResourcePattern = regexp.MustCompile(`"@(drawable|mipmap|raw|font)/(\w+)"`)
+)
+
+// AndroidPatternProvider provides patterns for native Android projects
+type AndroidPatternProvider struct{}
+
+func (a *AndroidPatternProvider) GetPatterns() []ReferencePattern {
+	return []ReferencePattern{
+		// Explicit resource APIs
+		{Pattern: AndroidComposeResourcePattern, Type: "AndroidResource", Confidence: 1.0, NameGroup: 2},
+		{Pattern: AndroidGetDrawablePattern, Type: "AndroidResource", Confidence: 1.0, NameGroup: 2},
+		{Pattern: AndroidResourcePattern, Type: "AndroidResource", Confidence: 0.95, NameGroup: 2},
+		{Pattern: AndroidXMLResourcePattern, Type: "AndroidResource", Confidence: 1.0, NameGroup: 2},
+
+		// Files under assets/ are opened by path
+		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.7},
+	}
+}
+
+func (a *AndroidPatternProvider) UseASTParsing() bool {
+	return false
+}
+
+func (a *AndroidPatternProvider) SupportedFileExtensions() []string {
+	return []string{".kt", ".java", ".xml"}
+}
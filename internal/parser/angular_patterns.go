@@ -1,7 +1,7 @@
 // Package parser - Angular-specific patterns
 //
 // Detects asset references in Angular projects including:
-// - @Component templateUrl and styleUrls
+// - @Component templateUrl, styleUrls, and styleUrl
 // - Lazy loading with loadChildren
 // - Assets in angular.json
 // - Template asset references
@@ -13,9 +13,15 @@ var (
 	// @Component decorator templateUrl
 	AngularTemplateUrlPattern = regexp.MustCompile(`templateUrl:\s*['"]([^'"]+\.html)['"]`)
 
-	// @Component decorator styleUrls (can be array)
+	// @Component decorator styleUrls array: styleUrls: ['./a.scss', './b.css']
 	AngularStyleUrlsPattern = regexp.MustCompile(`styleUrls:\s*\[([^\]]+)\]`)
 
+	// styleUrls arrays split across lines, one stylesheet per line
+	MultilineAngularStyleUrlsPattern = regexp.MustCompile(`styleUrls:\s*\[([^\]]*\n[^\]]*)\]`)
+
+	// Angular 17+ single stylesheet: styleUrl: './app.component.scss'
+	AngularStyleUrlPattern = regexp.MustCompile(`styleUrl:\s*['"]([^'"]+\.(?:css|scss|sass|less))['"]`)
+
 	// Lazy loading routes with loadChildren
 	AngularLazyLoadPattern = regexp.MustCompile(`loadChildren:\s*\(\)\s*=>\s*import\s*\(\s*['"]([^'"]+)['"]`)
 
@@ -27,10 +33,12 @@ var (
 type AngularPatternProvider struct{}
 
 func (a *AngularPatternProvider) GetPatterns() []ReferencePattern {
-	return []ReferencePattern{
+	return append([]ReferencePattern{
 		// Angular-specific patterns
 		{Pattern: AngularTemplateUrlPattern, Type: "TemplateUrl", Confidence: 1.0},
-		{Pattern: AngularStyleUrlsPattern, Type: "StyleUrls", Confidence: 1.0},
+		{Pattern: AngularStyleUrlsPattern, Type: "StyleUrls", Confidence: 1.0, List: true},
+		{Pattern: MultilineAngularStyleUrlsPattern, Type: "StyleUrls", Confidence: 1.0, List: true, Multiline: true},
+		{Pattern: AngularStyleUrlPattern, Type: "StyleUrls", Confidence: 1.0},
 		{Pattern: AngularLazyLoadPattern, Type: "LazyLoad", Confidence: 1.0},
 		{Pattern: AngularTemplateAssetPattern, Type: "TemplateBinding", Confidence: 0.95},
 
@@ -38,10 +46,17 @@ func (a *AngularPatternProvider) GetPatterns() []ReferencePattern {
 		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
 		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: CSSImageSetPattern, Type: "CSSUrl", Confidence: 0.95, List: true},
+		{Pattern: CSSImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLSrcsetPattern, Type: "HTMLAttribute", Confidence: 0.95, List: true},
+		{Pattern: HTMLPosterPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: URLConstructorPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.75},
 		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.75},
-	}
+	}, webMultilinePatterns()...)
 }
 
 func (a *AngularPatternProvider) UseASTParsing() bool {
@@ -10,8 +10,6 @@
 package parser
 
 import (
-	"bufio"
-	"os"
 	"regexp"
 	"strings"
 
@@ -54,36 +52,29 @@ var (
 
 // ParseFile performs AST-level parsing of a JavaScript/TypeScript file
 func (p *ASTParser) ParseFile() ([]*models.Reference, error) {
-	file, err := os.Open(p.filePath)
+	src, err := ReadSource(p.filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	return p.ParseSource(src), nil
+}
 
+// ParseSource performs AST-level parsing of already loaded file content
+func (p *ASTParser) ParseSource(src *SourceText) []*models.Reference {
 	var references []*models.Reference
-	scanner := bufio.NewScanner(file)
-	lineNumber := 0
 
-	// Read file line by line for context
-	var fileContent strings.Builder
-	for scanner.Scan() {
-		lineNumber++
-		line := scanner.Text()
-		fileContent.WriteString(line + "\n")
+	for i := 0; i < src.LineCount(); i++ {
+		line := src.Line(i)
 
 		// Check if line is a comment
 		isComment := isCommentLine(line)
 
 		// Apply AST-level patterns
-		refs := p.parseLine(line, lineNumber, isComment)
+		refs := p.parseLine(line, i+1, isComment)
 		references = append(references, refs...)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	return references, nil
+	return references
 }
 
 // parseLine applies AST-level patterns to a single line
@@ -105,15 +96,16 @@ func (p *ASTParser) parseLine(line string, lineNumber int, isComment bool) []*mo
 	}
 
 	for _, patternDef := range patterns {
-		matches := patternDef.pattern.FindAllStringSubmatch(line, -1)
+		matches := patternDef.pattern.FindAllStringSubmatchIndex(line, -1)
 		for _, match := range matches {
-			if len(match) > 1 {
+			if len(match) > 3 && match[2] >= 0 {
 				// Extract the asset path (usually first capture group)
-				assetPath := match[1]
+				assetPath := line[match[2]:match[3]]
 				if assetPath != "" {
 					ref := &models.Reference{
 						SourceFile:  p.filePath,
 						LineNumber:  lineNumber,
+						Column:      match[2] + 1,
 						MatchedText: assetPath,
 						Context:     strings.TrimSpace(line),
 						Type:        patternDef.refType,
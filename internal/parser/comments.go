@@ -0,0 +1,307 @@
+// Package parser - Comment state tracking
+//
+// A line-by-line comment check sees the first line of
+//
+//	/*
+//	const hero = require('./hero.png')
+//	*/
+//
+// but not the lines inside, which don't start like a comment. This walks a
+// whole file with the comment and string syntax of its language and reports
+// the byte ranges that are comments, so references inside multi-line block
+// comments, JSX {/* */} blocks, and <!-- --> spans are known to be commented out.
+// Single-file components are walked section by section: markup, then script,
+// style and expression blocks in the syntax of their own language.
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// commentSyntax is how a language writes comments and the strings in which
+// comment markers are plain text
+type commentSyntax struct {
+	line   []string    // markers commenting out the rest of the line
+	blocks [][2]string // opening and closing markers of block comments
+	quotes string      // string delimiters
+	regex  bool        // whether / can open a regular expression literal
+}
+
+var (
+	cSyntax      = commentSyntax{line: []string{"//"}, blocks: [][2]string{{"/*", "*/"}}, quotes: "'\"`"}
+	jsSyntax     = commentSyntax{line: []string{"//"}, blocks: [][2]string{{"/*", "*/"}}, quotes: "'\"`", regex: true}
+	cssSyntax    = commentSyntax{blocks: [][2]string{{"/*", "*/"}}, quotes: `'"`}
+	sassSyntax   = commentSyntax{line: []string{"//"}, blocks: [][2]string{{"/*", "*/"}}, quotes: `'"`}
+	markupSyntax = commentSyntax{blocks: [][2]string{{"<!--", "-->"}}}
+	hashSyntax   = commentSyntax{line: []string{"#"}, quotes: `'"`}
+	phpSyntax    = commentSyntax{line: []string{"//", "#"}, blocks: [][2]string{{"/*", "*/"}, {"<!--", "-->"}}, quotes: `'"`}
+)
+
+// componentExts are single-file component formats, which mix markup with
+// script and style blocks
+var componentExts = map[string]bool{".vue": true, ".svelte": true, ".astro": true, ".mdx": true}
+
+// commentSyntaxes maps file extensions to their comment syntax
+var commentSyntaxes = map[string]commentSyntax{
+	".js": jsSyntax, ".jsx": jsSyntax, ".ts": jsSyntax, ".tsx": jsSyntax, ".mjs": jsSyntax, ".cjs": jsSyntax,
+	".go": cSyntax, ".java": cSyntax, ".kt": cSyntax, ".swift": cSyntax, ".dart": cSyntax,
+	".m": cSyntax, ".mm": cSyntax, ".c": cSyntax, ".cpp": cSyntax, ".h": cSyntax, ".cs": cSyntax, ".rs": cSyntax,
+	".css": cssSyntax, ".scss": sassSyntax, ".less": sassSyntax,
+	".html": markupSyntax, ".htm": markupSyntax, ".xml": markupSyntax, ".svg": markupSyntax,
+	".mjml": markupSyntax, ".md": markupSyntax,
+	".php": phpSyntax,
+	".py":  hashSyntax, ".rb": hashSyntax, ".sh": hashSyntax, ".yaml": hashSyntax, ".yml": hashSyntax, ".toml": hashSyntax,
+}
+
+// CommentRange is the byte range [Start, End) of one comment
+type CommentRange struct {
+	Start, End int
+}
+
+// CommentRanges returns the comments of src in a file with extension ext,
+// in order; files of languages without a known syntax have none
+func CommentRanges(src *SourceText, ext string) []CommentRange {
+	ext = strings.ToLower(ext)
+	if componentExts[ext] {
+		return componentRanges(src.Content, ext)
+	}
+	syntax, ok := commentSyntaxes[ext]
+	if !ok {
+		return nil
+	}
+	return syntax.scan(src.Content, 0, nil)
+}
+
+// scan appends the comments of content from offset from on to ranges
+func (s commentSyntax) scan(content string, from int, ranges []CommentRange) []CommentRange {
+	for i := from; i < len(content); i++ {
+		c := content[i]
+		if strings.IndexByte(s.quotes, c) >= 0 {
+			i = stringEnd(content, i)
+			continue
+		}
+		if s.regex && regexAt(content, from, i) {
+			i = regexEnd(content, i)
+			continue
+		}
+		if end, ok := s.blockEnd(content, i); ok {
+			ranges = append(ranges, CommentRange{Start: i, End: end})
+			i = end - 1
+			continue
+		}
+		for _, marker := range s.line {
+			if lineCommentAt(content, i, marker) {
+				end := strings.IndexByte(content[i:], '\n')
+				if end < 0 {
+					end = len(content) - i
+				}
+				ranges = append(ranges, CommentRange{Start: i, End: i + end})
+				i += end
+				break
+			}
+		}
+	}
+	return ranges
+}
+
+// componentRanges returns the comments of a single-file component: <!-- -->
+// spans in its markup, and comments inside its <script> and <style> blocks,
+// {expression} blocks, Astro frontmatter and MDX import/export statements,
+// each in the syntax of its language. Quotes in markup are prose, while a
+// /* inside a script string is not a comment.
+func componentRanges(content, ext string) []CommentRange {
+	var ranges []CommentRange
+	i := 0
+	if ext == ".astro" && strings.HasPrefix(content, "---") {
+		end := len(content)
+		if j := strings.Index(content[3:], "\n---"); j >= 0 {
+			end = 3 + j
+		}
+		ranges = jsSyntax.scan(content[:end], 3, ranges)
+		i = end + len("\n---")
+	}
+	for i < len(content) {
+		if end, ok := markupSyntax.blockEnd(content, i); ok {
+			ranges = append(ranges, CommentRange{Start: i, End: end})
+			i = end
+			continue
+		}
+		if content[i] == '{' {
+			end := expressionEnd(content, i)
+			ranges = jsSyntax.scan(content[:end], i+1, ranges)
+			i = end + 1
+			continue
+		}
+		if syntax, start, end, ok := sectionAt(content, i); ok {
+			ranges = syntax.scan(content[:end], start, ranges)
+			i = end
+			continue
+		}
+		if ext == ".mdx" && (i == 0 || content[i-1] == '\n') &&
+			(strings.HasPrefix(content[i:], "import ") || strings.HasPrefix(content[i:], "export ")) {
+			// ESM statements run to the next blank line
+			end := len(content)
+			if j := strings.Index(content[i:], "\n\n"); j >= 0 {
+				end = i + j
+			}
+			ranges = jsSyntax.scan(content[:end], i, ranges)
+			i = end
+			continue
+		}
+		i++
+	}
+	return ranges
+}
+
+// sectionSyntaxes are the languages of a component's script and style blocks
+var sectionSyntaxes = map[string]commentSyntax{"script": jsSyntax, "style": sassSyntax}
+
+// sectionAt reports whether a <script> or <style> block opens at i,
+// returning the syntax of its body and the body's [start, end) offsets
+func sectionAt(content string, i int) (commentSyntax, int, int, bool) {
+	for tag, syntax := range sectionSyntaxes {
+		open := "<" + tag
+		if len(content)-i <= len(open) || !strings.EqualFold(content[i:i+len(open)], open) {
+			continue
+		}
+		if next := content[i+len(open)]; next != '>' && next != ' ' && next != '\t' && next != '\n' {
+			continue
+		}
+		gt := strings.IndexByte(content[i:], '>')
+		if gt < 0 {
+			break
+		}
+		start := i + gt + 1
+		end := len(content)
+		if j := strings.Index(strings.ToLower(content[start:]), "</"+tag); j >= 0 {
+			end = start + j
+		}
+		return syntax, start, end, true
+	}
+	return commentSyntax{}, 0, 0, false
+}
+
+// expressionEnd returns the offset of the brace closing the {expression}
+// opening at i, skipping strings, comments and nested braces
+func expressionEnd(content string, i int) int {
+	depth := 0
+	for j := i; j < len(content); j++ {
+		switch c := content[j]; {
+		case c == '\'' || c == '"' || c == '`':
+			j = stringEnd(content, j)
+		case strings.HasPrefix(content[j:], "/*"):
+			end := strings.Index(content[j+2:], "*/")
+			if end < 0 {
+				return len(content)
+			}
+			j += end + 3
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return len(content)
+}
+
+// regexAt reports whether a regular expression literal opens at i: a / that
+// doesn't start a comment and follows an operator or opening bracket rather
+// than an operand, after which it would be division
+func regexAt(content string, from, i int) bool {
+	if content[i] != '/' || i+1 >= len(content) || content[i+1] == '/' || content[i+1] == '*' {
+		return false
+	}
+	before := strings.TrimRight(content[from:i], " \t\r\n")
+	if before == "" || strings.HasSuffix(before, "return") {
+		return true
+	}
+	return strings.IndexByte("(,=:[!&|?{};", before[len(before)-1]) >= 0
+}
+
+// regexEnd returns the offset of the / closing the regular expression
+// literal opening at i. Slashes in character classes don't close it, and
+// it ends at a newline like single-quoted strings.
+func regexEnd(content string, i int) int {
+	inClass := false
+	for j := i + 1; j < len(content); j++ {
+		switch content[j] {
+		case '\\':
+			j++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '/':
+			if !inClass {
+				return j
+			}
+		case '\n':
+			return j
+		}
+	}
+	return len(content)
+}
+
+// lineCommentAt reports whether a line comment marker starts at i. A # only
+// comments after whitespace (not url#fragment), and // not after a colon or
+// parenthesis (Sass url(//cdn.example.com/a.png), URL schemes).
+func lineCommentAt(content string, i int, marker string) bool {
+	if !strings.HasPrefix(content[i:], marker) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	prev := content[i-1]
+	if marker == "#" {
+		return prev == ' ' || prev == '\t' || prev == '\n'
+	}
+	return prev != ':' && prev != '('
+}
+
+// blockEnd returns the offset just past the block comment opening at i; an
+// unclosed comment runs to the end of the file
+func (s commentSyntax) blockEnd(content string, i int) (int, bool) {
+	for _, block := range s.blocks {
+		if !strings.HasPrefix(content[i:], block[0]) {
+			continue
+		}
+		start := i + len(block[0])
+		if end := strings.Index(content[start:], block[1]); end >= 0 {
+			return start + end + len(block[1]), true
+		}
+		return len(content), true
+	}
+	return 0, false
+}
+
+// stringEnd returns the offset of the quote closing the string opening at
+// i, skipping escapes. Single and double quoted strings end at a newline,
+// so a stray apostrophe in prose doesn't swallow the rest of the file.
+func stringEnd(content string, i int) int {
+	quote := content[i]
+	for j := i + 1; j < len(content); j++ {
+		switch content[j] {
+		case '\\':
+			j++
+		case quote:
+			return j
+		case '\n':
+			if quote != '`' {
+				return j
+			}
+		}
+	}
+	return len(content)
+}
+
+// InComment reports whether offset lies inside one of ranges, as returned
+// by CommentRanges
+func InComment(ranges []CommentRange, offset int) bool {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i].End > offset })
+	return i < len(ranges) && ranges[i].Start <= offset
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommentRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		ext       string
+		content   string
+		commented []string
+		active    []string
+	}{
+		{
+			name:      "block comment spanning lines",
+			ext:       ".ts",
+			content:   "/*\nconst old = require('./old.png')\n*/\nconst hero = require('./hero.png')\n",
+			commented: []string{"old.png"},
+			active:    []string{"hero.png"},
+		},
+		{
+			name:      "JSX comment block",
+			ext:       ".tsx",
+			content:   "return (\n  <div>\n    {/*\n      <img src=\"./old.png\" />\n    */}\n    <img src=\"./hero.png\" />\n  </div>\n)\n",
+			commented: []string{"old.png"},
+			active:    []string{"hero.png"},
+		},
+		{
+			name:    "comment markers inside strings",
+			ext:     ".js",
+			content: "const glob = 'assets/*'\nconst hero = './hero.png'\nconst url = \"http://cdn.example.com/a.png\"\n",
+			active:  []string{"hero.png", "a.png"},
+		},
+		{
+			name:      "markup comment",
+			ext:       ".html",
+			content:   "<!--\n<img src=\"old.png\">\n-->\n<img src=\"hero.png\">\n",
+			commented: []string{"old.png"},
+			active:    []string{"hero.png"},
+		},
+		{
+			name:      "Sass line comments and protocol-relative urls",
+			ext:       ".scss",
+			content:   ".a { background: url(//cdn.example.com/hero.png); }\n// .b { background: url(old.png); }\n",
+			commented: []string{"old.png"},
+			active:    []string{"hero.png"},
+		},
+		{
+			name:      "hash comments but not fragments",
+			ext:       ".yaml",
+			content:   "icon: /img/sprite.svg#home\n# logo: /img/old.png\n",
+			commented: []string{"old.png"},
+			active:    []string{"sprite.svg"},
+		},
+		{
+			name:      "regular expression literals",
+			ext:       ".js",
+			content:   "const sep = /[/*]/\nconst hero = require('./hero.png')\nconst half = total / 2 /* old.png */\n",
+			commented: []string{"old.png"},
+			active:    []string{"hero.png"},
+		},
+		{
+			name:      "component script strings",
+			ext:       ".vue",
+			content:   "<template>\n  <p>Don't miss it</p>\n  <!-- <img src=\"./old.png\"> -->\n</template>\n<script setup>\nconst icons = import.meta.glob('./icons/*.svg')\n// const legacy = './legacy.png'\nconst hero = './hero.png'\n</script>\n",
+			commented: []string{"old.png", "legacy.png"},
+			active:    []string{"icons/", "hero.png"},
+		},
+		{
+			name:      "component style and expressions",
+			ext:       ".svelte",
+			content:   "<img src={'./assets/*.png'} alt=\"hero.png\">\n{/* <img src=\"./old.png\"> */}\n<style>\n.a { background: url(//cdn.example.com/bg.png); }\n/* .b { background: url(stale.png); } */\n</style>\n",
+			commented: []string{"old.png", "stale.png"},
+			active:    []string{"hero.png", "bg.png"},
+		},
+		{
+			name:      "Astro frontmatter",
+			ext:       ".astro",
+			content:   "---\nconst all = import.meta.glob('./img/*.png')\n// import old from './old.png'\n---\n<img src=\"./hero.png\">\n",
+			commented: []string{"old.png"},
+			active:    []string{"hero.png"},
+		},
+		{
+			name:      "MDX imports",
+			ext:       ".mdx",
+			content:   "import icons from './icons/*.svg'\n\nIt's here: <img src=\"./hero.png\" />\n\n{/* ![old](./old.png) */}\n",
+			commented: []string{"old.png"},
+			active:    []string{"hero.png"},
+		},
+		{
+			name:    "unknown language",
+			ext:     ".txt",
+			content: "/* old.png */\n",
+			active:  []string{"old.png"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewSourceText(tt.content)
+			ranges := CommentRanges(src, tt.ext)
+			for _, name := range tt.commented {
+				if !InComment(ranges, strings.Index(tt.content, name)) {
+					t.Errorf("%s should be inside a comment (ranges %v)", name, ranges)
+				}
+			}
+			for _, name := range tt.active {
+				if InComment(ranges, strings.Index(tt.content, name)) {
+					t.Errorf("%s should not be inside a comment (ranges %v)", name, ranges)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// CustomPatternType is the pattern type reported for user-configured patterns
+const CustomPatternType = "Custom"
+
+// CustomPatternConfidence is the confidence assigned to user-configured pattern matches
+const CustomPatternConfidence float32 = 0.8
+
+// CompileCustomPatterns compiles the custom_patterns config into reference patterns.
+// The first capture group is taken as the asset path; a pattern without groups
+// uses its whole match.
+func CompileCustomPatterns(exprs []string) ([]ReferencePattern, error) {
+	patterns := make([]ReferencePattern, 0, len(exprs))
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom pattern %q: %w", expr, err)
+		}
+		if re.NumSubexp() == 0 {
+			re = regexp.MustCompile("(" + expr + ")")
+		}
+		patterns = append(patterns, ReferencePattern{
+			Pattern:    re,
+			Type:       CustomPatternType,
+			Confidence: CustomPatternConfidence,
+		})
+	}
+	return patterns, nil
+}
@@ -0,0 +1,400 @@
+// Package parser - structural parsing for Dart
+//
+// Regex patterns see one call site at a time, so asset paths kept in
+// constants are only found where they are declared. This parser tokenizes Dart
+// (skipping comments, understanding string literals and class bodies) to:
+// - Resolve top-level and static string constants ('$_base/logo.png')
+// - Collect class-based registries (class Assets { static const logo = ... })
+// - Find where those constants are used (Assets.logo, or logo inside Assets)
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DartConstant is a string constant declared at top level or as a class member
+type DartConstant struct {
+	Name       string
+	Class      string // enclosing class, "" for top-level constants
+	Value      string // string value with interpolated constants resolved
+	LineNumber int    // line of the value literal
+}
+
+// Key returns how the constant is referred to: "Class.name" or "name"
+func (c DartConstant) Key() string {
+	if c.Class == "" {
+		return c.Name
+	}
+	return c.Class + "." + c.Name
+}
+
+// DartUsage is a place where a known constant is read
+type DartUsage struct {
+	Constant   DartConstant
+	LineNumber int
+	Offset     int // byte offset of the identifier read
+}
+
+// DartFile is a tokenized Dart source file
+type DartFile struct {
+	src       *SourceText
+	tokens    []dartToken
+	Constants []DartConstant
+	declNames map[int]bool // token indices naming a declared constant
+}
+
+type dartTokenKind int
+
+const (
+	dartIdent dartTokenKind = iota
+	dartString
+	dartPunct
+)
+
+type dartToken struct {
+	kind   dartTokenKind
+	text   string // identifier, punctuation, or string body without quotes
+	offset int
+	raw    bool // r'...' strings don't interpolate
+}
+
+// dartInterpolation matches ${expr} and $name inside string bodies
+var dartInterpolation = regexp.MustCompile(`\$\{\s*([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)?)\s*\}|\$([A-Za-z_][\w]*)`)
+
+// ParseDart tokenizes src and collects its string constants
+func ParseDart(src *SourceText) *DartFile {
+	f := &DartFile{
+		src:       src,
+		tokens:    tokenizeDart(src.Content),
+		declNames: make(map[int]bool),
+	}
+	f.collectConstants()
+	return f
+}
+
+// Usages returns the reads of known constants in the file. known is keyed by
+// DartConstant.Key and usually spans the whole project.
+func (f *DartFile) Usages(known map[string]DartConstant) []DartUsage {
+	var usages []DartUsage
+
+	f.walk(func(i int, class string, _ bool) {
+		tok := f.tokens[i]
+		if tok.kind != dartIdent || f.declNames[i] {
+			return
+		}
+
+		var key string
+		if i >= 2 && f.tokens[i-1].text == "." && f.tokens[i-2].kind == dartIdent {
+			key = f.tokens[i-2].text + "." + tok.text
+		} else if i >= 1 && f.tokens[i-1].text == "." {
+			return
+		} else if _, ok := known[class+"."+tok.text]; ok && class != "" {
+			key = class + "." + tok.text
+		} else {
+			key = tok.text
+		}
+
+		if c, ok := known[key]; ok {
+			usages = append(usages, DartUsage{Constant: c, LineNumber: f.src.LineNumber(tok.offset), Offset: tok.offset})
+		}
+	})
+
+	return usages
+}
+
+// walk calls fn for every token with the class whose body encloses it;
+// member reports whether the token sits directly at top level or directly in
+// that class body rather than inside a function or initializer
+func (f *DartFile) walk(fn func(i int, class string, member bool)) {
+	type classScope struct {
+		name  string
+		depth int
+	}
+	var classes []classScope
+	pending := ""
+	depth := 0
+
+	for i, tok := range f.tokens {
+		class, member := "", depth == 0
+		if len(classes) > 0 {
+			scope := classes[len(classes)-1]
+			class, member = scope.name, depth == scope.depth
+		}
+		fn(i, class, member)
+
+		if tok.kind == dartIdent {
+			switch tok.text {
+			case "class", "mixin", "enum", "extension":
+				if i+1 < len(f.tokens) && f.tokens[i+1].kind == dartIdent && f.tokens[i+1].text != "on" {
+					pending = f.tokens[i+1].text
+				}
+			}
+			continue
+		}
+		if tok.kind != dartPunct {
+			continue
+		}
+
+		switch tok.text {
+		case "{":
+			depth++
+			if pending != "" {
+				classes = append(classes, classScope{name: pending, depth: depth})
+				pending = ""
+			}
+		case "}":
+			if len(classes) > 0 && classes[len(classes)-1].depth == depth {
+				classes = classes[:len(classes)-1]
+			}
+			depth--
+		case ";":
+			pending = ""
+		}
+	}
+}
+
+// collectConstants finds const/final declarations initialized with string
+// literals at top level or directly in a class body
+func (f *DartFile) collectConstants() {
+	var declared []DartConstant
+	var bodies []dartToken
+
+	f.walk(func(i int, class string, member bool) {
+		tok := f.tokens[i]
+		if !member || tok.kind != dartIdent || (tok.text != "const" && tok.text != "final") {
+			return
+		}
+		if i > 0 {
+			prev := f.tokens[i-1]
+			if prev.kind == dartPunct && !strings.Contains(";{})", prev.text) {
+				return
+			}
+			if prev.kind == dartIdent && prev.text != "static" && prev.text != "late" {
+				return
+			}
+		}
+
+		name, value, ok := f.declaration(i + 1)
+		if !ok {
+			return
+		}
+		f.declNames[name] = true
+		declared = append(declared, DartConstant{
+			Name:       f.tokens[name].text,
+			Class:      class,
+			LineNumber: f.src.LineNumber(value.offset),
+		})
+		bodies = append(bodies, value)
+	})
+
+	// Resolve interpolations now that every constant in the file is known
+	scope := make(map[string]int, len(declared))
+	for i, c := range declared {
+		scope[c.Key()] = i
+	}
+	resolved := make([]*string, len(declared))
+	resolving := make([]bool, len(declared))
+	var resolve func(i int) (string, bool)
+	resolve = func(i int) (string, bool) {
+		if resolved[i] != nil {
+			return *resolved[i], true
+		}
+		if resolving[i] {
+			return "", false
+		}
+		resolving[i] = true
+		defer func() { resolving[i] = false }()
+
+		body := bodies[i]
+		if body.raw {
+			resolved[i] = &body.text
+			return body.text, true
+		}
+
+		ok := true
+		value := dartInterpolation.ReplaceAllStringFunc(body.text, func(m string) string {
+			sub := dartInterpolation.FindStringSubmatch(m)
+			ref := sub[1] + sub[2]
+			j, found := scope[declared[i].Class+"."+ref]
+			if !found {
+				j, found = scope[ref]
+			}
+			if !found {
+				ok = false
+				return m
+			}
+			v, vok := resolve(j)
+			ok = ok && vok
+			return v
+		})
+		if !ok {
+			return "", false
+		}
+		resolved[i] = &value
+		return value, true
+	}
+
+	for i, c := range declared {
+		if value, ok := resolve(i); ok {
+			c.Value = value
+			f.Constants = append(f.Constants, c)
+		}
+	}
+}
+
+// declaration parses "[Type] name = 'a' 'b';" starting at token i, returning
+// the name token index and the (adjacent-concatenated) string literal
+func (f *DartFile) declaration(i int) (int, dartToken, bool) {
+	name := -1
+	for ; i < len(f.tokens); i++ {
+		tok := f.tokens[i]
+		if tok.kind == dartIdent {
+			name = i
+			continue
+		}
+		if tok.kind == dartPunct && tok.text == "=" {
+			break
+		}
+		if tok.kind != dartPunct || !strings.Contains("<>?,.", tok.text) {
+			return 0, dartToken{}, false
+		}
+	}
+	if name < 0 || i >= len(f.tokens) {
+		return 0, dartToken{}, false
+	}
+
+	var value dartToken
+	found := false
+	for i++; i < len(f.tokens) && f.tokens[i].kind == dartString; i++ {
+		tok := f.tokens[i]
+		if !found {
+			value, found = tok, true
+			continue
+		}
+		value.text += tok.text
+		value.raw = value.raw && tok.raw
+	}
+	if !found || i >= len(f.tokens) {
+		return 0, dartToken{}, false
+	}
+	if end := f.tokens[i]; end.kind != dartPunct || (end.text != ";" && end.text != ",") {
+		return 0, dartToken{}, false
+	}
+	return name, value, true
+}
+
+// tokenizeDart splits Dart source into identifiers, string literals, and
+// punctuation, dropping whitespace and comments
+func tokenizeDart(content string) []dartToken {
+	var tokens []dartToken
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case strings.HasPrefix(content[i:], "//"):
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+
+		case strings.HasPrefix(content[i:], "/*"):
+			// Dart block comments nest
+			depth := 0
+			for i < len(content) {
+				if strings.HasPrefix(content[i:], "/*") {
+					depth++
+					i += 2
+				} else if strings.HasPrefix(content[i:], "*/") {
+					depth--
+					i += 2
+					if depth == 0 {
+						break
+					}
+				} else {
+					i++
+				}
+			}
+
+		case c == '\'' || c == '"':
+			tok, next := readDartString(content, i, false)
+			tokens = append(tokens, tok)
+			i = next
+
+		case c == 'r' && i+1 < len(content) && (content[i+1] == '\'' || content[i+1] == '"'):
+			tok, next := readDartString(content, i+1, true)
+			tok.offset = i
+			tokens = append(tokens, tok)
+			i = next
+
+		case isDartIdentByte(c):
+			start := i
+			for i < len(content) && isDartIdentByte(content[i]) {
+				i++
+			}
+			tokens = append(tokens, dartToken{kind: dartIdent, text: content[start:i], offset: start})
+
+		default:
+			tokens = append(tokens, dartToken{kind: dartPunct, text: string(c), offset: i})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// readDartString reads the string literal whose opening quote is at start and
+// returns it with the offset just past the closing quote
+func readDartString(content string, start int, raw bool) (dartToken, int) {
+	quote := content[start : start+1]
+	if strings.HasPrefix(content[start:], quote+quote+quote) {
+		quote = quote + quote + quote
+	}
+
+	var body strings.Builder
+	i := start + len(quote)
+	for i < len(content) {
+		if strings.HasPrefix(content[i:], quote) {
+			return dartToken{kind: dartString, text: body.String(), offset: start, raw: raw}, i + len(quote)
+		}
+		c := content[i]
+		if !raw && c == '\\' && i+1 < len(content) {
+			body.WriteByte(content[i+1])
+			i += 2
+			continue
+		}
+		if !raw && strings.HasPrefix(content[i:], "${") {
+			// Copy the interpolation, which may itself contain braces and quotes
+			depth := 0
+			for i < len(content) {
+				body.WriteByte(content[i])
+				if content[i] == '{' {
+					depth++
+				} else if content[i] == '}' {
+					depth--
+					if depth == 0 {
+						i++
+						break
+					}
+				}
+				i++
+			}
+			continue
+		}
+		if len(quote) == 1 && c == '\n' {
+			break // unterminated single-line string
+		}
+		body.WriteByte(c)
+		i++
+	}
+
+	return dartToken{kind: dartString, text: body.String(), offset: start, raw: raw}, i
+}
+
+// isDartIdentByte reports whether c can appear in a Dart identifier
+func isDartIdentByte(c byte) bool {
+	return c == '_' || c == '$' ||
+		c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
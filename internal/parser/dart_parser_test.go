@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+const dartRegistry = `// Generated asset registry
+const String _base = 'assets/images';
+
+class Assets {
+  Assets._();
+
+  static const logo = '$_base/logo.png';
+  static const String banner =
+      'assets/images/banner.png';
+  static const icon = r'assets/$raw.png';
+  static final dynamicPath = computePath();
+
+  /* static const commented = 'assets/old.png'; */
+
+  String describe() {
+    const local = 'assets/local.png';
+    return logo;
+  }
+}
+`
+
+func TestParseDart_Constants(t *testing.T) {
+	f := ParseDart(NewSourceText(dartRegistry))
+
+	got := make(map[string]string)
+	lines := make(map[string]int)
+	for _, c := range f.Constants {
+		got[c.Key()] = c.Value
+		lines[c.Key()] = c.LineNumber
+	}
+
+	expected := map[string]string{
+		"_base":         "assets/images",
+		"Assets.logo":   "assets/images/logo.png",
+		"Assets.banner": "assets/images/banner.png",
+		"Assets.icon":   "assets/$raw.png",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Constants = %v, want %v", got, expected)
+	}
+	if lines["Assets.banner"] != 9 {
+		t.Errorf("Assets.banner line = %d, want 9 (the literal)", lines["Assets.banner"])
+	}
+}
+
+func TestDartFile_Usages(t *testing.T) {
+	registry := ParseDart(NewSourceText(dartRegistry))
+	known := make(map[string]DartConstant)
+	for _, c := range registry.Constants {
+		known[c.Key()] = c
+	}
+
+	screen := ParseDart(NewSourceText(`import 'assets.dart';
+
+Widget build(BuildContext context) {
+  // Image.asset(Assets.icon)
+  return Image.asset(Assets.banner);
+}
+`))
+
+	var got []string
+	for _, usage := range screen.Usages(known) {
+		got = append(got, usage.Constant.Key())
+		if usage.LineNumber != 5 {
+			t.Errorf("usage of %s on line %d, want 5", usage.Constant.Key(), usage.LineNumber)
+		}
+	}
+	if !reflect.DeepEqual(got, []string{"Assets.banner"}) {
+		t.Errorf("Usages() = %v, want [Assets.banner]", got)
+	}
+
+	// Bare reads inside the declaring class resolve to its members
+	var inClass []string
+	for _, usage := range registry.Usages(known) {
+		inClass = append(inClass, usage.Constant.Key())
+	}
+	if !reflect.DeepEqual(inClass, []string{"Assets.logo"}) {
+		t.Errorf("Usages() in registry = %v, want [Assets.logo]", inClass)
+	}
+}
@@ -0,0 +1,33 @@
+// Package parser - Document link patterns
+//
+// Documents (PDFs, Office files) are linked and opened rather than embedded:
+// - <a href="/docs/guide.pdf" download>, with optional #page=3 or ?v=2
+// - window.open('/docs/terms.pdf'), location.href = '/files/report.xlsx'
+package parser
+
+import "regexp"
+
+// documentExts is the alternation of document extensions the patterns match
+const documentExts = `pdf|docx?|xlsx?|pptx?|odt|ods|odp|epub`
+
+var (
+	// <a href> links to documents, in any attribute order
+	DocumentLinkPattern = regexp.MustCompile(`<a\b[^>]*?\bhref\s*=\s*\{?\s*['"]([^'"?#]+\.(` + documentExts + `))(?:[?#][^'"]*)?['"]`)
+
+	// window.open() and location assignments navigating to a document
+	DocumentOpenPattern = regexp.MustCompile(`(?:\bwindow\.open\s*\(|\blocation(?:\.href)?\s*=)\s*['"]([^'"?#]+\.(` + documentExts + `))(?:[?#][^'"]*)?['"]`)
+
+	// String literals naming a document with a #page=N or ?query suffix, which
+	// the plain string literal pattern doesn't allow
+	DocumentFragmentPattern = regexp.MustCompile(`['"]([^'"?#]+\.(` + documentExts + `))[?#][^'"]*['"]`)
+)
+
+// DocumentPatterns returns the document link patterns added for every
+// project type
+func DocumentPatterns() []ReferencePattern {
+	return []ReferencePattern{
+		{Pattern: DocumentLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: DocumentOpenPattern, Type: "MediaLoader", Confidence: 0.95},
+		{Pattern: DocumentFragmentPattern, Type: "StringLiteral", Confidence: 0.8},
+	}
+}
@@ -0,0 +1,35 @@
+// Package parser - Email template patterns
+//
+// Email templates (MJML, or HTML built for mail clients) reference images by
+// attributes regular pages rarely use:
+// - <mj-image src>, <mj-hero background-url>, <mj-section background-url>
+// - <mj-social-element icon>, <mj-carousel-image thumbnails-src>
+// - Legacy table backgrounds: <td background="...">
+//
+// Images in email must be absolute URLs, so these references usually point at
+// the public CDN; PublicBaseURLs in the config maps them back to repo paths.
+package parser
+
+import "regexp"
+
+var (
+	// MJML image attributes other than src, with optional cache-busting query
+	EmailMJMLImagePattern = regexp.MustCompile(`\b(?:background-url|icon|thumbnails-src)\s*=\s*['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp))(?:\?[^'"]*)?['"]`)
+
+	// <table background="...">, <td background="..."> for clients without CSS
+	EmailBackgroundPattern = regexp.MustCompile(`<(?:table|td|th|body)\b[^>]*?\bbackground\s*=\s*['"]([^'"]+\.(jpg|jpeg|png|gif|webp))(?:\?[^'"]*)?['"]`)
+)
+
+// EmailExtensions are template files scanned when email templates are enabled
+var EmailExtensions = []string{".mjml"}
+
+// EmailPatterns returns the patterns added when scanning email templates;
+// src attributes and inline styles are covered by the HTML patterns
+func EmailPatterns() []ReferencePattern {
+	return []ReferencePattern{
+		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: EmailMJMLImagePattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: EmailBackgroundPattern, Type: "HTMLAttribute", Confidence: 0.9},
+	}
+}
@@ -27,7 +27,7 @@ var (
 type FlutterPatternProvider struct{}
 
 func (f *FlutterPatternProvider) GetPatterns() []ReferencePattern {
-	return []ReferencePattern{
+	return append([]ReferencePattern{
 		// Flutter-specific patterns (high confidence - these are explicit API calls)
 		{Pattern: FlutterImageAssetPattern, Type: "FlutterImageAsset", Confidence: 1.0},
 		{Pattern: FlutterAssetImagePattern, Type: "FlutterAssetImage", Confidence: 1.0},
@@ -37,11 +37,11 @@ func (f *FlutterPatternProvider) GetPatterns() []ReferencePattern {
 
 		// Standard patterns (lower confidence for generic matches in Dart)
 		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.7},
-	}
+	}, flutterMultilinePatterns()...)
 }
 
 func (f *FlutterPatternProvider) UseASTParsing() bool {
-	return false // Dart AST parsing not implemented yet, regex sufficient
+	return false // Dart constants are resolved structurally by ParseDart
 }
 
 func (f *FlutterPatternProvider) SupportedFileExtensions() []string {
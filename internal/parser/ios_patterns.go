@@ -0,0 +1,66 @@
+// Package parser - iOS-specific patterns
+//
+// Detects asset references in Swift, Objective-C, and Interface Builder files:
+// - UIImage(named:) and [UIImage imageNamed:]
+// - SwiftUI Image("...") and Image(decorative:)
+// - Bundle.main.url(forResource:withExtension:) and path(forResource:ofType:)
+// - NSDataAsset(name:)
+// - Storyboard and xib image attributes
+//
+// Named lookups usually omit the extension and refer to an asset catalog set
+// (logo -> Assets.xcassets/logo.imageset/*), which the scanner resolves.
+package parser
+
+import "regexp"
+
+var (
+	// UIImage(named: "logo"), UIImage(named: "logo", in: bundle, ...)
+	IOSUIImageNamedPattern = regexp.MustCompile(`UIImage\s*\(\s*named:\s*"([^"\\]+)"`)
+
+	// [UIImage imageNamed:@"logo"]
+	IOSImageNamedPattern = regexp.MustCompile(`imageNamed:\s*@?"([^"\\]+)"`)
+
+	// SwiftUI: Image("logo"), Image(decorative: "logo") (not Image(systemName:))
+	IOSSwiftUIImagePattern = regexp.MustCompile(`\bImage\s*\(\s*(?:decorative:\s*)?"([^"\\]+)"`)
+
+	// Bundle.main.url(forResource: "intro", withExtension: "mp4"),
+	// Bundle.main.path(forResource: "intro", ofType: "mp4")
+	IOSBundleResourcePattern = regexp.MustCompile(`(?:url|path)\s*\(\s*forResource:\s*"([^"\\]+)"(?:\s*,\s*(?:withExtension|ofType):\s*"([^"\\]+)")?`)
+
+	// NSDataAsset(name: "config")
+	IOSDataAssetPattern = regexp.MustCompile(`NSDataAsset\s*\(\s*name:\s*"([^"\\]+)"`)
+
+	// Interface Builder: <imageView image="logo">, <state backgroundImage="bg">,
+	// and <image name="logo" ...> in the resources section
+	IOSInterfaceBuilderImagePattern    = regexp.MustCompile(`\b(?:image|backgroundImage|selectedImage|highlightedImage)="([^"]+)"`)
+	IOSInterfaceBuilderResourcePattern = regexp.MustCompile(`<image\s+name="([^"]+)"`)
+)
+
+// IOSPatternProvider provides patterns for native iOS projects
+type IOSPatternProvider struct{}
+
+func (i *IOSPatternProvider) GetPatterns() []ReferencePattern {
+	return []ReferencePattern{
+		// Explicit resource APIs
+		{Pattern: IOSUIImageNamedPattern, Type: "IOSNamedAsset", Confidence: 1.0},
+		{Pattern: IOSImageNamedPattern, Type: "IOSNamedAsset", Confidence: 1.0},
+		{Pattern: IOSSwiftUIImagePattern, Type: "IOSNamedAsset", Confidence: 0.95},
+		{Pattern: IOSBundleResourcePattern, Type: "IOSBundleResource", Confidence: 1.0, ExtensionGroup: 2},
+		{Pattern: IOSDataAssetPattern, Type: "IOSNamedAsset", Confidence: 1.0},
+
+		// Interface Builder XML
+		{Pattern: IOSInterfaceBuilderImagePattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: IOSInterfaceBuilderResourcePattern, Type: "HTMLAttribute", Confidence: 0.9},
+
+		// Standard patterns
+		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.7},
+	}
+}
+
+func (i *IOSPatternProvider) UseASTParsing() bool {
+	return false
+}
+
+func (i *IOSPatternProvider) SupportedFileExtensions() []string {
+	return []string{".swift", ".m", ".mm", ".storyboard", ".xib"}
+}
@@ -0,0 +1,271 @@
+package parser
+
+import (
+	"regexp/syntax"
+	"strings"
+)
+
+// maxPrefilterLiterals caps the alternatives kept for one pattern's prefilter
+const maxPrefilterLiterals = 64
+
+// Matcher runs a set of reference patterns over lines. Each pattern gets a
+// prefilter of literal substrings derived from its regex, at least one of
+// which must appear in a line for the pattern to match; lines without any of
+// them skip the regex entirely.
+type Matcher struct {
+	patterns []ReferencePattern
+	filters  [][]string // per pattern; nil means the regex always runs
+}
+
+// NewMatcher builds a matcher with prefilters for the given patterns
+func NewMatcher(patterns []ReferencePattern) *Matcher {
+	m := &Matcher{
+		patterns: patterns,
+		filters:  make([][]string, len(patterns)),
+	}
+	for i, p := range patterns {
+		m.filters[i] = RequiredLiterals(p.Pattern.String())
+	}
+	return m
+}
+
+// Patterns returns the patterns the matcher runs
+func (m *Matcher) Patterns() []ReferencePattern {
+	return m.patterns
+}
+
+// MayMatch reports whether pattern i can match line, using only its prefilter
+func (m *Matcher) MayMatch(i int, line string) bool {
+	filter := m.filters[i]
+	if filter == nil {
+		return true
+	}
+	for _, literal := range filter {
+		if strings.Contains(line, literal) {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceMatch is a multi-line pattern match located in a SourceText
+type SourceMatch struct {
+	Groups     []string // capture groups, Groups[0] is the whole match
+	LineNumber int      // line of the first capture group, or of the match without one
+	StartLine  int      // line where the match begins
+	Offset     int      // byte offset of the first capture group, or of the match without one
+}
+
+// Match calls fn for every submatch of every single-line pattern on line,
+// with the submatch offsets within line
+func (m *Matcher) Match(line string, fn func(p ReferencePattern, groups []string, loc []int)) {
+	for i, p := range m.patterns {
+		if p.Multiline || !m.MayMatch(i, line) {
+			continue
+		}
+		for _, loc := range p.Pattern.FindAllStringSubmatchIndex(line, -1) {
+			groups := make([]string, len(loc)/2)
+			for g := range groups {
+				if loc[2*g] >= 0 {
+					groups[g] = line[loc[2*g]:loc[2*g+1]]
+				}
+			}
+			fn(p, groups, loc)
+		}
+	}
+}
+
+// MatchSource calls fn for every submatch of every multi-line pattern in src,
+// attributing each match to the line its asset path appears on
+func (m *Matcher) MatchSource(src *SourceText, fn func(p ReferencePattern, match SourceMatch)) {
+	for i, p := range m.patterns {
+		if !p.Multiline || !m.MayMatch(i, src.Content) {
+			continue
+		}
+		for _, loc := range p.Pattern.FindAllStringSubmatchIndex(src.Content, -1) {
+			fn(p, LocateMatch(src, loc))
+		}
+	}
+}
+
+// LocateMatch converts submatch offsets within src.Content into a SourceMatch
+func LocateMatch(src *SourceText, loc []int) SourceMatch {
+	match := SourceMatch{
+		Groups:    make([]string, len(loc)/2),
+		StartLine: src.LineNumber(loc[0]),
+	}
+	for g := range match.Groups {
+		if loc[2*g] >= 0 {
+			match.Groups[g] = src.Content[loc[2*g]:loc[2*g+1]]
+		}
+	}
+
+	match.LineNumber, match.Offset = match.StartLine, loc[0]
+	if len(loc) > 2 && loc[2] >= 0 {
+		match.LineNumber, match.Offset = src.LineNumber(loc[2]), loc[2]
+	}
+	return match
+}
+
+// RequiredLiterals returns substrings of which any match of expr must contain
+// at least one, or nil when no such set can be derived (or expr is invalid)
+func RequiredLiterals(expr string) []string {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	literals, _ := requiredLiterals(re.Simplify())
+	for _, literal := range literals {
+		if literal == "" {
+			return nil
+		}
+	}
+	return literals
+}
+
+// requiredLiterals returns a literal set for re; exact reports whether the set
+// lists every string re can match, so it can be combined with its neighbours
+func requiredLiterals(re *syntax.Regexp) ([]string, bool) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return nil, false
+		}
+		return []string{string(re.Rune)}, true
+
+	case syntax.OpCharClass:
+		// Small classes like ['"] expand to one literal per rune
+		var runes []string
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if len(runes) == 8 {
+					return nil, false
+				}
+				runes = append(runes, string(r))
+			}
+		}
+		return runes, len(runes) > 0
+
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine,
+		syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return []string{""}, true
+
+	case syntax.OpCapture:
+		return requiredLiterals(re.Sub[0])
+
+	case syntax.OpPlus:
+		literals, _ := requiredLiterals(re.Sub[0])
+		return literals, false
+
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return nil, false
+		}
+		literals, _ := requiredLiterals(re.Sub[0])
+		return literals, false
+
+	case syntax.OpAlternate:
+		var union []string
+		exact := true
+		for _, sub := range re.Sub {
+			literals, subExact := requiredLiterals(sub)
+			if literals == nil || len(union)+len(literals) > maxPrefilterLiterals {
+				return nil, false
+			}
+			union = append(union, literals...)
+			exact = exact && subExact
+		}
+		return union, exact
+
+	case syntax.OpConcat:
+		return concatLiterals(re.Sub)
+	}
+
+	return nil, false
+}
+
+// concatLiterals joins runs of exact neighbours into longer literals and
+// returns the most selective candidate set among the parts
+func concatLiterals(subs []*syntax.Regexp) ([]string, bool) {
+	var best []string
+	var run []string
+	inRun := false
+	exact := true
+
+	consider := func(literals []string) {
+		if literals != nil && betterLiterals(literals, best) {
+			best = literals
+		}
+	}
+
+	for _, sub := range subs {
+		literals, subExact := requiredLiterals(sub)
+		if !subExact {
+			exact = false
+			if inRun {
+				consider(run)
+				run, inRun = nil, false
+			}
+			consider(literals)
+			continue
+		}
+
+		if !inRun {
+			run, inRun = literals, true
+			continue
+		}
+		if joined := crossLiterals(run, literals); joined != nil {
+			run = joined
+			continue
+		}
+
+		// Too many combinations: keep what we have and start a new run
+		exact = false
+		consider(run)
+		run = literals
+	}
+	if inRun {
+		consider(run)
+	}
+
+	return best, exact
+}
+
+// crossLiterals concatenates every pair from a and b, or returns nil when the
+// result would exceed maxPrefilterLiterals
+func crossLiterals(a, b []string) []string {
+	if len(a)*len(b) > maxPrefilterLiterals {
+		return nil
+	}
+	joined := make([]string, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			joined = append(joined, x+y)
+		}
+	}
+	return joined
+}
+
+// betterLiterals reports whether candidate filters more lines than current:
+// longer shortest literal first, then fewer alternatives
+func betterLiterals(candidate, current []string) bool {
+	if current == nil {
+		return true
+	}
+	cMin, bMin := minLen(candidate), minLen(current)
+	if cMin != bMin {
+		return cMin > bMin
+	}
+	return len(candidate) < len(current)
+}
+
+// minLen returns the length of the shortest literal
+func minLen(literals []string) int {
+	shortest := -1
+	for _, literal := range literals {
+		if shortest == -1 || len(literal) < shortest {
+			shortest = len(literal)
+		}
+	}
+	return shortest
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{`Image\.asset\s*\(`, []string{"Image.asset"}},
+		{`url\s*\(\s*['"]?([^"')]+\.(png|svg))`, []string{".png", ".svg"}},
+		{`(?:src|href)=`, []string{"src=", "href="}},
+		{`\w+\.png`, []string{".png"}},
+		{`(?i)logo\.png`, nil},
+		{`.*`, nil},
+		{`a|.*`, nil},
+		{`(`, nil},
+	}
+
+	for _, tt := range tests {
+		got := RequiredLiterals(tt.expr)
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("RequiredLiterals(%q) = %q, want %q", tt.expr, got, tt.expected)
+		}
+	}
+}
+
+func TestMatcher_PrefilterKeepsMatches(t *testing.T) {
+	var patterns []ReferencePattern
+	for _, provider := range []PatternProvider{
+		&GenericPatternProvider{}, &ReactPatternProvider{}, &AngularPatternProvider{},
+		&VuePatternProvider{}, &FlutterPatternProvider{}, &SveltePatternProvider{},
+	} {
+		patterns = append(patterns, provider.GetPatterns()...)
+	}
+	matcher := NewMatcher(patterns)
+
+	lines := []string{
+		`import logo from './logo.png'`,
+		`const icon = require("./icons/icon.svg")`,
+		`background: url(../images/bg.jpg);`,
+		`<img src="/assets/hero.webp" alt="">`,
+		"const path = `assets/${name}.png`",
+		`Image.asset('assets/images/logo.png')`,
+		`const LazyPage = React.lazy(() => import('./Page'))`,
+		`templateUrl: './app.component.html'`,
+		`<img [src]="'assets/logo.svg'">`,
+		`const a = 1;`,
+	}
+
+	for _, line := range lines {
+		for i, p := range patterns {
+			if p.Pattern.MatchString(line) && !matcher.MayMatch(i, line) {
+				t.Errorf("prefilter for %s rejected matching line %q", p.Type, line)
+			}
+		}
+	}
+
+	if matcher.MayMatch(0, `const a = 1;`) && RequiredLiterals(patterns[0].Pattern.String()) != nil {
+		t.Error("prefilter should reject lines without its literals")
+	}
+}
+
+func TestMatcher_Match(t *testing.T) {
+	matcher := NewMatcher([]ReferencePattern{
+		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: regexp.MustCompile(`asset\("([^"]+)"\)`), Type: CustomPatternType, Confidence: CustomPatternConfidence},
+	})
+
+	var got []string
+	matcher.Match(`import a from './a.png'; asset("b")`, func(p ReferencePattern, groups []string, loc []int) {
+		got = append(got, p.Type+":"+groups[1])
+	})
+
+	expected := []string{"Import:./a.png", "Custom:b"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Match() = %v, want %v", got, expected)
+	}
+}
+
+func TestMatcher_MatchSource(t *testing.T) {
+	src := NewSourceText(`import React from 'react'
+import {
+  Logo,
+} from './logo.png'
+const icon = require(
+  './icon.svg'
+)
+Image.asset(
+  'assets/hero.png',
+)
+const same = require('./inline.png')
+`)
+
+	var patterns []ReferencePattern
+	patterns = append(patterns, webMultilinePatterns()...)
+	patterns = append(patterns, flutterMultilinePatterns()...)
+	matcher := NewMatcher(patterns)
+
+	got := make(map[string]int)
+	matcher.MatchSource(src, func(p ReferencePattern, match SourceMatch) {
+		got[match.Groups[1]] = match.LineNumber
+	})
+
+	expected := map[string]int{
+		"./logo.png":      4,
+		"./icon.svg":      6,
+		"assets/hero.png": 9,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("MatchSource() = %v, want %v", got, expected)
+	}
+}
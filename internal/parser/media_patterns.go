@@ -0,0 +1,56 @@
+// Package parser - 3D model and animation loader patterns
+//
+// Models and animations are loaded by library calls rather than imported:
+//   - three.js loaders: new GLTFLoader().load('robot.glb'), loader.loadAsync(...)
+//   - react-three-fiber/drei: useGLTF('/robot.glb'), useLoader(FBXLoader, '/x.fbx')
+//   - <model-viewer src="astronaut.glb">
+//   - Rive: new Rive({ src: 'hero.riv' }), useRive({ src: ... }), RiveAnimation.asset(...)
+//   - dotLottie players: <dotlottie-player src="intro.lottie">, <DotLottieReact src=...>
+//   - Lottie JSON: lottie.loadAnimation({ path: 'data.json' }), <lottie-player src>,
+//     <LottieView source={require('./a.json')}>, Lottie.asset('assets/a.json')
+package parser
+
+import "regexp"
+
+var (
+	// three.js loader calls and the react-three-fiber hooks wrapping them
+	ModelLoaderPattern = regexp.MustCompile(`(?:\.load(?:Async)?|\buseGLTF(?:\.preload)?|\buseFBX|\buseLoader\s*\(\s*[\w.]+\s*,)\s*\(?\s*['"]([^'"]+\.(glb|gltf|obj|fbx))['"]`)
+
+	// <model-viewer src> and its iOS Quick Look fallback
+	ModelViewerPattern = regexp.MustCompile(`<model-viewer\b[^>]*?\b(?:src|ios-src)\s*=\s*['"]([^'"]+\.(glb|gltf|usdz))['"]`)
+
+	// Rive runtimes for the web, React, and Flutter
+	RiveLoaderPattern = regexp.MustCompile(`(?:\b(?:new\s+(?:rive\.)?Rive|useRive)\s*\(\s*\{[^}]*?\bsrc\s*:|\bRive(?:Animation|File)\.asset\s*\()\s*['"]([^'"]+\.(riv))['"]`)
+
+	// dotLottie web components and React players
+	DotLottiePlayerPattern = regexp.MustCompile(`<(?:dotlottie-player|dotlottie-wc|DotLottieReact|DotLottiePlayer)\b[^>]*?\bsrc\s*=\s*\{?\s*['"]([^'"]+\.(lottie))['"]`)
+
+	// lottie-web: lottie.loadAnimation({ container, path: 'anim/data.json' })
+	LottieLoadPattern = regexp.MustCompile(`\bloadAnimation\s*\(\s*\{[^}]*?\bpath\s*:\s*['"]([^'"]+\.(json|lottie))['"]`)
+
+	// loadAnimation options spread over several lines, as usually written
+	MultilineLottieLoadPattern = regexp.MustCompile(`\bloadAnimation\s*\(\s*\{[^}]*?\n[^}]*?\bpath\s*:\s*['"]([^'"\n]+\.(json|lottie))['"]`)
+
+	// <lottie-player src>, lottie-react-native's <LottieView source={require(...)}>,
+	// and the Flutter lottie package's Lottie.asset()
+	LottiePlayerPattern = regexp.MustCompile(`(?:<(?:lottie-player|LottieView|Lottie)\b[^>]*?\b(?:src|source)\s*=\s*\{?\s*(?:require\s*\(\s*)?|\bLottie\.asset\s*\(\s*)['"]([^'"]+\.(json|lottie))['"]`)
+
+	// JSON modules imported for animationData: import confetti from './confetti.json'
+	JSONImportPattern = regexp.MustCompile(`(?:\bimport\s+\w+\s+from\s*|\brequire\s*\(\s*)['"]([^'"]+\.(json))['"]`)
+)
+
+// MediaLoaderPatterns returns the loader patterns added for every project
+// type. JSON imports match config and data files too, which resolve to no
+// asset unless they are Lottie animations.
+func MediaLoaderPatterns() []ReferencePattern {
+	return []ReferencePattern{
+		{Pattern: ModelLoaderPattern, Type: "MediaLoader", Confidence: 1.0},
+		{Pattern: ModelViewerPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: RiveLoaderPattern, Type: "MediaLoader", Confidence: 1.0},
+		{Pattern: DotLottiePlayerPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: LottieLoadPattern, Type: "MediaLoader", Confidence: 1.0},
+		{Pattern: MultilineLottieLoadPattern, Type: "MediaLoader", Confidence: 1.0, Multiline: true},
+		{Pattern: LottiePlayerPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: JSONImportPattern, Type: "Import", Confidence: 1.0},
+	}
+}
@@ -34,24 +34,73 @@ func GetPatternProvider(projectType models.ProjectType) PatternProvider {
 		return &FlutterPatternProvider{}
 	case models.ProjectTypeWebSvelte:
 		return &SveltePatternProvider{}
+	case models.ProjectTypeIOS:
+		return &IOSPatternProvider{}
+	case models.ProjectTypeAndroid:
+		return &AndroidPatternProvider{}
+	case models.ProjectTypeHugo:
+		return &HugoPatternProvider{}
+	case models.ProjectTypeJekyll:
+		return &JekyllPatternProvider{}
+	case models.ProjectTypeEleventy:
+		return &EleventyPatternProvider{}
+	case models.ProjectTypeWordPress:
+		return &WordPressPatternProvider{}
 	default:
 		// Fallback to generic patterns for unknown types
 		return &GenericPatternProvider{}
 	}
 }
 
+// ProviderName returns a human-readable name for a pattern provider
+func ProviderName(provider PatternProvider) string {
+	switch provider.(type) {
+	case *ReactPatternProvider:
+		return "React"
+	case *AngularPatternProvider:
+		return "Angular"
+	case *VuePatternProvider:
+		return "Vue"
+	case *FlutterPatternProvider:
+		return "Flutter"
+	case *SveltePatternProvider:
+		return "Svelte"
+	case *IOSPatternProvider:
+		return "iOS"
+	case *AndroidPatternProvider:
+		return "Android"
+	case *HugoPatternProvider:
+		return "Hugo"
+	case *JekyllPatternProvider:
+		return "Jekyll"
+	case *EleventyPatternProvider:
+		return "Eleventy"
+	case *WordPressPatternProvider:
+		return "WordPress"
+	default:
+		return "Generic"
+	}
+}
+
 // GenericPatternProvider provides basic patterns for unknown project types
 type GenericPatternProvider struct{}
 
 func (g *GenericPatternProvider) GetPatterns() []ReferencePattern {
-	return []ReferencePattern{
+	return append([]ReferencePattern{
 		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
 		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: CSSImageSetPattern, Type: "CSSUrl", Confidence: 0.95, List: true},
+		{Pattern: CSSImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLSrcsetPattern, Type: "HTMLAttribute", Confidence: 0.95, List: true},
+		{Pattern: HTMLPosterPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: URLConstructorPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.7},
 		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.7},
-	}
+	}, webMultilinePatterns()...)
 }
 
 func (g *GenericPatternProvider) UseASTParsing() bool {
@@ -61,26 +110,3 @@ func (g *GenericPatternProvider) UseASTParsing() bool {
 func (g *GenericPatternProvider) SupportedFileExtensions() []string {
 	return []string{".js", ".ts", ".jsx", ".tsx", ".css", ".html"}
 }
-
-// SveltePatternProvider provides patterns for Svelte projects
-// Uses mostly generic patterns with minor enhancements
-type SveltePatternProvider struct{}
-
-func (s *SveltePatternProvider) GetPatterns() []ReferencePattern {
-	return []ReferencePattern{
-		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
-		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
-		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
-		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
-		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.75},
-		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.75},
-	}
-}
-
-func (s *SveltePatternProvider) UseASTParsing() bool {
-	return true // Svelte uses JS/TS, benefit from AST parsing
-}
-
-func (s *SveltePatternProvider) SupportedFileExtensions() []string {
-	return []string{".js", ".ts", ".svelte", ".css"}
-}
@@ -10,27 +10,55 @@
 // Each pattern is assigned a confidence score indicating likelihood of actual usage.
 package parser
 
-import "regexp"
+import (
+	"path"
+	"regexp"
+	"strings"
+)
 
 // Common patterns for detecting asset references in code
 var (
 	// String literals with common asset paths (supports both single and double quotes)
-	StringLiteralPattern = regexp.MustCompile(`['"]([^'"]*\.(jpg|jpeg|png|gif|svg|webp|ico|bmp|ttf|woff|woff2|eot|otf|mp4|webm|mov|avi|mkv|mp3|wav|ogg|m4a|flac))['"]`)
+	StringLiteralPattern = regexp.MustCompile(`['"]([^'"]*\.(jpg|jpeg|png|gif|svg|webp|ico|bmp|ttf|woff|woff2|eot|otf|mp4|webm|mov|avi|mkv|mp3|wav|ogg|m4a|flac|glb|gltf|obj|fbx|lottie|riv|pdf|docx?|xlsx?|pptx?|odt|ods|odp|epub))['"]`)
 
 	// Import statements
-	ImportPattern = regexp.MustCompile(`import\s+.*?['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3))['"]`)
+	ImportPattern = regexp.MustCompile(`import\s+.*?['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3|glb|gltf|lottie|riv))['"]`)
 
 	// Require statements
-	RequirePattern = regexp.MustCompile(`require\s*\(\s*['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3))['"]\ s*\)`)
+	RequirePattern = regexp.MustCompile(`require\s*\(\s*['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3|glb|gltf|lottie|riv))['"]\ s*\)`)
 
 	// CSS url() function
 	CSSUrlPattern = regexp.MustCompile(`url\s*\(\s*['"]?([^"')]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|eot|otf))['"]?\s*\)`)
 
+	// image-set() and -webkit-image-set() candidate lists:
+	// image-set("bg.png" 1x, url(bg@2x.png) 2x)
+	CSSImageSetPattern = regexp.MustCompile(`image-set\s*\(((?:[^()]|\([^()]*\))*)\)`)
+
+	// @import of another stylesheet: @import "theme.css", @import url(print.css)
+	CSSImportPattern = regexp.MustCompile(`@import\s+(?:url\(\s*)?['"]?([^'"()\s;]+\.(css|scss|sass|less))`)
+
 	// HTML src/href attributes
 	HTMLSrcPattern = regexp.MustCompile(`(?:src|href)\s*=\s*['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|mp4|webm|mp3|wav))['"]`)
 
+	// srcset lists on <img> and <picture><source>, and imagesrcset on preload
+	// links: srcset="a.png 1x, a@2x.png 2x" (JSX spells it srcSet)
+	HTMLSrcsetPattern = regexp.MustCompile(`\b(?:srcset|srcSet|imagesrcset|imageSrcSet)\s*=\s*['"]([^'"]+)['"]`)
+
+	// <video poster="...">
+	HTMLPosterPattern = regexp.MustCompile(`\bposter\s*=\s*['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|avif))['"]`)
+
+	// <link rel="preload" as="image|font" href="...">, <link rel="icon" href="...">
+	HTMLLinkPattern = regexp.MustCompile(`<link\b[^>]*?\bhref\s*=\s*['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|avif|ico|ttf|otf|woff|woff2|mp4|webm))['"]`)
+
+	// Inline style="background-image:url(&quot;bg.png&quot;)", where the
+	// quotes around the URL are often HTML entities
+	HTMLInlineStylePattern = regexp.MustCompile(`\bstyle\s*=\s*"[^"]*?url\(\s*(?:&quot;|&#39;|&apos;|')?([^"'()&\s]+\.(jpg|jpeg|png|gif|svg|webp|avif))`)
+
 	// Template literals (basic pattern)
-	TemplateLiteralPattern = regexp.MustCompile("`([^`]*\\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3))`")
+	TemplateLiteralPattern = regexp.MustCompile("`([^`]*\\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3|glb|gltf|lottie|riv|pdf))`")
+
+	// Bundler asset URLs relative to the module: new URL('./hero.png', import.meta.url)
+	URLConstructorPattern = regexp.MustCompile("new\\s+URL\\s*\\(\\s*['\"`]([^'\"`$]+\\.(jpg|jpeg|png|gif|svg|webp|avif|ico|ttf|woff|woff2|mp4|webm|mp3|wav|json|wasm))['\"`]\\s*,\\s*import\\.meta\\.url")
 
 	// Flutter Image.asset() pattern
 	FlutterImageAssetPattern = regexp.MustCompile(`Image\.asset\s*\(\s*['"]([^'"]+\.(png|jpg|jpeg|gif|svg|webp|ico))['"]`)
@@ -41,6 +69,24 @@ var (
 	// Flutter general asset loading (rootBundle.load, etc.)
 	FlutterAssetLoadPattern = regexp.MustCompile(`(?:rootBundle\.load|DefaultAssetBundle\.of.*?\.load)\s*\(\s*['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3|wav|ogg))['"]`)
 
+	// Multi-line patterns are matched against whole files and require a line
+	// break inside the reference, so they never repeat single-line matches
+
+	// Imports split across lines: import logo\n  from './logo.png', import {\n a,\n} from '...'
+	MultilineImportPattern = regexp.MustCompile(`import\s+[^'";]*\n[^'";]*?\bfrom\s+['"]([^'"\n]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3))['"]`)
+
+	// require(\n  './logo.png'\n)
+	MultilineRequirePattern = regexp.MustCompile(`require\s*\([ \t]*\r?\n\s*['"]([^'"\n]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|mp4|mp3))['"]`)
+
+	// url(\n  '../images/bg.png'\n)
+	MultilineCSSUrlPattern = regexp.MustCompile(`url\s*\([ \t]*\r?\n\s*['"]?([^"')\s]+\.(jpg|jpeg|png|gif|svg|webp|ttf|woff|woff2|eot|otf))`)
+
+	// Image.asset(\n  'assets/logo.png',\n) as formatted by dart format
+	MultilineFlutterImageAssetPattern = regexp.MustCompile(`Image\.asset\s*\([ \t]*\r?\n\s*['"]([^'"\n]+\.(png|jpg|jpeg|gif|svg|webp|ico))['"]`)
+
+	// AssetImage(\n  'assets/logo.png',\n)
+	MultilineFlutterAssetImagePattern = regexp.MustCompile(`AssetImage\s*\([ \t]*\r?\n\s*['"]([^'"\n]+\.(png|jpg|jpeg|gif|svg|webp|ico))['"]`)
+
 	// Comment patterns
 	CommentPattern = regexp.MustCompile(`(?://.*?$|/\*[\s\S]*?\*/|#.*?$|<!--[\s\S]*?-->)`)
 )
@@ -50,6 +96,67 @@ type ReferencePattern struct {
 	Pattern    *regexp.Regexp
 	Type       string
 	Confidence float32
+	Multiline  bool // matched against the whole file instead of line by line
+	// ExtensionGroup is a capture group holding a file extension that belongs
+	// to the name in group 1, as in forResource: "intro", withExtension: "mp4"
+	ExtensionGroup int
+	// List marks group 1 as a srcset-style list of URLs, each followed by an
+	// optional width or density descriptor; URLs may be quoted or in url()
+	List bool
+	// NameGroup is a capture group holding a resource name that belongs under
+	// the resource type in group 1, as in R.drawable.logo (drawable/logo)
+	NameGroup int
+}
+
+// AssetPaths returns the asset paths a match refers to: one for most
+// patterns, every URL with a file extension for list patterns
+func (p ReferencePattern) AssetPaths(groups []string) []string {
+	if !p.List {
+		return []string{p.AssetPath(groups)}
+	}
+
+	var paths []string
+	for _, candidate := range strings.Split(groups[1], ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		url := strings.TrimSuffix(strings.TrimPrefix(fields[0], "url("), ")")
+		url = strings.Trim(url, `'"`)
+		if path.Ext(url) != "" && !strings.HasPrefix(url, "data:") {
+			paths = append(paths, url)
+		}
+	}
+	return paths
+}
+
+// AssetPath returns the asset path a match refers to, joining the resource
+// type and name or the name and extension when the pattern has those groups
+func (p ReferencePattern) AssetPath(groups []string) string {
+	if p.NameGroup > 0 && p.NameGroup < len(groups) {
+		return groups[1] + "/" + groups[p.NameGroup]
+	}
+	if p.ExtensionGroup > 0 && p.ExtensionGroup < len(groups) && groups[p.ExtensionGroup] != "" {
+		return groups[1] + "." + strings.TrimPrefix(groups[p.ExtensionGroup], ".")
+	}
+	return groups[1]
+}
+
+// webMultilinePatterns are the multi-line variants of the standard web patterns
+func webMultilinePatterns() []ReferencePattern {
+	return []ReferencePattern{
+		{Pattern: MultilineImportPattern, Type: "Import", Confidence: 1.0, Multiline: true},
+		{Pattern: MultilineRequirePattern, Type: "Import", Confidence: 1.0, Multiline: true},
+		{Pattern: MultilineCSSUrlPattern, Type: "CSSUrl", Confidence: 0.95, Multiline: true},
+	}
+}
+
+// flutterMultilinePatterns are the multi-line variants of the Flutter asset calls
+func flutterMultilinePatterns() []ReferencePattern {
+	return []ReferencePattern{
+		{Pattern: MultilineFlutterImageAssetPattern, Type: "FlutterImageAsset", Confidence: 1.0, Multiline: true},
+		{Pattern: MultilineFlutterAssetImagePattern, Type: "FlutterAssetImage", Confidence: 1.0, Multiline: true},
+	}
 }
 
 // GetAllPatterns returns all reference detection patterns
@@ -61,7 +168,14 @@ func GetAllPatterns() []ReferencePattern {
 		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
 		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: CSSImageSetPattern, Type: "CSSUrl", Confidence: 0.95, List: true},
+		{Pattern: CSSImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLSrcsetPattern, Type: "HTMLAttribute", Confidence: 0.95, List: true},
+		{Pattern: HTMLPosterPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: URLConstructorPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.8},
 		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.8},
 	}
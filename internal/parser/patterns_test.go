@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
 )
 
 func TestStringLiteralPattern(t *testing.T) {
@@ -111,6 +114,95 @@ func TestHTMLSrcPattern(t *testing.T) {
 	}
 }
 
+func TestHTMLAttributePatterns(t *testing.T) {
+	tests := []struct {
+		pattern  ReferencePattern
+		input    string
+		expected []string
+	}{
+		{
+			ReferencePattern{Pattern: HTMLSrcsetPattern, List: true},
+			`<img srcset="img/hero.png 1x, img/hero@2x.png 2x" src="img/hero.png">`,
+			[]string{"img/hero.png", "img/hero@2x.png"},
+		},
+		{
+			ReferencePattern{Pattern: HTMLSrcsetPattern, List: true},
+			`<source type="image/webp" srcset="a-480.webp 480w,a-800.webp 800w, data:image/gif;base64,R0l 1w">`,
+			[]string{"a-480.webp", "a-800.webp"},
+		},
+		{
+			ReferencePattern{Pattern: HTMLSrcsetPattern, List: true},
+			`<link rel="preload" as="image" imagesrcset="wide.jpg 1200w, narrow.jpg 600w">`,
+			[]string{"wide.jpg", "narrow.jpg"},
+		},
+		{
+			ReferencePattern{Pattern: CSSImageSetPattern, List: true},
+			`background-image: image-set("bg.avif" type("image/avif"), url(bg@2x.png) 2x);`,
+			[]string{"bg.avif", "bg@2x.png"},
+		},
+		{
+			ReferencePattern{Pattern: CSSImportPattern},
+			`@import url("themes/dark.css") screen;`,
+			[]string{"themes/dark.css"},
+		},
+		{
+			ReferencePattern{Pattern: HTMLPosterPattern},
+			`<video poster="media/cover.jpg" src="media/clip.mp4">`,
+			[]string{"media/cover.jpg"},
+		},
+		{
+			ReferencePattern{Pattern: HTMLLinkPattern},
+			`<link rel="preload" href="/fonts/inter.woff2" as="font" crossorigin>`,
+			[]string{"/fonts/inter.woff2"},
+		},
+		{
+			ReferencePattern{Pattern: HTMLInlineStylePattern},
+			`<div style="background-image: url(&quot;img/bg.webp&quot;)"></div>`,
+			[]string{"img/bg.webp"},
+		},
+		{
+			ReferencePattern{Pattern: EmailMJMLImagePattern},
+			`<mj-hero background-url="https://cdn.example.com/email/hero.jpg" mode="fluid-height">`,
+			[]string{"https://cdn.example.com/email/hero.jpg"},
+		},
+		{
+			ReferencePattern{Pattern: AngularStyleUrlsPattern, List: true},
+			`styleUrls: ['./app.component.scss', "./theme.css"],`,
+			[]string{"./app.component.scss", "./theme.css"},
+		},
+		{
+			ReferencePattern{Pattern: MultilineAngularStyleUrlsPattern, List: true},
+			"styleUrls: [\n    './card.component.scss',\n    '../shared/grid.css',\n  ],",
+			[]string{"./card.component.scss", "../shared/grid.css"},
+		},
+		{
+			ReferencePattern{Pattern: AngularStyleUrlPattern},
+			`styleUrl: './app.component.scss',`,
+			[]string{"./app.component.scss"},
+		},
+		{
+			ReferencePattern{Pattern: URLConstructorPattern},
+			`const worker = new Worker(new URL("./worker.js", import.meta.url)); const img = new URL('../img/hero.avif', import.meta.url)`,
+			[]string{"../img/hero.avif"},
+		},
+		{
+			ReferencePattern{Pattern: EmailBackgroundPattern},
+			`<td align="center" background="images/bg.png" bgcolor="#fff">`,
+			[]string{"images/bg.png"},
+		},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, groups := range tt.pattern.Pattern.FindAllStringSubmatch(tt.input, -1) {
+			got = append(got, tt.pattern.AssetPaths(groups)...)
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: got %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
 func TestFlutterImageAssetPattern(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -153,6 +245,336 @@ func TestFlutterAssetImagePattern(t *testing.T) {
 	}
 }
 
+func TestNextPatterns(t *testing.T) {
+	provider := &ReactPatternProvider{}
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<Image src="/images/hero.avif" width={800} height={600} alt="" />`, "/images/hero.avif"},
+		{`<Image alt="Logo" src={'/logo.svg'} fill />`, "/logo.svg"},
+		{`const placeholder = { src: '/blur/hero.png', width: 40, height: 30 }`, "/blur/hero.png"},
+		{`const inter = localFont({ src: './fonts/Inter.woff2', display: 'swap' })`, "./fonts/Inter.woff2"},
+		{`    { path: './fonts/Inter-Bold.woff2', weight: '700' },`, "./fonts/Inter-Bold.woff2"},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, p := range provider.GetPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				got = append(got, p.AssetPath(groups))
+			}
+		}
+		found := false
+		for _, path := range got {
+			if path == tt.expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected %s, got %v", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestSveltePatterns(t *testing.T) {
+	provider := &SveltePatternProvider{}
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{@html '<img src="/images/hero.png" alt="Hero">'}`, "/images/hero.png"},
+		{`{@html "<img src=\"promo/banner.webp\">"}`, "promo/banner.webp"},
+		{`<img src={'/logo.svg'} alt="Logo" />`, "/logo.svg"},
+		{"<video poster={`media/intro.jpg`} />", "media/intro.jpg"},
+		{`<img src="{base}/img/avatar.png" alt="" />`, "/img/avatar.png"},
+		{"const icon = `${assets}/icons/menu.svg`", "/icons/menu.svg"},
+		{`const bg = base + '/backgrounds/sky.jpg'`, "/backgrounds/sky.jpg"},
+		{`<link rel="icon" href="%sveltekit.assets%/favicon.png" />`, "/favicon.png"},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, p := range provider.GetPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				got = append(got, p.AssetPath(groups))
+			}
+		}
+		found := false
+		for _, path := range got {
+			if path == tt.expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected %s, got %v", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestStaticSitePatterns(t *testing.T) {
+	tests := []struct {
+		provider PatternProvider
+		input    string
+		expected string
+	}{
+		{&HugoPatternProvider{}, `{{ $logo := resources.Get "images/logo.png" }}`, "images/logo.png"},
+		{&HugoPatternProvider{}, `{{ with .Resources.GetMatch "cover.jpg" }}`, "cover.jpg"},
+		{&HugoPatternProvider{}, `<img src="{{ "img/avatar.webp" | relURL }}">`, "img/avatar.webp"},
+		{&HugoPatternProvider{}, `<link rel="icon" href="{{ absURL "favicon.ico" }}">`, "favicon.ico"},
+		{&HugoPatternProvider{}, `![Diagram](/img/diagram.svg "Flow")`, "/img/diagram.svg"},
+		{&JekyllPatternProvider{}, `<img src="{{ site.baseurl }}/assets/img/hero.jpg">`, "/assets/img/hero.jpg"},
+		{&JekyllPatternProvider{}, `<a href="{{ site.url }}{{ site.baseurl }}/assets/cv.pdf">`, "/assets/cv.pdf"},
+		{&JekyllPatternProvider{}, `<img src="{{ '/assets/logo.svg' | relative_url }}">`, "/assets/logo.svg"},
+		{&JekyllPatternProvider{}, `[Guide]({% link /assets/guide.pdf %})`, "/assets/guide.pdf"},
+		{&EleventyPatternProvider{}, `{% image "./src/img/cat.jpg", "A cat" %}`, "./src/img/cat.jpg"},
+		{&EleventyPatternProvider{}, `const stats = await Image("src/img/team.png", { widths: [300] })`, "src/img/team.png"},
+		{&EleventyPatternProvider{}, `<img src="{{ '/img/badge.png' | url }}">`, "/img/badge.png"},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, p := range tt.provider.GetPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				got = append(got, p.AssetPath(groups))
+			}
+		}
+		found := false
+		for _, path := range got {
+			if path == tt.expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected %s, got %v", tt.input, tt.expected, got)
+		}
+	}
+
+	if HugoResourcePattern.MatchString(`{{ range resources.Match "img/*.jpg" }}`) || HugoResourcePattern.MatchString(`resources.GetMatch "img/*.jpg"`) {
+		t.Error("HugoResourcePattern should not match resource globs")
+	}
+}
+
+func TestWordPressPatterns(t *testing.T) {
+	provider := &WordPressPatternProvider{}
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<img src="<?php echo get_template_directory_uri() . '/img/logo.png'; ?>">`, "/img/logo.png"},
+		{`<img src="<?php echo esc_url( get_stylesheet_directory_uri() ); ?>/img/hero.jpg">`, "/img/hero.jpg"},
+		{`<link rel="icon" href="<?php bloginfo('template_url'); ?>/favicon.ico">`, "/favicon.ico"},
+		{`$logo = get_theme_file_uri( 'assets/logo.svg' );`, "assets/logo.svg"},
+		{`$icon = plugins_url( 'img/icon.png', __FILE__ );`, "img/icon.png"},
+		{`$badge = plugin_dir_url( dirname( __FILE__ ) ) . 'img/badge.png';`, "img/badge.png"},
+		{`wp_enqueue_style( 'acme', get_template_directory_uri() . '/css/main.css', array(), '1.0' );`, "/css/main.css"},
+		{`wp_register_script( 'acme-app', $theme_uri . '/js/app.js', [], null, true );`, "/js/app.js"},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, p := range provider.GetPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				got = append(got, p.AssetPath(groups))
+			}
+		}
+		found := false
+		for _, path := range got {
+			if path == tt.expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected %s, got %v", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestMediaLoaderPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`new GLTFLoader().load('models/robot.glb', onLoad)`, "models/robot.glb"},
+		{`const gltf = await loader.loadAsync("/scenes/city.gltf")`, "/scenes/city.gltf"},
+		{`const { nodes } = useGLTF('/shoe.glb')`, "/shoe.glb"},
+		{`useGLTF.preload('/shoe.glb')`, "/shoe.glb"},
+		{`const fbx = useLoader(FBXLoader, '/dance.fbx')`, "/dance.fbx"},
+		{`<model-viewer src="astronaut.glb" ar></model-viewer>`, "astronaut.glb"},
+		{`new rive.Rive({ src: "/anim/hero.riv", canvas })`, "/anim/hero.riv"},
+		{`const { RiveComponent } = useRive({ src: 'loader.riv', autoplay: true })`, "loader.riv"},
+		{`RiveAnimation.asset('assets/animations/vehicles.riv')`, "assets/animations/vehicles.riv"},
+		{`<dotlottie-player src="/intro.lottie" autoplay loop></dotlottie-player>`, "/intro.lottie"},
+		{`<DotLottieReact src={"/confetti.lottie"} loop />`, "/confetti.lottie"},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, p := range MediaLoaderPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				got = append(got, p.AssetPath(groups))
+			}
+		}
+		if len(got) != 1 || got[0] != tt.expected {
+			t.Errorf("%s: expected [%s], got %v", tt.input, tt.expected, got)
+		}
+	}
+
+	for _, code := range []string{`loader.load(url)`, `fetch('/data/config.json')`, `useRive({ stateMachines: 'idle' })`} {
+		for _, p := range MediaLoaderPatterns() {
+			if p.Pattern.MatchString(code) {
+				t.Errorf("%s matched %s", p.Pattern, code)
+			}
+		}
+	}
+}
+
+func TestLottiePatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`lottie.loadAnimation({ container: el, path: 'animations/data.json' })`, "animations/data.json"},
+		{`<lottie-player src="/lottie/success.json" background="transparent"></lottie-player>`, "/lottie/success.json"},
+		{`<LottieView source={require('./assets/loader.json')} autoPlay />`, "./assets/loader.json"},
+		{`Lottie.asset('assets/lottie/celebrate.json')`, "assets/lottie/celebrate.json"},
+		{`import confetti from './confetti.json'`, "./confetti.json"},
+	}
+	for _, tt := range tests {
+		found := false
+		for _, p := range MediaLoaderPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				found = found || p.AssetPath(groups) == tt.expected
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected %s", tt.input, tt.expected)
+		}
+	}
+
+	multiline := "lottie.loadAnimation({\n  container: el,\n  renderer: 'svg',\n  path: '/anim/intro.json',\n})"
+	if groups := MultilineLottieLoadPattern.FindStringSubmatch(multiline); groups == nil || groups[1] != "/anim/intro.json" {
+		t.Errorf("MultilineLottieLoadPattern on %q = %v", multiline, groups)
+	}
+	if MultilineLottieLoadPattern.MatchString(tests[0].input) {
+		t.Error("MultilineLottieLoadPattern repeats a single-line match")
+	}
+}
+
+func TestDocumentPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<a href="/docs/guide.pdf" download>Guide</a>`, "/docs/guide.pdf"},
+		{`<a download="terms.pdf" class="link" href='files/terms-2024.pdf'>`, "files/terms-2024.pdf"},
+		{`<a href="/docs/manual.pdf#page=12">Chapter 3</a>`, "/docs/manual.pdf"},
+		{`<a href={"/reports/q3.xlsx"}>Q3</a>`, "/reports/q3.xlsx"},
+		{`window.open('/legal/privacy.pdf', '_blank')`, "/legal/privacy.pdf"},
+		{`window.location.href = "/files/handbook.docx"`, "/files/handbook.docx"},
+		{`const brochure = "assets/brochure.pdf?v=3"`, "assets/brochure.pdf"},
+	}
+	// A link with a #page fragment also matches as a string literal; the
+	// reference finder keeps one reference per path and line
+	for _, tt := range tests {
+		got := map[string]bool{}
+		for _, p := range DocumentPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				got[p.AssetPath(groups)] = true
+			}
+		}
+		if len(got) != 1 || !got[tt.expected] {
+			t.Errorf("%s: expected %s, got %v", tt.input, tt.expected, got)
+		}
+	}
+
+	for _, code := range []string{`<a href="/about">About</a>`, `window.open(url)`, `const f = 'report.pdf'`} {
+		for _, p := range DocumentPatterns() {
+			if p.Pattern.MatchString(code) {
+				t.Errorf("%s matched %s", p.Pattern, code)
+			}
+		}
+	}
+}
+
+func TestIOSPatterns(t *testing.T) {
+	provider := &IOSPatternProvider{}
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let logo = UIImage(named: "logo")`, "logo"},
+		{`UIImageView(image: [UIImage imageNamed:@"AppBanner"])`, "AppBanner"},
+		{`Image("Onboarding/step1").resizable()`, "Onboarding/step1"},
+		{`Image(decorative: "divider")`, "divider"},
+		{`Bundle.main.url(forResource: "intro", withExtension: "mp4")`, "intro.mp4"},
+		{`Bundle.main.path(forResource: "sound.caf", ofType: nil)`, "sound.caf"},
+		{`NSDataAsset(name: "levels")`, "levels"},
+		{`<imageView contentMode="scaleAspectFit" image="splash" id="x1"/>`, "splash"},
+		{`<image name="splash" width="320" height="480"/>`, "splash"},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, p := range provider.GetPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				got = append(got, p.AssetPath(groups))
+			}
+		}
+		found := false
+		for _, path := range got {
+			if path == tt.expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected %s, got %v", tt.input, tt.expected, got)
+		}
+	}
+
+	for _, p := range provider.GetPatterns() {
+		if p.Pattern.MatchString(`Image(systemName: "star.fill")`) {
+			t.Errorf("pattern %s should not match SF Symbols", p.Type)
+		}
+	}
+}
+
+func TestAndroidPatterns(t *testing.T) {
+	provider := &AndroidPatternProvider{}
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Image(painter = painterResource(R.drawable.logo), contentDescription = null)`, "drawable/logo"},
+		{`painterResource(id = R.drawable.ic_banner)`, "drawable/ic_banner"},
+		{`ContextCompat.getDrawable(context, R.drawable.bg_card)`, "drawable/bg_card"},
+		{`imageView.setImageResource(R.mipmap.ic_launcher)`, "mipmap/ic_launcher"},
+		{`MediaPlayer.create(this, R.raw.intro)`, "raw/intro"},
+		{`android:src="@drawable/header"`, "drawable/header"},
+		{`android:icon="@mipmap/ic_launcher_round"`, "mipmap/ic_launcher_round"},
+	}
+
+	for _, tt := range tests {
+		found := false
+		for _, p := range provider.GetPatterns() {
+			for _, groups := range p.Pattern.FindAllStringSubmatch(tt.input, -1) {
+				if p.AssetPath(groups) == tt.expected {
+					found = true
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected %s", tt.input, tt.expected)
+		}
+	}
+
+	for _, p := range provider.GetPatterns() {
+		if p.Pattern.MatchString(`android:src="@android:drawable/ic_menu_add"`) {
+			t.Errorf("pattern %s should not match framework resources", p.Type)
+		}
+	}
+}
+
 func TestGetAllPatterns(t *testing.T) {
 	patterns := GetAllPatterns()
 
@@ -226,3 +648,60 @@ func TestPatternNoFalsePositives(t *testing.T) {
 		}
 	}
 }
+
+func TestProviderName(t *testing.T) {
+	tests := []struct {
+		projectType models.ProjectType
+		expected    string
+	}{
+		{models.ProjectTypeWebReact, "React"},
+		{models.ProjectTypeWebAngular, "Angular"},
+		{models.ProjectTypeWebVue, "Vue"},
+		{models.ProjectTypeFlutter, "Flutter"},
+		{models.ProjectTypeWebSvelte, "Svelte"},
+		{models.ProjectTypeIOS, "iOS"},
+		{models.ProjectTypeAndroid, "Android"},
+		{models.ProjectTypeHugo, "Hugo"},
+		{models.ProjectTypeJekyll, "Jekyll"},
+		{models.ProjectTypeEleventy, "Eleventy"},
+		{models.ProjectTypeWordPress, "WordPress"},
+		{models.ProjectTypeUnknown, "Generic"},
+	}
+
+	for _, tt := range tests {
+		got := ProviderName(GetPatternProvider(tt.projectType))
+		if got != tt.expected {
+			t.Errorf("ProviderName(%s) = %s, want %s", tt.projectType, got, tt.expected)
+		}
+	}
+}
+
+func TestCompileCustomPatterns(t *testing.T) {
+	patterns, err := CompileCustomPatterns([]string{`asset\("([^"]+)"\)`, `icons/\w+\.svg`})
+	if err != nil {
+		t.Fatalf("CompileCustomPatterns() error = %v", err)
+	}
+
+	tests := []struct {
+		pattern  ReferencePattern
+		input    string
+		expected string
+	}{
+		{patterns[0], `img := asset("logo.png")`, "logo.png"},
+		{patterns[1], `<use href="icons/close.svg">`, "icons/close.svg"},
+	}
+
+	for _, tt := range tests {
+		match := tt.pattern.Pattern.FindStringSubmatch(tt.input)
+		if len(match) < 2 || match[1] != tt.expected {
+			t.Errorf("custom pattern on %q = %v, want %s", tt.input, match, tt.expected)
+		}
+		if tt.pattern.Type != CustomPatternType {
+			t.Errorf("Type = %s, want %s", tt.pattern.Type, CustomPatternType)
+		}
+	}
+
+	if _, err := CompileCustomPatterns([]string{`asset(`}); err == nil {
+		t.Error("CompileCustomPatterns() with invalid regex should fail")
+	}
+}
@@ -3,7 +3,7 @@
 // Detects asset references in React and React Native projects including:
 // - Public folder conventions
 // - React.lazy and dynamic imports
-// - Next.js specific patterns
+// - Next.js specific patterns: next/image, static image data, next/font/local
 // - JSX image references
 package parser
 
@@ -16,6 +16,18 @@ var (
 	// Next.js public folder references (implicit /public prefix)
 	NextPublicPattern = regexp.MustCompile(`['"]/(images?|assets?|static|fonts?|videos?|media)/([^'"]+\.(jpg|jpeg|png|gif|svg|webp|ico|ttf|woff|woff2|mp4|mp3))['"]`)
 
+	// next/image with a literal source: <Image src="/hero.png" />, src={'/hero.png'}
+	NextImageSrcPattern = regexp.MustCompile("<Image\\b[^>]*?\\bsrc\\s*=\\s*\\{?\\s*['\"`]([^'\"`]+\\.(jpg|jpeg|png|gif|svg|webp|avif|ico))['\"`]")
+
+	// Static image metadata written out by hand: { src: '/hero.png', width: 800, height: 600 }
+	NextStaticImageDataPattern = regexp.MustCompile(`\bsrc\s*:\s*['"]([^'"]+\.(jpg|jpeg|png|gif|svg|webp|avif|ico))['"]\s*,\s*(?:width|height|blurDataURL)\b`)
+
+	// next/font/local: localFont({ src: './fonts/Inter.woff2' })
+	NextLocalFontPattern = regexp.MustCompile(`localFont\s*\(\s*\{\s*src\s*:\s*['"]([^'"]+\.(woff2|woff|ttf|otf|eot))['"]`)
+
+	// next/font/local font lists: src: [{ path: './fonts/Inter-Bold.woff2', weight: '700' }]
+	NextLocalFontPathPattern = regexp.MustCompile(`\bpath\s*:\s*['"]([^'"]+\.(woff2|woff|ttf|otf|eot))['"]`)
+
 	// Dynamic imports with webpack magic comments
 	WebpackDynamicImport = regexp.MustCompile(`import\s*\(\s*/\*.*?\*/\s*['"]([^'"]+\.(jpg|jpeg|png|svg))['"]`)
 )
@@ -24,20 +36,31 @@ var (
 type ReactPatternProvider struct{}
 
 func (r *ReactPatternProvider) GetPatterns() []ReferencePattern {
-	return []ReferencePattern{
+	return append([]ReferencePattern{
 		// React-specific patterns
 		{Pattern: ReactLazyPattern, Type: "DynamicImport", Confidence: 1.0},
 		{Pattern: NextPublicPattern, Type: "PublicFolder", Confidence: 0.95},
 		{Pattern: WebpackDynamicImport, Type: "DynamicImport", Confidence: 0.9},
+		{Pattern: NextImageSrcPattern, Type: "TemplateBinding", Confidence: 0.95},
+		{Pattern: NextStaticImageDataPattern, Type: "PublicFolder", Confidence: 0.9},
+		{Pattern: NextLocalFontPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: NextLocalFontPathPattern, Type: "Import", Confidence: 1.0},
 
 		// Standard patterns
 		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
 		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: CSSImageSetPattern, Type: "CSSUrl", Confidence: 0.95, List: true},
+		{Pattern: CSSImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLSrcsetPattern, Type: "HTMLAttribute", Confidence: 0.95, List: true},
+		{Pattern: HTMLPosterPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: URLConstructorPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.75},
 		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.75},
-	}
+	}, webMultilinePatterns()...)
 }
 
 func (r *ReactPatternProvider) UseASTParsing() bool {
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// SourceText is a source file held in memory with the offset of every line,
+// so matches anywhere in the content map back to line numbers
+type SourceText struct {
+	Content    string
+	lineStarts []int
+}
+
+// ReadSource reads a whole file into a SourceText. Unlike a line scanner it
+// has no line length limit, so long minified lines are scanned in full.
+func ReadSource(path string) (*SourceText, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSourceText(string(data)), nil
+}
+
+// NewSourceText indexes the line offsets of content
+func NewSourceText(content string) *SourceText {
+	starts := []int{0}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' && i+1 < len(content) {
+			starts = append(starts, i+1)
+		}
+	}
+	if content == "" {
+		starts = nil
+	}
+	return &SourceText{Content: content, lineStarts: starts}
+}
+
+// LineCount returns the number of lines
+func (s *SourceText) LineCount() int {
+	return len(s.lineStarts)
+}
+
+// Line returns line i (0-based) without its line terminator
+func (s *SourceText) Line(i int) string {
+	end := len(s.Content)
+	if i+1 < len(s.lineStarts) {
+		end = s.lineStarts[i+1]
+	}
+	line := s.Content[s.lineStarts[i]:end]
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r")
+}
+
+// LineStart returns the byte offset at which 1-based line begins
+func (s *SourceText) LineStart(line int) int {
+	if line < 1 || line > len(s.lineStarts) {
+		return 0
+	}
+	return s.lineStarts[line-1]
+}
+
+// Column returns the 1-based byte column of offset within its line
+func (s *SourceText) Column(offset int) int {
+	return offset - s.LineStart(s.LineNumber(offset)) + 1
+}
+
+// LineNumber returns the 1-based line containing byte offset
+func (s *SourceText) LineNumber(offset int) int {
+	return sort.Search(len(s.lineStarts), func(i int) bool {
+		return s.lineStarts[i] > offset
+	})
+}
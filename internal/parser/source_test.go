@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSourceText_Lines(t *testing.T) {
+	src := NewSourceText("first\r\nsecond\n\nlast")
+
+	expected := []string{"first", "second", "", "last"}
+	if src.LineCount() != len(expected) {
+		t.Fatalf("LineCount() = %d, want %d", src.LineCount(), len(expected))
+	}
+	for i, want := range expected {
+		if got := src.Line(i); got != want {
+			t.Errorf("Line(%d) = %q, want %q", i, got, want)
+		}
+	}
+
+	if empty := NewSourceText(""); empty.LineCount() != 0 {
+		t.Errorf("LineCount() of empty content = %d, want 0", empty.LineCount())
+	}
+	if trailing := NewSourceText("a\nb\n"); trailing.LineCount() != 2 {
+		t.Errorf("LineCount() with trailing newline = %d, want 2", trailing.LineCount())
+	}
+}
+
+func TestSourceText_LineNumber(t *testing.T) {
+	content := "import a\nfrom './a.png'\n"
+	src := NewSourceText(content)
+
+	tests := []struct {
+		offset   int
+		expected int
+	}{
+		{0, 1},
+		{strings.Index(content, "\n"), 1},
+		{strings.Index(content, "from"), 2},
+		{strings.Index(content, "a.png"), 2},
+	}
+
+	for _, tt := range tests {
+		if got := src.LineNumber(tt.offset); got != tt.expected {
+			t.Errorf("LineNumber(%d) = %d, want %d", tt.offset, got, tt.expected)
+		}
+	}
+}
+
+func TestReadSource_LongLine(t *testing.T) {
+	// Longer than bufio.Scanner's default 64KB token limit
+	line := strings.Repeat("x", 100*1024) + `"assets/logo.png"`
+	path := filepath.Join(t.TempDir(), "bundle.min.js")
+	if err := os.WriteFile(path, []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := ReadSource(path)
+	if err != nil {
+		t.Fatalf("ReadSource() failed: %v", err)
+	}
+	if src.LineCount() != 1 || !strings.HasSuffix(src.Line(0), `"assets/logo.png"`) {
+		t.Errorf("ReadSource() did not keep the full line")
+	}
+}
@@ -0,0 +1,122 @@
+// Package parser - Static site generator patterns
+//
+// Detects asset references in Hugo, Jekyll, and Eleventy sites including:
+// - Hugo resources: resources.Get "images/logo.png", .Resources.GetMatch "cover.jpg"
+// - Hugo URL functions: {{ "img/x.png" | relURL }}, absURL "img/x.png"
+// - Jekyll base URLs: {{ site.baseurl }}/assets/img/x.png
+// - Liquid and Nunjucks URL filters: {{ '/img/x.png' | relative_url }}, | url
+// - Eleventy Image: {% image "./src/img/cat.jpg", "A cat" %}, Image("src/img/x.jpg")
+// - Markdown images: ![alt](/img/diagram.png)
+package parser
+
+import "regexp"
+
+// ssgExts is the alternation of asset extensions the static site patterns match
+const ssgExts = `jpg|jpeg|png|gif|svg|webp|avif|ico|bmp|ttf|woff|woff2|eot|otf|mp4|webm|mov|mp3|wav|ogg|pdf`
+
+var (
+	// Hugo resources from assets/ and page bundles: resources.Get "images/logo.png",
+	// resources.GetMatch "img/*.jpg" (globs don't match), .Resources.GetMatch "cover.jpg"
+	HugoResourcePattern = regexp.MustCompile(`(?:\bresources|\.Resources)\.Get(?:Match)?\s+["` + "`" + `]([^"` + "`" + `*?\[]+\.(` + ssgExts + `))["` + "`" + `]`)
+
+	// Hugo URL functions: {{ absURL "img/x.png" }}, {{ relLangURL "docs/a.pdf" }}
+	HugoURLFunctionPattern = regexp.MustCompile(`\b(?:rel|abs)(?:Lang)?URL\s+"([^"]+\.(` + ssgExts + `))"`)
+
+	// Hugo URL functions in a pipeline: {{ "img/x.png" | relURL }}
+	HugoURLPipePattern = regexp.MustCompile(`"([^"]+\.(` + ssgExts + `))"\s*\|\s*(?:rel|abs)(?:Lang)?URL\b`)
+
+	// Jekyll site variables prefixing a path: {{ site.baseurl }}/assets/x.png,
+	// {{ site.url }}{{ site.baseurl }}/img/x.png
+	JekyllBaseURLPattern = regexp.MustCompile(`\{\{-?\s*site\.(?:baseurl|url)\s*-?\}\}(?:\{\{-?\s*site\.baseurl\s*-?\}\})?(/[^'"\s{}()<>]+\.(` + ssgExts + `))`)
+
+	// Liquid and Nunjucks URL filters: {{ '/img/x.png' | relative_url }},
+	// {{ "/img/x.png" | url }}, {{ 'logo.png' | asset_url }}
+	LiquidURLFilterPattern = regexp.MustCompile(`\{\{-?\s*['"]([^'"]+\.(` + ssgExts + `))['"]\s*\|\s*(?:relative_url|absolute_url|url|asset_url|img_url)\b`)
+
+	// Jekyll tags linking files: {% link /assets/guide.pdf %}, {% asset logo.png %}
+	JekyllLinkTagPattern = regexp.MustCompile(`\{%-?\s*(?:link|asset|asset_path|static_file)\s+['"]?([^'"\s%]+\.(` + ssgExts + `))`)
+
+	// Eleventy Image shortcodes and calls: {% image "./src/img/cat.jpg", "A cat" %},
+	// await Image("src/img/x.jpg", { widths: [300] })
+	EleventyImagePattern = regexp.MustCompile(`(?:\{%-?\s*(?:image|img)\s+|\bImage\s*\(\s*)['"]([^'"]+\.(` + ssgExts + `))['"]`)
+
+	// Markdown images: ![alt](/img/diagram.png "Title")
+	MarkdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+\.(` + ssgExts + `))`)
+)
+
+// ssgStandardPatterns are the standard web patterns the static site
+// providers share, with Markdown images
+func ssgStandardPatterns() []ReferencePattern {
+	return append([]ReferencePattern{
+		{Pattern: MarkdownImagePattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
+		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: CSSImageSetPattern, Type: "CSSUrl", Confidence: 0.95, List: true},
+		{Pattern: CSSImportPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLSrcsetPattern, Type: "HTMLAttribute", Confidence: 0.95, List: true},
+		{Pattern: HTMLPosterPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: URLConstructorPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.75},
+		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.7},
+	}, webMultilinePatterns()...)
+}
+
+// HugoPatternProvider provides patterns for Hugo sites
+type HugoPatternProvider struct{}
+
+func (h *HugoPatternProvider) GetPatterns() []ReferencePattern {
+	return append([]ReferencePattern{
+		{Pattern: HugoResourcePattern, Type: "MediaLoader", Confidence: 0.95},
+		{Pattern: HugoURLFunctionPattern, Type: "StaticFolder", Confidence: 0.95},
+		{Pattern: HugoURLPipePattern, Type: "StaticFolder", Confidence: 0.95},
+	}, ssgStandardPatterns()...)
+}
+
+func (h *HugoPatternProvider) UseASTParsing() bool {
+	return false
+}
+
+func (h *HugoPatternProvider) SupportedFileExtensions() []string {
+	return []string{".html", ".md", ".markdown", ".toml", ".yaml", ".json", ".css", ".scss", ".js", ".ts"}
+}
+
+// JekyllPatternProvider provides patterns for Jekyll sites
+type JekyllPatternProvider struct{}
+
+func (j *JekyllPatternProvider) GetPatterns() []ReferencePattern {
+	return append([]ReferencePattern{
+		{Pattern: JekyllBaseURLPattern, Type: "StaticFolder", Confidence: 0.95},
+		{Pattern: LiquidURLFilterPattern, Type: "StaticFolder", Confidence: 0.95},
+		{Pattern: JekyllLinkTagPattern, Type: "StaticFolder", Confidence: 0.9},
+	}, ssgStandardPatterns()...)
+}
+
+func (j *JekyllPatternProvider) UseASTParsing() bool {
+	return false
+}
+
+func (j *JekyllPatternProvider) SupportedFileExtensions() []string {
+	return []string{".html", ".md", ".markdown", ".liquid", ".yml", ".yaml", ".css", ".scss", ".sass", ".js"}
+}
+
+// EleventyPatternProvider provides patterns for Eleventy sites
+type EleventyPatternProvider struct{}
+
+func (e *EleventyPatternProvider) GetPatterns() []ReferencePattern {
+	return append([]ReferencePattern{
+		{Pattern: EleventyImagePattern, Type: "MediaLoader", Confidence: 0.95},
+		{Pattern: LiquidURLFilterPattern, Type: "StaticFolder", Confidence: 0.95},
+	}, ssgStandardPatterns()...)
+}
+
+func (e *EleventyPatternProvider) UseASTParsing() bool {
+	return false
+}
+
+func (e *EleventyPatternProvider) SupportedFileExtensions() []string {
+	return []string{".html", ".md", ".markdown", ".njk", ".liquid", ".js", ".cjs", ".mjs", ".json", ".css", ".scss"}
+}
@@ -0,0 +1,86 @@
+// Package parser - CSS-in-JS template parsing
+//
+// styled-components and emotion put CSS in tagged template literals:
+//
+//	const Hero = styled.div`
+//	  background: url(./hero.png);
+//	  color: ${(p) => p.color};
+//	`
+//
+// Line patterns see url() there, but other interpolations on the line make the
+// reference look dynamic. This finds the tagged templates as a whole and
+// reports each static url() inside them.
+package parser
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// StyledURLConfidence is the confidence of a url() in a CSS-in-JS template
+const StyledURLConfidence = 0.95
+
+var (
+	// Tags whose template is CSS: styled.div, styled(Button), styled.div.attrs(...),
+	// css, keyframes, createGlobalStyle, injectGlobal
+	styledTagPattern = regexp.MustCompile("(?:\\bstyled(?:\\.\\w+|\\([^()`]*\\))(?:\\.attrs\\([^`]*?\\))?|\\bcss|\\bkeyframes|\\bcreateGlobalStyle|\\binjectGlobal)\\s*`")
+
+	// url(...) inside CSS, quoted or not
+	styledURLPattern = regexp.MustCompile(`url\(\s*['"]?([^'")]+?)['"]?\s*\)`)
+)
+
+// StyledURL is a static url() found in a CSS-in-JS template
+type StyledURL struct {
+	Path       string
+	LineNumber int
+	Offset     int // byte offset of the path
+}
+
+// FindStyledURLs returns the static url() references in every CSS tagged
+// template of src; url(${...}) interpolations are left to the import patterns
+func FindStyledURLs(src *SourceText) []StyledURL {
+	var urls []StyledURL
+
+	for _, loc := range styledTagPattern.FindAllStringIndex(src.Content, -1) {
+		start := loc[1]
+		end := templateEnd(src.Content, start)
+
+		body := src.Content[start:end]
+		for _, m := range styledURLPattern.FindAllStringSubmatchIndex(body, -1) {
+			url := body[m[2]:m[3]]
+			if strings.Contains(url, "${") || strings.HasPrefix(url, "data:") || path.Ext(url) == "" {
+				continue
+			}
+			urls = append(urls, StyledURL{Path: url, LineNumber: src.LineNumber(start + m[2]), Offset: start + m[2]})
+		}
+	}
+
+	return urls
+}
+
+// templateEnd returns the offset of the backtick closing the template literal
+// whose body starts at start, skipping escapes and ${...} interpolations
+func templateEnd(content string, start int) int {
+	for i := start; i < len(content); i++ {
+		switch {
+		case content[i] == '\\':
+			i++
+		case content[i] == '`':
+			return i
+		case strings.HasPrefix(content[i:], "${"):
+			depth := 0
+			for ; i < len(content); i++ {
+				if content[i] == '{' {
+					depth++
+				} else if content[i] == '}' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+		}
+	}
+	return len(content)
+}
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindStyledURLs(t *testing.T) {
+	src := NewSourceText("import bg from './bg.png';\n" +
+		"const Hero = styled.div`\n" +
+		"  background: url(./hero.png) no-repeat;\n" +
+		"  color: ${(p) => (p.dark ? '#fff' : '#000')};\n" +
+		"  cursor: url('cursors/hand.svg'), auto; border-image: url(${bg});\n" +
+		"`;\n" +
+		"const card = css`background-image: url(\"card.webp\"); width: ${w}px;`;\n" +
+		"const text = `not css url(ignored.png)`;\n" +
+		"const Button = styled(Base).attrs({ type: 'button' })`mask: url(icons/mask.svg);`;\n")
+
+	var got []StyledURL
+	got = append(got, FindStyledURLs(src)...)
+
+	expected := []StyledURL{
+		{Path: "./hero.png", LineNumber: 3, Offset: 70},
+		{Path: "cursors/hand.svg", LineNumber: 5, Offset: 155},
+		{Path: "card.webp", LineNumber: 7, Offset: 250},
+		{Path: "icons/mask.svg", LineNumber: 9, Offset: 385},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("FindStyledURLs() = %+v, want %+v", got, expected)
+	}
+	for _, url := range got {
+		if !strings.HasPrefix(src.Content[url.Offset:], url.Path) {
+			t.Errorf("Offset %d of %s points at %q", url.Offset, url.Path, src.Content[url.Offset:url.Offset+len(url.Path)])
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package parser - Svelte-specific patterns
+//
+// Detects asset references in Svelte and SvelteKit projects including:
+// - {@html} blocks with embedded markup
+// - Attribute expressions: <img src={'/logo.png'}>
+// - $app/paths base and assets concatenation
+// - %sveltekit.assets% in src/app.html (static/ served at the site root)
+package parser
+
+import "regexp"
+
+var (
+	// URLs inside markup rendered with {@html '<img src="/hero.png">'}
+	SvelteHTMLTagPattern = regexp.MustCompile(`\{@html\s[^}]*?\b(?:src|href|poster)\s*=\s*\\?['"]([^'"\\\s]+\.(jpg|jpeg|png|gif|svg|webp|avif|ico|mp4|webm|mp3|wav))`)
+
+	// Attribute expressions with a literal: src={'/logo.png'}, poster={"intro.jpg"}
+	SvelteAttributeExpressionPattern = regexp.MustCompile("\\b(?:src|href|poster)\\s*=\\s*\\{\\s*['\"`]([^'\"`{}$]+\\.(jpg|jpeg|png|gif|svg|webp|avif|ico|mp4|webm|mp3|wav))['\"`]\\s*\\}")
+
+	// SvelteKit paths: src="{base}/img/logo.png", `${assets}/logo.png`, base + '/logo.png'
+	SvelteKitBasePathPattern = regexp.MustCompile("(?:\\{\\s*(?:base|assets)\\s*\\}|\\$\\{\\s*(?:base|assets)\\s*\\}|\\b(?:base|assets)\\s*\\+\\s*['\"`])(/[^'\"`\\s{}]+\\.(jpg|jpeg|png|gif|svg|webp|avif|ico|ttf|woff|woff2|mp4|webm|mp3|wav))")
+
+	// src/app.html placeholder for the static/ directory: href="%sveltekit.assets%/favicon.png"
+	SvelteKitAssetsPlaceholderPattern = regexp.MustCompile(`%sveltekit\.assets%(/[^'"\s]+\.(jpg|jpeg|png|gif|svg|webp|avif|ico|webmanifest|json))`)
+)
+
+// SveltePatternProvider provides patterns for Svelte projects
+type SveltePatternProvider struct{}
+
+func (s *SveltePatternProvider) GetPatterns() []ReferencePattern {
+	return append([]ReferencePattern{
+		// Svelte-specific patterns
+		{Pattern: SvelteHTMLTagPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: SvelteAttributeExpressionPattern, Type: "TemplateBinding", Confidence: 0.95},
+		{Pattern: SvelteKitBasePathPattern, Type: "StaticFolder", Confidence: 0.9},
+		{Pattern: SvelteKitAssetsPlaceholderPattern, Type: "StaticFolder", Confidence: 0.95},
+
+		// Standard patterns
+		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
+		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: CSSImageSetPattern, Type: "CSSUrl", Confidence: 0.95, List: true},
+		{Pattern: CSSImportPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLSrcsetPattern, Type: "HTMLAttribute", Confidence: 0.95, List: true},
+		{Pattern: HTMLPosterPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: URLConstructorPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.75},
+		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.75},
+	}, webMultilinePatterns()...)
+}
+
+func (s *SveltePatternProvider) UseASTParsing() bool {
+	return true // Svelte uses JS/TS, benefit from AST parsing
+}
+
+func (s *SveltePatternProvider) SupportedFileExtensions() []string {
+	return []string{".js", ".ts", ".svelte", ".html", ".css", ".scss", ".sass", ".less"}
+}
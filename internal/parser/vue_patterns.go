@@ -27,7 +27,7 @@ var (
 type VuePatternProvider struct{}
 
 func (v *VuePatternProvider) GetPatterns() []ReferencePattern {
-	return []ReferencePattern{
+	return append([]ReferencePattern{
 		// Vue-specific patterns
 		{Pattern: VueAsyncComponentPattern, Type: "AsyncComponent", Confidence: 1.0},
 		{Pattern: VueTemplateImgPattern, Type: "TemplateBinding", Confidence: 0.95},
@@ -38,10 +38,17 @@ func (v *VuePatternProvider) GetPatterns() []ReferencePattern {
 		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
 		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: CSSImageSetPattern, Type: "CSSUrl", Confidence: 0.95, List: true},
+		{Pattern: CSSImportPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLSrcsetPattern, Type: "HTMLAttribute", Confidence: 0.95, List: true},
+		{Pattern: HTMLPosterPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: URLConstructorPattern, Type: "Import", Confidence: 1.0},
 		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.75},
 		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.75},
-	}
+	}, webMultilinePatterns()...)
 }
 
 func (v *VuePatternProvider) UseASTParsing() bool {
@@ -0,0 +1,73 @@
+// Package parser - WordPress theme and plugin patterns
+//
+// Detects asset references in WordPress themes and plugins including:
+// - Theme URIs: get_template_directory_uri() . '/img/x.png', get_theme_file_uri('img/x.png')
+// - Template output: <?php echo get_stylesheet_directory_uri(); ?>/img/x.png
+// - Plugin URLs: plugins_url('img/x.png', __FILE__), plugin_dir_url(__FILE__) . 'img/x.png'
+// - Enqueued files: wp_enqueue_style('main', $uri . '/css/main.css')
+//
+// The paths are relative to the theme or plugin directory, which the scanner
+// finds among the referencing file's parents.
+package parser
+
+import "regexp"
+
+// wpExts is the alternation of file extensions the WordPress patterns match
+const wpExts = `jpg|jpeg|png|gif|svg|webp|avif|ico|ttf|woff|woff2|eot|otf|mp4|webm|mp3|wav|pdf|css|js|json`
+
+var (
+	// Theme and plugin directories concatenated with a path:
+	// get_template_directory_uri() . '/img/x.png', plugin_dir_url( __FILE__ ) . 'img/x.png'
+	WPDirectoryConcatPattern = regexp.MustCompile(`\b(?:get_(?:template|stylesheet)_directory(?:_uri)?\s*\(\s*\)|plugin_dir_(?:url|path)\s*\((?:[^()]|\([^()]*\))*\))\s*\.\s*['"]([^'"]+\.(` + wpExts + `))['"]`)
+
+	// Theme directory echoed into markup before the path:
+	// <?php echo esc_url( get_template_directory_uri() ); ?>/img/x.png, <?php bloginfo('template_url'); ?>/img/x.png
+	WPDirectoryEchoPattern = regexp.MustCompile(`(?:\bget_(?:template|stylesheet)_directory_uri\s*\(\s*\)|\bbloginfo\s*\(\s*['"](?:template_url|template_directory|stylesheet_url|stylesheet_directory)['"]\s*\))\s*\)?\s*;?\s*\?>(/[^'"\s<>?#]+\.(` + wpExts + `))`)
+
+	// Theme file functions: get_theme_file_uri('img/x.png'), get_parent_theme_file_path('/fonts/a.woff2')
+	WPThemeFilePattern = regexp.MustCompile(`\bget_(?:parent_)?theme_file_(?:uri|path)\s*\(\s*['"]([^'"]+\.(` + wpExts + `))['"]`)
+
+	// Plugin URLs: plugins_url('img/x.png', __FILE__)
+	WPPluginsURLPattern = regexp.MustCompile(`\bplugins_url\s*\(\s*['"]([^'"]+\.(` + wpExts + `))['"]`)
+
+	// Stylesheets and scripts registered with any concatenation:
+	// wp_enqueue_style('main', $theme_uri . '/css/main.css', [], '1.0')
+	WPEnqueuePattern = regexp.MustCompile(`\bwp_(?:enqueue|register)_(?:style|script)\s*\(\s*['"][^'"]*['"]\s*,[^;]*?['"]([^'"]+\.(css|js|mjs))['"]`)
+)
+
+// WordPressPatternProvider provides patterns for WordPress themes and plugins
+type WordPressPatternProvider struct{}
+
+func (w *WordPressPatternProvider) GetPatterns() []ReferencePattern {
+	return append([]ReferencePattern{
+		// WordPress-specific patterns
+		{Pattern: WPDirectoryConcatPattern, Type: "WordPressAsset", Confidence: 0.95},
+		{Pattern: WPDirectoryEchoPattern, Type: "WordPressAsset", Confidence: 0.95},
+		{Pattern: WPThemeFilePattern, Type: "WordPressAsset", Confidence: 0.95},
+		{Pattern: WPPluginsURLPattern, Type: "WordPressAsset", Confidence: 0.95},
+		{Pattern: WPEnqueuePattern, Type: "WordPressAsset", Confidence: 0.9},
+
+		// Standard patterns
+		{Pattern: CSSUrlPattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: CSSImageSetPattern, Type: "CSSUrl", Confidence: 0.95, List: true},
+		{Pattern: CSSImportPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: HTMLSrcPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLSrcsetPattern, Type: "HTMLAttribute", Confidence: 0.95, List: true},
+		{Pattern: HTMLPosterPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLLinkPattern, Type: "HTMLAttribute", Confidence: 0.95},
+		{Pattern: HTMLInlineStylePattern, Type: "CSSUrl", Confidence: 0.95},
+		{Pattern: ImportPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: RequirePattern, Type: "Import", Confidence: 1.0},
+		{Pattern: URLConstructorPattern, Type: "Import", Confidence: 1.0},
+		{Pattern: StringLiteralPattern, Type: "StringLiteral", Confidence: 0.7},
+		{Pattern: TemplateLiteralPattern, Type: "TemplateLiteral", Confidence: 0.7},
+	}, webMultilinePatterns()...)
+}
+
+func (w *WordPressPatternProvider) UseASTParsing() bool {
+	return false
+}
+
+func (w *WordPressPatternProvider) SupportedFileExtensions() []string {
+	return []string{".php", ".css", ".scss", ".js", ".html", ".json"}
+}
@@ -10,15 +10,22 @@ package scanner
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/HabibPro1999/easyClean/internal/imagemeta"
 	"github.com/HabibPro1999/easyClean/internal/models"
 	"github.com/HabibPro1999/easyClean/internal/utils"
 )
 
 // AssetFinder scans the filesystem for asset files
 type AssetFinder struct {
-	config *models.ProjectConfig
-	root   string
+	config       *models.ProjectConfig
+	root         string
+	keepPatterns []string
+	warnings     []string
+	tree         *FileTree // shared walk, when set
+	animations   []*lottieAnimation
+	throttle     *utils.Throttle
 }
 
 // NewAssetFinder creates a new AssetFinder instance
@@ -29,54 +36,111 @@ func NewAssetFinder(root string, config *models.ProjectConfig) *AssetFinder {
 	}
 }
 
+// SetFileTree has FindAssets use a walk shared with the ReferenceFinder
+// instead of walking the project itself
+func (af *AssetFinder) SetFileTree(tree *FileTree) {
+	af.tree = tree
+}
+
+// SetThrottle has asset contents (Lottie JSON, sniffed types, image
+// metadata, hashes of embedded copies) read within the limits of throttle,
+// with a pause after each directory FindAssets visits
+func (af *AssetFinder) SetThrottle(throttle *utils.Throttle) {
+	af.throttle = throttle
+}
+
 // FindAssets walks the filesystem and collects all asset files
 func (af *AssetFinder) FindAssets() ([]models.AssetFile, error) {
-	assets := []models.AssetFile{}
-
-	err := filepath.WalkDir(af.root, func(path string, d os.DirEntry, err error) error {
+	tree := af.tree
+	if tree == nil {
+		walked, err := WalkTree(af.root, af.config)
 		if err != nil {
-			// Skip paths we can't access
-			return nil
+			return nil, err
 		}
+		tree = walked
+	}
 
-		// Skip symlinks unless configured to follow them
-		if !af.config.FollowSymlinks && utils.IsSymlink(path) {
-			if d.IsDir() {
-				return filepath.SkipDir
+	assets := []models.AssetFile{}
+	lastDir := ""
+	for _, path := range tree.Files() {
+		name := filepath.Base(path)
+		if dir := filepath.Dir(path); dir != lastDir {
+			if lastDir != "" {
+				af.throttle.Pause()
 			}
-			return nil
+			lastDir = dir
 		}
 
-		// Check if this directory should be excluded
-		if d.IsDir() {
-			if shouldExcludeDir(path, af.root, af.config.ExcludePaths) {
-				return filepath.SkipDir
+		// Collect keep globs from sidecar files
+		if name == KeepFileName {
+			if patterns, err := parseKeepFile(path, af.root); err == nil {
+				af.keepPatterns = append(af.keepPatterns, patterns...)
 			}
-			return nil
+			continue
 		}
 
 		// Check if this file is an asset
-		if af.isAssetFile(path) {
-			asset, err := af.createAssetFile(path)
-			if err == nil {
-				assets = append(assets, asset)
-			}
+		if shouldSkipFile(name, af.config) {
+			continue
+		}
+		if asset, ok := af.readAsset(path, tree.extensionsFor(path, af.config)); ok {
+			assets = append(assets, asset)
 		}
+	}
+	markEmbeddedCopies(af.root, assets, af.animations, af.throttle)
 
-		return nil
-	})
+	af.warnings = tree.Warnings()
+	return assets, nil
+}
 
+// readAsset returns the asset at path if it is one: a file with one of
+// extensions, a Lottie animation, or a sniffed extension-less file
+func (af *AssetFinder) readAsset(path string, extensions []string) (models.AssetFile, bool) {
+	af.throttle.Acquire()
+	defer af.throttle.Release()
+
+	var animation *lottieAnimation
+	if strings.EqualFold(filepath.Ext(path), ".json") && af.inAssetPaths(path) {
+		animation = readLottie(path)
+	}
+	sniffed := ""
+	if !isAssetFile(path, extensions) && animation == nil {
+		if sniffed = af.sniffAssetType(path, extensions); sniffed == "" {
+			return models.AssetFile{}, false
+		}
+	}
+	asset, err := af.createAssetFile(path, sniffed)
 	if err != nil {
-		return nil, err
+		return models.AssetFile{}, false
 	}
+	if animation != nil {
+		asset.Category = models.CategoryAnimation
+		af.animations = append(af.animations, animation)
+	}
+	return asset, true
+}
 
-	return assets, nil
+// LottieReferences returns the image files the Lottie animations found by
+// FindAssets load, keyed by absolute path like ReferenceFinder.FindReferences
+func (af *AssetFinder) LottieReferences() map[string][]*models.Reference {
+	return lottieReferences(af.animations)
+}
+
+// Warnings describes the traversal limits (max_depth, max_files) the last
+// FindAssets hit
+func (af *AssetFinder) Warnings() []string {
+	return af.warnings
+}
+
+// KeepPatterns returns keep globs collected from .easycleankeep files during FindAssets
+func (af *AssetFinder) KeepPatterns() []string {
+	return af.keepPatterns
 }
 
 // isAssetFile checks if a file is an asset based on extension
-func (af *AssetFinder) isAssetFile(path string) bool {
+func isAssetFile(path string, extensions []string) bool {
 	ext := filepath.Ext(path)
-	for _, e := range af.config.Extensions {
+	for _, e := range extensions {
 		if ext == e {
 			return true
 		}
@@ -84,8 +148,31 @@ func (af *AssetFinder) isAssetFile(path string) bool {
 	return false
 }
 
-// createAssetFile creates an AssetFile struct from a file path
-func (af *AssetFinder) createAssetFile(path string) (models.AssetFile, error) {
+// sniffAssetType returns the extension the content of an extension-less file
+// in an asset path matches, when sniff_content is set and it is one of
+// extensions
+func (af *AssetFinder) sniffAssetType(path string, extensions []string) string {
+	if !af.config.SniffContent || filepath.Ext(path) != "" || !af.inAssetPaths(path) {
+		return ""
+	}
+	ext := sniffExtension(path)
+	for _, e := range extensions {
+		if ext != "" && strings.EqualFold(e, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// inAssetPaths reports whether the file at path lies in a configured asset path
+func (af *AssetFinder) inAssetPaths(path string) bool {
+	rel, err := filepath.Rel(af.root, path)
+	return err == nil && inAssetPaths(filepath.ToSlash(rel), af.config.AssetPaths)
+}
+
+// createAssetFile creates an AssetFile struct from a file path; sniffed is
+// the type detected from the content of an extension-less file, if any
+func (af *AssetFinder) createAssetFile(path, sniffed string) (models.AssetFile, error) {
 	info, err := os.Stat(path)
 	if err != nil {
 		return models.AssetFile{}, err
@@ -99,23 +186,61 @@ func (af *AssetFinder) createAssetFile(path string) (models.AssetFile, error) {
 	ext := filepath.Ext(path)
 	name := filepath.Base(path)
 
-	return models.AssetFile{
+	asset := models.AssetFile{
 		Path:         path,
 		RelativePath: relPath,
 		Name:         name,
 		Extension:    ext,
+		SniffedType:  sniffed,
 		Size:         info.Size(),
 		ModTime:      info.ModTime(),
-		Category:     models.DetermineCategoryFromExtension(ext),
 		Status:       models.StatusUnused, // Default status, will be updated during classification
 		References:   []*models.Reference{},
 		RefCount:     0,
-	}, nil
+	}
+	asset.Category = af.config.CategoryFor(asset.TypeExtension())
+
+	populateImageMetadata(&asset)
+	if af.config.PrivacyScan {
+		populatePrivacyFlags(&asset)
+	}
+
+	return asset, nil
+}
+
+// populateImageMetadata fills dimensions, frames, and color profile for image assets
+func populateImageMetadata(asset *models.AssetFile) {
+	if asset.Category != models.CategoryImage || !imagemeta.Supported(asset.TypeExtension()) {
+		return
+	}
+
+	info, err := imagemeta.Decode(asset.Path)
+	if err != nil {
+		return
+	}
+
+	asset.Width = info.Width
+	asset.Height = info.Height
+	asset.Frames = info.Frames
+	asset.ColorProfile = info.ColorProfile
+}
+
+// populatePrivacyFlags records EXIF GPS/serial metadata on image assets
+func populatePrivacyFlags(asset *models.AssetFile) {
+	if asset.Category != models.CategoryImage {
+		return
+	}
+
+	flags, err := imagemeta.CheckPrivacy(asset.Path)
+	if err == nil && len(flags) > 0 {
+		asset.PrivacyFlags = flags
+	}
 }
 
 // CountAssets returns the estimated number of asset files without collecting them
 func (af *AssetFinder) CountAssets() (int, error) {
 	count := 0
+	guard := newWalkGuard(af.root, af.config)
 
 	err := filepath.WalkDir(af.root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -123,13 +248,16 @@ func (af *AssetFinder) CountAssets() (int, error) {
 		}
 
 		if d.IsDir() {
-			if shouldExcludeDir(path, af.root, af.config.ExcludePaths) {
+			if shouldSkipDir(path, af.root, af.config) || !guard.enterDir(path) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
+		if !guard.visitFile() {
+			return filepath.SkipAll
+		}
 
-		if af.isAssetFile(path) {
+		if !shouldSkipFile(d.Name(), af.config) && isAssetFile(path, af.config.Extensions) {
 			count++
 		}
 
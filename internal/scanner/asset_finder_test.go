@@ -1,12 +1,18 @@
 package scanner
 
 import (
+	"image"
+	"image/png"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/HabibPro1999/easyClean/internal/config"
 	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
 )
 
 func TestAssetFinder_FindAssets(t *testing.T) {
@@ -77,6 +83,124 @@ func TestAssetFinder_ExcludeDirectories(t *testing.T) {
 	}
 }
 
+func TestAssetFinder_HiddenFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	createTestFile(t, filepath.Join(tmpDir, "public", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "public", ".hidden.png"))
+	createTestFile(t, filepath.Join(tmpDir, "public", ".well-known", "badge.png"))
+	createTestFile(t, filepath.Join(tmpDir, ".cache", "thumb.png"))
+	createTestFile(t, filepath.Join(tmpDir, ".vitepress", "public", "hero.png"))
+
+	tests := []struct {
+		name          string
+		includeHidden bool
+		scanDotfiles  bool
+		want          []string
+	}{
+		{"defaults", false, false, []string{".vitepress/public/hero.png", "public/.well-known/badge.png", "public/logo.png"}},
+		{"include hidden", true, false, []string{".cache/thumb.png", ".vitepress/public/hero.png", "public/.well-known/badge.png", "public/logo.png"}},
+		{"scan dotfiles", false, true, []string{".vitepress/public/hero.png", "public/.hidden.png", "public/.well-known/badge.png", "public/logo.png"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Extensions = []string{".png"}
+			cfg.AssetPaths = []string{"public/", ".vitepress/public/"}
+			cfg.IncludeHidden = tt.includeHidden
+			cfg.ScanDotfiles = tt.scanDotfiles
+
+			finder := NewAssetFinder(tmpDir, cfg)
+			assets, err := finder.FindAssets()
+			if err != nil {
+				t.Fatalf("FindAssets() failed: %v", err)
+			}
+
+			var got []string
+			for _, asset := range assets {
+				got = append(got, filepath.ToSlash(asset.RelativePath))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAssets() = %v, want %v", got, tt.want)
+			}
+
+			if count, _ := finder.CountAssets(); count != len(tt.want) {
+				t.Errorf("CountAssets() = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestAssetFinder_WalkLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	createTestFile(t, filepath.Join(tmpDir, "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "assets", "icon.png"))
+	createTestFile(t, filepath.Join(tmpDir, "assets", "deep", "nested", "bg.png"))
+
+	cfg := config.DefaultConfig()
+	cfg.Extensions = []string{".png"}
+	cfg.MaxDepth = 1
+
+	finder := NewAssetFinder(tmpDir, cfg)
+	assets, err := finder.FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	if len(assets) != 2 {
+		t.Errorf("Expected 2 assets within max_depth, got %d", len(assets))
+	}
+	if warnings := finder.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "max_depth (1)") {
+		t.Errorf("Warnings() = %v, want a max_depth warning", warnings)
+	}
+
+	cfg.MaxDepth = 0
+	cfg.MaxFiles = 2
+	assets, err = finder.FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	if len(assets) != 2 {
+		t.Errorf("Expected 2 assets within max_files, got %d", len(assets))
+	}
+	if warnings := finder.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "max_files (2)") {
+		t.Errorf("Warnings() = %v, want a max_files warning", warnings)
+	}
+
+	cfg.MaxFiles = 0
+	if _, err := finder.FindAssets(); err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	if warnings := finder.Warnings(); len(warnings) != 0 {
+		t.Errorf("Warnings() = %v, want none without limits", warnings)
+	}
+}
+
+func TestIsSkippedPath(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "project")
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{".vitepress/public/"}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"src/App.tsx", false},
+		{"node_modules/pkg/logo.png", true},
+		{".cache/thumb.png", true},
+		{".vitepress/config.ts", false},
+		{".vitepress/public/hero.png", false},
+		{"public/.well-known/badge.png", false},
+		{"public/.hidden.png", true},
+	}
+	for _, tt := range tests {
+		if got := IsSkippedPath(filepath.Join(root, tt.path), root, cfg); got != tt.want {
+			t.Errorf("IsSkippedPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
 func TestAssetFinder_CountAssets(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -177,3 +301,67 @@ func createTestFile(t *testing.T, path string) {
 		t.Fatalf("Failed to create file %s: %v", path, err)
 	}
 }
+
+func TestAssetFinder_ImageMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	path := filepath.Join(tmpDir, "logo.png")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if err := png.Encode(file, image.NewRGBA(image.Rect(0, 0, 64, 32))); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+	file.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Extensions = []string{".png"}
+
+	assets, err := NewAssetFinder(tmpDir, cfg).FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+
+	if len(assets) != 1 {
+		t.Fatalf("Expected 1 asset, got %d", len(assets))
+	}
+	if assets[0].Width != 64 || assets[0].Height != 32 {
+		t.Errorf("Expected 64x32 dimensions, got %dx%d", assets[0].Width, assets[0].Height)
+	}
+}
+
+func TestAssetFinder_Throttle(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{"a", "b", "c"} {
+		path := filepath.Join(tmpDir, dir, "logo.png")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+		if err := png.Encode(file, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+			t.Fatalf("Failed to encode PNG: %v", err)
+		}
+		file.Close()
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Extensions = []string{".png"}
+	finder := NewAssetFinder(tmpDir, cfg)
+	finder.SetThrottle(utils.NewThrottle(1, 20*time.Millisecond))
+
+	start := time.Now()
+	assets, err := finder.FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("FindAssets() took %v, want a pause between each of 3 directories", elapsed)
+	}
+	if len(assets) != 3 || assets[0].Width != 8 {
+		t.Errorf("Expected 3 assets with metadata, got %+v", assets)
+	}
+}
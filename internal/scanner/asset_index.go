@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// AssetIndex looks up the assets a scan discovered by absolute path,
+// root-relative path, and file name, so resolving a reference doesn't walk
+// the asset directories again
+type AssetIndex struct {
+	byPath     map[string]bool
+	byRelative map[string]string   // slash-separated relative path -> absolute path
+	byName     map[string][]string // file name -> absolute paths, sorted
+}
+
+// NewAssetIndex indexes the assets returned by AssetFinder.FindAssets
+func NewAssetIndex(assets []models.AssetFile) *AssetIndex {
+	index := &AssetIndex{
+		byPath:     make(map[string]bool, len(assets)),
+		byRelative: make(map[string]string, len(assets)),
+		byName:     make(map[string][]string),
+	}
+	for _, asset := range assets {
+		index.byPath[asset.Path] = true
+		index.byRelative[filepath.ToSlash(asset.RelativePath)] = asset.Path
+		name := filepath.Base(asset.Path)
+		index.byName[name] = append(index.byName[name], asset.Path)
+	}
+	for _, paths := range index.byName {
+		sort.Strings(paths)
+	}
+	return index
+}
+
+// Has reports whether path is an indexed asset
+func (ix *AssetIndex) Has(path string) bool {
+	return ix.byPath[path]
+}
+
+// ByRelativePath returns the asset at a root-relative path, or ""
+func (ix *AssetIndex) ByRelativePath(rel string) string {
+	return ix.byRelative[filepath.ToSlash(rel)]
+}
+
+// FindByName returns the first asset named name below dir, or ""
+func (ix *AssetIndex) FindByName(dir, name string) string {
+	prefix := strings.TrimSuffix(dir, string(filepath.Separator)) + string(filepath.Separator)
+	for _, path := range ix.byName[name] {
+		if strings.HasPrefix(path, prefix) {
+			return path
+		}
+	}
+	return ""
+}
+
+// Len returns how many assets are indexed
+func (ix *AssetIndex) Len() int {
+	return len(ix.byPath)
+}
@@ -0,0 +1,393 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// checkpointEvery is how many source files FindReferences reads between
+// checkpoint shards, so a killed scan loses at most that much work
+const checkpointEvery = 1000
+
+const (
+	checkpointManifest = "checkpoint.json"
+	checkpointShards   = "shard-*.gob"
+)
+
+// Checkpoint persists the progress of FindReferences: each directory whose
+// source files were all read is written to a shard with the references
+// found in it, so an interrupted scan can resume without rereading them.
+// A directory is only reused if its source files, and the files elsewhere
+// its references were resolved with, are unchanged.
+type Checkpoint struct {
+	dir         string
+	fingerprint string
+	completed   map[string]*checkpointDir // restored, by directory
+	pending     []*checkpointDir          // completed since the last shard
+	shards      int
+}
+
+// checkpointDir is the recorded work of one directory
+type checkpointDir struct {
+	Dir          string
+	Files        []checkpointFile
+	References   []*models.Reference
+	ByAsset      map[string][]int // indexes into References, by resolved asset path
+	KeepPatterns []string
+	FilesScanned int
+	BytesRead    int64
+	Inputs       string // digest of the cross-file inputs, see checkpointRun.inputs
+
+	stored map[*models.Reference]int // References index of each reference, while recording
+}
+
+// checkpointFile identifies the version of a source file a directory's
+// references were read from
+type checkpointFile struct {
+	Path    string
+	Size    int64
+	ModTime int64
+}
+
+// checkpointHeader ties the shards to the config and assets they were found with
+type checkpointHeader struct {
+	Fingerprint string `json:"fingerprint"`
+}
+
+// CheckpointFingerprint identifies what references are resolved against:
+// the effective config and the assets found. A checkpoint written with a
+// different fingerprint is discarded; inputs shared across directories are
+// checked per directory as it is resumed.
+func CheckpointFingerprint(config *models.ProjectConfig, assets []models.AssetFile) string {
+	// Output and throttling settings don't change what is found
+	effective := *config
+	effective.Verbose, effective.ShowProgress, effective.ColorOutput = false, false, false
+	effective.IOThrottle = models.IOThrottle{}
+
+	hash := sha256.New()
+	if data, err := json.Marshal(&effective); err == nil {
+		hash.Write(data)
+	}
+	paths := make([]string, len(assets))
+	for i, asset := range assets {
+		paths[i] = asset.RelativePath
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		hash.Write([]byte("\x00" + path))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// OpenCheckpoint prepares the checkpoint directory dir. With resume, the
+// shards of an earlier scan with the same fingerprint are loaded; otherwise
+// any earlier checkpoint is discarded.
+func OpenCheckpoint(dir, fingerprint string, resume bool) (*Checkpoint, error) {
+	cp := &Checkpoint{dir: dir, fingerprint: fingerprint, completed: make(map[string]*checkpointDir)}
+
+	if resume {
+		if err := cp.load(); err != nil {
+			return nil, err
+		}
+		if len(cp.completed) > 0 {
+			return cp, nil
+		}
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("failed to clear checkpoint: %w", err)
+	}
+	if err := utils.EnsureCacheDirExists(dir); err != nil {
+		return nil, err
+	}
+	err := utils.WriteCacheFile(filepath.Join(dir, checkpointManifest), false, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(checkpointHeader{Fingerprint: fingerprint})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// load reads the shards of a checkpoint with a matching fingerprint; a
+// missing or stale checkpoint loads nothing
+func (cp *Checkpoint) load() error {
+	header, err := readCheckpointHeader(filepath.Join(cp.dir, checkpointManifest))
+	if err != nil || header.Fingerprint != cp.fingerprint {
+		return nil
+	}
+
+	shards, err := filepath.Glob(filepath.Join(cp.dir, checkpointShards))
+	if err != nil {
+		return err
+	}
+	sort.Strings(shards)
+	for _, shard := range shards {
+		dirs, err := readCheckpointShard(shard)
+		if err != nil {
+			// Its directories are simply read again
+			continue
+		}
+		for _, dir := range dirs {
+			cp.completed[dir.Dir] = dir
+		}
+	}
+	cp.shards = len(shards)
+	return nil
+}
+
+// Restored returns how many directories the checkpoint holds from an earlier scan
+func (cp *Checkpoint) Restored() int {
+	return len(cp.completed)
+}
+
+// reusable returns the restored record of dir if files, its source files
+// in this scan, are the ones the record was read from, with the same
+// cross-file inputs
+func (cp *Checkpoint) reusable(dir string, files []string, inputs string) *checkpointDir {
+	record := cp.completed[dir]
+	if record == nil || len(record.Files) != len(files) || record.Inputs != inputs {
+		return nil
+	}
+	for i, file := range files {
+		recorded := record.Files[i]
+		info, err := os.Stat(file)
+		if err != nil || recorded.Path != file || recorded.Size != info.Size() || recorded.ModTime != info.ModTime().UnixNano() {
+			return nil
+		}
+	}
+	return record
+}
+
+// complete queues the record of a directory whose files were all read
+func (cp *Checkpoint) complete(record *checkpointDir) {
+	cp.pending = append(cp.pending, record)
+}
+
+// add records a reference to assetPath found in the directory. A
+// reference recorded under several asset paths is stored once, as
+// MatchReferencesToAssets counts references by identity.
+func (record *checkpointDir) add(assetPath string, ref *models.Reference) {
+	if record.ByAsset == nil {
+		record.ByAsset = make(map[string][]int)
+		record.stored = make(map[*models.Reference]int)
+	}
+	i, ok := record.stored[ref]
+	if !ok {
+		i = len(record.References)
+		record.References = append(record.References, ref)
+		record.stored[ref] = i
+	}
+	record.ByAsset[assetPath] = append(record.ByAsset[assetPath], i)
+}
+
+// statCheckpointFile records the version of a source file about to be read
+func statCheckpointFile(path string) checkpointFile {
+	file := checkpointFile{Path: path}
+	if info, err := os.Stat(path); err == nil {
+		file.Size, file.ModTime = info.Size(), info.ModTime().UnixNano()
+	}
+	return file
+}
+
+// Flush writes the directories completed since the last call to a new shard
+func (cp *Checkpoint) Flush() error {
+	if len(cp.pending) == 0 {
+		return nil
+	}
+	cp.shards++
+	path := filepath.Join(cp.dir, fmt.Sprintf("shard-%06d.gob", cp.shards))
+	err := utils.WriteCacheFile(path, true, func(w io.Writer) error {
+		return gob.NewEncoder(w).Encode(cp.pending)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	cp.pending = nil
+	return nil
+}
+
+// Remove deletes the checkpoint once the scan it records has finished
+func (cp *Checkpoint) Remove() error {
+	return os.RemoveAll(cp.dir)
+}
+
+// readCheckpointHeader reads the manifest of a checkpoint
+func readCheckpointHeader(path string) (checkpointHeader, error) {
+	var header checkpointHeader
+	f, err := utils.OpenMaybeGzip(path)
+	if err != nil {
+		return header, err
+	}
+	defer f.Close()
+	err = json.NewDecoder(f).Decode(&header)
+	return header, err
+}
+
+// readCheckpointShard reads the directories one shard recorded
+func readCheckpointShard(path string) ([]*checkpointDir, error) {
+	f, err := utils.OpenMaybeGzip(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var dirs []*checkpointDir
+	if err := gob.NewDecoder(f).Decode(&dirs); err != nil {
+		return nil, fmt.Errorf("corrupt checkpoint shard %s: %w", filepath.Base(path), err)
+	}
+	return dirs, nil
+}
+
+// checkpointRun tracks one FindReferences pass over the source files in
+// walk order, in which a directory's files are not contiguous
+type checkpointRun struct {
+	cp        *Checkpoint
+	reused    map[string]*checkpointDir // directories restored, until merged
+	skipped   map[string]bool           // directories restored
+	remaining map[string]int            // files left to read per directory
+	records   map[string]*checkpointDir // directories being read
+	read      int                       // files read since the last shard
+
+	// Digests of the inputs shared across directories
+	next   string // next.config basePath and assetPrefix
+	styles string // style files, which define custom properties
+	dart   string // Dart files, which define constants
+}
+
+// newCheckpointRun decides which directories among paths the checkpoint
+// can restore. next identifies the next.config prefixes references are
+// resolved with.
+func newCheckpointRun(cp *Checkpoint, paths []string, next string) *checkpointRun {
+	byDir := make(map[string][]string)
+	var styles, dart []string
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		byDir[dir] = append(byDir[dir], path)
+		switch ext := strings.ToLower(filepath.Ext(path)); {
+		case styleExtensions[ext]:
+			styles = append(styles, path)
+		case ext == ".dart":
+			dart = append(dart, path)
+		}
+	}
+
+	run := &checkpointRun{
+		cp:        cp,
+		reused:    make(map[string]*checkpointDir),
+		skipped:   make(map[string]bool),
+		remaining: make(map[string]int),
+		records:   make(map[string]*checkpointDir),
+		next:      next,
+		styles:    statDigest(styles),
+		dart:      statDigest(dart),
+	}
+	inputs := make(map[string]string, len(byDir))
+	for dir, files := range byDir {
+		inputs[dir] = run.inputs(dir, files)
+		if record := cp.reusable(dir, files, inputs[dir]); record != nil {
+			run.reused[dir] = record
+			run.skipped[dir] = true
+		} else {
+			run.remaining[dir] = len(files)
+			run.records[dir] = &checkpointDir{Dir: dir, Inputs: inputs[dir]}
+		}
+	}
+	return run
+}
+
+// inputs digests what the references of dir, with source files files,
+// depend on outside those files: custom properties are resolved across
+// every style file, Dart constants across every Dart file, dead Go code
+// against the whole package, and every path against next.config
+func (run *checkpointRun) inputs(dir string, files []string) string {
+	var styles, dart, goFiles bool
+	for _, file := range files {
+		switch ext := strings.ToLower(filepath.Ext(file)); {
+		case styleExtensions[ext]:
+			styles = true
+		case ext == ".dart":
+			dart = true
+		case ext == ".go":
+			goFiles = true
+		}
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(run.next))
+	if styles {
+		hash.Write([]byte("\x00styles" + run.styles))
+	}
+	if dart {
+		hash.Write([]byte("\x00dart" + run.dart))
+	}
+	if goFiles {
+		// unusedGoFuncs reads every .go file of the directory
+		var pkg []string
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			if !entry.IsDir() && filepath.Ext(entry.Name()) == ".go" {
+				pkg = append(pkg, filepath.Join(dir, entry.Name()))
+			}
+		}
+		hash.Write([]byte("\x00go" + statDigest(pkg)))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// statDigest identifies the versions of files by their size and mtime
+func statDigest(files []string) string {
+	hash := sha256.New()
+	for _, path := range files {
+		file := statCheckpointFile(path)
+		fmt.Fprintf(hash, "%s\x00%d\x00%d\x00", file.Path, file.Size, file.ModTime)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// restored reports whether path lies in a restored directory, returning
+// the directory's record for its first file only
+func (run *checkpointRun) restored(path string) (*checkpointDir, bool) {
+	dir := filepath.Dir(path)
+	if !run.skipped[dir] {
+		return nil, false
+	}
+	record := run.reused[dir]
+	delete(run.reused, dir)
+	return record, true
+}
+
+// start returns the record of the directory of a file about to be read
+func (run *checkpointRun) start(path string) *checkpointDir {
+	record := run.records[filepath.Dir(path)]
+	record.Files = append(record.Files, statCheckpointFile(path))
+	return record
+}
+
+// finish marks a file read, completing its directory after its last file
+// and writing a shard every checkpointEvery files
+func (run *checkpointRun) finish(path string) error {
+	dir := filepath.Dir(path)
+	run.remaining[dir]--
+	if run.remaining[dir] == 0 {
+		run.cp.complete(run.records[dir])
+		delete(run.records, dir)
+	}
+
+	run.read++
+	if run.read < checkpointEvery {
+		return nil
+	}
+	run.read = 0
+	return run.cp.Flush()
+}
@@ -0,0 +1,179 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_CheckpointResume(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"src/a/one.ts":          "import logo from '../../assets/logo.png'\n",
+		"src/a/two.ts":          "// easyclean:keep assets/keep-*.png\n",
+		"src/b/three.ts":        "const hero = '/assets/hero.jpg'\n",
+		"src/b/nested/four.css": ".x { background: url('../../../assets/bg.png'); }\n",
+		"assets/logo.png":       "",
+		"assets/hero.jpg":       "",
+		"assets/bg.png":         "",
+		"assets/icon.png":       "",
+	})
+	cfg := config.DefaultConfig()
+	checkpointDir := filepath.Join(t.TempDir(), "checkpoint")
+
+	// An interrupted scan has saved the directories it completed
+	cp, err := OpenCheckpoint(checkpointDir, "fp", false)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() failed: %v", err)
+	}
+	first := NewReferenceFinder(tmpDir, cfg)
+	first.SetCheckpoint(cp)
+	if _, err := first.FindReferences(); err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if err := cp.Flush(); err != nil {
+		t.Fatalf("Flush() failed: %v", err)
+	}
+
+	// src/b changes before the resumed scan
+	three := filepath.Join(tmpDir, "src", "b", "three.ts")
+	if err := os.WriteFile(three, []byte("const icon = '/assets/icon.png'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(three, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := OpenCheckpoint(checkpointDir, "fp", true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint(resume) failed: %v", err)
+	}
+	if resumed.Restored() != 3 {
+		t.Errorf("Restored() = %d, want 3", resumed.Restored())
+	}
+	finder := NewReferenceFinder(tmpDir, cfg)
+	finder.SetCheckpoint(resumed)
+	got, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() after resume failed: %v", err)
+	}
+
+	fresh := NewReferenceFinder(tmpDir, cfg)
+	want, err := fresh.FindReferences()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("resumed scan found references to %d assets, want %d", len(got), len(want))
+	}
+	for assetPath, refs := range want {
+		if len(got[assetPath]) != len(refs) {
+			t.Errorf("resumed scan found %d references to %s, want %d", len(got[assetPath]), assetPath, len(refs))
+		}
+	}
+	if finder.FilesScanned() != fresh.FilesScanned() || finder.BytesRead() != fresh.BytesRead() {
+		t.Errorf("resumed scan counted %d files, %d bytes; want %d, %d",
+			finder.FilesScanned(), finder.BytesRead(), fresh.FilesScanned(), fresh.BytesRead())
+	}
+	if len(finder.KeepPatterns()) != 1 {
+		t.Errorf("resumed scan KeepPatterns() = %v, want the restored annotation", finder.KeepPatterns())
+	}
+
+	// A scan with another config or other assets starts over
+	stale, err := OpenCheckpoint(checkpointDir, "other", true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() with another fingerprint failed: %v", err)
+	}
+	if stale.Restored() != 0 {
+		t.Errorf("OpenCheckpoint() with another fingerprint restored %d directories, want 0", stale.Restored())
+	}
+}
+
+func TestReferenceFinder_CheckpointInterrupt(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"src/app.ts":      "import logo from '../assets/logo.png'\n",
+		"assets/logo.png": "",
+	})
+
+	cp, err := OpenCheckpoint(filepath.Join(t.TempDir(), "checkpoint"), "fp", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	finder := NewReferenceFinder(tmpDir, config.DefaultConfig())
+	finder.SetContext(ctx)
+	finder.SetCheckpoint(cp)
+	if _, err := finder.FindReferences(); !errors.Is(err, context.Canceled) {
+		t.Errorf("FindReferences() after cancel = %v, want context.Canceled", err)
+	}
+}
+
+func TestReferenceFinder_CheckpointCrossFileInputs(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"src/theme/vars.css": ":root { --hero: url('/assets/hero.png'); }\n",
+		"src/pages/page.css": ".x { background: var(--hero); }\n",
+		"assets/hero.png":    "",
+		"assets/icon.png":    "",
+	})
+	cfg := config.DefaultConfig()
+	checkpointDir := filepath.Join(t.TempDir(), "checkpoint")
+
+	cp, err := OpenCheckpoint(checkpointDir, "fp", false)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() failed: %v", err)
+	}
+	first := NewReferenceFinder(tmpDir, cfg)
+	first.SetCheckpoint(cp)
+	if _, err := first.FindReferences(); err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if err := cp.Flush(); err != nil {
+		t.Fatalf("Flush() failed: %v", err)
+	}
+
+	// The theme changes what var(--hero) in src/pages resolves to
+	vars := filepath.Join(tmpDir, "src", "theme", "vars.css")
+	if err := os.WriteFile(vars, []byte(":root { --hero: url('/assets/icon.png'); }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(vars, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := OpenCheckpoint(checkpointDir, "fp", true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint(resume) failed: %v", err)
+	}
+	finder := NewReferenceFinder(tmpDir, cfg)
+	finder.SetCheckpoint(resumed)
+	got, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() after resume failed: %v", err)
+	}
+
+	want, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for assetPath, refs := range want {
+		if len(got[assetPath]) != len(refs) {
+			t.Errorf("resumed scan found %d references to %s, want %d", len(got[assetPath]), assetPath, len(refs))
+		}
+	}
+	for assetPath := range got {
+		if _, ok := want[assetPath]; !ok {
+			t.Errorf("resumed scan kept a stale reference to %s", assetPath)
+		}
+	}
+}
@@ -1,6 +1,16 @@
 package scanner
 
-import "path/filepath"
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// wellKnownDir is served at the site root by convention, so it is scanned
+// even when hidden directories are not
+const wellKnownDir = ".well-known"
 
 // shouldExcludeDir checks if a directory should be excluded from scanning
 // This function is shared between AssetFinder and ReferenceFinder to avoid duplication
@@ -24,3 +34,114 @@ func shouldExcludeDir(path, root string, excludePatterns []string) bool {
 
 	return false
 }
+
+// isHidden reports whether a file or directory name is dot-prefixed
+func isHidden(name string) bool {
+	return len(name) > 1 && name[0] == '.' && name != ".."
+}
+
+// shouldSkipDir reports whether a walk skips the directory at path: excluded
+// directories always, hidden ones unless include_hidden is set, a configured
+// asset path lies in or below them, or they are .well-known
+func shouldSkipDir(path, root string, config *models.ProjectConfig) bool {
+	if shouldExcludeDir(path, root, config.ExcludePaths) {
+		return true
+	}
+	name := filepath.Base(path)
+	if config.IncludeHidden || path == root || !isHidden(name) || name == wellKnownDir {
+		return false
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return true
+	}
+	rel = filepath.ToSlash(rel)
+	for _, assetPath := range config.AssetPaths {
+		assetPath = strings.Trim(filepath.ToSlash(filepath.Clean(assetPath)), "/")
+		if assetPath == rel || strings.HasPrefix(assetPath, rel+"/") || strings.HasPrefix(rel, assetPath+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+// shouldSkipFile reports whether a walk ignores a file: dotfiles are only
+// scanned with scan_dotfiles
+func shouldSkipFile(name string, config *models.ProjectConfig) bool {
+	return !config.ScanDotfiles && isHidden(name)
+}
+
+// IsSkippedPath reports whether a scan ignores the file at path, because it is
+// a dotfile or one of its parent directories is skipped
+func IsSkippedPath(path, root string, config *models.ProjectConfig) bool {
+	if shouldSkipFile(filepath.Base(path), config) {
+		return true
+	}
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return false
+		}
+		if shouldSkipDir(dir, root, config) {
+			return true
+		}
+	}
+}
+
+// walkGuard enforces the max_depth and max_files limits on one walk, so an
+// accidental scan of a home directory or vendored tree stays bounded
+type walkGuard struct {
+	root        string
+	maxDepth    int
+	maxFiles    int
+	files       int
+	skippedDirs int
+	truncated   bool
+}
+
+func newWalkGuard(root string, config *models.ProjectConfig) *walkGuard {
+	return &walkGuard{root: root, maxDepth: config.MaxDepth, maxFiles: config.MaxFiles}
+}
+
+// enterDir reports whether the walk may descend into the directory at path,
+// which must be no more than max_depth levels below the root
+func (g *walkGuard) enterDir(path string) bool {
+	if g.maxDepth <= 0 || path == g.root {
+		return true
+	}
+	rel, err := filepath.Rel(g.root, path)
+	if err != nil {
+		return true
+	}
+	if strings.Count(filepath.ToSlash(rel), "/")+1 > g.maxDepth {
+		g.skippedDirs++
+		return false
+	}
+	return true
+}
+
+// visitFile counts a file, reporting false once max_files files were visited
+func (g *walkGuard) visitFile() bool {
+	if g.maxFiles <= 0 {
+		return true
+	}
+	if g.files >= g.maxFiles {
+		g.truncated = true
+		return false
+	}
+	g.files++
+	return true
+}
+
+// warnings describes the limits the walk hit
+func (g *walkGuard) warnings(walk string) []string {
+	var warnings []string
+	if g.skippedDirs > 0 {
+		warnings = append(warnings, fmt.Sprintf("%s walk skipped %d directories deeper than max_depth (%d)", walk, g.skippedDirs, g.maxDepth))
+	}
+	if g.truncated {
+		warnings = append(warnings, fmt.Sprintf("%s walk stopped after max_files (%d) files; results are incomplete", walk, g.maxFiles))
+	}
+	return warnings
+}
@@ -0,0 +1,162 @@
+package scanner
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// contentConfidence is the confidence of an asset named by a content field
+const contentConfidence = 0.9
+
+// contentExtensions are the files under content_sources read for asset fields
+var contentExtensions = map[string]bool{
+	".md": true, ".mdx": true, ".markdown": true,
+	".json": true, ".yaml": true, ".yml": true, ".toml": true,
+}
+
+// markdownLinkPattern matches the targets of Markdown images and links,
+// inline (![alt](/img/a.png "title")) and reference-style ([a]: /img/a.png)
+var markdownLinkPattern = regexp.MustCompile(`\]\(\s*<?([^)\s>]+)|^\s*\[[^\]]+\]:\s*<?([^\s>]+)`)
+
+// isContentFile reports whether a file is a content file under one of the
+// configured content sources
+func (rf *ReferenceFinder) isContentFile(path string) bool {
+	if len(rf.config.ContentSources) == 0 || !contentExtensions[strings.ToLower(filepath.Ext(path))] {
+		return false
+	}
+	rel, err := filepath.Rel(rf.root, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, source := range rf.config.ContentSources {
+		source = strings.Trim(filepath.ToSlash(source), "/")
+		if source == "" || source == "." || strings.HasPrefix(rel, source+"/") || utils.MatchGlob(source, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// contentReferences returns a reference to every asset a content file's
+// fields name: Markdown front matter and the images and links of its body,
+// and any string value of a JSON, YAML, or TOML content file
+func (rf *ReferenceFinder) contentReferences(path string, src *parser.SourceText) []*models.Reference {
+	if !rf.isContentFile(path) {
+		return nil
+	}
+
+	var refs []*models.Reference
+	add := func(lineNumber int, value string, refType models.ReferenceType) {
+		// Links may carry a query or fragment (/docs/guide.pdf#page=2)
+		if i := strings.IndexAny(value, "?#"); i > 0 {
+			value = value[:i]
+		}
+		if !utils.HasExtension(value, rf.config.Extensions) {
+			return
+		}
+		refs = append(refs, &models.Reference{
+			SourceFile:  path,
+			LineNumber:  lineNumber,
+			MatchedText: value,
+			Context:     strings.TrimSpace(src.Line(lineNumber - 1)),
+			Type:        refType,
+			Confidence:  contentConfidence,
+		})
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var doc any
+		if err := json.Unmarshal([]byte(src.Content), &doc); err != nil {
+			return nil
+		}
+		for _, value := range jsonStrings(doc, nil) {
+			// Object keys decode in no particular order, so each value is
+			// attributed to the first line declaring it
+			lineNumber := 1
+			if i := strings.Index(src.Content, `"`+value+`"`); i >= 0 {
+				lineNumber = src.LineNumber(i + 1)
+			}
+			add(lineNumber, value, models.RefTypeConfig)
+		}
+	case ".yaml", ".yml", ".toml":
+		for i := 0; i < src.LineCount(); i++ {
+			for _, value := range fieldValues(src.Line(i)) {
+				add(i+1, value, models.RefTypeConfig)
+			}
+		}
+	default:
+		body := frontMatterEnd(src)
+		for i := 0; i < body; i++ {
+			for _, value := range fieldValues(src.Line(i)) {
+				add(i+1, value, models.RefTypeConfig)
+			}
+		}
+		for i := body; i < src.LineCount(); i++ {
+			for _, match := range markdownLinkPattern.FindAllStringSubmatch(src.Line(i), -1) {
+				add(i+1, match[1]+match[2], models.RefTypeHTMLAttribute)
+			}
+		}
+	}
+	return refs
+}
+
+// frontMatterEnd returns the index of the first body line of a Markdown
+// file: the line after its closing --- (YAML) or +++ (TOML) front matter
+// delimiter, or 0 without front matter
+func frontMatterEnd(src *parser.SourceText) int {
+	if src.LineCount() == 0 {
+		return 0
+	}
+	delimiter := strings.TrimSpace(src.Line(0))
+	if delimiter != "---" && delimiter != "+++" {
+		return 0
+	}
+	for i := 1; i < src.LineCount(); i++ {
+		if strings.TrimSpace(src.Line(i)) == delimiter {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// fieldValues splits a YAML or TOML line into the scalars it may hold:
+// "image: /img/hero.jpg", "- a.png", and "gallery = ['a.png', 'b.png']" all
+// yield their paths. Comments are dropped; keys never carry an asset
+// extension, so they are filtered out with the other words.
+func fieldValues(line string) []string {
+	if i := strings.Index(line, " #"); i >= 0 {
+		line = line[:i]
+	}
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return nil
+	}
+	return strings.FieldsFunc(line, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '"' || r == '\'' || r == ',' || r == '=' ||
+			r == '[' || r == ']' || r == '{' || r == '}' || r == '(' || r == ')'
+	})
+}
+
+// jsonStrings appends every string value of a decoded JSON document
+func jsonStrings(v any, values []string) []string {
+	switch v := v.(type) {
+	case string:
+		values = append(values, v)
+	case []any:
+		for _, item := range v {
+			values = jsonStrings(item, values)
+		}
+	case map[string]any:
+		for _, item := range v {
+			values = jsonStrings(item, values)
+		}
+	}
+	return values
+}
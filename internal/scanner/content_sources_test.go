@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_ContentSources(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"content/posts/launch.md": `---
+title: Launch
+image: /img/hero.jpg
+gallery:
+  - /img/gallery-1.png # first
+---
+
+Read the ![diagram](/img/diagram.svg "Diagram") and [the guide](/docs/guide.pdf#page=2).
+
+[logo]: /img/logo.png
+`,
+		"content/data/team.json": `{
+  "members": [
+    { "name": "Ada", "avatar": "/img/ada.webp" }
+  ]
+}
+`,
+		"content/site.toml":  "cover = 'img/cover.jpg'\n",
+		"notes/draft.md":     "---\nimage: /img/draft.jpg\n---\n",
+		"content/readme.txt": "image: /img/ignored.jpg\n",
+		"content/about.md":   "No front matter: /img/prose.jpg\n",
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.ContentSources = []string{"content/"}
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	expected := map[string]int{
+		"img/hero.jpg":      3,
+		"img/gallery-1.png": 5,
+		"img/diagram.svg":   8,
+		"docs/guide.pdf":    8,
+		"img/logo.png":      10,
+		"img/ada.webp":      3,
+		"img/cover.jpg":     1,
+	}
+	for asset, line := range expected {
+		found := false
+		for _, ref := range references[asset] {
+			if ref.Confidence >= contentConfidence && ref.LineNumber == line {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a content reference to %s on line %d, got %v", asset, line, references[asset])
+		}
+	}
+
+	for _, asset := range []string{"img/draft.jpg", "img/ignored.jpg", "img/prose.jpg"} {
+		if len(references[asset]) > 0 {
+			t.Errorf("expected no reference to %s outside content fields, got %v", asset, references[asset])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package scanner
+
+// conventionDirs are the directories served at the site root
+var conventionDirs = []string{"", "public/", "static/"}
+
+// rootConventions are files browsers, crawlers, and social platforms request
+// by well-known path at the site root without any reference in the project
+var rootConventions = []string{
+	"favicon.ico", "apple-touch-icon*.png", "maskable_icon*.png",
+	"og-image.*", "twitter-image.*",
+	"robots.txt", "humans.txt", "ads.txt", "app-ads.txt", "security.txt",
+	"sitemap*.xml", "sitemap*.xml.gz",
+	".well-known/**",
+}
+
+// ConventionalFiles are the built-in globs, relative to the project root, for
+// assets used by convention; cfg.ConventionalFiles extends them
+var ConventionalFiles = conventionalFiles()
+
+func conventionalFiles() []string {
+	var globs []string
+	for _, dir := range conventionDirs {
+		for _, name := range rootConventions {
+			globs = append(globs, dir+name)
+		}
+	}
+	// Next.js app router metadata files, found in any route segment
+	for _, dir := range []string{"app/", "src/app/"} {
+		globs = append(globs,
+			dir+"favicon.ico", dir+"**/icon*", dir+"**/apple-icon*",
+			dir+"**/opengraph-image*", dir+"**/twitter-image*",
+			dir+"robots.txt", dir+"sitemap*.xml", dir+"manifest.webmanifest",
+		)
+	}
+	return globs
+}
@@ -0,0 +1,39 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+func TestConventionalFiles(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"favicon.ico", true},
+		{"public/favicon.ico", true},
+		{"public/apple-touch-icon-180x180.png", true},
+		{"public/maskable_icon_x512.png", true},
+		{"static/og-image.jpg", true},
+		{"public/robots.txt", true},
+		{"public/sitemap-0.xml", true},
+		{"public/.well-known/security.txt", true},
+		{"app/icon.png", true},
+		{"app/blog/opengraph-image.png", true},
+		{"src/assets/favicon.ico", false},
+		{"public/images/og-image.png", false},
+	}
+
+	for _, tt := range tests {
+		matched := false
+		for _, pattern := range ConventionalFiles {
+			if utils.MatchGlob(pattern, tt.path) {
+				matched = true
+			}
+		}
+		if matched != tt.expected {
+			t.Errorf("ConventionalFiles match %s = %v, want %v", tt.path, matched, tt.expected)
+		}
+	}
+}
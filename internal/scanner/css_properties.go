@@ -0,0 +1,110 @@
+package scanner
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+)
+
+// cssVarConfidence is the confidence of a reference through a custom property
+const cssVarConfidence = 0.9
+
+var (
+	// --bg-image: url(bg.png); (value runs to the end of the declaration)
+	cssPropertyPattern = regexp.MustCompile(`(--[\w-]+)\s*:\s*([^;}]*)`)
+	// url(...) and quoted image-set() candidates inside a property value
+	cssValueURLPattern = regexp.MustCompile(`url\(\s*['"]?([^'")\s]+)['"]?\s*\)|['"]([^'"\s]+\.\w+)['"]`)
+	// var(--bg-image) and var(--bg-image, fallback)
+	cssVarPattern = regexp.MustCompile(`var\(\s*(--[\w-]+)`)
+)
+
+// styleExtensions are files that can define or read CSS custom properties
+var styleExtensions = map[string]bool{
+	".css": true, ".scss": true, ".sass": true, ".less": true,
+	".vue": true, ".svelte": true, ".html": true, ".htm": true,
+}
+
+// cssProperties indexes the asset URLs assigned to custom properties across
+// the project, so a property defined in a theme file reached through @import
+// chains resolves wherever var() reads it. The index is cached for the scan.
+func (rf *ReferenceFinder) cssProperties() map[string][]string {
+	if rf.cssVars != nil {
+		return rf.cssVars
+	}
+
+	values := make(map[string][]string) // property -> raw values, one per definition
+	_ = rf.walkSourceFiles(func(file string) {
+		if !styleExtensions[strings.ToLower(filepath.Ext(file))] {
+			return
+		}
+		src, err := rf.readSource(file)
+		if err != nil {
+			return
+		}
+		for _, match := range cssPropertyPattern.FindAllStringSubmatch(src.Content, -1) {
+			values[match[1]] = append(values[match[1]], match[2])
+		}
+	})
+
+	// Resolve values, following properties defined in terms of others
+	resolved := make(map[string][]string, len(values))
+	var resolve func(name string, seen map[string]bool) []string
+	resolve = func(name string, seen map[string]bool) []string {
+		if urls, ok := resolved[name]; ok || seen[name] {
+			return urls
+		}
+		seen[name] = true
+
+		var urls []string
+		for _, value := range values[name] {
+			for _, match := range cssValueURLPattern.FindAllStringSubmatch(value, -1) {
+				if url := match[1] + match[2]; !strings.HasPrefix(url, "data:") {
+					urls = append(urls, url)
+				}
+			}
+			for _, match := range cssVarPattern.FindAllStringSubmatch(value, -1) {
+				urls = append(urls, resolve(match[1], seen)...)
+			}
+		}
+		resolved[name] = urls
+		return urls
+	}
+	for name := range values {
+		resolve(name, make(map[string]bool))
+	}
+
+	rf.cssVars = resolved
+	return resolved
+}
+
+// cssVarReferences returns a reference to each asset behind every var() read
+// in a style file; the definition site already matches the url() patterns
+func (rf *ReferenceFinder) cssVarReferences(file string, src *parser.SourceText) []*models.Reference {
+	if !styleExtensions[strings.ToLower(filepath.Ext(file))] || !strings.Contains(src.Content, "var(") {
+		return nil
+	}
+
+	properties := rf.cssProperties()
+	var refs []*models.Reference
+	for i := 0; i < src.LineCount(); i++ {
+		line := src.Line(i)
+		for _, loc := range cssVarPattern.FindAllStringSubmatchIndex(line, -1) {
+			for _, url := range properties[line[loc[2]:loc[3]]] {
+				refs = append(refs, &models.Reference{
+					SourceFile:  file,
+					LineNumber:  i + 1,
+					Column:      loc[0] + 1, // the var() read
+					MatchedText: url,
+					Context:     strings.TrimSpace(line),
+					Type:        models.RefTypeCSSUrl,
+					Confidence:  cssVarConfidence,
+					IsComment:   rf.isCommentLine(line),
+				})
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestReferenceFinder_CSSCustomProperties(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"styles/theme.css": `:root {
+  --hero-image: url("img/hero.png");
+  --banner-image: var(--hero-image);
+}
+`,
+		"styles/main.css": `@import "theme.css";
+
+.hero { background-image: var(--hero-image); }
+.banner { background: var(--banner-image, none); }
+`,
+	})
+
+	cfg := config.DefaultConfig()
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	lines := make(map[int]bool)
+	for _, ref := range references["img/hero.png"] {
+		if filepath.Base(ref.SourceFile) == "main.css" && ref.Type == models.RefTypeCSSUrl {
+			lines[ref.LineNumber] = true
+		}
+	}
+	if !lines[3] || !lines[4] {
+		t.Errorf("expected var() references to hero.png on main.css lines 3 and 4, got %v", references["img/hero.png"])
+	}
+}
@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+)
+
+// dartConstantConfidence is the confidence of a reference through a constant
+const dartConstantConfidence = 0.95
+
+// dartIndex holds the Dart string constants of a project and where each is read
+type dartIndex struct {
+	usages map[string][]parser.DartUsage    // source file -> reads of asset constants
+	unused map[string][]parser.DartConstant // source file -> asset constants never read
+}
+
+// dartConstants parses every Dart file once and links constant usages across
+// the project. The result is cached for the rest of the scan.
+func (rf *ReferenceFinder) dartConstants() *dartIndex {
+	if rf.dart != nil {
+		return rf.dart
+	}
+
+	files := make(map[string]*parser.DartFile)
+	_ = rf.walkSourceFiles(func(file string) {
+		if filepath.Ext(file) != ".dart" {
+			return
+		}
+		if src, err := rf.readSource(file); err == nil {
+			files[file] = parser.ParseDart(src)
+		}
+	})
+
+	// Only constants that look like file paths can name assets
+	known := make(map[string]parser.DartConstant)
+	for _, f := range files {
+		for _, c := range f.Constants {
+			if path.Ext(c.Value) != "" && !strings.Contains(c.Value, " ") {
+				known[c.Key()] = c
+			}
+		}
+	}
+
+	idx := &dartIndex{
+		usages: make(map[string][]parser.DartUsage),
+		unused: make(map[string][]parser.DartConstant),
+	}
+	used := make(map[string]bool)
+	for file, f := range files {
+		for _, usage := range f.Usages(known) {
+			idx.usages[file] = append(idx.usages[file], usage)
+			used[usage.Constant.Key()] = true
+		}
+	}
+	for file, f := range files {
+		for _, c := range f.Constants {
+			if _, ok := known[c.Key()]; ok && !used[c.Key()] {
+				idx.unused[file] = append(idx.unused[file], c)
+			}
+		}
+	}
+
+	rf.dart = idx
+	return idx
+}
+
+// dartConstantReferences returns a reference at every read of an asset path
+// constant in a Dart file
+func (rf *ReferenceFinder) dartConstantReferences(file string, src *parser.SourceText) []*models.Reference {
+	var refs []*models.Reference
+	for _, usage := range rf.dartConstants().usages[file] {
+		line := src.Line(usage.LineNumber - 1)
+		refs = append(refs, &models.Reference{
+			SourceFile:  file,
+			LineNumber:  usage.LineNumber,
+			Column:      src.Column(usage.Offset),
+			MatchedText: usage.Constant.Value,
+			Context:     strings.TrimSpace(line),
+			Type:        models.RefTypeConstant,
+			Confidence:  dartConstantConfidence,
+			IsComment:   rf.isCommentLine(line),
+		})
+	}
+	return refs
+}
+
+// markUnusedDartConstants flags the literal in a never-read constant's
+// declaration as dead code, so a registry entry alone doesn't keep an asset
+func (rf *ReferenceFinder) markUnusedDartConstants(file string, refs []*models.Reference) {
+	for _, c := range rf.dartConstants().unused[file] {
+		for _, ref := range refs {
+			if ref.LineNumber == c.LineNumber && ref.Type != models.RefTypeConstant {
+				ref.IsDeadCode = true
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestReferenceFinder_DartConstants(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"lib/assets.dart": `class Assets {
+  static const _dir = 'assets/images';
+  static const logo = '$_dir/logo.png';
+  static const legacy = 'assets/images/legacy.png';
+}
+`,
+		"lib/main.dart": `import 'assets.dart';
+
+void main() => runApp(Image.asset(Assets.logo));
+`,
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.ProjectType = models.ProjectTypeFlutter
+	cfg.DeadCodeAnalysis = true
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	found := false
+	for _, ref := range references["assets/images/logo.png"] {
+		if ref.Type == models.RefTypeConstant && filepath.Base(ref.SourceFile) == "main.dart" && ref.LineNumber == 3 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a constant reference to logo.png from main.dart:3, got %+v", references["assets/images/logo.png"])
+	}
+
+	legacy := references["assets/images/legacy.png"]
+	if len(legacy) == 0 {
+		t.Fatal("expected the legacy declaration to be reported")
+	}
+	for _, ref := range legacy {
+		if !ref.IsDeadCode {
+			t.Errorf("declaration of an unread constant should be dead code: %+v", ref)
+		}
+	}
+
+	// Without dead code analysis the declaration counts as usage
+	cfg.DeadCodeAnalysis = false
+	references, err = NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	for _, ref := range references["assets/images/legacy.png"] {
+		if ref.IsDeadCode {
+			t.Errorf("dead code should only be flagged with dead_code_analysis: %+v", ref)
+		}
+	}
+}
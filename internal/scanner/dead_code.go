@@ -0,0 +1,124 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/parser"
+)
+
+var (
+	// Blocks guarded by a constant false condition: if (false) {, if (0) {, if false {
+	falseConditionPattern = regexp.MustCompile(`\bif\s*(?:\(\s*(?:false|0)\s*\)|false)\s*\{`)
+
+	// Unexported top-level Go functions (methods are skipped: they may satisfy interfaces)
+	goFuncPattern = regexp.MustCompile(`(?m)^func\s+([a-z_]\w*)\s*[\[(]`)
+)
+
+// lineRange is an inclusive range of 1-based line numbers
+type lineRange struct {
+	start, end int
+}
+
+// deadCodeRanges returns the line ranges of obviously unreachable code in src
+func (rf *ReferenceFinder) deadCodeRanges(path string, src *parser.SourceText) []lineRange {
+	var ranges []lineRange
+
+	for _, loc := range falseConditionPattern.FindAllStringIndex(src.Content, -1) {
+		if r, ok := blockRange(src, loc[1]-1); ok {
+			ranges = append(ranges, r)
+		}
+	}
+
+	if filepath.Ext(path) == ".go" && !strings.HasSuffix(path, "_test.go") {
+		unused := rf.unusedGoFuncs(filepath.Dir(path))
+		for _, loc := range goFuncPattern.FindAllStringSubmatchIndex(src.Content, -1) {
+			if !unused[src.Content[loc[2]:loc[3]]] {
+				continue
+			}
+			if open := strings.Index(src.Content[loc[1]:], "{"); open >= 0 {
+				if r, ok := blockRange(src, loc[1]+open); ok {
+					ranges = append(ranges, r)
+				}
+			}
+		}
+	}
+
+	return ranges
+}
+
+// unusedGoFuncs returns unexported functions in a Go package directory whose
+// names appear nowhere in the package except their declaration
+func (rf *ReferenceFinder) unusedGoFuncs(dir string) map[string]bool {
+	if unused, ok := rf.goPackages[dir]; ok {
+		return unused
+	}
+
+	var contents []string
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" {
+			continue
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, entry.Name())); err == nil {
+			contents = append(contents, string(data))
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, content := range contents {
+		for _, match := range goFuncPattern.FindAllStringSubmatch(content, -1) {
+			if name := match[1]; name != "init" && name != "main" && name != "_" {
+				declared[name] = true
+			}
+		}
+	}
+
+	unused := make(map[string]bool)
+	for name := range declared {
+		word := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+		uses := 0
+		for _, content := range contents {
+			uses += len(word.FindAllStringIndex(content, -1))
+		}
+		if uses <= 1 {
+			unused[name] = true
+		}
+	}
+
+	if rf.goPackages == nil {
+		rf.goPackages = make(map[string]map[string]bool)
+	}
+	rf.goPackages[dir] = unused
+	return unused
+}
+
+// blockRange finds the brace matching the "{" at offset and returns the lines
+// it spans. Braces inside strings and comments are not special-cased.
+func blockRange(src *parser.SourceText, offset int) (lineRange, bool) {
+	depth := 0
+	for i := offset; i < len(src.Content); i++ {
+		switch src.Content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return lineRange{start: src.LineNumber(offset), end: src.LineNumber(i)}, true
+			}
+		}
+	}
+	return lineRange{}, false
+}
+
+// inRanges reports whether line falls inside any of the ranges
+func inRanges(line int, ranges []lineRange) bool {
+	for _, r := range ranges {
+		if line >= r.start && line <= r.end {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_DeadCode(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeContent(t, filepath.Join(tmpDir, "app.js"), `const live = "assets/live.png";
+if (false) {
+  const old = "assets/old.png";
+}
+const after = "assets/after.png";
+`)
+	writeContent(t, filepath.Join(tmpDir, "main.go"), `package main
+
+func main() {
+	render()
+}
+
+func render() string {
+	return "assets/used.png"
+}
+
+func legacyBanner() string {
+	return "assets/banner.png"
+}
+`)
+
+	tests := []struct {
+		enabled  bool
+		expected map[string]bool
+	}{
+		{true, map[string]bool{
+			"assets/live.png": false, "assets/old.png": true, "assets/after.png": false,
+			"assets/used.png": false, "assets/banner.png": true,
+		}},
+		{false, map[string]bool{"assets/old.png": false, "assets/banner.png": false}},
+	}
+
+	for _, tt := range tests {
+		cfg := config.DefaultConfig()
+		cfg.DeadCodeAnalysis = tt.enabled
+		finder := NewReferenceFinder(tmpDir, cfg)
+
+		references, err := finder.FindReferences()
+		if err != nil {
+			t.Fatalf("FindReferences() failed: %v", err)
+		}
+
+		for asset, want := range tt.expected {
+			refs := references[asset]
+			if len(refs) == 0 {
+				t.Errorf("expected a reference to %s", asset)
+				continue
+			}
+			if refs[0].IsDeadCode != want {
+				t.Errorf("dead code analysis %v: %s IsDeadCode = %v, want %v", tt.enabled, asset, refs[0].IsDeadCode, want)
+			}
+		}
+	}
+}
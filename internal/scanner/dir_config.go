@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// DirConfigFileName is the config file that, below the project root,
+// overrides extensions, exclude_paths, and keep_paths for its subtree
+const DirConfigFileName = ".unusedassets.yaml"
+
+// dirPolicy is what the nested configs above a directory set for it
+type dirPolicy struct {
+	extensions []string // nil: the project's
+	excludes   []dirExclude
+}
+
+// dirExclude is one nested config's exclude_paths, relative to its directory
+type dirExclude struct {
+	base     string
+	patterns []string
+}
+
+// merge returns the policy of a directory holding dirCfg below one with p.
+// The nearest extensions list wins; excludes accumulate down the tree.
+func (p *dirPolicy) merge(dir string, dirCfg *models.DirConfig) *dirPolicy {
+	merged := &dirPolicy{}
+	if p != nil {
+		merged.extensions = p.extensions
+		merged.excludes = p.excludes
+	}
+	if len(dirCfg.Extensions) > 0 {
+		merged.extensions = dirCfg.Extensions
+	}
+	if len(dirCfg.ExcludePaths) > 0 {
+		merged.excludes = append(merged.excludes[:len(merged.excludes):len(merged.excludes)],
+			dirExclude{base: dir, patterns: dirCfg.ExcludePaths})
+	}
+	return merged
+}
+
+// excluded reports whether a nested config excludes the directory at path
+func (p *dirPolicy) excluded(path string) bool {
+	if p == nil {
+		return false
+	}
+	for _, exclude := range p.excludes {
+		if shouldExcludeDir(path, exclude.base, exclude.patterns) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadDirConfig reads the nested config in dir, if there is one, and returns
+// it with Dir set relative to root
+func loadDirConfig(dir, root string) (*models.DirConfig, error) {
+	configPath := filepath.Join(dir, DirConfigFileName)
+	if info, err := os.Stat(configPath); err != nil || info.IsDir() {
+		return nil, nil
+	}
+	dirCfg, err := config.LoadDirConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return nil, err
+	}
+	dirCfg.Dir = filepath.ToSlash(rel)
+	return dirCfg, nil
+}
+
+// dirKeepPaths returns the keep_paths of a nested config as globs relative
+// to the project root
+func dirKeepPaths(dirCfg *models.DirConfig) []string {
+	patterns := make([]string, 0, len(dirCfg.KeepPaths))
+	for _, pattern := range dirCfg.KeepPaths {
+		patterns = append(patterns, path.Join(dirCfg.Dir, strings.TrimPrefix(pattern, "./")))
+	}
+	return patterns
+}
@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// FileTree lists the files one walk of a project visited, so asset discovery
+// and every source pass share a single traversal and the same exclusions
+type FileTree struct {
+	files      []string
+	warnings   []string
+	dirConfigs []models.DirConfig
+	keepPaths  []string
+	policies   map[string]*dirPolicy // directories below a nested config
+}
+
+// WalkTree walks root once, skipping excluded and hidden directories,
+// symlinks unless follow_symlinks is set, and whatever max_depth and
+// max_files cut off. Dotfiles are kept; each consumer decides on those.
+// A .unusedassets.yaml below root overrides the policy for its subtree and
+// fails the walk if it is invalid.
+func WalkTree(root string, config *models.ProjectConfig) (*FileTree, error) {
+	tree := &FileTree{policies: make(map[string]*dirPolicy)}
+	guard := newWalkGuard(root, config)
+
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			// Skip paths we can't access
+			return nil
+		}
+
+		// Skip symlinks unless configured to follow them
+		if !config.FollowSymlinks && utils.IsSymlink(path) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			parent := tree.policies[filepath.Dir(path)]
+			if shouldSkipDir(path, root, config) || parent.excluded(path) || !guard.enterDir(path) {
+				return filepath.SkipDir
+			}
+			return tree.enterDir(path, root, parent)
+		}
+		if !guard.visitFile() {
+			return filepath.SkipAll
+		}
+
+		tree.files = append(tree.files, path)
+		return nil
+	})
+
+	tree.warnings = guard.warnings("file")
+	if err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// enterDir records the policy of a directory the walk descends into,
+// applying its nested config if it has one
+func (t *FileTree) enterDir(dir, root string, parent *dirPolicy) error {
+	policy := parent
+	if dir != root {
+		dirCfg, err := loadDirConfig(dir, root)
+		if err != nil {
+			return err
+		}
+		if dirCfg != nil {
+			t.dirConfigs = append(t.dirConfigs, *dirCfg)
+			t.keepPaths = append(t.keepPaths, dirKeepPaths(dirCfg)...)
+			policy = parent.merge(dir, dirCfg)
+		}
+	}
+	if policy != nil {
+		t.policies[dir] = policy
+	}
+	return nil
+}
+
+// Files returns the absolute paths of the files visited, in walk order
+func (t *FileTree) Files() []string {
+	return t.files
+}
+
+// Warnings describes the traversal limits (max_depth, max_files) the walk hit
+func (t *FileTree) Warnings() []string {
+	return t.warnings
+}
+
+// DirConfigs returns the nested .unusedassets.yaml files the walk applied,
+// in walk order
+func (t *FileTree) DirConfigs() []models.DirConfig {
+	return t.dirConfigs
+}
+
+// KeepPaths returns the keep_paths of nested configs, relative to the root
+func (t *FileTree) KeepPaths() []string {
+	return t.keepPaths
+}
+
+// extensionsFor returns the asset extensions that apply to the file at path:
+// those of the nearest nested config setting any, else the project's
+func (t *FileTree) extensionsFor(path string, config *models.ProjectConfig) []string {
+	if policy := t.policies[filepath.Dir(path)]; policy != nil && policy.extensions != nil {
+		return policy.extensions
+	}
+	return config.Extensions
+}
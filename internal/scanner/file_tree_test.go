@@ -0,0 +1,143 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestWalkTree_SharedByFinders(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "assets", "old.png"))
+	createTestFile(t, filepath.Join(tmpDir, "node_modules", "pkg", "icon.png"))
+	writeContent(t, filepath.Join(tmpDir, "assets", KeepFileName), "old.png\n")
+	if err := os.MkdirAll(filepath.Join(tmpDir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeContent(t, filepath.Join(tmpDir, "src", "app.js"), "import logo from '../assets/logo.png'\n")
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+	tree, err := WalkTree(tmpDir, cfg)
+	if err != nil {
+		t.Fatalf("WalkTree() failed: %v", err)
+	}
+	if len(tree.Files()) != 4 {
+		t.Errorf("Expected 4 files outside node_modules, got %v", tree.Files())
+	}
+
+	// Files created after the walk are invisible to finders sharing it
+	writeContent(t, filepath.Join(tmpDir, "src", "late.js"), "import old from '../assets/old.png'\n")
+
+	assetFinder := NewAssetFinder(tmpDir, cfg)
+	assetFinder.SetFileTree(tree)
+	assets, err := assetFinder.FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	if len(assets) != 2 {
+		t.Errorf("Expected 2 assets, got %d", len(assets))
+	}
+	if len(assetFinder.KeepPatterns()) != 1 {
+		t.Errorf("KeepPatterns() = %v, want the sidecar glob", assetFinder.KeepPatterns())
+	}
+
+	referenceFinder := NewReferenceFinder(tmpDir, cfg)
+	referenceFinder.SetFileTree(tree)
+	references, err := referenceFinder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if referenceFinder.FilesScanned() != 1 {
+		t.Errorf("FilesScanned() = %d, want 1", referenceFinder.FilesScanned())
+	}
+	if len(references[filepath.Join(tmpDir, "assets", "logo.png")]) != 1 || len(references[filepath.Join(tmpDir, "assets", "old.png")]) != 0 {
+		t.Errorf("Unexpected references %v", references)
+	}
+}
+
+func TestWalkTree_SkipsSymlinkedSources(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "logo.png"))
+	writeContent(t, filepath.Join(tmpDir, "shared.js"), "import logo from './assets/logo.png'\n")
+	if err := os.MkdirAll(filepath.Join(tmpDir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(tmpDir, "shared.js"), filepath.Join(tmpDir, "src", "link.js")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	tree, err := WalkTree(tmpDir, cfg)
+	if err != nil {
+		t.Fatalf("WalkTree() failed: %v", err)
+	}
+	for _, file := range tree.Files() {
+		if filepath.Base(file) == "link.js" {
+			t.Errorf("Expected symlinked source to be skipped like symlinked assets")
+		}
+	}
+
+	cfg.FollowSymlinks = true
+	if tree, _ = WalkTree(tmpDir, cfg); len(tree.Files()) != 3 {
+		t.Errorf("Expected 3 files following symlinks, got %v", tree.Files())
+	}
+}
+
+func TestWalkTree_DirConfigs(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "apps", "web", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "apps", "web", "hero.webp"))
+	createTestFile(t, filepath.Join(tmpDir, "apps", "web", "fixtures", "sample.png"))
+	createTestFile(t, filepath.Join(tmpDir, "apps", "web", "legacy", "fixtures", "old.png"))
+	createTestFile(t, filepath.Join(tmpDir, "apps", "web", "legacy", "banner.webp"))
+	createTestFile(t, filepath.Join(tmpDir, "apps", "web", "legacy", "banner.png"))
+	createTestFile(t, filepath.Join(tmpDir, "apps", "mobile", "icon.png"))
+	createTestFile(t, filepath.Join(tmpDir, "apps", "mobile", "fixtures", "sample.png"))
+	writeContent(t, filepath.Join(tmpDir, "apps", "web", DirConfigFileName),
+		"extensions: [\".png\"]\nexclude_paths: [\"fixtures\"]\nkeep_paths: [\"logo.png\"]\n")
+	writeContent(t, filepath.Join(tmpDir, "apps", "web", "legacy", DirConfigFileName), "extensions: [\".webp\"]\n")
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"apps/"}
+	tree, err := WalkTree(tmpDir, cfg)
+	if err != nil {
+		t.Fatalf("WalkTree() failed: %v", err)
+	}
+	if len(tree.DirConfigs()) != 2 || tree.DirConfigs()[0].Dir != "apps/web" {
+		t.Errorf("DirConfigs() = %+v, want apps/web and apps/web/legacy", tree.DirConfigs())
+	}
+	if len(tree.KeepPaths()) != 1 || tree.KeepPaths()[0] != "apps/web/logo.png" {
+		t.Errorf("KeepPaths() = %v, want apps/web/logo.png", tree.KeepPaths())
+	}
+
+	assetFinder := NewAssetFinder(tmpDir, cfg)
+	assetFinder.SetFileTree(tree)
+	assets, err := assetFinder.FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, asset := range assets {
+		found[asset.RelativePath] = true
+	}
+	// apps/web drops .webp and fixtures/ down to legacy/, which only takes
+	// .webp back; apps/mobile keeps the project's policy
+	want := []string{"apps/web/logo.png", "apps/web/legacy/banner.webp", "apps/mobile/icon.png", "apps/mobile/fixtures/sample.png"}
+	if len(assets) != len(want) {
+		t.Errorf("FindAssets() = %v, want %v", found, want)
+	}
+	for _, rel := range want {
+		if !found[rel] {
+			t.Errorf("FindAssets() is missing %s: %v", rel, found)
+		}
+	}
+
+	writeContent(t, filepath.Join(tmpDir, "apps", "mobile", DirConfigFileName), "asset_paths: [\"images\"]\n")
+	if _, err := WalkTree(tmpDir, cfg); err == nil {
+		t.Error("WalkTree() with a nested asset_paths setting succeeded, want an error")
+	}
+}
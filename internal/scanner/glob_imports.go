@@ -0,0 +1,259 @@
+package scanner
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+var (
+	// import.meta.glob('./icons/*.svg'), import.meta.glob<string>([...], { eager: true }),
+	// and the deprecated import.meta.globEager
+	globImportPattern = regexp.MustCompile(`import\.meta\.glob(?:Eager)?\s*(?:<[^>()]*>)?\s*\(\s*(\[[^\]]*\]|'[^']*'|"[^"]*")`)
+
+	// webpack require.context('./images', true, /\.png$/): directory, recursive, filter
+	requireContextPattern = regexp.MustCompile(`require\.context\s*\(\s*['"]([^'"]+)['"]\s*(?:,\s*(true|false)\s*)?(?:,\s*/((?:\\.|[^/\\\n])+)/([a-z]*))?`)
+)
+
+// contextImport is a bundler import of every file matching a pattern
+type contextImport struct {
+	offset  int      // where the import starts in the source
+	targets []string // root-relative files it matches
+}
+
+// contextImports finds the Vite glob imports and webpack require.context
+// calls in content and expands them against the filesystem
+func (rf *ReferenceFinder) contextImports(file, content string) []contextImport {
+	var imports []contextImport
+	if strings.Contains(content, "import.meta.glob") {
+		for _, m := range globImportPattern.FindAllStringSubmatchIndex(content, -1) {
+			imports = append(imports, contextImport{m[0], rf.expandGlobImport(file, content[m[2]:m[3]])})
+		}
+	}
+	if strings.Contains(content, "require.context") {
+		for _, m := range requireContextPattern.FindAllStringSubmatchIndex(content, -1) {
+			recursive := m[4] < 0 || content[m[4]:m[5]] == "true"
+			filter := ""
+			if m[6] >= 0 {
+				filter = content[m[6]:m[7]]
+				if strings.Contains(content[m[8]:m[9]], "i") {
+					filter = "(?i)" + filter
+				}
+			}
+			imports = append(imports, contextImport{m[0], rf.expandRequireContext(file, content[m[2]:m[3]], recursive, filter)})
+		}
+	}
+	return imports
+}
+
+// globImportReferences returns a reference to every file a glob import or
+// require.context matches; the bundler includes all of them, so none is
+// dynamic
+func (rf *ReferenceFinder) globImportReferences(file string, src *parser.SourceText) []*models.Reference {
+	var refs []*models.Reference
+	for _, imp := range rf.contextImports(file, src.Content) {
+		lineNumber := src.LineNumber(imp.offset)
+		line := src.Line(lineNumber - 1)
+		for _, target := range imp.targets {
+			refs = append(refs, &models.Reference{
+				SourceFile:  file,
+				LineNumber:  lineNumber,
+				Column:      src.Column(imp.offset),
+				MatchedText: target,
+				Context:     strings.TrimSpace(line),
+				Type:        models.RefTypeImport,
+				Confidence:  1.0,
+				IsComment:   rf.isCommentLine(line),
+			})
+		}
+	}
+	return refs
+}
+
+// globImportTargets returns the root-relative files the glob imports and
+// require.context calls in content match, for module graph edges
+func (rf *ReferenceFinder) globImportTargets(file, content string) []string {
+	var targets []string
+	for _, imp := range rf.contextImports(file, content) {
+		targets = append(targets, imp.targets...)
+	}
+	return targets
+}
+
+// expandGlobImport expands the glob argument of an import: one pattern or an
+// array where "!" patterns exclude. Patterns are relative to the importing
+// file, or to the root when they start with "/".
+func (rf *ReferenceFinder) expandGlobImport(file, arg string) []string {
+	var include, exclude []string
+	for _, q := range quotedStringPattern.FindAllStringSubmatch(arg, -1) {
+		pattern, negated := strings.CutPrefix(q[1], "!")
+		glob := rf.globFromRoot(file, pattern)
+		if glob == "" {
+			continue
+		}
+		if negated {
+			exclude = append(exclude, expandBraces(glob)...)
+		} else {
+			include = append(include, expandBraces(glob)...)
+		}
+	}
+	return rf.matchGlobs(include, exclude)
+}
+
+// expandRequireContext lists the files a require.context call bundles: those
+// in dir (and below it when recursive) whose "./"-prefixed path relative to
+// dir matches the filter. A filter Go can't compile, such as a lookahead,
+// matches every file so nothing the bundle may include is reported unused.
+func (rf *ReferenceFinder) expandRequireContext(file, dir string, recursive bool, filter string) []string {
+	base := rf.globFromRoot(file, dir)
+	if base == "" || isGlobPattern(base) {
+		return nil
+	}
+	var re *regexp.Regexp
+	if filter != "" {
+		re, _ = regexp.Compile(filter)
+	}
+
+	var files []string
+	baseDir := filepath.Join(rf.root, base)
+	filepath.WalkDir(baseDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if p != baseDir && (!recursive || shouldSkipDir(p, rf.root, rf.config)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if shouldSkipFile(d.Name(), rf.config) {
+			return nil
+		}
+		request, err := filepath.Rel(baseDir, p)
+		if err != nil {
+			return nil
+		}
+		if re == nil || re.MatchString("./"+filepath.ToSlash(request)) {
+			files = append(files, path.Join(base, filepath.ToSlash(request)))
+		}
+		return nil
+	})
+	return files
+}
+
+// globFromRoot makes an import glob root-relative, or "" for packages and
+// patterns outside the project
+func (rf *ReferenceFinder) globFromRoot(file, pattern string) string {
+	var glob string
+	switch {
+	case strings.HasPrefix(pattern, "./"), strings.HasPrefix(pattern, "../"):
+		dir, err := filepath.Rel(rf.root, filepath.Dir(file))
+		if err != nil {
+			return ""
+		}
+		glob = path.Join(filepath.ToSlash(dir), pattern)
+	case strings.HasPrefix(pattern, "/"):
+		glob = path.Clean(strings.TrimPrefix(pattern, "/"))
+	case strings.HasPrefix(pattern, "@/"), strings.HasPrefix(pattern, "~/"):
+		glob = path.Join("src", pattern[2:])
+	case strings.HasPrefix(pattern, "**/"):
+		// Usually an exclusion: '!**/draft-*' applies anywhere
+		glob = pattern
+	default:
+		return ""
+	}
+	if glob == ".." || strings.HasPrefix(glob, "../") {
+		return ""
+	}
+	return glob
+}
+
+// matchGlobs walks the static directories of the include globs and returns
+// the root-relative files that match one of them and no exclude glob
+func (rf *ReferenceFinder) matchGlobs(include, exclude []string) []string {
+	seen := make(map[string]bool)
+	for _, glob := range include {
+		dir := globStaticDir(glob)
+		filepath.WalkDir(filepath.Join(rf.root, dir), func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if shouldSkipDir(p, rf.root, rf.config) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if shouldSkipFile(d.Name(), rf.config) {
+				return nil
+			}
+			rel, err := filepath.Rel(rf.root, p)
+			if err != nil {
+				return nil
+			}
+			rel = filepath.ToSlash(rel)
+			if !utils.MatchGlob(glob, rel) {
+				return nil
+			}
+			for _, skip := range exclude {
+				if utils.MatchGlob(skip, rel) {
+					return nil
+				}
+			}
+			seen[rel] = true
+			return nil
+		})
+	}
+
+	files := make([]string, 0, len(seen))
+	for file := range seen {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// globStaticDir returns the directories of a glob before its first wildcard
+func globStaticDir(glob string) string {
+	var dirs []string
+	for _, part := range strings.Split(path.Dir(glob), "/") {
+		if strings.ContainsAny(part, "*?[") {
+			break
+		}
+		dirs = append(dirs, part)
+	}
+	return path.Join(dirs...)
+}
+
+// expandBraces expands {a,b} alternatives, which MatchGlob doesn't support:
+// icons/*.{png,svg} -> icons/*.png, icons/*.svg
+func expandBraces(glob string) []string {
+	open := strings.Index(glob, "{")
+	if open < 0 {
+		return []string{glob}
+	}
+	end := strings.Index(glob[open:], "}")
+	if end < 0 {
+		return []string{glob}
+	}
+	end += open
+
+	var globs []string
+	for _, alt := range strings.Split(glob[open+1:end], ",") {
+		globs = append(globs, expandBraces(glob[:open]+alt+glob[end+1:])...)
+	}
+	return globs
+}
+
+// isGlobPattern reports whether a matched string is a file glob rather than
+// a path; glob imports are expanded into references to each file instead
+func isGlobPattern(matched string) bool {
+	return strings.Contains(matched, "*")
+}
@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_GlobImports(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"src/icons.ts": "const icons = import.meta.glob('./assets/icons/*.svg', { eager: true })\n" +
+			"const photos = import.meta.glob<string>([\n  '/src/assets/photos/**/*.{jpg,webp}',\n  '!**/draft-*',\n], { query: '?url' })\n",
+		"src/assets/icons/home.svg":        "",
+		"src/assets/icons/menu.svg":        "",
+		"src/assets/icons/nested/deep.svg": "",
+		"src/assets/photos/2024/beach.jpg": "",
+		"src/assets/photos/2024/city.webp": "",
+		"src/assets/photos/draft-city.jpg": "",
+		"src/assets/photos/notes.txt":      "",
+		"src/assets/unused.png":            "",
+	})
+
+	references, err := NewReferenceFinder(tmpDir, config.DefaultConfig()).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	var got []string
+	for assetPath, refs := range references {
+		rel, _ := filepath.Rel(tmpDir, assetPath)
+		got = append(got, filepath.ToSlash(rel))
+		for _, ref := range refs {
+			if ref.IsDynamic {
+				t.Errorf("reference to %s marked dynamic", rel)
+			}
+		}
+	}
+	sort.Strings(got)
+	expected := []string{
+		"src/assets/icons/home.svg",
+		"src/assets/icons/menu.svg",
+		"src/assets/photos/2024/beach.jpg",
+		"src/assets/photos/2024/city.webp",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("referenced = %v, want %v", got, expected)
+	}
+}
+
+func TestReferenceFinder_RequireContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"src/images.js": "const images = require.context('./images', true, /\\.PNG$/i)\n" +
+			"const flags = require.context('../flags', false)\n" +
+			"const icons = require.context('./icons', true, /^\\.\\/(?!legacy).*\\.svg$/)\n" +
+			"export const get = (name) => images('./' + name + '.png')\n",
+		"src/images/logo.png":        "",
+		"src/images/team/alice.png":  "",
+		"src/images/hero.jpg":        "",
+		"flags/fr.svg":               "",
+		"flags/old/de.svg":           "",
+		"src/icons/legacy/arrow.svg": "",
+	})
+
+	references, err := NewReferenceFinder(tmpDir, config.DefaultConfig()).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	// The lookahead filter doesn't compile in Go, so every icon counts
+	for _, rel := range []string{"src/images/logo.png", "src/images/team/alice.png", "flags/fr.svg", "src/icons/legacy/arrow.svg"} {
+		refs := references[filepath.Join(tmpDir, filepath.FromSlash(rel))]
+		if len(refs) == 0 {
+			t.Errorf("no reference to %s", rel)
+		}
+		for _, ref := range refs {
+			if ref.IsDynamic {
+				t.Errorf("reference to %s marked dynamic", rel)
+			}
+		}
+	}
+	for _, rel := range []string{"src/images/hero.jpg", "flags/old/de.svg"} {
+		if refs := references[filepath.Join(tmpDir, filepath.FromSlash(rel))]; len(refs) != 0 {
+			t.Errorf("unexpected references to %s: %v", rel, refs)
+		}
+	}
+}
+
+func TestModuleGraph_GlobImports(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"src/main.ts":         "const pages = import.meta.glob('./pages/*.vue')\n",
+		"src/pages/Home.vue":  "<template></template>\n",
+		"src/pages/About.vue": "<template></template>\n",
+		"src/legacy/Old.vue":  "<template></template>\n",
+	})
+
+	graph, err := NewReferenceFinder(tmpDir, config.DefaultConfig()).BuildModuleGraph()
+	if err != nil {
+		t.Fatalf("BuildModuleGraph() failed: %v", err)
+	}
+	reachable := graph.Reachable([]string{"src/main.ts"})
+	for file, want := range map[string]bool{"src/pages/Home.vue": true, "src/pages/About.vue": true, "src/legacy/Old.vue": false} {
+		if reachable[file] != want {
+			t.Errorf("reachable[%s] = %v, want %v", file, reachable[file], want)
+		}
+	}
+}
+
+func TestExpandBraces(t *testing.T) {
+	got := expandBraces("icons/*.{png,svg}")
+	expected := []string{"icons/*.png", "icons/*.svg"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expandBraces() = %v, want %v", got, expected)
+	}
+	if got := globStaticDir("src/assets/**/*.png"); got != "src/assets" {
+		t.Errorf("globStaticDir() = %q, want src/assets", got)
+	}
+}
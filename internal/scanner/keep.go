@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// KeepFileName is the sidecar file that marks assets in its directory as kept
+const KeepFileName = ".easycleankeep"
+
+// keepAnnotation matches inline annotations like "// easyclean:keep assets/legacy/**"
+var keepAnnotation = regexp.MustCompile(`easyclean:keep\s+(\S+)`)
+
+// parseKeepAnnotations extracts keep globs from a single source line
+func parseKeepAnnotations(line string) []string {
+	var patterns []string
+	for _, match := range keepAnnotation.FindAllStringSubmatch(line, -1) {
+		patterns = append(patterns, strings.TrimPrefix(match[1], "./"))
+	}
+	return patterns
+}
+
+// parseKeepFile reads a .easycleankeep file and returns globs relative to root.
+// Each non-empty, non-# line is a glob relative to the file's directory;
+// an empty file keeps everything under its directory.
+func parseKeepFile(filePath, root string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	dir, err := filepath.Rel(root, filepath.Dir(filePath))
+	if err != nil {
+		return nil, err
+	}
+	dir = filepath.ToSlash(dir)
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, path.Join(dir, strings.TrimPrefix(line, "./")))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(patterns) == 0 {
+		patterns = append(patterns, path.Join(dir, "**"))
+	}
+	return patterns, nil
+}
@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestParseKeepAnnotations(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"// easyclean:keep assets/legacy/**", []string{"assets/legacy/**"}},
+		{"/* easyclean:keep ./public/og.png */", []string{"public/og.png"}},
+		{"# easyclean:keep a.png easyclean:keep b.png", []string{"a.png", "b.png"}},
+		{"const logo = 'logo.png'", nil},
+	}
+
+	for _, tt := range tests {
+		if got := parseKeepAnnotations(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseKeepAnnotations(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseKeepFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		dir     string
+		content string
+		want    []string
+	}{
+		{"globs", "assets/brand", "# marketing\nlogo-*.png\n\n./press/**\n", []string{"assets/brand/logo-*.png", "assets/brand/press/**"}},
+		{"empty keeps directory", "assets/legacy", "", []string{"assets/legacy/**"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.dir, KeepFileName)
+			createTestFile(t, path)
+			writeContent(t, path, tt.content)
+
+			got, err := parseKeepFile(path, tmpDir)
+			if err != nil {
+				t.Fatalf("parseKeepFile() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeepFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinders_KeepPatterns(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	createTestFile(t, filepath.Join(tmpDir, "assets", "legacy", KeepFileName))
+	writeContent(t, filepath.Join(tmpDir, "assets", "legacy", KeepFileName), "")
+	createTestFile(t, filepath.Join(tmpDir, "assets", "legacy", "old.png"))
+	createTestFile(t, filepath.Join(tmpDir, "src", "app.js"))
+	writeContent(t, filepath.Join(tmpDir, "src", "app.js"), "// easyclean:keep assets/seasonal/**\n")
+
+	cfg := config.DefaultConfig()
+
+	assetFinder := NewAssetFinder(tmpDir, cfg)
+	assets, err := assetFinder.FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	if len(assets) != 1 {
+		t.Errorf("FindAssets() found %d assets, want 1 (keep file is not an asset)", len(assets))
+	}
+
+	refFinder := NewReferenceFinder(tmpDir, cfg)
+	if _, err := refFinder.FindReferences(); err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	got := append(assetFinder.KeepPatterns(), refFinder.KeepPatterns()...)
+	sort.Strings(got)
+	want := []string{"assets/legacy/**", "assets/seasonal/**"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("KeepPatterns() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// maxLottieSize caps the JSON files read to tell Lottie animations apart
+// from config and data files
+const maxLottieSize = 32 << 20
+
+// lottieAnimation is a Lottie JSON file and the images it uses
+type lottieAnimation struct {
+	path     string
+	files    []string // images next to the animation, slash-separated u + p
+	embedded []embeddedImage
+}
+
+// embeddedImage identifies an image a Lottie file carries as a data URI
+type embeddedImage struct {
+	size int64
+	sum  [sha256.Size]byte
+}
+
+// readLottie parses path as a Lottie animation, or returns nil if it is some
+// other JSON: Lottie files carry a version, a frame rate, and layers
+func readLottie(filePath string) *lottieAnimation {
+	info, err := os.Stat(filePath)
+	if err != nil || info.Size() > maxLottieSize {
+		return nil
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+
+	var doc struct {
+		Version   string            `json:"v"`
+		FrameRate float64           `json:"fr"`
+		Layers    []json.RawMessage `json:"layers"`
+		Assets    []struct {
+			Dir  string `json:"u"`
+			Name string `json:"p"`
+		} `json:"assets"`
+	}
+	if json.Unmarshal(data, &doc) != nil || doc.Version == "" || doc.FrameRate == 0 || doc.Layers == nil {
+		return nil
+	}
+
+	animation := &lottieAnimation{path: filePath}
+	for _, asset := range doc.Assets {
+		// Precompositions are assets too, with layers instead of a file
+		switch {
+		case asset.Name == "":
+		case strings.HasPrefix(asset.Name, "data:"):
+			if image, ok := decodeDataURI(asset.Name); ok {
+				animation.embedded = append(animation.embedded, image)
+			}
+		case !strings.Contains(asset.Name, "://"):
+			animation.files = append(animation.files, path.Join(strings.TrimPrefix(asset.Dir, "/"), asset.Name))
+		}
+	}
+	return animation
+}
+
+// decodeDataURI hashes the bytes of a base64 data URI
+func decodeDataURI(uri string) (embeddedImage, bool) {
+	header, payload, ok := strings.Cut(uri, ",")
+	if !ok || !strings.HasSuffix(header, ";base64") {
+		return embeddedImage{}, false
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return embeddedImage{}, false
+	}
+	return embeddedImage{size: int64(len(data)), sum: sha256.Sum256(data)}, true
+}
+
+// lottieReferences returns the references Lottie animations make to image
+// files next to them, keyed by the absolute path of the image
+func lottieReferences(animations []*lottieAnimation) map[string][]*models.Reference {
+	references := make(map[string][]*models.Reference)
+	for _, animation := range animations {
+		for _, file := range animation.files {
+			target := filepath.Join(filepath.Dir(animation.path), filepath.FromSlash(file))
+			references[target] = append(references[target], &models.Reference{
+				SourceFile:  animation.path,
+				MatchedText: file,
+				Context:     "Lottie image asset",
+				Type:        models.RefTypeStringLiteral,
+				Confidence:  0.9,
+			})
+		}
+	}
+	return references
+}
+
+// markEmbeddedCopies sets EmbeddedIn on the image assets a Lottie animation
+// also carries as a data URI, comparing content hashes of same-size files
+// read within the limits of throttle
+func markEmbeddedCopies(root string, assets []models.AssetFile, animations []*lottieAnimation, throttle *utils.Throttle) {
+	bySize := make(map[int64][]int)
+	for i, asset := range assets {
+		if asset.Category == models.CategoryImage {
+			bySize[asset.Size] = append(bySize[asset.Size], i)
+		}
+	}
+
+	sums := make(map[int][sha256.Size]byte)
+	for _, animation := range animations {
+		rel, err := filepath.Rel(root, animation.path)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		for _, image := range animation.embedded {
+			for _, i := range bySize[image.size] {
+				sum, ok := sums[i]
+				if !ok {
+					throttle.Acquire()
+					data, err := os.ReadFile(assets[i].Path)
+					throttle.Release()
+					if err != nil {
+						continue
+					}
+					sum = sha256.Sum256(data)
+					sums[i] = sum
+				}
+				if sum == image.sum && !slices.Contains(assets[i].EmbeddedIn, rel) {
+					assets[i].EmbeddedIn = append(assets[i].EmbeddedIn, rel)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestFindAssets_Lottie(t *testing.T) {
+	tmpDir := t.TempDir()
+	animDir := filepath.Join(tmpDir, "assets", "anim")
+	if err := os.MkdirAll(filepath.Join(animDir, "images"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	star := "\x89PNG\r\n\x1a\nstar"
+	writeContent(t, filepath.Join(animDir, "images", "img_0.png"), "\x89PNG\r\n\x1a\nframe")
+	writeContent(t, filepath.Join(tmpDir, "assets", "star.png"), star)
+	writeContent(t, filepath.Join(tmpDir, "assets", "moon.png"), "\x89PNG\r\n\x1a\nmoon")
+	writeContent(t, filepath.Join(animDir, "intro.json"), `{"v":"5.7.4","fr":30,"ip":0,"op":60,"w":100,"h":100,"layers":[],
+		"assets":[{"id":"image_0","u":"images/","p":"img_0.png","e":0},
+		{"id":"image_1","u":"","p":"data:image/png;base64,`+base64.StdEncoding.EncodeToString([]byte(star))+`","e":1},
+		{"id":"comp_0","layers":[]}]}`)
+	writeContent(t, filepath.Join(tmpDir, "assets", "i18n.json"), `{"hello":"Hello","layers":"none"}`)
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+	finder := NewAssetFinder(tmpDir, cfg)
+	assets, err := finder.FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+
+	found := make(map[string]models.AssetFile)
+	for _, asset := range assets {
+		found[filepath.ToSlash(asset.RelativePath)] = asset
+	}
+	if len(found) != 4 {
+		t.Errorf("FindAssets() = %v, want 3 images and the animation, not i18n.json", found)
+	}
+	if anim := found["assets/anim/intro.json"]; anim.Category != models.CategoryAnimation {
+		t.Errorf("intro.json category = %v, want Animation", anim.Category)
+	}
+	if embedded := found["assets/star.png"].EmbeddedIn; len(embedded) != 1 || embedded[0] != "assets/anim/intro.json" {
+		t.Errorf("star.png EmbeddedIn = %v, want the animation", embedded)
+	}
+	if embedded := found["assets/moon.png"].EmbeddedIn; len(embedded) != 0 {
+		t.Errorf("moon.png EmbeddedIn = %v, want none", embedded)
+	}
+
+	refs := finder.LottieReferences()
+	frame := filepath.Join(animDir, "images", "img_0.png")
+	if len(refs) != 1 || len(refs[frame]) != 1 || refs[frame][0].SourceFile != filepath.Join(animDir, "intro.json") {
+		t.Errorf("LottieReferences() = %v, want img_0.png referenced by intro.json", refs)
+	}
+}
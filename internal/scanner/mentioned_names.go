@@ -0,0 +1,31 @@
+package scanner
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// fileNamePattern matches anything in source text shaped like a file path,
+// with or without a directory: logo.png, images/hero@2x.webp, /fonts/inter.woff2
+var fileNamePattern = regexp.MustCompile(`[\w@%+~.-]+(?:/[\w@%+~.-]+)*\.[A-Za-z0-9]{2,5}\b`)
+
+// MentionedFileNames returns the lowercased base name of every file-like token
+// in the project's source files. Assets kept outside the repo (object storage,
+// CDNs) are referenced by URL, so their names are all a scan can look for.
+func (rf *ReferenceFinder) MentionedFileNames() (map[string]bool, error) {
+	names := make(map[string]bool)
+	err := rf.walkSourceFiles(func(file string) {
+		src, err := rf.readSource(file)
+		if err != nil {
+			return
+		}
+		for _, token := range fileNamePattern.FindAllString(src.Content, -1) {
+			names[strings.ToLower(path.Base(token))] = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
@@ -0,0 +1,30 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_MentionedFileNames(t *testing.T) {
+	root := t.TempDir()
+	writeFileTree(t, root, map[string]string{
+		"src/App.tsx":       "const hero = `${CDN}/images/Hero@2x.webp`\n<img src=\"https://assets.example.com/logo.png?v=2\" />",
+		"src/theme.css":     "body { background: url(/bg/paper.jpg); }",
+		"node_modules/x.js": "'skipped.png'",
+	})
+
+	names, err := NewReferenceFinder(root, config.DefaultConfig()).MentionedFileNames()
+	if err != nil {
+		t.Fatalf("MentionedFileNames() error = %v", err)
+	}
+
+	for _, name := range []string{"hero@2x.webp", "logo.png", "paper.jpg"} {
+		if !names[name] {
+			t.Errorf("MentionedFileNames() missing %s", name)
+		}
+	}
+	if names["skipped.png"] {
+		t.Error("MentionedFileNames() read an excluded directory")
+	}
+}
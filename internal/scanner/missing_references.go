@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// MissingReferences returns the references in a source file to assets that
+// don't exist: typos, and files renamed or deleted since the code was written.
+// URLs, dynamic and commented-out references, and names without a configured
+// asset extension are never reported.
+func (rf *ReferenceFinder) MissingReferences(file string) ([]*models.Reference, error) {
+	refs, err := rf.scanFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []*models.Reference
+	for _, ref := range refs {
+		if ref.IsComment || ref.IsDynamic {
+			continue
+		}
+		target, _, _ := strings.Cut(ref.MatchedText, "?")
+		target, _, _ = strings.Cut(target, "#")
+		if strings.Contains(target, "://") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "data:") {
+			continue
+		}
+		if !utils.HasExtension(target, rf.config.Extensions) || len(rf.resourceFiles(target)) > 0 {
+			continue
+		}
+		if rf.assetExists(file, target) {
+			continue
+		}
+		missing = append(missing, ref)
+	}
+	return missing, nil
+}
+
+// assetExists reports whether a reference resolves from the project root,
+// the configured asset paths, or the referencing file's directory
+func (rf *ReferenceFinder) assetExists(file, target string) bool {
+	cleaned := rf.cleanPath(target)
+	if rf.tryExactMatch(cleaned) != "" || rf.tryAssetPathMatch(cleaned) != "" || rf.tryBasenameMatch(cleaned) != "" {
+		return true
+	}
+	return !strings.HasPrefix(target, "/") && utils.Exists(filepath.Join(filepath.Dir(file), target))
+}
@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_MissingReferences(t *testing.T) {
+	root := t.TempDir()
+	writeFileTree(t, root, map[string]string{
+		"public/images/logo.png": "png",
+		"src/icons/cart.svg":     "<svg/>",
+		"src/App.tsx": `import cart from './icons/cart.svg'
+const logo = '/images/logo.png'
+const hero = '/images/hero.png'
+const remote = 'https://cdn.example.com/missing.png'
+const themed = ` + "`/images/${theme}.png`" + `
+// const old = '/images/old.png'
+`,
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"public"}
+	missing, err := NewReferenceFinder(root, cfg).MissingReferences(filepath.Join(root, "src", "App.tsx"))
+	if err != nil {
+		t.Fatalf("MissingReferences() error = %v", err)
+	}
+
+	if len(missing) != 1 {
+		for _, ref := range missing {
+			t.Logf("missing: %s (line %d)", ref.MatchedText, ref.LineNumber)
+		}
+		t.Fatalf("MissingReferences() returned %d references, want 1", len(missing))
+	}
+	if missing[0].MatchedText != "/images/hero.png" || missing[0].LineNumber != 3 {
+		t.Errorf("MissingReferences() = %s on line %d, want /images/hero.png on line 3", missing[0].MatchedText, missing[0].LineNumber)
+	}
+}
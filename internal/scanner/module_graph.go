@@ -0,0 +1,382 @@
+package scanner
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// moduleExtensions are source files that take part in the module graph;
+// other source files (Go, Swift, Kotlin, ...) are always treated as reachable.
+// Templates (templateExtensions) take part through their includes.
+var moduleExtensions = map[string]bool{
+	".js": true, ".jsx": true, ".ts": true, ".tsx": true, ".mjs": true, ".cjs": true,
+	".vue": true, ".svelte": true,
+	".css": true, ".scss": true, ".sass": true, ".less": true,
+	".html": true, ".htm": true,
+	".dart": true,
+}
+
+// resolveExtensions are tried, in order, for extensionless import specifiers
+var resolveExtensions = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs", ".vue", ".svelte", ".dart", ".scss", ".css"}
+
+var (
+	// import x from '...', export { x } from '...', import '...'
+	esImportPattern = regexp.MustCompile(`(?:\bimport|\bexport)\s+(?:[^'";]*?\bfrom\s*)?['"]([^'"\n]+)['"]`)
+	// import('...') and require('...')
+	callImportPattern = regexp.MustCompile(`(?:\bimport|\brequire)\s*\(\s*['"]([^'"\n]+)['"]`)
+	// @import 'x.css', @use 'x', @forward 'x'
+	cssImportPattern = regexp.MustCompile(`@(?:import|use|forward)\s+(?:url\(\s*)?['"]?([^'")\s;]+)`)
+	// <script src="..."> and <link href="...">
+	htmlModulePattern = regexp.MustCompile(`<(?:script|link)\b[^>]*\b(?:src|href)\s*=\s*['"]([^'"]+)['"]`)
+	// import 'package:app/x.dart', export '...', part '...'
+	dartImportPattern = regexp.MustCompile(`(?m)^\s*(?:import|export|part)\s+['"]([^'"]+)['"]`)
+	// Angular component resources: templateUrl: './x.html', styleUrl: './x.scss'
+	angularResourcePattern = regexp.MustCompile(`\b(?:templateUrl|styleUrl)\s*:\s*['"]([^'"\n]+)['"]`)
+	// styleUrls: ['./a.scss', './b.css'], possibly across lines
+	angularStyleUrlsPattern = regexp.MustCompile(`\bstyleUrls\s*:\s*\[([^\]]*)\]`)
+	// name: my_app in pubspec.yaml
+	pubspecNamePattern = regexp.MustCompile(`(?m)^name:\s*(\S+)`)
+)
+
+// ModuleGraph records which module files import which, keyed by paths
+// relative to the project root
+type ModuleGraph struct {
+	root        string
+	files       map[string]bool
+	imports     map[string][]string
+	included    map[string]bool // templates some other template includes
+	importedBy  map[string][]string
+	dartPackage string
+}
+
+// BuildModuleGraph parses imports from every module file a scan would read
+func (rf *ReferenceFinder) BuildModuleGraph() (*ModuleGraph, error) {
+	g := &ModuleGraph{
+		root:     rf.root,
+		files:    make(map[string]bool),
+		imports:  make(map[string][]string),
+		included: make(map[string]bool),
+	}
+	if data, err := os.ReadFile(filepath.Join(rf.root, "pubspec.yaml")); err == nil {
+		if m := pubspecNamePattern.FindSubmatch(data); m != nil {
+			g.dartPackage = string(m[1])
+		}
+	}
+
+	var sources []string
+	err := rf.walkSourceFiles(func(file string) {
+		if ext := strings.ToLower(filepath.Ext(file)); moduleExtensions[ext] || templateExtensions[ext] {
+			sources = append(sources, file)
+			g.files[g.rel(file)] = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range sources {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		from := g.rel(file)
+		for _, spec := range importSpecifiers(from, string(data)) {
+			if target := g.resolve(from, spec); target != "" {
+				g.imports[from] = append(g.imports[from], target)
+			}
+		}
+		for _, target := range rf.globImportTargets(file, string(data)) {
+			if g.files[target] && target != from {
+				g.imports[from] = append(g.imports[from], target)
+			}
+		}
+		if includesTemplates(from) {
+			for _, spec := range includeSpecifiers(string(data)) {
+				if target := g.resolveInclude(from, spec); target != "" {
+					g.imports[from] = append(g.imports[from], target)
+					g.included[target] = true
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// importSpecifiers extracts the module specifiers a file imports
+func importSpecifiers(file, content string) []string {
+	var patterns []*regexp.Regexp
+	if isTemplateFile(file) {
+		return nil // linked by includeSpecifiers instead
+	}
+	switch strings.ToLower(path.Ext(file)) {
+	case ".css", ".scss", ".sass", ".less":
+		patterns = []*regexp.Regexp{cssImportPattern}
+	case ".html", ".htm":
+		patterns = []*regexp.Regexp{htmlModulePattern}
+	case ".dart":
+		patterns = []*regexp.Regexp{dartImportPattern}
+	case ".vue", ".svelte":
+		patterns = []*regexp.Regexp{esImportPattern, callImportPattern, cssImportPattern}
+	default:
+		patterns = []*regexp.Regexp{esImportPattern, callImportPattern}
+	}
+
+	var specs []string
+	for _, pattern := range patterns {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			specs = append(specs, match[1])
+		}
+	}
+	if ext := strings.ToLower(path.Ext(file)); ext == ".ts" || ext == ".js" {
+		specs = append(specs, componentResources(content)...)
+	}
+	return specs
+}
+
+// componentResources extracts the template and stylesheets of Angular
+// components, which resolve relative to the component file
+func componentResources(content string) []string {
+	var resources []string
+	for _, match := range angularResourcePattern.FindAllStringSubmatch(content, -1) {
+		resources = append(resources, match[1])
+	}
+	for _, list := range angularStyleUrlsPattern.FindAllStringSubmatch(content, -1) {
+		for _, match := range quotedStringPattern.FindAllStringSubmatch(list[1], -1) {
+			resources = append(resources, match[1])
+		}
+	}
+
+	for i, resource := range resources {
+		if !strings.HasPrefix(resource, ".") && !strings.HasPrefix(resource, "/") && !strings.Contains(resource, ":") {
+			resources[i] = "./" + resource
+		}
+	}
+	return resources
+}
+
+// resolve maps an import specifier to a module file in the graph, or ""
+// for packages and files outside the project
+func (g *ModuleGraph) resolve(from, spec string) string {
+	spec = strings.SplitN(strings.SplitN(spec, "?", 2)[0], "#", 2)[0]
+
+	var base string
+	switch {
+	case strings.HasPrefix(spec, "package:"):
+		name, rest, _ := strings.Cut(strings.TrimPrefix(spec, "package:"), "/")
+		if name != g.dartPackage {
+			return ""
+		}
+		base = path.Join("lib", rest)
+	case strings.HasPrefix(spec, "./"), strings.HasPrefix(spec, "../"):
+		base = path.Join(path.Dir(from), spec)
+	case strings.HasPrefix(spec, "/"):
+		base = strings.TrimPrefix(spec, "/")
+	case strings.HasPrefix(spec, "@/"), strings.HasPrefix(spec, "~/"):
+		// Common bundler alias for src/
+		base = path.Join("src", spec[2:])
+	case path.Ext(from) == ".dart" && !strings.Contains(spec, ":"):
+		base = path.Join(path.Dir(from), spec)
+	case isStyleFile(from) && !strings.Contains(spec, ":"):
+		// Stylesheet imports are relative without a ./ prefix
+		base = path.Join(path.Dir(from), spec)
+	case isHTMLFile(from) && !strings.Contains(spec, "://"):
+		base = path.Join(path.Dir(from), spec)
+	default:
+		return ""
+	}
+
+	candidates := []string{base}
+	for _, ext := range resolveExtensions {
+		candidates = append(candidates, base+ext)
+	}
+	for _, ext := range resolveExtensions {
+		candidates = append(candidates, path.Join(base, "index"+ext))
+	}
+	if isStyleFile(from) {
+		// Sass partials: @use 'theme' -> _theme.scss, @use 'theme' -> theme/_index.scss;
+		// Less: @import 'vars' -> vars.less
+		dir, name := path.Split(base)
+		candidates = append(candidates,
+			path.Join(dir, "_"+name+".scss"), path.Join(dir, "_"+name+".sass"), base+".sass", base+".less",
+			path.Join(base, "_index.scss"), path.Join(base, "_index.sass"))
+	}
+
+	for _, candidate := range candidates {
+		if g.files[candidate] {
+			return candidate
+		}
+	}
+	if strings.ToLower(path.Ext(base)) == ".css" {
+		return g.compiledFrom(base)
+	}
+	return ""
+}
+
+// compiledFrom maps a stylesheet that only exists once built (css/main.css)
+// to the Sass or Less source of the same name, preferring the one nearest it
+func (g *ModuleGraph) compiledFrom(css string) string {
+	stem := strings.TrimSuffix(path.Base(css), path.Ext(css))
+	best, bestScore := "", -1
+	for _, file := range g.Files() {
+		ext := strings.ToLower(path.Ext(file))
+		if ext != ".scss" && ext != ".sass" && ext != ".less" {
+			continue
+		}
+		if strings.TrimSuffix(path.Base(file), path.Ext(file)) != stem {
+			continue
+		}
+		if score := sharedDirs(css, file); score > bestScore {
+			best, bestScore = file, score
+		}
+	}
+	return best
+}
+
+// Reachable returns the module files reachable from files matching the entry
+// globs, following stylesheet imports into the partials they pull in. Templates no other template includes are pages rendered by server
+// code, so they are roots too unless named like partials; they only count
+// once an entry matched, as without one the analysis is skipped.
+func (g *ModuleGraph) Reachable(entries []string) map[string]bool {
+	reachable := make(map[string]bool)
+	var queue []string
+
+	for _, file := range g.Files() {
+		for _, entry := range entries {
+			if utils.MatchGlob(entry, file) {
+				reachable[file] = true
+				queue = append(queue, file)
+				break
+			}
+		}
+	}
+	if len(queue) > 0 {
+		for _, file := range g.Files() {
+			if isTemplateFile(file) && !g.included[file] && !isPartial(file) && !reachable[file] {
+				reachable[file] = true
+				queue = append(queue, file)
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		file := queue[0]
+		queue = queue[1:]
+		for _, target := range g.imports[file] {
+			if !reachable[target] {
+				reachable[target] = true
+				queue = append(queue, target)
+			}
+		}
+	}
+
+	return reachable
+}
+
+// Files returns the module files in the graph, sorted
+func (g *ModuleGraph) Files() []string {
+	files := make([]string, 0, len(g.files))
+	for file := range g.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Imports returns the module files imported by file
+func (g *ModuleGraph) Imports(file string) []string {
+	return g.imports[file]
+}
+
+// MarkUnreachable flags references whose source module is in the graph but
+// not reachable, returning how many were flagged
+func (g *ModuleGraph) MarkUnreachable(references map[string][]*models.Reference, reachable map[string]bool) int {
+	marked := 0
+	for _, refs := range references {
+		for _, ref := range refs {
+			source := g.rel(ref.SourceFile)
+			if g.files[source] && !reachable[source] {
+				ref.FromUnreachable = true
+				marked++
+			}
+		}
+	}
+	return marked
+}
+
+// UnreachableChains maps the source file of each reference MarkUnreachable
+// flagged to its ImporterChain, so an asset used only from an orphaned
+// stylesheet can name the files that leave it unused
+func (g *ModuleGraph) UnreachableChains(references map[string][]*models.Reference) map[string][]string {
+	chains := make(map[string][]string)
+	for _, refs := range references {
+		for _, ref := range refs {
+			if _, done := chains[ref.SourceFile]; ref.FromUnreachable && !done {
+				chains[ref.SourceFile] = g.ImporterChain(g.rel(ref.SourceFile))
+			}
+		}
+	}
+	return chains
+}
+
+// ImporterChain returns file followed by a module importing it, one
+// importing that, and so on up to a module nothing imports. Where several
+// modules import a file the first in path order is followed.
+func (g *ModuleGraph) ImporterChain(file string) []string {
+	if g.importedBy == nil {
+		g.importedBy = make(map[string][]string)
+		for _, source := range g.Files() {
+			for _, target := range g.imports[source] {
+				g.importedBy[target] = append(g.importedBy[target], source)
+			}
+		}
+	}
+
+	chain := []string{file}
+	seen := map[string]bool{file: true}
+	for {
+		next := ""
+		for _, importer := range g.importedBy[file] {
+			if !seen[importer] {
+				next = importer
+				break
+			}
+		}
+		if next == "" {
+			return chain
+		}
+		chain = append(chain, next)
+		seen[next] = true
+		file = next
+	}
+}
+
+// rel converts a path to the slash-separated, root-relative form used as keys
+func (g *ModuleGraph) rel(file string) string {
+	if rel, err := filepath.Rel(g.root, file); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(file)
+}
+
+// isStyleFile reports whether a module is a stylesheet
+func isStyleFile(file string) bool {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".css", ".scss", ".sass", ".less":
+		return true
+	}
+	return false
+}
+
+// isHTMLFile reports whether a module is an HTML page
+func isHTMLFile(file string) bool {
+	ext := strings.ToLower(path.Ext(file))
+	return ext == ".html" || ext == ".htm"
+}
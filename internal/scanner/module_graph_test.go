@@ -0,0 +1,213 @@
+package scanner
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestModuleGraph_Reachable(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"index.html":                `<script type="module" src="/src/main.ts"></script>`,
+		"src/main.ts":               "import App from './App'\nimport './styles/app.scss'\n",
+		"src/App.tsx":               "import {\n  Header,\n} from '@/components'\nconst Page = () => import('./pages/Page.vue')\n",
+		"src/components/index.ts":   "export { Header } from './Header'\n",
+		"src/components/Header.tsx": "import logo from '../../assets/logo.png'\nimport React from 'react'\n",
+		"src/pages/Page.vue":        "<script>\nimport x from './x'\n</script>\n",
+		"src/styles/app.scss":       "@use 'theme';\n",
+		"src/styles/_theme.scss":    "$c: red;\n",
+		"src/old/Legacy.tsx":        "import banner from '../../assets/banner.png'\n",
+	})
+
+	finder := NewReferenceFinder(tmpDir, config.DefaultConfig())
+	graph, err := finder.BuildModuleGraph()
+	if err != nil {
+		t.Fatalf("BuildModuleGraph() failed: %v", err)
+	}
+
+	reachable := graph.Reachable([]string{"index.html"})
+
+	var got []string
+	for _, file := range graph.Files() {
+		if !reachable[file] {
+			got = append(got, file)
+		}
+	}
+	expected := []string{"src/old/Legacy.tsx"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unreachable files = %v, want %v", got, expected)
+	}
+
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if marked := graph.MarkUnreachable(references, reachable); marked == 0 {
+		t.Error("MarkUnreachable() flagged no references")
+	}
+	for _, refs := range references {
+		for _, ref := range refs {
+			want := filepath.Base(ref.SourceFile) == "Legacy.tsx"
+			if ref.FromUnreachable != want {
+				t.Errorf("%s:%d FromUnreachable = %v, want %v", ref.SourceFile, ref.LineNumber, ref.FromUnreachable, want)
+			}
+		}
+	}
+}
+
+func TestModuleGraph_AngularComponentResources(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"src/main.ts": "import { AppComponent } from './app/app.component'\n",
+		"src/app/app.component.ts": "@Component({\n  templateUrl: './app.component.html',\n" +
+			"  styleUrls: [\n    './app.component.scss',\n    '../styles/grid.css',\n  ],\n})\n",
+		"src/app/app.component.html":   `<img src="assets/logo.png">`,
+		"src/app/app.component.scss":   ".hero { background: url('../assets/hero.png'); }\n",
+		"src/styles/grid.css":          ".grid {}\n",
+		"src/card/card.component.ts":   "@Component({ templateUrl: 'card.component.html', styleUrl: 'card.component.scss' })\n",
+		"src/card/card.component.html": "<div></div>",
+		"src/card/card.component.scss": ".card {}\n",
+		"src/old/old.component.scss":   ".old { background: url('../assets/old.png'); }\n",
+	})
+
+	graph, err := NewReferenceFinder(tmpDir, config.DefaultConfig()).BuildModuleGraph()
+	if err != nil {
+		t.Fatalf("BuildModuleGraph() failed: %v", err)
+	}
+
+	reachable := graph.Reachable([]string{"src/main.ts", "src/card/card.component.ts"})
+
+	var got []string
+	for _, file := range graph.Files() {
+		if !reachable[file] {
+			got = append(got, file)
+		}
+	}
+	expected := []string{"src/old/old.component.scss"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unreachable files = %v, want %v", got, expected)
+	}
+}
+
+func TestModuleGraph_StylesheetImports(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		// css/main.css is compiled from scss/main.scss
+		"index.html":                   `<link rel="stylesheet" href="css/main.css">`,
+		"scss/main.scss":               "@use 'partials/hero';\n@use 'components';\n",
+		"scss/partials/_hero.scss":     "@import 'buttons';\n.hero { background: url('../assets/hero.jpg'); }\n",
+		"scss/partials/_buttons.scss":  ".btn { background: url('../assets/button.png'); }\n",
+		"scss/components/_index.scss":  "@forward 'card';\n",
+		"scss/components/_card.scss":   ".card {}\n",
+		"scss/partials/_old-hero.scss": ".old { background: url('../assets/old-hero.jpg'); }\n",
+		"assets/hero.jpg":              "",
+		"assets/button.png":            "",
+		"assets/old-hero.jpg":          "",
+	})
+
+	finder := NewReferenceFinder(tmpDir, config.DefaultConfig())
+	graph, err := finder.BuildModuleGraph()
+	if err != nil {
+		t.Fatalf("BuildModuleGraph() failed: %v", err)
+	}
+
+	reachable := graph.Reachable([]string{"index.html"})
+
+	var got []string
+	for _, file := range graph.Files() {
+		if !reachable[file] {
+			got = append(got, file)
+		}
+	}
+	expected := []string{"scss/partials/_old-hero.scss"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unreachable files = %v, want %v", got, expected)
+	}
+
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	graph.MarkUnreachable(references, reachable)
+	for _, ref := range references[filepath.Join(tmpDir, "assets", "hero.jpg")] {
+		if ref.FromUnreachable {
+			t.Errorf("hero.jpg reference from %s flagged unreachable", ref.SourceFile)
+		}
+	}
+}
+
+func TestModuleGraph_UnreachableChains(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"index.html":           `<link rel="stylesheet" href="css/main.css">`,
+		"css/main.css":         ".a { background: url('../assets/hero.jpg'); }\n",
+		"css/old-theme.css":    "@import 'legacy.css';\n",
+		"css/legacy.css":       ".old { background: url('../assets/bg.png'); }\n",
+		"css/legacy-print.css": "@import 'legacy.css';\n",
+		"assets/hero.jpg":      "",
+		"assets/bg.png":        "",
+	})
+
+	finder := NewReferenceFinder(tmpDir, config.DefaultConfig())
+	graph, err := finder.BuildModuleGraph()
+	if err != nil {
+		t.Fatalf("BuildModuleGraph() failed: %v", err)
+	}
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	graph.MarkUnreachable(references, graph.Reachable([]string{"index.html"}))
+
+	chains := graph.UnreachableChains(references)
+	if _, ok := chains[filepath.Join(tmpDir, "css", "main.css")]; ok {
+		t.Error("UnreachableChains() lists reachable css/main.css")
+	}
+	// Of the two importers, the first in path order is followed
+	got := chains[filepath.Join(tmpDir, "css", "legacy.css")]
+	expected := []string{"css/legacy.css", "css/legacy-print.css"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("UnreachableChains() for css/legacy.css = %v, want %v", got, expected)
+	}
+}
+
+func TestModuleGraph_DartPackageImports(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"pubspec.yaml":                  "name: my_app\n",
+		"lib/main.dart":                 "import 'package:my_app/screens/home.dart';\nimport 'package:flutter/material.dart';\n",
+		"lib/screens/home.dart":         "part 'home_widgets.dart';\n",
+		"lib/screens/home_widgets.dart": "",
+		"lib/unused.dart":               "",
+	})
+
+	finder := NewReferenceFinder(tmpDir, config.DefaultConfig())
+	graph, err := finder.BuildModuleGraph()
+	if err != nil {
+		t.Fatalf("BuildModuleGraph() failed: %v", err)
+	}
+
+	reachable := graph.Reachable([]string{"lib/main.dart"})
+	for file, want := range map[string]bool{
+		"lib/screens/home.dart":         true,
+		"lib/screens/home_widgets.dart": true,
+		"lib/unused.dart":               false,
+	} {
+		if reachable[file] != want {
+			t.Errorf("reachable[%s] = %v, want %v", file, reachable[file], want)
+		}
+	}
+}
+
+// writeFileTree writes files given as relative path -> content under root
+func writeFileTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		createTestFile(t, path)
+		writeContent(t, path, content)
+	}
+}
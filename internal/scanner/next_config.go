@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// nextConfigFiles are the names Next.js loads its config from
+var nextConfigFiles = []string{"next.config.js", "next.config.mjs", "next.config.cjs", "next.config.ts"}
+
+var (
+	// basePath: '/docs'
+	nextBasePathPattern = regexp.MustCompile(`\bbasePath\s*:\s*['"\x60](/[^'"\x60]*)['"\x60]`)
+	// assetPrefix: 'https://cdn.example.com' or '/static'
+	nextAssetPrefixPattern = regexp.MustCompile(`\bassetPrefix\s*:\s*['"\x60]([^'"\x60]+)['"\x60]`)
+)
+
+// nextConfig holds the next.config settings that change the URLs public/
+// files are served from
+type nextConfig struct {
+	basePath    string // path prefix of every route and public file
+	assetPrefix string // URL or path prefix of static files
+}
+
+// readNextConfig reads basePath and assetPrefix from the project's
+// next.config; the zero value when there is none or they are computed
+func readNextConfig(root string) nextConfig {
+	for _, name := range nextConfigFiles {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			continue
+		}
+		var cfg nextConfig
+		if m := nextBasePathPattern.FindSubmatch(data); m != nil {
+			cfg.basePath = strings.TrimSuffix(string(m[1]), "/")
+		}
+		if m := nextAssetPrefixPattern.FindSubmatch(data); m != nil {
+			cfg.assetPrefix = strings.TrimSuffix(string(m[1]), "/")
+		}
+		return cfg
+	}
+	return nextConfig{}
+}
+
+// stripNextPrefix removes the basePath or a path assetPrefix from a
+// reference, so /docs/images/a.png resolves to public/images/a.png
+func (rf *ReferenceFinder) stripNextPrefix(ref string) string {
+	for _, prefix := range []string{rf.next.basePath, rf.next.assetPrefix} {
+		if !strings.HasPrefix(prefix, "/") || prefix == "/" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(ref, prefix); ok && strings.HasPrefix(rest, "/") {
+			return rest
+		}
+	}
+	return ref
+}
@@ -0,0 +1,112 @@
+package scanner
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/parser"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// PatternMatch describes a single regex match for pattern debugging
+type PatternMatch struct {
+	LineNumber int
+	Line       string
+	Type       string
+	Confidence float32
+	Pattern    string
+	Groups     []string // capture groups, Groups[0] is the whole match
+	IsComment  bool
+	IsDynamic  bool
+	Resolved   string // asset path the match resolves to, relative to root when possible
+	Exists     bool   // whether the resolved asset exists on disk
+}
+
+// MatchPatterns runs every active pattern over r (line by line, or over the
+// whole sample for multi-line patterns) and reports each match with its
+// capture groups and the asset path it resolves to; relative paths resolve
+// from dir, the sample file's directory
+func (rf *ReferenceFinder) MatchPatterns(r io.Reader, dir string) ([]PatternMatch, error) {
+	var matches []PatternMatch
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	src := parser.NewSourceText(string(data))
+
+	for i := 0; i < src.LineCount(); i++ {
+		line := src.Line(i)
+
+		for _, patternDef := range rf.patterns {
+			if patternDef.Multiline {
+				continue
+			}
+			for _, groups := range patternDef.Pattern.FindAllStringSubmatch(line, -1) {
+				match := PatternMatch{
+					LineNumber: i + 1,
+					Line:       strings.TrimSpace(line),
+					Type:       patternDef.Type,
+					Confidence: patternDef.Confidence,
+					Pattern:    patternDef.Pattern.String(),
+					Groups:     groups,
+					IsComment:  rf.isCommentLine(line),
+					IsDynamic:  rf.isDynamicReference(line),
+				}
+				if len(groups) < 2 {
+					matches = append(matches, match)
+					continue
+				}
+				// One entry per asset, so srcset lists show each resolution
+				for _, assetPath := range patternDef.AssetPaths(groups) {
+					match.Resolved, match.Exists = rf.describeResolution(dir, assetPath)
+					matches = append(matches, match)
+				}
+			}
+		}
+	}
+
+	// Multi-line patterns see the whole sample
+	for _, patternDef := range rf.patterns {
+		if !patternDef.Multiline {
+			continue
+		}
+		for _, loc := range patternDef.Pattern.FindAllStringSubmatchIndex(src.Content, -1) {
+			located := parser.LocateMatch(src, loc)
+			match := PatternMatch{
+				LineNumber: located.LineNumber,
+				Line:       strings.Join(strings.Fields(located.Groups[0]), " "),
+				Type:       patternDef.Type,
+				Confidence: patternDef.Confidence,
+				Pattern:    patternDef.Pattern.String(),
+				Groups:     located.Groups,
+				IsComment:  rf.isCommentLine(src.Line(located.StartLine - 1)),
+				IsDynamic:  rf.isDynamicReference(located.Groups[0]),
+			}
+			if len(located.Groups) > 1 {
+				match.Resolved, match.Exists = rf.describeResolution(dir, patternDef.AssetPath(located.Groups))
+			}
+			matches = append(matches, match)
+		}
+	}
+
+	return matches, nil
+}
+
+// describeResolution resolves a matched path and reports it relative to the root
+func (rf *ReferenceFinder) describeResolution(dir, matched string) (string, bool) {
+	resolved := rf.resolveAssetPath(dir, matched)
+	if files := rf.resourceFiles(matched); len(files) > 0 {
+		resolved = files[0]
+	}
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(rf.root, resolved)
+	}
+	exists := utils.Exists(resolved)
+
+	if rel, err := filepath.Rel(rf.root, resolved); err == nil {
+		resolved = filepath.ToSlash(rel)
+	}
+	return resolved, exists
+}
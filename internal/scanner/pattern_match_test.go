@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_MatchPatterns(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "logo.png"))
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+	cfg.CustomPatterns = []string{`asset\("([^"]+)"\)`}
+	finder := NewReferenceFinder(tmpDir, cfg)
+
+	snippet := `import logo from './assets/logo.png';
+// const old = "missing.jpg";
+img := asset("brand")`
+
+	matches, err := finder.MatchPatterns(strings.NewReader(snippet), tmpDir)
+	if err != nil {
+		t.Fatalf("MatchPatterns() failed: %v", err)
+	}
+
+	var sawImport, sawComment, sawCustom bool
+	for _, m := range matches {
+		switch {
+		case m.Type == "Import" && m.LineNumber == 1:
+			sawImport = true
+			if m.Resolved != "assets/logo.png" || !m.Exists {
+				t.Errorf("import resolved to %s (exists=%v), want assets/logo.png", m.Resolved, m.Exists)
+			}
+			if len(m.Groups) < 2 || m.Groups[1] != "./assets/logo.png" {
+				t.Errorf("import groups = %v", m.Groups)
+			}
+		case m.LineNumber == 2:
+			sawComment = m.IsComment
+			if m.Exists {
+				t.Errorf("missing.jpg should not resolve to an existing asset")
+			}
+		case m.Type == "Custom":
+			sawCustom = m.Groups[1] == "brand"
+		}
+	}
+
+	if !sawImport {
+		t.Error("Expected an Import match on line 1")
+	}
+	if !sawComment {
+		t.Error("Expected line 2 match to be flagged as comment")
+	}
+	if !sawCustom {
+		t.Error("Expected custom pattern to match asset(\"brand\")")
+	}
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// PatternTiming is the time one pattern spent across all profiled files
+type PatternTiming struct {
+	Type     string
+	Pattern  string
+	Duration time.Duration
+	Matches  int
+}
+
+// FileTiming is the total pattern time spent on one source file
+type FileTiming struct {
+	Path           string // relative to root
+	Lines          int
+	Bytes          int64
+	Duration       time.Duration
+	SlowestPattern string
+}
+
+// PatternProfile is the result of ProfilePatterns
+type PatternProfile struct {
+	Patterns     []PatternTiming // sorted slowest first
+	Files        []FileTiming    // sorted slowest first
+	FilesScanned int
+	LinesScanned int
+	Total        time.Duration
+}
+
+// ProfilePatterns runs every active pattern over each source file that
+// FindReferences would scan and records the time spent per pattern and file,
+// prefilter checks included. AST parsing is not included.
+func (rf *ReferenceFinder) ProfilePatterns() (*PatternProfile, error) {
+	profile := &PatternProfile{Patterns: make([]PatternTiming, len(rf.patterns))}
+	for i, patternDef := range rf.patterns {
+		profile.Patterns[i] = PatternTiming{Type: patternDef.Type, Pattern: patternDef.Pattern.String()}
+	}
+
+	err := rf.walkSourceFiles(func(path string) {
+		src, err := rf.readSource(path)
+		if err != nil {
+			return
+		}
+		lines := make([]string, src.LineCount())
+		for i := range lines {
+			lines[i] = src.Line(i)
+		}
+
+		file := FileTiming{Path: path, Lines: len(lines), Bytes: int64(len(src.Content))}
+		if rel, err := filepath.Rel(rf.root, path); err == nil {
+			file.Path = filepath.ToSlash(rel)
+		}
+
+		var slowest time.Duration
+		for i, patternDef := range rf.patterns {
+			start := time.Now()
+			matches := 0
+			if patternDef.Multiline {
+				if rf.matcher.MayMatch(i, src.Content) {
+					matches += len(patternDef.Pattern.FindAllStringSubmatchIndex(src.Content, -1))
+				}
+			} else {
+				for _, line := range lines {
+					if rf.matcher.MayMatch(i, line) {
+						matches += len(patternDef.Pattern.FindAllStringSubmatchIndex(line, -1))
+					}
+				}
+			}
+			elapsed := time.Since(start)
+
+			profile.Patterns[i].Duration += elapsed
+			profile.Patterns[i].Matches += matches
+			file.Duration += elapsed
+			if elapsed > slowest {
+				slowest = elapsed
+				file.SlowestPattern = patternDef.Type
+			}
+		}
+
+		profile.Files = append(profile.Files, file)
+		profile.FilesScanned++
+		profile.LinesScanned += len(lines)
+		profile.Total += file.Duration
+	})
+
+	sort.SliceStable(profile.Patterns, func(i, j int) bool {
+		return profile.Patterns[i].Duration > profile.Patterns[j].Duration
+	})
+	sort.SliceStable(profile.Files, func(i, j int) bool {
+		return profile.Files[i].Duration > profile.Files[j].Duration
+	})
+
+	return profile, err
+}
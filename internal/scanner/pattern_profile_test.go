@@ -0,0 +1,47 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_ProfilePatterns(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "app.js"))
+	writeContent(t, filepath.Join(tmpDir, "app.js"), "import logo from './logo.png';\nconst a = 1;\n")
+	createTestFile(t, filepath.Join(tmpDir, "node_modules", "lib.js"))
+
+	cfg := config.DefaultConfig()
+	finder := NewReferenceFinder(tmpDir, cfg)
+
+	profile, err := finder.ProfilePatterns()
+	if err != nil {
+		t.Fatalf("ProfilePatterns() failed: %v", err)
+	}
+
+	if profile.FilesScanned != 1 {
+		t.Errorf("FilesScanned = %d, want 1", profile.FilesScanned)
+	}
+	if profile.LinesScanned != 2 {
+		t.Errorf("LinesScanned = %d, want 2", profile.LinesScanned)
+	}
+	if len(profile.Patterns) != len(finder.Patterns()) {
+		t.Errorf("len(Patterns) = %d, want %d", len(profile.Patterns), len(finder.Patterns()))
+	}
+	if len(profile.Files) != 1 || profile.Files[0].Path != "app.js" {
+		t.Errorf("Files = %+v, want app.js", profile.Files)
+	}
+
+	matches := 0
+	for i, p := range profile.Patterns {
+		matches += p.Matches
+		if i > 0 && p.Duration > profile.Patterns[i-1].Duration {
+			t.Error("Patterns should be sorted slowest first")
+		}
+	}
+	if matches == 0 {
+		t.Error("Expected pattern matches to be counted")
+	}
+}
@@ -0,0 +1,38 @@
+package scanner
+
+import "strings"
+
+// stripPublicBaseURL turns an absolute URL under a configured public base URL
+// into the path it is served from: https://cdn.example.com/img/a.png -> /img/a.png.
+// The scheme is optional on either side so protocol-relative URLs match too.
+func (rf *ReferenceFinder) stripPublicBaseURL(ref string) string {
+	if !strings.Contains(ref, "//") {
+		return ref
+	}
+	url := withoutScheme(ref)
+	bases := rf.config.PublicBaseURLs
+	if strings.Contains(rf.next.assetPrefix, "//") {
+		// next.config assetPrefix serves static files from a CDN
+		bases = append(bases[:len(bases):len(bases)], rf.next.assetPrefix)
+	}
+	for _, base := range bases {
+		base = strings.TrimSuffix(withoutScheme(base), "/")
+		if base == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(url, base); ok && (rest == "" || rest[0] == '/') {
+			// Cache-busting query strings don't change the file
+			rest, _, _ = strings.Cut(rest, "?")
+			return "/" + strings.TrimPrefix(rest, "/")
+		}
+	}
+	return ref
+}
+
+// withoutScheme drops http:, https:, and the // of a URL
+func withoutScheme(url string) string {
+	if i := strings.Index(url, "://"); i >= 0 {
+		return url[i+3:]
+	}
+	return strings.TrimPrefix(url, "//")
+}
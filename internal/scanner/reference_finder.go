@@ -1,9 +1,11 @@
 package scanner
 
 import (
-	"bufio"
+	"context"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/HabibPro1999/easyClean/internal/models"
@@ -16,8 +18,23 @@ type ReferenceFinder struct {
 	config          *models.ProjectConfig
 	root            string
 	patterns        []parser.ReferencePattern
+	matcher         *parser.Matcher
 	projectType     models.ProjectType
 	patternProvider parser.PatternProvider
+	keepPatterns    []string
+	goPackages      map[string]map[string]bool // dir -> unused unexported funcs
+	dart            *dartIndex                 // Dart constants, built on first .dart file
+	resources       map[string][]string        // name -> files, for name-only references
+	cssVars         map[string][]string        // custom property -> asset URLs
+	next            nextConfig                 // basePath and assetPrefix from next.config
+	assets          *AssetIndex                // assets the scan found, when set
+	tree            *FileTree                  // shared walk, when set
+	filesScanned    int
+	bytesRead       int64
+	walkWarnings    []string // limits the last source walk hit
+	ctx             context.Context
+	checkpoint      *Checkpoint
+	throttle        *utils.Throttle
 }
 
 // NewReferenceFinder creates a new ReferenceFinder instance
@@ -31,51 +48,285 @@ func NewReferenceFinder(root string, config *models.ProjectConfig) *ReferenceFin
 	}
 
 	provider := parser.GetPatternProvider(projectType)
+	patterns := provider.GetPatterns()
+
+	// Custom patterns are validated when the config is loaded
+	if custom, err := parser.CompileCustomPatterns(config.CustomPatterns); err == nil {
+		patterns = append(patterns, custom...)
+	}
+	if config.EmailTemplates {
+		patterns = appendMissingPatterns(patterns, parser.EmailPatterns())
+	}
+	patterns = appendMissingPatterns(patterns, parser.MediaLoaderPatterns())
+	patterns = appendMissingPatterns(patterns, parser.DocumentPatterns())
 
 	return &ReferenceFinder{
 		config:          config,
 		root:            root,
-		patterns:        provider.GetPatterns(),
+		patterns:        patterns,
+		matcher:         parser.NewMatcher(patterns),
 		projectType:     projectType,
 		patternProvider: provider,
+		next:            readNextConfig(root),
 	}
 }
 
-// FindReferences scans source files and finds references to assets
+// SetContext has FindReferences stop early once ctx is canceled
+func (rf *ReferenceFinder) SetContext(ctx context.Context) {
+	rf.ctx = ctx
+}
+
+// SetCheckpoint has FindReferences resume from and save its progress to cp
+func (rf *ReferenceFinder) SetCheckpoint(cp *Checkpoint) {
+	rf.checkpoint = cp
+}
+
+// SetThrottle has source files read within the limits of throttle, with a
+// pause after each directory FindReferences reads
+func (rf *ReferenceFinder) SetThrottle(throttle *utils.Throttle) {
+	rf.throttle = throttle
+}
+
+// SetAssetIndex has references resolved against the assets a scan found
+// rather than by walking the asset directories for each one
+func (rf *ReferenceFinder) SetAssetIndex(index *AssetIndex) {
+	rf.assets = index
+}
+
+// SetFileTree has every pass over source files use a walk shared with the
+// AssetFinder instead of walking the project again
+func (rf *ReferenceFinder) SetFileTree(tree *FileTree) {
+	rf.tree = tree
+}
+
+// appendMissingPatterns appends the patterns not already in patterns
+func appendMissingPatterns(patterns, extra []parser.ReferencePattern) []parser.ReferencePattern {
+	for _, p := range extra {
+		found := false
+		for _, existing := range patterns {
+			if existing.Pattern == p.Pattern {
+				found = true
+				break
+			}
+		}
+		if !found {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// FindReferences scans source files and finds references to assets. With a
+// checkpoint set, directories an interrupted scan completed are restored
+// rather than read again, and progress is checkpointed as the scan goes;
+// once the context set with SetContext is canceled, the progress is saved
+// and the context's error returned.
 func (rf *ReferenceFinder) FindReferences() (map[string][]*models.Reference, error) {
 	references := make(map[string][]*models.Reference)
+	rf.filesScanned, rf.bytesRead = 0, 0
 
-	err := filepath.WalkDir(rf.root, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return nil
+	var paths []string
+	err := rf.walkSourceFiles(func(path string) {
+		paths = append(paths, path)
+	})
+	if err != nil {
+		return references, err
+	}
+
+	var run *checkpointRun
+	if rf.checkpoint != nil {
+		run = newCheckpointRun(rf.checkpoint, paths, rf.next.basePath+"\x00"+rf.next.assetPrefix)
+	}
+	lastDir := ""
+	for _, path := range paths {
+		if dir := filepath.Dir(path); dir != lastDir {
+			if lastDir != "" {
+				rf.throttle.Pause()
+			}
+			lastDir = dir
+		}
+		if rf.ctx != nil && rf.ctx.Err() != nil {
+			if run != nil {
+				if err := rf.checkpoint.Flush(); err != nil {
+					return references, err
+				}
+			}
+			return references, rf.ctx.Err()
+		}
+		if run == nil {
+			rf.findFileReferences(path, references, nil)
+			continue
 		}
 
-		// Skip directories and excluded paths
-		if d.IsDir() {
-			if shouldExcludeDir(path, rf.root, rf.config.ExcludePaths) {
-				return filepath.SkipDir
+		if restored, skip := run.restored(path); skip {
+			if restored != nil {
+				rf.restoreReferences(restored, references)
 			}
-			return nil
+			continue
 		}
+		record := run.start(path)
+		keep, scanned, read := len(rf.keepPatterns), rf.filesScanned, rf.bytesRead
+		rf.findFileReferences(path, references, record)
+		record.KeepPatterns = append(record.KeepPatterns, rf.keepPatterns[keep:]...)
+		record.FilesScanned += rf.filesScanned - scanned
+		record.BytesRead += rf.bytesRead - read
+		if err := run.finish(path); err != nil {
+			return references, err
+		}
+	}
 
-		// Only scan source files
-		if rf.isSourceFile(path) {
-			refs, err := rf.scanFile(path)
-			if err == nil {
-				// Group references by the asset path they reference
-				for _, ref := range refs {
-					assetPath := rf.resolveAssetPath(ref.MatchedText)
-					if assetPath != "" {
-						references[assetPath] = append(references[assetPath], ref)
-					}
-				}
+	return references, nil
+}
+
+// findFileReferences adds the references of one source file, recording
+// them in the checkpoint record of its directory when one is given
+func (rf *ReferenceFinder) findFileReferences(path string, references map[string][]*models.Reference, record *checkpointDir) {
+	refs, err := rf.scanFile(path)
+	if err != nil {
+		return
+	}
+
+	// Group references by the asset path they reference. The AST and
+	// regex passes can spell one asset differently on the same line
+	// (./logo.png, ../assets/logo.png), so those merge once resolved.
+	found := make(map[string][]*models.Reference)
+	var order []string
+	seen := make(map[referenceKey]int)
+	add := func(assetPath string, ref *models.Reference) {
+		key := referenceKey{ref.SourceFile, ref.LineNumber, assetPath}
+		if i, ok := seen[key]; ok {
+			if ref.Confidence > found[assetPath][i].Confidence {
+				found[assetPath][i] = ref
+			}
+			return
+		}
+		if _, ok := found[assetPath]; !ok {
+			order = append(order, assetPath)
+		}
+		seen[key] = len(found[assetPath])
+		found[assetPath] = append(found[assetPath], ref)
+	}
+	for _, ref := range refs {
+		// Name-only references can stand for several files (@2x, @3x, ...)
+		if files := rf.resourceFiles(ref.MatchedText); len(files) > 0 {
+			for _, file := range files {
+				add(file, ref)
 			}
+			continue
 		}
 
-		return nil
-	})
+		if assetPath := rf.resolveAssetPath(filepath.Dir(ref.SourceFile), ref.MatchedText); assetPath != "" {
+			add(assetPath, ref)
+		}
+	}
+
+	for _, assetPath := range order {
+		references[assetPath] = append(references[assetPath], found[assetPath]...)
+		if record != nil {
+			for _, ref := range found[assetPath] {
+				record.add(assetPath, ref)
+			}
+		}
+	}
+}
+
+// readSource reads a source file within the limits of the throttle
+func (rf *ReferenceFinder) readSource(path string) (*parser.SourceText, error) {
+	rf.throttle.Acquire()
+	defer rf.throttle.Release()
+	return parser.ReadSource(path)
+}
+
+// restoreReferences adds the references a checkpoint recorded for a directory
+func (rf *ReferenceFinder) restoreReferences(record *checkpointDir, references map[string][]*models.Reference) {
+	for assetPath, indexes := range record.ByAsset {
+		for _, i := range indexes {
+			references[assetPath] = append(references[assetPath], record.References[i])
+		}
+	}
+	rf.keepPatterns = append(rf.keepPatterns, record.KeepPatterns...)
+	rf.filesScanned += record.FilesScanned
+	rf.bytesRead += record.BytesRead
+}
+
+// walkSourceFiles calls fn for every source file under the root outside
+// excluded paths, from the shared walk when one is set
+func (rf *ReferenceFinder) walkSourceFiles(fn func(path string)) error {
+	tree := rf.tree
+	if tree == nil {
+		walked, err := WalkTree(rf.root, rf.config)
+		if err != nil {
+			return err
+		}
+		tree = walked
+	}
+	rf.walkWarnings = tree.Warnings()
 
-	return references, err
+	for _, path := range tree.Files() {
+		// Only scan source and content files; the license mapping lists asset paths but never uses them
+		if !shouldSkipFile(filepath.Base(path), rf.config) && (rf.isSourceFile(path) || rf.isContentFile(path)) && !rf.isLicenseFile(path) {
+			fn(path)
+		}
+	}
+	return nil
+}
+
+// FilesScanned returns how many source files the last FindReferences read
+func (rf *ReferenceFinder) FilesScanned() int {
+	return rf.filesScanned
+}
+
+// BytesRead returns the total size of the source files the last FindReferences read
+func (rf *ReferenceFinder) BytesRead() int64 {
+	return rf.bytesRead
+}
+
+// Warnings describes the traversal limits (max_depth, max_files) the last
+// walk over source files hit
+func (rf *ReferenceFinder) Warnings() []string {
+	return rf.walkWarnings
+}
+
+// KeepPatterns returns keep globs collected from easyclean:keep annotations during FindReferences
+func (rf *ReferenceFinder) KeepPatterns() []string {
+	return rf.keepPatterns
+}
+
+// PatternProvider returns the pattern provider selected for the project type
+func (rf *ReferenceFinder) PatternProvider() parser.PatternProvider {
+	return rf.patternProvider
+}
+
+// Patterns returns the regex patterns applied to each line, including custom patterns
+func (rf *ReferenceFinder) Patterns() []parser.ReferencePattern {
+	return rf.patterns
+}
+
+// SourceExtensions returns the sorted file extensions scanned for references,
+// combining the provider's extensions with the generic source extensions
+func (rf *ReferenceFinder) SourceExtensions() []string {
+	seen := make(map[string]bool, len(sourceExtensions))
+	for ext := range sourceExtensions {
+		seen[ext] = true
+	}
+	for ext := range templateExtensions {
+		seen[ext] = true
+	}
+	for _, ext := range rf.patternProvider.SupportedFileExtensions() {
+		seen[ext] = true
+	}
+	if rf.config.EmailTemplates {
+		for _, ext := range parser.EmailExtensions {
+			seen[ext] = true
+		}
+	}
+
+	exts := make([]string, 0, len(seen))
+	for ext := range seen {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return exts
 }
 
 // sourceExtensions maps file extensions to source code files
@@ -104,8 +355,25 @@ func (rf *ReferenceFinder) isSourceFile(path string) bool {
 		}
 	}
 
-	// Fallback to generic source extensions
-	return sourceExtensions[ext]
+	if rf.config.EmailTemplates {
+		for _, supported := range parser.EmailExtensions {
+			if ext == supported {
+				return true
+			}
+		}
+	}
+
+	// Fallback to generic source extensions and templates; web manifests are
+	// JSON/XML files that only matter under their conventional names
+	return sourceExtensions[ext] || templateExtensions[strings.ToLower(ext)] || isWebManifest(path)
+}
+
+// isLicenseFile checks if a file is the configured asset license mapping
+func (rf *ReferenceFinder) isLicenseFile(path string) bool {
+	if rf.config.LicenseFile == "" {
+		return false
+	}
+	return path == filepath.Join(rf.root, rf.config.LicenseFile)
 }
 
 // scanFile scans a single file for asset references
@@ -114,67 +382,165 @@ func (rf *ReferenceFinder) scanFile(path string) ([]*models.Reference, error) {
 
 	// Check if we should use AST parsing for this file
 	ext := filepath.Ext(path)
-	useAST := rf.patternProvider.UseASTParsing() &&
+	useAST := !rf.config.RegexOnly && rf.patternProvider.UseASTParsing() &&
 		(ext == ".js" || ext == ".jsx" || ext == ".ts" || ext == ".tsx")
 
+	// Read the whole file once; line scanners cap line length and miss minified code
+	src, err := rf.readSource(path)
+	if err != nil {
+		return nil, err
+	}
+	rf.filesScanned++
+	rf.bytesRead += int64(len(src.Content))
+
+	// CSS-in-JS url()s come first so they win ties in deduplication over line
+	// matches marked dynamic by unrelated interpolations
+	if ext == ".js" || ext == ".jsx" || ext == ".ts" || ext == ".tsx" {
+		references = append(references, rf.styledReferences(path, src)...)
+	}
+
 	if useAST {
 		// Use AST parser for deep analysis
 		astParser := parser.NewASTParser(path)
-		astRefs, err := astParser.ParseFile()
-		if err == nil && len(astRefs) > 0 {
-			references = append(references, astRefs...)
-		}
+		references = append(references, astParser.ParseSource(src)...)
 		// Continue with regex patterns as fallback/supplement
 	}
 
 	// Regex-based scanning (works for all files)
-	file, err := os.Open(path)
-	if err != nil {
-		return references, err // Return AST results if available
-	}
-	defer file.Close()
+	for i := 0; i < src.LineCount(); i++ {
+		lineNumber := i + 1
+		line := src.Line(i)
 
-	scanner := bufio.NewScanner(file)
-	lineNumber := 0
-
-	for scanner.Scan() {
-		lineNumber++
-		line := scanner.Text()
+		// Collect easyclean:keep annotations
+		rf.keepPatterns = append(rf.keepPatterns, parseKeepAnnotations(line)...)
 
 		// Check if line is a comment
 		isComment := rf.isCommentLine(line)
 
-		// Try each pattern
-		for _, patternDef := range rf.patterns {
-			matches := patternDef.Pattern.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) > 1 {
-					ref := &models.Reference{
-						SourceFile:  path,
-						LineNumber:  lineNumber,
-						MatchedText: match[1],
-						Context:     strings.TrimSpace(line),
-						Type:        rf.stringToRefType(patternDef.Type),
-						Confidence:  patternDef.Confidence,
-						IsComment:   isComment,
-						IsDynamic:   rf.isDynamicReference(line),
-					}
-					references = append(references, ref)
+		// Try each pattern whose prefilter literals appear in the line
+		rf.matcher.Match(line, func(patternDef parser.ReferencePattern, match []string, loc []int) {
+			if len(match) < 2 {
+				return
+			}
+			// srcset-style patterns name several assets in one match
+			for _, assetPath := range patternDef.AssetPaths(match) {
+				ref := &models.Reference{
+					SourceFile:  path,
+					LineNumber:  lineNumber,
+					Column:      matchColumn(line, loc, assetPath),
+					MatchedText: assetPath,
+					Context:     strings.TrimSpace(line),
+					Type:        rf.stringToRefType(patternDef.Type),
+					Confidence:  patternDef.Confidence,
+					IsComment:   isComment,
+					IsDynamic:   rf.isDynamicReference(line),
 				}
+				references = append(references, ref)
 			}
+		})
+	}
+
+	// Multi-line patterns run over the whole file for references split across lines
+	rf.matcher.MatchSource(src, func(patternDef parser.ReferencePattern, match parser.SourceMatch) {
+		if len(match.Groups) > 1 {
+			references = append(references, &models.Reference{
+				SourceFile:  path,
+				LineNumber:  match.LineNumber,
+				Column:      src.Column(match.Offset),
+				MatchedText: patternDef.AssetPath(match.Groups),
+				Context:     strings.Join(strings.Fields(match.Groups[0]), " "),
+				Type:        rf.stringToRefType(patternDef.Type),
+				Confidence:  patternDef.Confidence,
+				IsComment:   rf.isCommentLine(src.Line(match.StartLine - 1)),
+				IsDynamic:   rf.isDynamicReference(match.Groups[0]),
+			})
 		}
+	})
+
+	// Dart asset paths kept in constants are used where the constant is read
+	if ext == ".dart" {
+		references = append(references, rf.dartConstantReferences(path, src)...)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return references, err
+	// CMS content files name assets in front matter and data fields
+	references = append(references, rf.contentReferences(path, src)...)
+
+	// Web app manifests declare PWA icons and tiles
+	references = append(references, rf.webManifestReferences(path, src)...)
+
+	// Service workers list assets for offline caching
+	if ext == ".js" || ext == ".ts" || ext == ".mjs" {
+		references = append(references, rf.precacheReferences(path, src)...)
 	}
 
-	// De-duplicate references (AST + regex may find same references)
+	// Themed CSS reads asset URLs through custom properties
+	references = append(references, rf.cssVarReferences(path, src)...)
+
+	// Vite glob imports and webpack require.context bundle every file they
+	// match; a glob itself matched as a string literal names no file
+	references = append(references, rf.globImportReferences(path, src)...)
+	kept := references[:0]
+	for _, ref := range references {
+		if !isGlobPattern(ref.MatchedText) {
+			kept = append(kept, ref)
+		}
+	}
+	references = kept
+
+	// Locate references before their paths are normalized, then
+	// de-duplicate them (AST + regex may find same references)
+	locateReferences(src, references)
+	// Lines inside multi-line comments don't look like comments on their own
+	if comments := parser.CommentRanges(src, ext); len(comments) > 0 {
+		for _, ref := range references {
+			if ref.Column > 0 && parser.InComment(comments, ref.Offset) {
+				ref.IsComment = true
+			}
+		}
+	}
+	for _, ref := range references {
+		ref.MatchedText = normalizeReferencePath(ref.MatchedText)
+	}
 	references = rf.deduplicateReferences(references)
 
+	if rf.config.DeadCodeAnalysis {
+		if ranges := rf.deadCodeRanges(path, src); len(ranges) > 0 {
+			for _, ref := range references {
+				ref.IsDeadCode = inRanges(ref.LineNumber, ranges)
+			}
+		}
+	}
+	if ext == ".dart" && rf.config.DeadCodeAnalysis {
+		rf.markUnusedDartConstants(path, references)
+	}
+
 	return references, nil
 }
 
+// styledReferences returns the static url() references in styled-components
+// and emotion templates
+func (rf *ReferenceFinder) styledReferences(path string, src *parser.SourceText) []*models.Reference {
+	if !strings.Contains(src.Content, "`") {
+		return nil
+	}
+
+	var refs []*models.Reference
+	for _, url := range parser.FindStyledURLs(src) {
+		line := src.Line(url.LineNumber - 1)
+		refs = append(refs, &models.Reference{
+			SourceFile:  path,
+			LineNumber:  url.LineNumber,
+			Column:      src.Column(url.Offset),
+			MatchedText: url.Path,
+			Context:     strings.TrimSpace(line),
+			Type:        models.RefTypeCSSUrl,
+			Confidence:  parser.StyledURLConfidence,
+			IsComment:   rf.isCommentLine(line),
+		})
+	}
+	return refs
+}
+
 // isCommentLine checks if a line is primarily a comment
 func (rf *ReferenceFinder) isCommentLine(line string) bool {
 	trimmed := strings.TrimSpace(line)
@@ -194,9 +560,19 @@ func (rf *ReferenceFinder) isDynamicReference(line string) bool {
 		strings.Contains(line, "join")
 }
 
-// resolveAssetPath attempts to resolve a matched reference to an actual asset path
-func (rf *ReferenceFinder) resolveAssetPath(matched string) string {
+// resolveAssetPath attempts to resolve a reference matched in a file in
+// fromDir to an actual asset path, relative to that file first
+func (rf *ReferenceFinder) resolveAssetPath(fromDir, matched string) string {
+	if path := rf.tryRelativeMatch(fromDir, matched); path != "" {
+		return path
+	}
 	cleaned := rf.cleanPath(matched)
+	// WordPress paths are relative to the theme or plugin directory
+	if rf.projectType == models.ProjectTypeWordPress {
+		if path := rf.tryAncestorMatch(fromDir, cleaned); path != "" {
+			return path
+		}
+	}
 
 	// Try strategies in order
 	if path := rf.tryExactMatch(cleaned); path != "" {
@@ -212,8 +588,34 @@ func (rf *ReferenceFinder) resolveAssetPath(matched string) string {
 	return cleaned
 }
 
-// cleanPath removes leading ./ or / from path
+// tryRelativeMatch resolves a path against the referencing file's directory,
+// as browsers, bundlers, and CSS do for ./logo.png and ../img/bg.png; root
+// paths and URLs never resolve here, nor do files outside the project
+func (rf *ReferenceFinder) tryRelativeMatch(fromDir, matched string) string {
+	if fromDir == "" || strings.HasPrefix(matched, "/") || strings.Contains(matched, ":") {
+		return ""
+	}
+	matched, _, _ = strings.Cut(matched, "?")
+	matched, _, _ = strings.Cut(matched, "#")
+	if matched == "" {
+		return ""
+	}
+
+	target := filepath.Join(fromDir, filepath.FromSlash(matched))
+	rel, err := filepath.Rel(rf.root, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	if info, err := os.Stat(target); err != nil || info.IsDir() {
+		return ""
+	}
+	return target
+}
+
+// cleanPath removes a public base URL, the Next.js basePath, and leading ./
+// or / from path
 func (rf *ReferenceFinder) cleanPath(path string) string {
+	path = rf.stripNextPrefix(rf.stripPublicBaseURL(path))
 	cleaned := strings.TrimPrefix(path, "./")
 	cleaned = strings.TrimPrefix(cleaned, "/")
 	return cleaned
@@ -221,6 +623,11 @@ func (rf *ReferenceFinder) cleanPath(path string) string {
 
 // tryExactMatch tries to match the path exactly from project root
 func (rf *ReferenceFinder) tryExactMatch(cleaned string) string {
+	if rf.assets != nil {
+		if asset := rf.assets.ByRelativePath(cleaned); asset != "" {
+			return asset
+		}
+	}
 	fullPath := filepath.Join(rf.root, cleaned)
 	if utils.Exists(fullPath) {
 		return fullPath
@@ -239,7 +646,7 @@ func (rf *ReferenceFinder) tryAssetPathMatch(cleaned string) string {
 	for _, assetPath := range rf.config.AssetPaths {
 		// Try with asset path prefix
 		fullPath := filepath.Join(rf.root, assetPath, cleaned)
-		if utils.Exists(fullPath) {
+		if rf.hasFile(fullPath) {
 			return fullPath
 		}
 
@@ -248,7 +655,7 @@ func (rf *ReferenceFinder) tryAssetPathMatch(cleaned string) string {
 			withoutPrefix := strings.TrimPrefix(cleaned, assetPath)
 			withoutPrefix = strings.TrimPrefix(withoutPrefix, "/")
 			fullPath := filepath.Join(rf.root, assetPath, withoutPrefix)
-			if utils.Exists(fullPath) {
+			if rf.hasFile(fullPath) {
 				return fullPath
 			}
 		}
@@ -256,9 +663,18 @@ func (rf *ReferenceFinder) tryAssetPathMatch(cleaned string) string {
 	return ""
 }
 
-// tryBasenameMatch tries to find asset by basename in configured asset paths
+// tryBasenameMatch tries to find asset by basename in configured asset paths,
+// looking it up in the asset index when one is set instead of walking them
 func (rf *ReferenceFinder) tryBasenameMatch(cleaned string) string {
 	basename := filepath.Base(cleaned)
+	if rf.assets != nil {
+		for _, assetPath := range rf.config.AssetPaths {
+			if found := rf.assets.FindByName(filepath.Join(rf.root, assetPath), basename); found != "" {
+				return found
+			}
+		}
+		return ""
+	}
 	for _, assetPath := range rf.config.AssetPaths {
 		assetDir := filepath.Join(rf.root, assetPath)
 		if !utils.Exists(assetDir) {
@@ -271,7 +687,13 @@ func (rf *ReferenceFinder) tryBasenameMatch(cleaned string) string {
 			if err != nil {
 				return nil
 			}
-			if !d.IsDir() && filepath.Base(path) == basename {
+			if d.IsDir() {
+				if path != assetDir && shouldSkipDir(path, rf.root, rf.config) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Base(path) == basename && !shouldSkipFile(basename, rf.config) {
 				foundPath = path
 				return filepath.SkipAll
 			}
@@ -285,6 +707,15 @@ func (rf *ReferenceFinder) tryBasenameMatch(cleaned string) string {
 	return ""
 }
 
+// hasFile reports whether path exists, answering from the asset index for
+// assets the scan found
+func (rf *ReferenceFinder) hasFile(path string) bool {
+	if rf.assets != nil && rf.assets.Has(path) {
+		return true
+	}
+	return utils.Exists(path)
+}
+
 // stringToRefType converts a string type to ReferenceType
 func (rf *ReferenceFinder) stringToRefType(typeStr string) models.ReferenceType {
 	switch typeStr {
@@ -300,23 +731,91 @@ func (rf *ReferenceFinder) stringToRefType(typeStr string) models.ReferenceType
 		return models.RefTypeImport
 	case "YAMLAsset":
 		return models.RefTypeConfig
+	case "IOSNamedAsset", "IOSBundleResource", "AndroidResource", "MediaLoader", "WordPressAsset":
+		return models.RefTypeFunctionCall
 	default:
 		return models.RefTypeStringLiteral
 	}
 }
 
-// deduplicateReferences removes duplicate references (same file + line + matched text)
+// matchColumn returns the 1-based column of an asset path within a match on
+// line, or of the match's first group when the path was rewritten
+func matchColumn(line string, loc []int, assetPath string) int {
+	if assetPath != "" {
+		if i := strings.Index(line[loc[0]:loc[1]], assetPath); i >= 0 {
+			return loc[0] + i + 1
+		}
+	}
+	if len(loc) > 2 && loc[2] >= 0 {
+		return loc[2] + 1
+	}
+	return loc[0] + 1
+}
+
+// locateReferences fills in the byte offset of every reference, and the
+// column of those whose source only knew the line by finding the matched
+// text on it
+func locateReferences(src *parser.SourceText, refs []*models.Reference) {
+	for _, ref := range refs {
+		if ref.LineNumber < 1 || ref.LineNumber > src.LineCount() {
+			continue
+		}
+		if ref.Column == 0 && ref.MatchedText != "" {
+			if i := strings.Index(src.Line(ref.LineNumber-1), ref.MatchedText); i >= 0 {
+				ref.Column = i + 1
+			}
+		}
+		if ref.Column > 0 {
+			ref.Offset = src.LineStart(ref.LineNumber) + ref.Column - 1
+		}
+	}
+}
+
+// normalizeReferencePath gives the spellings of one path the same form:
+// backslashes become slashes, and redundant ./ and // segments go; a
+// trailing slash (directory references) and URLs are kept as they are
+func normalizeReferencePath(matched string) string {
+	if strings.Contains(matched, "://") || strings.HasPrefix(matched, "data:") || strings.HasPrefix(matched, "//") {
+		return matched
+	}
+	normalized := strings.ReplaceAll(strings.ReplaceAll(matched, `\\`, "/"), `\`, "/")
+	if normalized == "" || strings.Contains(normalized, "${") {
+		return normalized
+	}
+
+	cleaned := path.Clean(normalized)
+	if strings.HasSuffix(normalized, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	if cleaned == "." {
+		return matched
+	}
+	return cleaned
+}
+
+// referenceKey identifies the references one source line makes to one path
+type referenceKey struct {
+	file string
+	line int
+	path string
+}
+
+// deduplicateReferences removes duplicate references (same file + line + matched text),
+// keeping the highest-confidence one
 func (rf *ReferenceFinder) deduplicateReferences(refs []*models.Reference) []*models.Reference {
-	seen := make(map[string]bool)
+	seen := make(map[referenceKey]int)
 	var unique []*models.Reference
 
 	for _, ref := range refs {
-		// Create a key from source file, line number, and matched text
-		key := filepath.Join(ref.SourceFile, string(rune(ref.LineNumber)), ref.MatchedText)
-		if !seen[key] {
-			seen[key] = true
-			unique = append(unique, ref)
+		key := referenceKey{ref.SourceFile, ref.LineNumber, ref.MatchedText}
+		if i, ok := seen[key]; ok {
+			if ref.Confidence > unique[i].Confidence {
+				unique[i] = ref
+			}
+			continue
 		}
+		seen[key] = len(unique)
+		unique = append(unique, ref)
 	}
 
 	return unique
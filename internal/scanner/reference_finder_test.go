@@ -3,6 +3,8 @@ package scanner
 import (
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/HabibPro1999/easyClean/internal/config"
@@ -146,6 +148,45 @@ const actualRef = "assets/icon.svg";
 	}
 }
 
+func TestReferenceFinder_BlockCommentDetection(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"old.png", "hero.png", "legacy.png"} {
+		createTestFile(t, filepath.Join(tmpDir, "assets", name))
+	}
+	createTestFile(t, filepath.Join(tmpDir, "src", "App.tsx"))
+	writeContent(t, filepath.Join(tmpDir, "src", "App.tsx"), `export const App = () => (
+  <div>
+    {/*
+      <img src="/assets/old.png" />
+    */}
+    <img src="/assets/hero.png" />
+  </div>
+)
+/*
+const legacy = require('../assets/legacy.png')
+*/
+`)
+
+	finder := NewReferenceFinder(tmpDir, config.DefaultConfig())
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	for name, wantComment := range map[string]bool{"old.png": true, "legacy.png": true, "hero.png": false} {
+		refs := references[filepath.Join(tmpDir, "assets", name)]
+		if len(refs) == 0 {
+			t.Errorf("no references to %s", name)
+			continue
+		}
+		for _, ref := range refs {
+			if ref.IsComment != wantComment {
+				t.Errorf("reference to %s on line %d: IsComment = %v, want %v", name, ref.LineNumber, ref.IsComment, wantComment)
+			}
+		}
+	}
+}
+
 func TestReferenceFinder_DynamicReferenceDetection(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -232,6 +273,30 @@ func TestReferenceFinder_isSourceFile(t *testing.T) {
 	}
 }
 
+func TestReferenceFinder_SourceExtensions(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ProjectType = models.ProjectTypeWebVue
+	finder := NewReferenceFinder(".", cfg)
+
+	exts := finder.SourceExtensions()
+	if !sort.StringsAreSorted(exts) {
+		t.Errorf("SourceExtensions() = %v, want sorted", exts)
+	}
+
+	for _, want := range []string{".js", ".vue", ".css"} {
+		found := false
+		for _, ext := range exts {
+			if ext == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("SourceExtensions() missing %s", want)
+		}
+	}
+}
+
 func TestReferenceFinder_resolveAssetPath(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -255,13 +320,92 @@ func TestReferenceFinder_resolveAssetPath(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := finder.resolveAssetPath(tt.input)
+		result := finder.resolveAssetPath(tmpDir, tt.input)
 		if result != tt.expected {
 			t.Errorf("resolveAssetPath(%s) = %s, expected %s", tt.input, result, tt.expected)
 		}
 	}
 }
 
+func TestReferenceFinder_AssetIndex(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "icons", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "public", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "public", "icon.svg"))
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/", "public/"}
+	assets, err := NewAssetFinder(tmpDir, cfg).FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	index := NewAssetIndex(assets)
+	if index.Len() != 3 {
+		t.Fatalf("Expected 3 indexed assets, got %d", index.Len())
+	}
+
+	finder := NewReferenceFinder(tmpDir, cfg)
+	finder.SetAssetIndex(index)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"/public/icon.svg", filepath.Join(tmpDir, "public", "icon.svg")},
+		{"icon.svg", filepath.Join(tmpDir, "public", "icon.svg")},
+		{"logo.png", filepath.Join(tmpDir, "public", "logo.png")},
+		{"icons/logo.png", filepath.Join(tmpDir, "assets", "icons", "logo.png")},
+		{"img/logo.png", filepath.Join(tmpDir, "assets", "icons", "logo.png")}, // basename match, first asset path
+		{"img/missing.png", "img/missing.png"},
+	}
+	for _, tt := range tests {
+		if got := finder.resolveAssetPath(tmpDir, tt.input); got != tt.expected {
+			t.Errorf("resolveAssetPath(%s) = %s, expected %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestReferenceFinder_RelativeResolution(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "src", "components", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "src", "styles", "img", "bg.png"))
+	writeContent(t, filepath.Join(tmpDir, "src", "components", "Header.tsx"),
+		"import logo from './logo.png'\nimport brand from '../../assets/logo.png'\n")
+	writeContent(t, filepath.Join(tmpDir, "src", "styles", "app.css"), ".hero { background: url(img/bg.png); }\n")
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+	finder := NewReferenceFinder(tmpDir, cfg)
+
+	components := filepath.Join(tmpDir, "src", "components")
+	tests := []struct {
+		fromDir  string
+		input    string
+		expected string
+	}{
+		{components, "./logo.png", filepath.Join(components, "logo.png")},
+		{components, "../../assets/logo.png", filepath.Join(tmpDir, "assets", "logo.png")},
+		{components, "/logo.png", filepath.Join(tmpDir, "assets", "logo.png")}, // root paths skip the file's directory
+		{filepath.Join(tmpDir, "src"), "./logo.png", filepath.Join(tmpDir, "assets", "logo.png")},
+	}
+	for _, tt := range tests {
+		if got := finder.resolveAssetPath(tt.fromDir, tt.input); got != tt.expected {
+			t.Errorf("resolveAssetPath(%s, %s) = %s, expected %s", tt.fromDir, tt.input, got, tt.expected)
+		}
+	}
+
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	for _, asset := range []string{"src/components/logo.png", "assets/logo.png", "src/styles/img/bg.png"} {
+		if len(references[filepath.Join(tmpDir, filepath.FromSlash(asset))]) != 1 {
+			t.Errorf("Expected one reference to %s, got %v", asset, references[filepath.Join(tmpDir, filepath.FromSlash(asset))])
+		}
+	}
+}
+
 // Helper to write content to file
 func writeContent(t *testing.T, path, content string) {
 	t.Helper()
@@ -269,3 +413,374 @@ func writeContent(t *testing.T, path, content string) {
 		t.Fatalf("Failed to write to file %s: %v", path, err)
 	}
 }
+
+func TestReferenceFinder_MinifiedLongLine(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "logo.png"))
+	writeContent(t, filepath.Join(tmpDir, "bundle.js"), strings.Repeat("var a=1;", 20*1024)+`var l="assets/logo.png";`)
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+	finder := NewReferenceFinder(tmpDir, cfg)
+
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if len(references[filepath.Join(tmpDir, "assets", "logo.png")]) == 0 {
+		t.Error("Expected reference at the end of a long minified line")
+	}
+}
+
+func TestReferenceFinder_MultilineReferences(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "logo.png"))
+	writeContent(t, filepath.Join(tmpDir, "app.js"), "// header\nimport logo\n  from './assets/logo.png';\n")
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+	finder := NewReferenceFinder(tmpDir, cfg)
+
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	var imports []*models.Reference
+	for _, ref := range references[filepath.Join(tmpDir, "assets", "logo.png")] {
+		if ref.Type == models.RefTypeImport {
+			imports = append(imports, ref)
+		}
+	}
+	if len(imports) != 1 {
+		t.Fatalf("Expected 1 multi-line import reference, got %d", len(imports))
+	}
+	if imports[0].LineNumber != 3 {
+		t.Errorf("LineNumber = %d, want 3", imports[0].LineNumber)
+	}
+	if imports[0].Context != "import logo from './assets/logo.png'" {
+		t.Errorf("Context = %q", imports[0].Context)
+	}
+}
+
+func TestReferenceFinder_Srcset(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "img", "hero.png"))
+	createTestFile(t, filepath.Join(tmpDir, "img", "hero@2x.png"))
+	writeContent(t, filepath.Join(tmpDir, "index.html"), `<picture>
+  <source srcset="img/hero.png 1x, img/hero@2x.png 2x">
+</picture>
+`)
+
+	cfg := config.DefaultConfig()
+	finder := NewReferenceFinder(tmpDir, cfg)
+
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	for _, name := range []string{"hero.png", "hero@2x.png"} {
+		refs := references[filepath.Join(tmpDir, "img", name)]
+		if len(refs) == 0 || refs[0].LineNumber != 2 {
+			t.Errorf("Expected a line 2 srcset reference to %s, got %v", name, refs)
+		}
+	}
+}
+
+func TestReferenceFinder_FilesScanned(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeContent(t, filepath.Join(tmpDir, "app.js"), "import logo from './logo.png';\n")
+	writeContent(t, filepath.Join(tmpDir, "style.css"), ".a { color: red; }\n")
+	writeContent(t, filepath.Join(tmpDir, "notes.txt"), "not source\n")
+
+	finder := NewReferenceFinder(tmpDir, config.DefaultConfig())
+	if _, err := finder.FindReferences(); err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	if finder.FilesScanned() != 2 {
+		t.Errorf("FilesScanned() = %d, want 2", finder.FilesScanned())
+	}
+	if want := int64(len("import logo from './logo.png';\n") + len(".a { color: red; }\n")); finder.BytesRead() != want {
+		t.Errorf("BytesRead() = %d, want %d", finder.BytesRead(), want)
+	}
+}
+
+func TestReferenceFinder_HiddenFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeContent(t, filepath.Join(tmpDir, "app.js"), "import logo from './logo.png';\n")
+	createTestFile(t, filepath.Join(tmpDir, ".storybook", "preview.js"))
+	writeContent(t, filepath.Join(tmpDir, ".storybook", "preview.js"), "import bg from './bg.png';\n")
+	writeContent(t, filepath.Join(tmpDir, ".eslintrc.js"), "module.exports = {};\n")
+
+	cfg := config.DefaultConfig()
+	finder := NewReferenceFinder(tmpDir, cfg)
+	if _, err := finder.FindReferences(); err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if finder.FilesScanned() != 1 {
+		t.Errorf("FilesScanned() = %d, want 1 by default", finder.FilesScanned())
+	}
+
+	cfg.IncludeHidden = true
+	cfg.ScanDotfiles = true
+	finder = NewReferenceFinder(tmpDir, cfg)
+	if _, err := finder.FindReferences(); err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if finder.FilesScanned() != 3 {
+		t.Errorf("FilesScanned() = %d, want 3 with hidden files included", finder.FilesScanned())
+	}
+}
+
+func TestReferenceFinder_MaxFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"a.js", "b.js", "c.js"} {
+		writeContent(t, filepath.Join(tmpDir, name), "import logo from './logo.png';\n")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.MaxFiles = 2
+	finder := NewReferenceFinder(tmpDir, cfg)
+	if _, err := finder.FindReferences(); err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if finder.FilesScanned() != 2 {
+		t.Errorf("FilesScanned() = %d, want 2", finder.FilesScanned())
+	}
+	if len(finder.Warnings()) != 1 {
+		t.Errorf("Warnings() = %v, want a max_files warning", finder.Warnings())
+	}
+}
+
+func TestReferenceFinder_EmailTemplates(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "public", "email", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "public", "email", "hero.jpg"))
+	writeContent(t, filepath.Join(tmpDir, "welcome.mjml"), `<mjml>
+  <mj-body>
+    <mj-hero background-url="https://cdn.example.com/email/hero.jpg?v=3">
+      <mj-image src="//cdn.example.com/email/logo.png" />
+    </mj-hero>
+  </mj-body>
+</mjml>
+`)
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"public/"}
+	cfg.PublicBaseURLs = []string{"https://cdn.example.com"}
+
+	// .mjml files are only scanned with email templates enabled
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if refs := references[filepath.Join(tmpDir, "public", "email", "logo.png")]; len(refs) != 0 {
+		t.Errorf("Expected no references without email_templates, got %v", refs)
+	}
+
+	cfg.EmailTemplates = true
+	references, err = NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	for name, line := range map[string]int{"hero.jpg": 3, "logo.png": 4} {
+		refs := references[filepath.Join(tmpDir, "public", "email", name)]
+		if len(refs) != 1 || refs[0].LineNumber != line {
+			t.Errorf("Expected one line %d reference to %s, got %v", line, name, refs)
+		}
+	}
+}
+
+func TestStripPublicBaseURL(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.PublicBaseURLs = []string{"https://cdn.example.com/static/"}
+	finder := NewReferenceFinder(t.TempDir(), cfg)
+
+	tests := []struct {
+		ref, expected string
+	}{
+		{"https://cdn.example.com/static/img/a.png", "/img/a.png"},
+		{"http://cdn.example.com/static/img/a.png?v=2", "/img/a.png"},
+		{"//cdn.example.com/static/img/a.png", "/img/a.png"},
+		{"https://cdn.example.com/staticfiles/a.png", "https://cdn.example.com/staticfiles/a.png"},
+		{"https://other.example.com/static/a.png", "https://other.example.com/static/a.png"},
+		{"img/a.png", "img/a.png"},
+	}
+	for _, tt := range tests {
+		if got := finder.stripPublicBaseURL(tt.ref); got != tt.expected {
+			t.Errorf("stripPublicBaseURL(%q) = %q, want %q", tt.ref, got, tt.expected)
+		}
+	}
+}
+
+func TestReferenceFinder_URLConstructor(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "src", "components", "img", "hero.png"))
+	createTestFile(t, filepath.Join(tmpDir, "assets", "img", "hero.png"))
+	createTestFile(t, filepath.Join(tmpDir, "src", "workers", "model.wasm"))
+	writeContent(t, filepath.Join(tmpDir, "src", "components", "Hero.ts"),
+		"const hero = new URL('./img/hero.png', import.meta.url).href\n"+
+			"const wasm = new URL(`../workers/model.wasm`, import.meta.url)\n")
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	for _, rel := range []string{"src/components/img/hero.png", "src/workers/model.wasm"} {
+		refs := references[filepath.Join(tmpDir, filepath.FromSlash(rel))]
+		if len(refs) == 0 || refs[0].Confidence != 1.0 {
+			t.Errorf("Expected a full-confidence reference to %s, got %v", rel, refs)
+		}
+	}
+}
+
+func TestReferenceFinder_NextConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "public", "images", "hero.png"))
+	createTestFile(t, filepath.Join(tmpDir, "public", "og.png"))
+	writeContent(t, filepath.Join(tmpDir, "next.config.mjs"), `export default {
+  basePath: '/docs',
+  assetPrefix: 'https://cdn.example.com/site/',
+}
+`)
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"public/"}
+	finder := NewReferenceFinder(tmpDir, cfg)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"/docs/images/hero.png", filepath.Join(tmpDir, "public", "images", "hero.png")},
+		{"/images/hero.png", filepath.Join(tmpDir, "public", "images", "hero.png")},
+		{"https://cdn.example.com/site/og.png", filepath.Join(tmpDir, "public", "og.png")},
+		{"/docsite/og.png", filepath.Join(tmpDir, "public", "og.png")}, // basename match, not basePath
+	}
+	for _, tt := range tests {
+		if got := finder.resolveAssetPath(tmpDir, tt.input); got != tt.expected {
+			t.Errorf("resolveAssetPath(%s) = %s, expected %s", tt.input, got, tt.expected)
+		}
+	}
+
+	if got := readNextConfig(t.TempDir()); got != (nextConfig{}) {
+		t.Errorf("readNextConfig() without next.config = %+v, want zero", got)
+	}
+}
+
+func TestReferenceFinder_StyledComponents(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "card.webp"))
+	writeContent(t, filepath.Join(tmpDir, "Card.jsx"), "const Card = styled.div`background: url(./assets/card.webp); width: ${(p) => p.width}px;`;\n")
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+	finder := NewReferenceFinder(tmpDir, cfg)
+
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	refs := references[filepath.Join(tmpDir, "assets", "card.webp")]
+	if len(refs) != 1 {
+		t.Fatalf("Expected 1 reference, got %d", len(refs))
+	}
+	if refs[0].IsDynamic || refs[0].Type != models.RefTypeCSSUrl || refs[0].Confidence != 0.95 {
+		t.Errorf("Expected a static CSSUrl reference at 0.95, got %+v", refs[0])
+	}
+}
+
+func TestNormalizeReferencePath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"./assets/logo.png", "assets/logo.png"},
+		{"assets//icons/./logo.png", "assets/icons/logo.png"},
+		{`assets\images\logo.png`, "assets/images/logo.png"},
+		{`assets\\images\\logo.png`, "assets/images/logo.png"},
+		{"../assets/logo.png", "../assets/logo.png"},
+		{"/images/", "/images/"},
+		{"https://cdn.example.com/a//b.png", "https://cdn.example.com/a//b.png"},
+		{"${base}/../logo.png", "${base}/../logo.png"},
+	}
+	for _, tt := range tests {
+		if got := normalizeReferencePath(tt.input); got != tt.expected {
+			t.Errorf("normalizeReferencePath(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestReferenceFinder_DeduplicateReferences(t *testing.T) {
+	finder := NewReferenceFinder(t.TempDir(), config.DefaultConfig())
+
+	// Line numbers in the surrogate range all became the same invalid rune
+	refs := []*models.Reference{
+		{SourceFile: "app.js", LineNumber: 55296, MatchedText: "logo.png", Confidence: 0.8},
+		{SourceFile: "app.js", LineNumber: 55297, MatchedText: "logo.png", Confidence: 0.8},
+		{SourceFile: "app.js", LineNumber: 55297, MatchedText: "logo.png", Confidence: 1.0},
+	}
+	unique := finder.deduplicateReferences(refs)
+	if len(unique) != 2 {
+		t.Fatalf("Expected 2 unique references, got %d", len(unique))
+	}
+	if unique[1].Confidence != 1.0 {
+		t.Errorf("Expected the higher-confidence duplicate to be kept, got %v", unique[1].Confidence)
+	}
+}
+
+func TestReferenceFinder_MergesSpellingsOfOneAsset(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "src", "assets", "logo.png"))
+	writeContent(t, filepath.Join(tmpDir, "src", "Logo.jsx"),
+		"import logo from './assets/logo.png'; const url = require('../src/assets//logo.png');\n")
+
+	cfg := config.DefaultConfig()
+	finder := NewReferenceFinder(tmpDir, cfg)
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	if refs := references[filepath.Join(tmpDir, "src", "assets", "logo.png")]; len(refs) != 1 {
+		t.Errorf("Expected one merged reference, got %d: %v", len(refs), refs)
+	}
+}
+
+func TestReferenceFinder_Columns(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestFile(t, filepath.Join(tmpDir, "assets", "logo.png"))
+	createTestFile(t, filepath.Join(tmpDir, "assets", "bg.png"))
+	content := "// header\nconst a = 1; import logo from './assets/logo.png';\n.hero { background: url(assets/bg.png); }\n"
+	file := filepath.Join(tmpDir, "app.js")
+	writeContent(t, file, content)
+
+	cfg := config.DefaultConfig()
+	refs, err := NewReferenceFinder(tmpDir, cfg).scanFile(file)
+	if err != nil {
+		t.Fatalf("scanFile() failed: %v", err)
+	}
+
+	expected := map[string]string{"assets/logo.png": "./assets/logo.png", "assets/bg.png": "assets/bg.png"}
+	for _, ref := range refs {
+		raw, ok := expected[ref.MatchedText]
+		if !ok {
+			continue
+		}
+		if ref.Column == 0 {
+			t.Errorf("%s has no column", ref.MatchedText)
+			continue
+		}
+		if !strings.HasPrefix(content[ref.Offset:], raw) {
+			t.Errorf("%s at line %d column %d (offset %d) points at %q", ref.MatchedText, ref.LineNumber, ref.Column, ref.Offset, content[ref.Offset:])
+		}
+		delete(expected, ref.MatchedText)
+	}
+	if len(expected) > 0 {
+		t.Errorf("Missing references %v in %v", expected, refs)
+	}
+}
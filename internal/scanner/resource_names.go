@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// catalogSetExtensions are asset catalog folders looked up by name at runtime
+var catalogSetExtensions = map[string]bool{
+	".imageset":   true,
+	".dataset":    true,
+	".appiconset": true,
+	".colorset":   true,
+	".symbolset":  true,
+}
+
+// androidResourceTypes are res/ directories holding files referenced as
+// R.<type>.<name> or @<type>/<name>, with any qualifier (drawable-hdpi)
+var androidResourceTypes = map[string]bool{
+	"drawable": true,
+	"mipmap":   true,
+	"raw":      true,
+	"font":     true,
+}
+
+// resourceFiles returns the asset files behind a name-only reference, such as
+// the images of Assets.xcassets/logo.imageset for UIImage(named: "logo") or
+// every res/drawable*/logo.* for R.drawable.logo ("drawable/logo").
+// Names with an extension are ordinary paths and return nil.
+func (rf *ReferenceFinder) resourceFiles(name string) []string {
+	if name == "" || path.Ext(name) != "" {
+		return nil
+	}
+	if rf.resources == nil {
+		rf.resources = rf.indexResourceNames()
+	}
+	if files, ok := rf.resources[name]; ok {
+		return files
+	}
+	// Catalog folders with "Provides Namespace" are referenced as "Icons/logo"
+	return rf.resources[path.Base(name)]
+}
+
+// indexResourceNames maps catalog set names to the files inside each set, and
+// Android resource type/name pairs to their files across qualifiers
+func (rf *ReferenceFinder) indexResourceNames() map[string][]string {
+	index := make(map[string][]string)
+	guard := newWalkGuard(rf.root, rf.config)
+
+	filepath.WalkDir(rf.root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if shouldSkipDir(p, rf.root, rf.config) || !guard.enterDir(p) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !guard.visitFile() {
+			return filepath.SkipAll
+		}
+
+		dir := filepath.Dir(p)
+		if filepath.Base(filepath.Dir(dir)) == "res" {
+			resType, _, _ := strings.Cut(filepath.Base(dir), "-")
+			if androidResourceTypes[resType] {
+				// logo.png, logo.xml, and nine-patch logo.9.png are all "logo"
+				name, _, _ := strings.Cut(d.Name(), ".")
+				key := resType + "/" + name
+				index[key] = append(index[key], p)
+				return nil
+			}
+		}
+
+		set := dir
+		if !catalogSetExtensions[filepath.Ext(set)] || !strings.Contains(filepath.ToSlash(set), ".xcassets/") {
+			return nil
+		}
+		if d.Name() == "Contents.json" {
+			return nil
+		}
+		name := strings.TrimSuffix(filepath.Base(set), filepath.Ext(set))
+		index[name] = append(index[name], p)
+		return nil
+	})
+
+	return index
+}
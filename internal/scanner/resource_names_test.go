@@ -0,0 +1,90 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestReferenceFinder_AssetCatalogNames(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"App/Assets.xcassets/logo.imageset/Contents.json": "{}",
+		"App/Assets.xcassets/logo.imageset/logo@2x.png":   "png",
+		"App/Assets.xcassets/logo.imageset/logo@3x.png":   "png",
+		"App/Assets.xcassets/unused.imageset/unused.png":  "png",
+		"App/Resources/intro.mp4":                         "mp4",
+		"App/ContentView.swift": `import SwiftUI
+
+struct ContentView: View {
+    var body: some View {
+        Image("logo")
+        Image(systemName: "star")
+    }
+    let video = Bundle.main.url(forResource: "intro", withExtension: "mp4")
+}
+`,
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.ProjectType = models.ProjectTypeIOS
+	cfg.ExcludePaths = nil
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	catalog := filepath.Join(tmpDir, "App", "Assets.xcassets")
+	for _, file := range []string{"logo.imageset/logo@2x.png", "logo.imageset/logo@3x.png"} {
+		if len(references[filepath.Join(catalog, file)]) == 0 {
+			t.Errorf("expected a reference to %s", file)
+		}
+	}
+	if refs := references[filepath.Join(catalog, "unused.imageset", "unused.png")]; len(refs) != 0 {
+		t.Errorf("unexpected references to unused.png: %v", refs)
+	}
+
+	found := false
+	for path := range references {
+		if filepath.Base(path) == "intro.mp4" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected forResource:withExtension: to reference intro.mp4, got %v", references)
+	}
+}
+
+func TestReferenceFinder_AndroidResources(t *testing.T) {
+	tmpDir := t.TempDir()
+	res := "app/src/main/res"
+	writeFileTree(t, tmpDir, map[string]string{
+		res + "/drawable-hdpi/logo.png":       "png",
+		res + "/drawable-xhdpi/logo.png":      "png",
+		res + "/drawable/bubble.9.png":        "png",
+		res + "/drawable/unused.png":          "png",
+		res + "/mipmap-hdpi/ic_launcher.png":  "png",
+		res + "/layout/activity_main.xml":     `<ImageView android:src="@drawable/bubble" />`,
+		"app/src/main/AndroidManifest.xml":    `<application android:icon="@mipmap/ic_launcher">`,
+		"app/src/main/java/com/app/Screen.kt": `Image(painter = painterResource(R.drawable.logo), contentDescription = null)`,
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.ProjectType = models.ProjectTypeAndroid
+	cfg.ExcludePaths = nil
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	for _, file := range []string{"drawable-hdpi/logo.png", "drawable-xhdpi/logo.png", "drawable/bubble.9.png", "mipmap-hdpi/ic_launcher.png"} {
+		if len(references[filepath.Join(tmpDir, res, file)]) == 0 {
+			t.Errorf("expected a reference to %s", file)
+		}
+	}
+	if refs := references[filepath.Join(tmpDir, res, "drawable", "unused.png")]; len(refs) != 0 {
+		t.Errorf("unexpected references to unused.png: %v", refs)
+	}
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+)
+
+// precacheConfidence is the confidence of an asset listed in a precache manifest
+const precacheConfidence = 0.95
+
+var (
+	// Arrays handed to the cache: precacheAndRoute([...]), precache([...]),
+	// cache.addAll([...]), and self.__precacheManifest = [...].concat(...)
+	precacheArrayPattern = regexp.MustCompile(`(?:\bprecacheAndRoute|\bprecache|\baddAll)\s*\(\s*\[|__precacheManifest\s*=\s*(?:\(\s*self\.__precacheManifest\s*\|\|\s*\[\]\s*\)\.concat\(\s*)?\[`)
+
+	// cache.addAll(urlsToCache): the list lives in a variable
+	precacheVariablePattern = regexp.MustCompile(`\baddAll\s*\(\s*([A-Za-z_$][\w$]*)\s*\)`)
+
+	// Quoted strings inside a manifest array
+	quotedStringPattern = regexp.MustCompile(`['"]([^'"\n]+)['"]`)
+)
+
+// precacheReferences returns a reference to every asset a service worker
+// precaches, including Workbox manifests injected in place of self.__WB_MANIFEST
+func (rf *ReferenceFinder) precacheReferences(file string, src *parser.SourceText) []*models.Reference {
+	content := src.Content
+	if !strings.Contains(content, "addAll") && !strings.Contains(content, "precache") &&
+		!strings.Contains(content, "__precacheManifest") {
+		return nil
+	}
+
+	var arrays [][2]int
+	for _, loc := range precacheArrayPattern.FindAllStringIndex(content, -1) {
+		arrays = append(arrays, [2]int{loc[1] - 1, arrayEnd(content, loc[1]-1)})
+	}
+	for _, match := range precacheVariablePattern.FindAllStringSubmatch(content, -1) {
+		decl := regexp.MustCompile(`\b` + regexp.QuoteMeta(match[1]) + `\s*=\s*\[`)
+		if loc := decl.FindStringIndex(content); loc != nil {
+			arrays = append(arrays, [2]int{loc[1] - 1, arrayEnd(content, loc[1]-1)})
+		}
+	}
+
+	var refs []*models.Reference
+	for _, span := range arrays {
+		body := content[span[0]:span[1]]
+		for _, m := range quotedStringPattern.FindAllStringSubmatchIndex(body, -1) {
+			url := body[m[2]:m[3]]
+			// Workbox entries pair each url with a revision hash, which has no extension
+			if path.Ext(url) == "" || strings.Contains(url, "://") {
+				continue
+			}
+			lineNumber := src.LineNumber(span[0] + m[2])
+			line := src.Line(lineNumber - 1)
+			refs = append(refs, &models.Reference{
+				SourceFile:  file,
+				LineNumber:  lineNumber,
+				Column:      src.Column(span[0] + m[2]),
+				MatchedText: url,
+				Context:     strings.TrimSpace(line),
+				Type:        models.RefTypeConfig,
+				Confidence:  precacheConfidence,
+				IsComment:   rf.isCommentLine(line),
+			})
+		}
+	}
+	return refs
+}
+
+// arrayEnd returns the offset just past the "]" matching the "[" at open,
+// skipping brackets inside string literals
+func arrayEnd(content string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(content)
+}
@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestReferenceFinder_Precache(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		// Workbox injectManifest output, with self.__WB_MANIFEST replaced
+		"public/sw.js": `importScripts('workbox-sw.js');
+workbox.precaching.precacheAndRoute([{"revision":"3ca0b8","url":"img/offline.svg"},{"revision":null,"url":"fonts/inter.woff2"}]);
+`,
+		// Hand-written service worker caching a list from a variable
+		"public/service-worker.js": `const urlsToCache = [
+  '/',
+  '/img/logo.png',
+  'https://cdn.example.com/lib.js',
+];
+self.addEventListener('install', (e) => {
+  e.waitUntil(caches.open('v1').then((cache) => cache.addAll(urlsToCache)));
+});
+`,
+		// Older Workbox precache-manifest file
+		"public/precache-manifest.js": `self.__precacheManifest = (self.__precacheManifest || []).concat([
+  { "revision": "9f1c", "url": "/static/media/hero.jpg" }
+]);
+`,
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.ExcludePaths = nil
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	expected := map[string]int{
+		"img/offline.svg":       2,
+		"fonts/inter.woff2":     2,
+		"img/logo.png":          3,
+		"static/media/hero.jpg": 2,
+	}
+	for asset, line := range expected {
+		found := false
+		for _, ref := range references[asset] {
+			if ref.Type == models.RefTypeConfig && ref.LineNumber == line && ref.Confidence == precacheConfidence {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a precache reference to %s on line %d, got %v", asset, line, references[asset])
+		}
+	}
+	for path := range references {
+		if filepath.Ext(path) == ".js" {
+			t.Errorf("unexpected reference to script %s", path)
+		}
+	}
+}
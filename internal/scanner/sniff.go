@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sniffLen is how much of a file content detection reads
+const sniffLen = 512
+
+// magic is a file signature at a fixed offset and the extension it implies
+type magic struct {
+	offset int
+	prefix string
+	ext    string
+}
+
+// magics are checked in order; more specific signatures come first
+var magics = []magic{
+	{0, "\x89PNG\r\n\x1a\n", ".png"},
+	{0, "\xff\xd8\xff", ".jpg"},
+	{0, "GIF87a", ".gif"},
+	{0, "GIF89a", ".gif"},
+	{8, "WEBP", ".webp"},
+	{8, "WAVE", ".wav"},
+	{8, "AVI ", ".avi"},
+	{0, "\x00\x00\x01\x00", ".ico"},
+	{0, "wOFF", ".woff"},
+	{0, "wOF2", ".woff2"},
+	{0, "OTTO", ".otf"},
+	{0, "\x00\x01\x00\x00", ".ttf"},
+	{0, "true", ".ttf"},
+	{4, "ftypqt", ".mov"},
+	{4, "ftypM4A", ".m4a"},
+	{4, "ftyp", ".mp4"},
+	{0, "\x1a\x45\xdf\xa3", ".webm"},
+	{0, "ID3", ".mp3"},
+	{0, "\xff\xfb", ".mp3"},
+	{0, "OggS", ".ogg"},
+	{0, "fLaC", ".flac"},
+	{0, "%PDF-", ".pdf"},
+}
+
+// sniffExtension returns the extension matching the content of the file at
+// path by its magic bytes, or "" if it is not a recognized asset type
+func sniffExtension(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return ""
+	}
+	return detectExtension(head[:n])
+}
+
+// detectExtension matches the start of a file against known signatures
+func detectExtension(head []byte) string {
+	for _, m := range magics {
+		if len(head) >= m.offset+len(m.prefix) && string(head[m.offset:m.offset+len(m.prefix)]) == m.prefix {
+			// RIFF containers carry their format at offset 8
+			if m.offset == 8 && !bytes.HasPrefix(head, []byte("RIFF")) {
+				continue
+			}
+			return m.ext
+		}
+	}
+
+	// SVG is text: an optional XML prolog, comments, or doctype before <svg
+	text := bytes.TrimLeft(head, "\xef\xbb\xbf \t\r\n")
+	if bytes.HasPrefix(text, []byte("<")) && bytes.Contains(text, []byte("<svg")) {
+		return ".svg"
+	}
+	return ""
+}
+
+// inAssetPaths reports whether a root-relative, slash-separated path lies in
+// one of the configured asset paths
+func inAssetPaths(rel string, assetPaths []string) bool {
+	for _, assetPath := range assetPaths {
+		assetPath = strings.Trim(filepath.ToSlash(filepath.Clean(assetPath)), "/")
+		if assetPath == "." || assetPath == "" || rel == assetPath || strings.HasPrefix(rel, assetPath+"/") {
+			return true
+		}
+	}
+	return false
+}
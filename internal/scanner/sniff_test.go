@@ -0,0 +1,75 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestDetectExtension(t *testing.T) {
+	tests := map[string]string{
+		"\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR":          ".png",
+		"\xff\xd8\xff\xe0\x00\x10JFIF":                 ".jpg",
+		"RIFF\x24\x00\x00\x00WEBPVP8 ":                 ".webp",
+		"RIFF\x24\x00\x00\x00WAVEfmt ":                 ".wav",
+		"wOF2\x00\x01\x00\x00":                         ".woff2",
+		"\x00\x01\x00\x00\x00\x10\x01\x00":             ".ttf",
+		"\x00\x00\x00\x20ftypisom":                     ".mp4",
+		"<?xml version=\"1.0\"?>\n<svg xmlns=\"...\">": ".svg",
+		"\xef\xbb\xbf<svg viewBox=\"0 0 1 1\"></svg>":  ".svg",
+		"#!/bin/sh\necho <svg>":                        "",
+		"MIT License\n\nCopyright (c)":                 "",
+		"xxxx\x24\x00\x00\x00WEBP":                     "",
+	}
+	for head, want := range tests {
+		if got := detectExtension([]byte(head)); got != want {
+			t.Errorf("detectExtension(%q) = %q, want %q", head, got, want)
+		}
+	}
+}
+
+func TestFindAssets_SniffContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{"assets/blobs", "assets/fonts", "bin"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeContent(t, filepath.Join(tmpDir, "assets", "blobs", "3f9a1c"), "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	writeContent(t, filepath.Join(tmpDir, "assets", "fonts", "Inter"), "wOF2\x00\x01\x00\x00")
+	writeContent(t, filepath.Join(tmpDir, "assets", "LICENSE"), "MIT License\n")
+	writeContent(t, filepath.Join(tmpDir, "bin", "tool"), "\x89PNG\r\n\x1a\n")
+
+	cfg := config.DefaultConfig()
+	cfg.AssetPaths = []string{"assets/"}
+
+	assets, err := NewAssetFinder(tmpDir, cfg).FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	if len(assets) != 0 {
+		t.Errorf("FindAssets() without sniff_content = %v, want none", assets)
+	}
+
+	cfg.SniffContent = true
+	assets, err = NewAssetFinder(tmpDir, cfg).FindAssets()
+	if err != nil {
+		t.Fatalf("FindAssets() failed: %v", err)
+	}
+	found := make(map[string]models.AssetFile)
+	for _, asset := range assets {
+		found[filepath.ToSlash(asset.RelativePath)] = asset
+	}
+	if len(found) != 2 {
+		t.Fatalf("FindAssets() = %v, want the blob and the font in assets/", found)
+	}
+	if blob := found["assets/blobs/3f9a1c"]; blob.SniffedType != ".png" || blob.Extension != "" || blob.Category != models.CategoryImage {
+		t.Errorf("blob = %+v, want a sniffed .png image", blob)
+	}
+	if font := found["assets/fonts/Inter"]; font.SniffedType != ".woff2" || font.Category != models.CategoryFont {
+		t.Errorf("font = %+v, want a sniffed .woff2 font", font)
+	}
+}
@@ -0,0 +1,149 @@
+package scanner
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// templateExtensions are server-side and static-site template languages;
+// their files are scanned for references and linked by their includes
+var templateExtensions = map[string]bool{
+	".hbs": true, ".handlebars": true, ".mustache": true,
+	".njk": true, ".nunjucks": true, ".jinja": true, ".jinja2": true, ".j2": true, ".twig": true,
+	".erb": true, ".ejs": true, ".liquid": true,
+}
+
+// templateDirs are where template languages look up include names that are
+// not relative to the including file
+var templateDirs = []string{
+	"templates", "views", "partials", "includes", "layouts",
+	"_includes", "_layouts", "snippets", "app/views", "src/templates", "src/partials",
+}
+
+var templateIncludePatterns = []*regexp.Regexp{
+	// Handlebars/Mustache: {{> header}}, {{> "partials/header"}}, {{#> layout}}
+	regexp.MustCompile(`\{\{~?#?>\s*['"]?([\w./-]+)`),
+	// Django, Jinja, Nunjucks, Twig, Liquid: {% include "header.html" %},
+	// {% extends 'base.njk' %}, {% render 'card' %}, {% include footer.html %}
+	regexp.MustCompile(`\{%-?\s*(?:include|include_relative|extends|import|from|embed|render)\s+['"]?([^'"\s%}]+)`),
+	// ERB: <%= render "shared/header" %>, render partial: "card"
+	regexp.MustCompile(`<%[=-]?\s*render\s*\(?\s*(?:partial:\s*|:partial\s*=>\s*)?['"]([^'"]+)['"]`),
+	// EJS: <%- include('partials/header') %>
+	regexp.MustCompile(`<%[-=_]?\s*include\s*\(?\s*['"]([^'"]+)['"]`),
+}
+
+// isTemplateFile reports whether a module is written in a template language
+func isTemplateFile(file string) bool {
+	return templateExtensions[strings.ToLower(path.Ext(file))]
+}
+
+// includesTemplates reports whether a module can include template partials;
+// Django and Jinja templates are often plain .html files
+func includesTemplates(file string) bool {
+	return isTemplateFile(file) || isHTMLFile(file)
+}
+
+// isPartial reports whether a template is by convention only ever included:
+// underscore-prefixed (Rails, Jekyll) or in a partials directory
+func isPartial(file string) bool {
+	if strings.HasPrefix(path.Base(file), "_") {
+		return true
+	}
+	for _, dir := range strings.Split(path.Dir(file), "/") {
+		switch dir {
+		case "partials", "includes", "_includes", "snippets":
+			return true
+		}
+	}
+	return false
+}
+
+// includeSpecifiers extracts the partial names a template includes
+func includeSpecifiers(content string) []string {
+	var specs []string
+	for _, pattern := range templateIncludePatterns {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			specs = append(specs, match[1])
+		}
+	}
+	return specs
+}
+
+// templateStem strips template extensions (header.html.erb -> header) and a
+// partial's underscore prefix
+func templateStem(file string) string {
+	dir, name := path.Split(file)
+	for ext := path.Ext(name); ext != "" && (templateExtensions[strings.ToLower(ext)] || isHTMLFile(name)); ext = path.Ext(name) {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return dir + strings.TrimPrefix(name, "_")
+}
+
+// resolveInclude maps an included partial name to a template in the graph:
+// relative to the including file, the root, or a conventional template
+// directory, with the includer's extensions and a partial's "_" prefix
+// optional; as a last resort any template whose path ends with the name
+func (g *ModuleGraph) resolveInclude(from, spec string) string {
+	spec = strings.TrimPrefix(path.Clean(spec), "/")
+	if spec == "." || strings.Contains(spec, "://") {
+		return ""
+	}
+
+	bases := []string{path.Join(path.Dir(from), spec), spec}
+	for _, dir := range templateDirs {
+		bases = append(bases, path.Join(dir, spec))
+	}
+
+	// Includes usually omit the extension of the includer (.html.erb, .hbs)
+	suffixes := []string{""}
+	if i := strings.Index(path.Base(from), "."); i >= 0 {
+		full := path.Base(from)[i:]
+		suffixes = append(suffixes, full)
+		if ext := path.Ext(from); ext != full {
+			suffixes = append(suffixes, ext)
+		}
+	}
+
+	for _, base := range bases {
+		dir, file := path.Split(base)
+		for _, suffix := range suffixes {
+			for _, candidate := range []string{base + suffix, dir + "_" + file + suffix} {
+				if g.files[candidate] && candidate != from {
+					return candidate
+				}
+			}
+		}
+	}
+
+	// Partials registered by name elsewhere ({{> header}} for partials/header.hbs),
+	// preferring the includer's language, then the template nearest to it
+	stem := templateStem(spec)
+	best, bestScore := "", -1
+	for _, file := range g.Files() {
+		if file == from || !includesTemplates(file) {
+			continue
+		}
+		if s := templateStem(file); s != stem && !strings.HasSuffix(s, "/"+stem) {
+			continue
+		}
+		score := sharedDirs(from, file)
+		if path.Ext(file) == path.Ext(from) {
+			score += 1000
+		}
+		if score > bestScore {
+			best, bestScore = file, score
+		}
+	}
+	return best
+}
+
+// sharedDirs counts the leading directories two paths have in common
+func sharedDirs(a, b string) int {
+	da, db := strings.Split(path.Dir(a), "/"), strings.Split(path.Dir(b), "/")
+	n := 0
+	for n < len(da) && n < len(db) && da[n] == db[n] {
+		n++
+	}
+	return n
+}
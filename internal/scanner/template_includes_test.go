@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestModuleGraph_TemplateIncludes(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"index.html":                        `<script src="/src/main.js"></script>`,
+		"src/main.js":                       "",
+		"views/home.hbs":                    "{{> header}}\n{{#> layouts/base}}body{{/layouts/base}}\n",
+		"views/partials/header.hbs":         `<img src="/images/logo.png">`,
+		"views/layouts/base.hbs":            "{{> footer}}\n",
+		"views/partials/footer.hbs":         "",
+		"views/partials/old-banner.hbs":     `<img src="/images/banner.png">`,
+		"templates/page.html":               "{% extends \"base.html\" %}\n{% include 'nav.html' %}\n",
+		"templates/base.html":               "",
+		"templates/nav.html":                "",
+		"app/views/home/index.html.erb":     `<%= render "shared/header" %>`,
+		"app/views/shared/_header.html.erb": "",
+		"app/views/shared/_unused.html.erb": "",
+		"site/index.njk":                    `{% include "card.njk" %}`,
+		"site/card.njk":                     "",
+		"public/index.ejs":                  `<%- include('partials/nav') %>`,
+		"public/partials/nav.ejs":           "",
+	})
+
+	finder := NewReferenceFinder(tmpDir, config.DefaultConfig())
+	graph, err := finder.BuildModuleGraph()
+	if err != nil {
+		t.Fatalf("BuildModuleGraph() failed: %v", err)
+	}
+
+	for from, want := range map[string][]string{
+		"views/home.hbs":                {"views/partials/header.hbs", "views/layouts/base.hbs"},
+		"views/layouts/base.hbs":        {"views/partials/footer.hbs"},
+		"templates/page.html":           {"templates/base.html", "templates/nav.html"},
+		"app/views/home/index.html.erb": {"app/views/shared/_header.html.erb"},
+		"site/index.njk":                {"site/card.njk"},
+		"public/index.ejs":              {"public/partials/nav.ejs"},
+	} {
+		got := graph.Imports(from)
+		if len(got) != len(want) {
+			t.Errorf("Imports(%s) = %v, want %v", from, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Imports(%s) = %v, want %v", from, got, want)
+				break
+			}
+		}
+	}
+
+	// Pages nobody includes are rendered by server code, so they are roots;
+	// partials only count when a reachable template includes them
+	reachable := graph.Reachable([]string{"index.html"})
+	for file, want := range map[string]bool{
+		"views/home.hbs":                    true,
+		"views/partials/header.hbs":         true,
+		"views/partials/footer.hbs":         true,
+		"views/partials/old-banner.hbs":     false,
+		"app/views/shared/_header.html.erb": true,
+		"app/views/shared/_unused.html.erb": false,
+	} {
+		if reachable[file] != want {
+			t.Errorf("reachable[%s] = %v, want %v", file, reachable[file], want)
+		}
+	}
+	if len(graph.Reachable([]string{"missing.js"})) != 0 {
+		t.Error("Reachable() without a matching entry should be empty")
+	}
+
+	// References inside partials are found and attributed to the partial
+	references, err := finder.FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+	found := false
+	for _, refs := range references {
+		for _, ref := range refs {
+			if filepath.ToSlash(ref.SourceFile) == filepath.ToSlash(filepath.Join(tmpDir, "views/partials/header.hbs")) {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Error("FindReferences() missed the reference inside views/partials/header.hbs")
+	}
+}
@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/parser"
+)
+
+// manifestConfidence is the confidence of an icon declared in a web manifest
+const manifestConfidence = 0.95
+
+// browserconfigTilePattern matches tile images in browserconfig.xml:
+// <square150x150logo src="/mstile-150x150.png"/>, <TileImage src="..."/>
+var browserconfigTilePattern = regexp.MustCompile(`<(?:square\d+x\d+logo|wide\d+x\d+logo|TileImage)\s+src="([^"]+)"`)
+
+// webManifest is the part of a web app manifest that names image files
+type webManifest struct {
+	Icons       []manifestImage `json:"icons"`
+	Screenshots []manifestImage `json:"screenshots"`
+	Shortcuts   []struct {
+		Icons []manifestImage `json:"icons"`
+	} `json:"shortcuts"`
+}
+
+type manifestImage struct {
+	Src string `json:"src"`
+}
+
+// isWebManifest reports whether a file is a web app manifest or browserconfig.xml
+func isWebManifest(path string) bool {
+	name := strings.ToLower(filepath.Base(path))
+	return name == "manifest.json" || name == "browserconfig.xml" || filepath.Ext(name) == ".webmanifest"
+}
+
+// webManifestReferences returns a reference to every icon, screenshot, and
+// tile image a web app manifest or browserconfig.xml declares
+func (rf *ReferenceFinder) webManifestReferences(file string, src *parser.SourceText) []*models.Reference {
+	if !isWebManifest(file) {
+		return nil
+	}
+
+	var images []string
+	if strings.EqualFold(filepath.Base(file), "browserconfig.xml") {
+		for _, match := range browserconfigTilePattern.FindAllStringSubmatch(src.Content, -1) {
+			images = append(images, match[1])
+		}
+	} else {
+		var manifest webManifest
+		if err := json.Unmarshal([]byte(src.Content), &manifest); err != nil {
+			return nil
+		}
+		all := append(manifest.Icons, manifest.Screenshots...)
+		for _, shortcut := range manifest.Shortcuts {
+			all = append(all, shortcut.Icons...)
+		}
+		for _, image := range all {
+			if image.Src != "" {
+				images = append(images, image.Src)
+			}
+		}
+	}
+
+	var refs []*models.Reference
+	offset := 0
+	for _, image := range images {
+		// Attribute each entry to the line it is declared on, in order
+		lineNumber, column := 1, 0
+		if i := strings.Index(src.Content[offset:], `"`+image+`"`); i >= 0 {
+			offset += i + 1
+			lineNumber, column = src.LineNumber(offset), src.Column(offset)
+		}
+		refs = append(refs, &models.Reference{
+			SourceFile:  file,
+			LineNumber:  lineNumber,
+			Column:      column,
+			MatchedText: image,
+			Context:     strings.TrimSpace(src.Line(lineNumber - 1)),
+			Type:        models.RefTypeConfig,
+			Confidence:  manifestConfidence,
+		})
+	}
+	return refs
+}
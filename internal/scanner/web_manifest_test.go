@@ -0,0 +1,51 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+)
+
+func TestReferenceFinder_WebManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"public/site.webmanifest": `{
+  "name": "App",
+  "icons": [
+    { "src": "icons/icon-192.png", "sizes": "192x192", "type": "image/png" },
+    { "src": "icons/maskable.png", "sizes": "512x512", "purpose": "maskable" }
+  ],
+  "shortcuts": [{ "name": "New", "icons": [{ "src": "icons/new.png" }] }]
+}
+`,
+		"public/browserconfig.xml": `<?xml version="1.0" encoding="utf-8"?>
+<browserconfig><msapplication><tile>
+  <square150x150logo src="/mstile-150x150.png"/>
+</tile></msapplication></browserconfig>
+`,
+	})
+
+	cfg := config.DefaultConfig()
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	expected := map[string]int{
+		"icons/icon-192.png": 4,
+		"icons/maskable.png": 5,
+		"icons/new.png":      7,
+		"mstile-150x150.png": 3,
+	}
+	for asset, line := range expected {
+		found := false
+		for _, ref := range references[asset] {
+			if ref.Confidence >= manifestConfidence && ref.LineNumber == line {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a manifest reference to %s on line %d, got %v", asset, line, references[asset])
+		}
+	}
+}
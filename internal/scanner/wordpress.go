@@ -0,0 +1,30 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// tryAncestorMatch resolves a theme- or plugin-relative path against the
+// referencing file's directory and each of its parents up to the root:
+// get_template_directory_uri() . '/img/x.png' in inc/setup.php names
+// img/x.png at the theme root, wherever the theme sits in the repository
+func (rf *ReferenceFinder) tryAncestorMatch(fromDir, cleaned string) string {
+	if cleaned == "" || strings.Contains(cleaned, ":") {
+		return ""
+	}
+	rel, err := filepath.Rel(rf.root, fromDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+
+	for dir := fromDir; dir != rf.root; dir = filepath.Dir(dir) {
+		if candidate := filepath.Join(dir, filepath.FromSlash(cleaned)); rf.hasFile(candidate) {
+			return candidate
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/config"
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestReferenceFinder_WordPressThemePaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileTree(t, tmpDir, map[string]string{
+		"wp-content/themes/acme/style.css":           "/*\nTheme Name: Acme\n*/\n",
+		"wp-content/themes/acme/img/logo.png":        "",
+		"wp-content/themes/acme/css/main.css":        "",
+		"wp-content/themes/acme/inc/setup.php":       "<?php\nwp_enqueue_style( 'acme', get_template_directory_uri() . '/css/main.css' );\n",
+		"wp-content/themes/acme/header.php":          "<img src=\"<?php echo esc_url( get_template_directory_uri() ); ?>/img/logo.png\">\n",
+		"wp-content/plugins/gallery/gallery.php":     "<?php\n/* Plugin Name: Gallery */\n$icon = plugins_url( 'assets/icon.svg', __FILE__ );\n",
+		"wp-content/plugins/gallery/assets/icon.svg": "",
+		"wp-content/themes/other/img/logo.png":       "",
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.ProjectType = models.ProjectTypeWordPress
+	cfg.AssetPaths = config.DefaultAssetPathsForProjectType(models.ProjectTypeWordPress)
+	references, err := NewReferenceFinder(tmpDir, cfg).FindReferences()
+	if err != nil {
+		t.Fatalf("FindReferences() failed: %v", err)
+	}
+
+	for _, asset := range []string{
+		"wp-content/themes/acme/img/logo.png",
+		"wp-content/themes/acme/css/main.css",
+		"wp-content/plugins/gallery/assets/icon.svg",
+	} {
+		if len(references[filepath.Join(tmpDir, filepath.FromSlash(asset))]) == 0 {
+			t.Errorf("expected a reference to %s, got none", asset)
+		}
+	}
+	if refs := references[filepath.Join(tmpDir, "wp-content", "themes", "other", "img", "logo.png")]; len(refs) > 0 {
+		t.Errorf("expected theme paths to resolve within their own theme, got %v", refs)
+	}
+}
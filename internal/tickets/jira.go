@@ -0,0 +1,140 @@
+package tickets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// httpTimeout bounds every tracker API request
+const httpTimeout = 30 * time.Second
+
+// Jira files tickets through the Jira REST API v2, labelled with the ticket
+// key so reruns find them again
+type Jira struct {
+	BaseURL   string // e.g. https://example.atlassian.net
+	Email     string
+	Token     string // API token (Cloud) or personal access token
+	Project   string // project key, e.g. WEB
+	IssueType string // defaults to Task
+
+	Client *http.Client
+}
+
+// Name implements Tracker
+func (j *Jira) Name() string {
+	return "jira"
+}
+
+// FindOpen implements Tracker
+func (j *Jira) FindOpen(ctx context.Context, key string) (string, error) {
+	jql := fmt.Sprintf(`project = %q AND labels = %q AND statusCategory != Done ORDER BY created DESC`, j.Project, key)
+	query := url.Values{"jql": {jql}, "fields": {"key"}, "maxResults": {"1"}}
+
+	var resp struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	if err := j.do(ctx, http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Issues) == 0 {
+		return "", nil
+	}
+	return resp.Issues[0].Key, nil
+}
+
+// Create implements Tracker
+func (j *Jira) Create(ctx context.Context, t Ticket) (string, error) {
+	issueType := j.IssueType
+	if issueType == "" {
+		issueType = "Task"
+	}
+	req := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": j.Project},
+			"issuetype":   map[string]string{"name": issueType},
+			"summary":     t.Title,
+			"description": t.Body,
+			"labels":      []string{"easyclean", t.Key},
+		},
+	}
+
+	var resp struct {
+		Key string `json:"key"`
+	}
+	if err := j.do(ctx, http.MethodPost, "/rest/api/2/issue", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.Key, nil
+}
+
+// Update implements Tracker
+func (j *Jira) Update(ctx context.Context, id string, t Ticket) error {
+	req := map[string]any{
+		"fields": map[string]any{"summary": t.Title, "description": t.Body},
+	}
+	return j.do(ctx, http.MethodPut, "/rest/api/2/issue/"+url.PathEscape(id), req, nil)
+}
+
+func (j *Jira) do(ctx context.Context, method, path string, body, out any) error {
+	req, err := newJSONRequest(ctx, method, strings.TrimSuffix(j.BaseURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(j.Email, j.Token)
+	return doJSON(j.Client, req, out)
+}
+
+// newJSONRequest builds a request with body encoded as JSON (none when nil)
+func newJSONRequest(ctx context.Context, method, url string, body any) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// doJSON sends req and decodes a JSON response into out (ignored when nil),
+// turning non-2xx statuses into errors carrying the start of the response
+func doJSON(client *http.Client, req *http.Request, out any) error {
+	if client == nil {
+		client = &http.Client{Timeout: httpTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// linearAPI is the Linear GraphQL endpoint
+const linearAPI = "https://api.linear.app/graphql"
+
+// Linear files tickets through the Linear GraphQL API. Labels there are
+// workspace objects created ahead of time, so open issues are found by the
+// key in their description instead, which survives renaming the issue.
+type Linear struct {
+	APIKey string
+	TeamID string
+
+	Endpoint string // defaults to the Linear API
+	Client   *http.Client
+}
+
+// Name implements Tracker
+func (l *Linear) Name() string {
+	return "linear"
+}
+
+// FindOpen implements Tracker
+func (l *Linear) FindOpen(ctx context.Context, key string) (string, error) {
+	const query = `query($team: ID!, $marker: String!) {
+  issues(first: 1, filter: {
+    team: { id: { eq: $team } }
+    description: { contains: $marker }
+    state: { type: { nin: ["completed", "canceled"] } }
+  }) { nodes { identifier } }
+}`
+	var data struct {
+		Issues struct {
+			Nodes []struct {
+				Identifier string `json:"identifier"`
+			} `json:"nodes"`
+		} `json:"issues"`
+	}
+	if err := l.graphql(ctx, query, map[string]any{"team": l.TeamID, "marker": keyMarker(key)}, &data); err != nil {
+		return "", err
+	}
+	if len(data.Issues.Nodes) == 0 {
+		return "", nil
+	}
+	return data.Issues.Nodes[0].Identifier, nil
+}
+
+// Create implements Tracker
+func (l *Linear) Create(ctx context.Context, t Ticket) (string, error) {
+	const query = `mutation($input: IssueCreateInput!) {
+  issueCreate(input: $input) { success issue { identifier } }
+}`
+	input := map[string]any{"teamId": l.TeamID, "title": t.Title, "description": t.Body}
+
+	var data struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				Identifier string `json:"identifier"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+	}
+	if err := l.graphql(ctx, query, map[string]any{"input": input}, &data); err != nil {
+		return "", err
+	}
+	if !data.IssueCreate.Success {
+		return "", fmt.Errorf("issueCreate was not successful")
+	}
+	return data.IssueCreate.Issue.Identifier, nil
+}
+
+// Update implements Tracker
+func (l *Linear) Update(ctx context.Context, id string, t Ticket) error {
+	const query = `mutation($id: String!, $input: IssueUpdateInput!) {
+  issueUpdate(id: $id, input: $input) { success }
+}`
+	var data struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+	vars := map[string]any{"id": id, "input": map[string]any{"description": t.Body}}
+	if err := l.graphql(ctx, query, vars, &data); err != nil {
+		return err
+	}
+	if !data.IssueUpdate.Success {
+		return fmt.Errorf("issueUpdate was not successful")
+	}
+	return nil
+}
+
+func (l *Linear) graphql(ctx context.Context, query string, vars map[string]any, out any) error {
+	endpoint := l.Endpoint
+	if endpoint == "" {
+		endpoint = linearAPI
+	}
+	req, err := newJSONRequest(ctx, http.MethodPost, endpoint, map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", l.APIKey)
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := doJSON(l.Client, req, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		msgs := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			msgs[i] = e.Message
+		}
+		return fmt.Errorf("linear: %s", strings.Join(msgs, "; "))
+	}
+	return json.Unmarshal(resp.Data, out)
+}
@@ -0,0 +1,198 @@
+// Package tickets files one issue per owning feature/team listing its unused
+// assets, in the Jira or Linear issue tracker.
+//
+// Owners come from the features mapping in .unusedassets.yaml. Every ticket
+// carries a stable key derived from its owner, which the tracker is searched
+// for before creating anything: reruns update the owner's open ticket instead
+// of filing a duplicate, and a new ticket is only created once the previous
+// one is closed.
+package tickets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// UnownedTeam owns the unused assets no feature rule matches
+const UnownedTeam = "unowned"
+
+// keyPrefix starts every ticket key
+const keyPrefix = "easyclean-unused-"
+
+// Ticket is the issue filed for one owner
+type Ticket struct {
+	Owner  string             `json:"owner"`
+	Key    string             `json:"key"` // stable across runs, e.g. easyclean-unused-checkout
+	Title  string             `json:"title"`
+	Body   string             `json:"body"`
+	Assets []models.AssetFile `json:"-"`
+	Size   int64              `json:"size_bytes"`
+}
+
+// Tracker is an issue tracker tickets are filed in
+type Tracker interface {
+	// Name is the tracker shown in output ("jira", "linear")
+	Name() string
+	// FindOpen returns the ID of the open issue carrying key, or "" if none
+	FindOpen(ctx context.Context, key string) (string, error)
+	// Create files a new issue and returns its ID
+	Create(ctx context.Context, t Ticket) (string, error)
+	// Update replaces the description of an existing issue
+	Update(ctx context.Context, id string, t Ticket) error
+}
+
+// Filed reports what happened to one ticket
+type Filed struct {
+	Ticket  Ticket `json:"ticket"`
+	ID      string `json:"id"`
+	Created bool   `json:"created"` // false when an open ticket was updated
+}
+
+// Build groups the unused assets of a scan by owning feature, one ticket per
+// owner, sorted by owner
+func Build(result *models.ScanResult, formatSize func(int64) string) []Ticket {
+	byOwner := make(map[string][]models.AssetFile)
+	for _, asset := range result.Assets {
+		if !asset.Status.IsUnused() {
+			continue
+		}
+		owner := asset.Feature
+		if owner == "" {
+			owner = UnownedTeam
+		}
+		byOwner[owner] = append(byOwner[owner], asset)
+	}
+
+	owners := make([]string, 0, len(byOwner))
+	for owner := range byOwner {
+		owners = append(owners, owner)
+	}
+	keys, titles := ownerKeys(owners)
+
+	tickets := make([]Ticket, 0, len(byOwner))
+	for owner, assets := range byOwner {
+		sort.Slice(assets, func(i, j int) bool { return assets[i].RelativePath < assets[j].RelativePath })
+
+		var size int64
+		var body strings.Builder
+		for _, asset := range assets {
+			size += asset.Size
+		}
+		ownedBy := "owned by " + owner
+		if owner == UnownedTeam {
+			ownedBy = "matching no feature"
+		}
+		fmt.Fprintf(&body, "easyClean found %d unused assets (%s) %s in %s.\n\n",
+			len(assets), formatSize(size), ownedBy, result.ProjectRoot)
+		for _, asset := range assets {
+			fmt.Fprintf(&body, "- %s (%s)\n", asset.RelativePath, formatSize(asset.Size))
+		}
+		fmt.Fprintf(&body, "\nReview them with `easyClean review`, or delete them with `easyClean delete`. "+
+			"This ticket is updated on every run while it is open.\n\n%s\n", keyMarker(keys[owner]))
+
+		tickets = append(tickets, Ticket{
+			Owner:  owner,
+			Key:    keys[owner],
+			Title:  titles[owner],
+			Body:   body.String(),
+			Assets: assets,
+			Size:   size,
+		})
+	}
+
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].Owner < tickets[j].Owner })
+	return tickets
+}
+
+// keyMarker is the line of a ticket's description carrying its key, which
+// trackers without labels search for. The backticks keep a key from
+// matching inside a longer one.
+func keyMarker(key string) string {
+	return "Tracking key: `" + key + "`"
+}
+
+// ownerKeys returns the ticket key and title of each owner. Tickets are found
+// by key, so owners whose keys would collide ("Checkout Flow" and
+// "checkout-flow") both get a short hash of their name appended to key and
+// title, rather than sharing a ticket.
+func ownerKeys(owners []string) (keys, titles map[string]string) {
+	byKey := make(map[string][]string, len(owners))
+	for _, owner := range owners {
+		byKey[Key(owner)] = append(byKey[Key(owner)], owner)
+	}
+
+	keys = make(map[string]string, len(owners))
+	titles = make(map[string]string, len(owners))
+	for key, group := range byKey {
+		for _, owner := range group {
+			keys[owner] = key
+			titles[owner] = fmt.Sprintf("Unused assets: %s", owner)
+			if len(group) > 1 {
+				hash := ownerHash(owner)
+				keys[owner] = key + "-" + hash
+				titles[owner] = fmt.Sprintf("Unused assets: %s (%s)", owner, hash)
+			}
+		}
+	}
+	return keys, titles
+}
+
+// ownerHash is a short, stable hash of an owner's name
+func ownerHash(owner string) string {
+	sum := sha256.Sum256([]byte(owner))
+	return hex.EncodeToString(sum[:3])
+}
+
+// Key returns the stable ticket key of an owner: lowercase letters, digits,
+// and dashes, as Jira labels allow no spaces. An owner without any letters or
+// digits to keep is keyed by a hash of its name.
+func Key(owner string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(owner) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	if sb.Len() == 0 {
+		return keyPrefix + ownerHash(owner)
+	}
+	return keyPrefix + strings.TrimSuffix(sb.String(), "-")
+}
+
+// File creates or updates the ticket of every owner, stopping at the first
+// tracker error
+func File(ctx context.Context, tracker Tracker, tickets []Ticket) ([]Filed, error) {
+	filed := make([]Filed, 0, len(tickets))
+	for _, t := range tickets {
+		id, err := tracker.FindOpen(ctx, t.Key)
+		if err != nil {
+			return filed, fmt.Errorf("failed to search %s for %s: %w", tracker.Name(), t.Key, err)
+		}
+
+		if id != "" {
+			if err := tracker.Update(ctx, id, t); err != nil {
+				return filed, fmt.Errorf("failed to update %s %s: %w", tracker.Name(), id, err)
+			}
+			filed = append(filed, Filed{Ticket: t, ID: id})
+			continue
+		}
+
+		id, err = tracker.Create(ctx, t)
+		if err != nil {
+			return filed, fmt.Errorf("failed to create %s ticket for %s: %w", tracker.Name(), t.Owner, err)
+		}
+		filed = append(filed, Filed{Ticket: t, ID: id, Created: true})
+	}
+	return filed, nil
+}
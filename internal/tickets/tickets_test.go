@@ -0,0 +1,239 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func testResult() *models.ScanResult {
+	return &models.ScanResult{
+		ProjectRoot: "/srv/app",
+		Assets: []models.AssetFile{
+			{RelativePath: "src/checkout/b.png", Size: 200, Status: models.StatusUnused, Feature: "checkout"},
+			{RelativePath: "src/checkout/a.png", Size: 100, Status: models.StatusUnused, Feature: "checkout"},
+			{RelativePath: "src/checkout/used.png", Size: 100, Status: models.StatusUsed, Feature: "checkout"},
+			{RelativePath: "public/old.svg", Size: 50, Status: models.StatusUnused},
+			{RelativePath: "public/maybe.svg", Size: 50, Status: models.StatusPotentiallyUnused},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	tickets := Build(testResult(), func(n int64) string { return fmt.Sprintf("%d B", n) })
+	if len(tickets) != 2 {
+		t.Fatalf("Build() returned %d tickets, want 2", len(tickets))
+	}
+
+	checkout := tickets[0]
+	if checkout.Owner != "checkout" || checkout.Key != "easyclean-unused-checkout" || checkout.Size != 300 {
+		t.Errorf("checkout ticket = %+v", checkout)
+	}
+	if len(checkout.Assets) != 2 || checkout.Assets[0].RelativePath != "src/checkout/a.png" {
+		t.Errorf("checkout assets = %v, want a.png and b.png in order", checkout.Assets)
+	}
+	if !strings.Contains(checkout.Body, "- src/checkout/a.png (100 B)") || strings.Contains(checkout.Body, "used.png") {
+		t.Errorf("checkout body = %q", checkout.Body)
+	}
+
+	if tickets[1].Owner != UnownedTeam || len(tickets[1].Assets) != 1 {
+		t.Errorf("unowned ticket = %+v, want only public/old.svg", tickets[1])
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := map[string]string{
+		"checkout":        "easyclean-unused-checkout",
+		"Growth Team":     "easyclean-unused-growth-team",
+		"web/ui & design": "easyclean-unused-web-ui-design",
+	}
+	for owner, want := range tests {
+		if got := Key(owner); got != want {
+			t.Errorf("Key(%q) = %q, want %q", owner, got, want)
+		}
+	}
+
+	if Key("チェックアウト") == keyPrefix || Key("チェックアウト") == Key("決済") {
+		t.Errorf("owners without ASCII letters should get distinct keys, got %q and %q", Key("チェックアウト"), Key("決済"))
+	}
+}
+
+func TestBuild_KeyCollisions(t *testing.T) {
+	result := &models.ScanResult{Assets: []models.AssetFile{
+		{RelativePath: "a.png", Status: models.StatusUnused, Feature: "Checkout Flow"},
+		{RelativePath: "b.png", Status: models.StatusUnused, Feature: "checkout-flow"},
+		{RelativePath: "c.png", Status: models.StatusUnused, Feature: "growth"},
+	}}
+	tickets := Build(result, func(n int64) string { return fmt.Sprintf("%d B", n) })
+	if len(tickets) != 3 {
+		t.Fatalf("Build() returned %d tickets, want 3", len(tickets))
+	}
+
+	keys := make(map[string]bool)
+	titles := make(map[string]bool)
+	for _, ticket := range tickets {
+		keys[ticket.Key] = true
+		titles[strings.ToLower(ticket.Title)] = true
+		if !strings.Contains(ticket.Body, "Tracking key: `"+ticket.Key+"`\n") {
+			t.Errorf("%s body should carry its key %s", ticket.Owner, ticket.Key)
+		}
+	}
+	if len(keys) != 3 || len(titles) != 3 {
+		t.Errorf("colliding owners should get distinct keys and titles, got %v and %v", keys, titles)
+	}
+	if growth := tickets[2]; growth.Key != "easyclean-unused-growth" || growth.Title != "Unused assets: growth" {
+		t.Errorf("owners without a collision should keep their plain key, got %+v", growth)
+	}
+}
+
+// fakeJira is a Jira server holding issues in memory
+type fakeJira struct {
+	mu     sync.Mutex
+	issues map[string][]string // label -> issue keys
+	bodies map[string]string
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, pass, ok := r.BasicAuth(); !ok || user != "dev@example.com" || pass != "token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Fields struct {
+			Description string   `json:"description"`
+			Labels      []string `json:"labels"`
+		} `json:"fields"`
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/search":
+		var found []map[string]string
+		for label, keys := range f.issues {
+			if strings.Contains(r.URL.Query().Get("jql"), `labels = "`+label+`"`) {
+				found = append(found, map[string]string{"key": keys[0]})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"issues": found})
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+		json.NewDecoder(r.Body).Decode(&req)
+		key := fmt.Sprintf("WEB-%d", len(f.bodies)+1)
+		f.bodies[key] = req.Fields.Description
+		f.issues[req.Fields.Labels[1]] = append(f.issues[req.Fields.Labels[1]], key)
+		json.NewEncoder(w).Encode(map[string]string{"key": key})
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/rest/api/2/issue/"):
+		json.NewDecoder(r.Body).Decode(&req)
+		f.bodies[strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")] = req.Fields.Description
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestFile_Jira(t *testing.T) {
+	fake := &fakeJira{issues: map[string][]string{}, bodies: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	jira := &Jira{BaseURL: server.URL, Email: "dev@example.com", Token: "token", Project: "WEB"}
+	tickets := Build(testResult(), func(n int64) string { return fmt.Sprintf("%d B", n) })
+
+	first, err := File(context.Background(), jira, tickets)
+	if err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	if len(first) != 2 || !first[0].Created || !first[1].Created {
+		t.Fatalf("first File() = %+v, want 2 created tickets", first)
+	}
+
+	// Rerun after one asset was cleaned up: tickets are updated, not duplicated
+	tickets[0].Body = "updated"
+	second, err := File(context.Background(), jira, tickets)
+	if err != nil {
+		t.Fatalf("second File() error = %v", err)
+	}
+	if second[0].Created || second[0].ID != first[0].ID {
+		t.Errorf("second File() = %+v, want %s updated", second[0], first[0].ID)
+	}
+	if len(fake.bodies) != 2 || fake.bodies[first[0].ID] != "updated" {
+		t.Errorf("issues after rerun = %v", fake.bodies)
+	}
+
+	jira.Token = "wrong"
+	if _, err := File(context.Background(), jira, tickets); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("File() with a bad token error = %v, want 401", err)
+	}
+}
+
+func TestFile_Linear(t *testing.T) {
+	var mu sync.Mutex
+	open := map[string]string{} // identifier -> description
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "lin_api_key" {
+			json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]string{{"message": "Authentication required"}}})
+			return
+		}
+
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case strings.Contains(req.Query, "issues("):
+			nodes := []map[string]string{}
+			for id, description := range open {
+				if strings.Contains(description, req.Variables["marker"].(string)) {
+					nodes = append(nodes, map[string]string{"identifier": id})
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"issues": map[string]any{"nodes": nodes}}})
+		case strings.Contains(req.Query, "issueCreate"):
+			input := req.Variables["input"].(map[string]any)
+			id := fmt.Sprintf("ENG-%d", len(open)+1)
+			open[id] = input["description"].(string)
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+				"issueCreate": map[string]any{"success": true, "issue": map[string]string{"identifier": id}},
+			}})
+		case strings.Contains(req.Query, "issueUpdate"):
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"issueUpdate": map[string]bool{"success": true}}})
+		}
+	}))
+	defer server.Close()
+
+	linear := &Linear{APIKey: "lin_api_key", TeamID: "team-1", Endpoint: server.URL}
+	tickets := Build(testResult(), func(n int64) string { return fmt.Sprintf("%d B", n) })
+
+	first, err := File(context.Background(), linear, tickets)
+	if err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	// Renaming an issue doesn't lose it, as it is found by its key
+	if first[0].ID != "ENG-1" {
+		t.Fatalf("File() = %+v, want ENG-1 filed first", first[0])
+	}
+	tickets[0].Title = "Checkout: clean up images"
+	second, err := File(context.Background(), linear, tickets)
+	if err != nil {
+		t.Fatalf("second File() error = %v", err)
+	}
+	if second[0].Created || second[0].ID != "ENG-1" || len(open) != 2 {
+		t.Errorf("second File() = %+v with %d issues, want ENG-1 updated and no new issues", second[0], len(open))
+	}
+
+	linear.APIKey = "wrong"
+	if _, err := File(context.Background(), linear, tickets); err == nil || !strings.Contains(err.Error(), "Authentication required") {
+		t.Errorf("File() with a bad key error = %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// Badge colors, as used by shields.io
+const (
+	BadgeGreen  = "#4c1"
+	BadgeYellow = "#dfb317"
+	BadgeRed    = "#e05d44"
+	BadgeGrey   = "#555"
+)
+
+// UsageBadge returns the message and color of an unused assets badge:
+// "12 / 3.4 MB", colored by the highest severity among the assets
+func UsageBadge(result *models.ScanResult) (message, color string) {
+	if result.Stats.UnusedCount == 0 {
+		message = "none"
+	} else {
+		message = fmt.Sprintf("%d / %s", result.Stats.UnusedCount, FormatBytes(result.Stats.UnusedSize))
+	}
+
+	switch result.MaxSeverity() {
+	case models.SeverityError:
+		color = BadgeRed
+	case models.SeverityWarning:
+		color = BadgeYellow
+	default:
+		color = BadgeGreen
+	}
+	return message, color
+}
+
+// RenderBadge draws a flat shields-style SVG badge with a grey label and a
+// colored message. Widths are estimated from the text, as Verdana 11px
+// averages about 7px a character.
+func RenderBadge(label, message, color string) []byte {
+	labelWidth := badgeTextWidth(label)
+	messageWidth := badgeTextWidth(message)
+	width := labelWidth + messageWidth
+
+	label, message = html.EscapeString(label), html.EscapeString(message)
+	title := label + ": " + message
+
+	return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[2]s">
+  <title>%[2]s</title>
+  <linearGradient id="s" x2="0" y2="100%%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+  <g clip-path="url(#r)">
+    <rect width="%[3]d" height="20" fill="%[4]s"/>
+    <rect x="%[3]d" width="%[5]d" height="20" fill="%[6]s"/>
+    <rect width="%[1]d" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[8]s</text>
+    <text x="%[7]d" y="14">%[8]s</text>
+    <text x="%[9]d" y="15" fill="#010101" fill-opacity=".3">%[10]s</text>
+    <text x="%[9]d" y="14">%[10]s</text>
+  </g>
+</svg>
+`, width, title, labelWidth, BadgeGrey, messageWidth, color,
+		labelWidth/2, label, labelWidth+messageWidth/2, message))
+}
+
+// badgeTextWidth estimates the width of a badge half holding text, padding included
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func TestUsageBadge(t *testing.T) {
+	tests := []struct {
+		name        string
+		unused      int
+		size        int64
+		severities  []models.Severity
+		wantMessage string
+		wantColor   string
+	}{
+		{"clean", 0, 0, nil, "none", BadgeGreen},
+		{"info only", 2, 512, []models.Severity{models.SeverityInfo}, "2 / 512 B", BadgeGreen},
+		{"warning", 12, 3565158, []models.Severity{models.SeverityInfo, models.SeverityWarning}, "12 / 3.4 MB", BadgeYellow},
+		{"error", 1, 2048, []models.Severity{models.SeverityWarning, models.SeverityError}, "1 / 2.0 KB", BadgeRed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &models.ScanResult{}
+			result.Stats.UnusedCount = tt.unused
+			result.Stats.UnusedSize = tt.size
+			for _, severity := range tt.severities {
+				result.Assets = append(result.Assets, models.AssetFile{Severity: severity})
+			}
+
+			message, color := UsageBadge(result)
+			if message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", message, tt.wantMessage)
+			}
+			if color != tt.wantColor {
+				t.Errorf("color = %q, want %q", color, tt.wantColor)
+			}
+		})
+	}
+}
+
+func TestRenderBadge_Golden(t *testing.T) {
+	got := RenderBadge(`unused <assets> & "more"`, "12 / 3.4 MB", BadgeYellow)
+
+	golden := filepath.Join("testdata", "badge.svg")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("RenderBadge() differs from %s (rerun with -update if intended):\n%s", golden, got)
+	}
+
+	if strings.Contains(string(got), "<assets>") || strings.Contains(string(got), `"more"`) {
+		t.Error("Expected the label to be escaped")
+	}
+}
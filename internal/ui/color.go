@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// Role is the meaning of a piece of output, mapped to a color by the theme
+type Role int
+
+const (
+	RoleUsed Role = iota
+	RoleUnused
+	RolePotentiallyUnused
+	RoleNeedsReview
+	RoleKept
+	RoleHeading
+	RoleMuted
+	RolePositive
+	RoleNegative
+)
+
+// Theme maps roles to ANSI SGR parameters (e.g. "1;32")
+type Theme map[Role]string
+
+// Themes are the built-in palettes selectable with --theme. Dark terminals
+// get bright colors; light terminals get the normal intensities, which stay
+// readable on white.
+var Themes = map[string]Theme{
+	"dark": {
+		RoleUsed:              "92",
+		RoleUnused:            "91",
+		RolePotentiallyUnused: "93",
+		RoleNeedsReview:       "96",
+		RoleKept:              "94",
+		RoleHeading:           "1",
+		RoleMuted:             "90",
+		RolePositive:          "92",
+		RoleNegative:          "91",
+	},
+	"light": {
+		RoleUsed:              "32",
+		RoleUnused:            "31",
+		RolePotentiallyUnused: "33",
+		RoleNeedsReview:       "36",
+		RoleKept:              "34",
+		RoleHeading:           "1",
+		RoleMuted:             "2",
+		RolePositive:          "32",
+		RoleNegative:          "31",
+	},
+}
+
+// DefaultTheme is used when --theme is not given
+const DefaultTheme = "dark"
+
+// Color output is off until ConfigureColor enables it, so formatted text
+// written to files or returned to tests stays plain
+var (
+	colorEnabled bool
+	activeTheme  = Themes[DefaultTheme]
+)
+
+// ConfigureColor enables or disables ANSI colors and selects the theme
+func ConfigureColor(enabled bool, theme string) error {
+	if theme == "" {
+		theme = DefaultTheme
+	}
+	palette, ok := Themes[strings.ToLower(theme)]
+	if !ok {
+		names := make([]string, 0, len(Themes))
+		for name := range Themes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown theme %q (want %s)", theme, strings.Join(names, " or "))
+	}
+	colorEnabled = enabled
+	activeTheme = palette
+	return nil
+}
+
+// DisableColor turns colors off, e.g. before writing output to a file
+func DisableColor() {
+	colorEnabled = false
+}
+
+// ShouldColor reports whether output to f should be colored: never when the
+// NO_COLOR environment variable is set (https://no-color.org) or when f is
+// not a terminal
+func ShouldColor(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(f)
+}
+
+// Colorize wraps s in the theme's color for role when colors are enabled
+func Colorize(role Role, s string) string {
+	code, ok := activeTheme[role]
+	if !colorEnabled || !ok || s == "" {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+// StatusRole returns the color role of an asset status
+func StatusRole(status models.AssetStatus) Role {
+	switch status {
+	case models.StatusUsed:
+		return RoleUsed
+	case models.StatusUnused, models.StatusTransitivelyUnused:
+		return RoleUnused
+	case models.StatusPotentiallyUnused:
+		return RolePotentiallyUnused
+	case models.StatusNeedsManualReview:
+		return RoleNeedsReview
+	default:
+		return RoleKept
+	}
+}
@@ -9,18 +9,25 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/HabibPro1999/easyClean/internal/models"
 )
 
 const (
 	headerWidth           = 45
-	maxDisplayedAssets    = 10
 	bytesPerKilobyte      = 1024
 	separatorWidth        = 45
 )
 
+// MaxDisplayedAssets caps the assets listed in each text report section
+// (scan --top overrides it)
+var MaxDisplayedAssets = 10
+
 // PrintHeader prints the application header
 func PrintHeader(name, version string) {
 	width := headerWidth
@@ -45,46 +52,419 @@ func FormatScanResult(result *models.ScanResult) string {
 	sb.WriteString("\n" + separator + "\n\n")
 
 	// Print summary
-	sb.WriteString("📊 Scan Complete\n\n")
+	sb.WriteString(Colorize(RoleHeading, "📊 Scan Complete") + "\n\n")
 	sb.WriteString(fmt.Sprintf("  Total Assets:           %d\n", result.Stats.TotalAssets))
-	sb.WriteString(fmt.Sprintf("  ✓ Used Assets:          %d\n", result.Stats.TotalAssets-result.Stats.UnusedCount-result.Stats.PotentiallyUnusedCount-result.Stats.NeedsReviewCount))
-	sb.WriteString(fmt.Sprintf("  ⚠️  Unused Assets:       %d\n", result.Stats.UnusedCount))
+	sb.WriteString(fmt.Sprintf("  ✓ Used Assets:          %s\n", colorCount(RoleUsed, result.Stats.TotalAssets-result.Stats.UnusedCount-result.Stats.PotentiallyUnusedCount-result.Stats.NeedsReviewCount-result.Stats.KeptCount-result.Stats.ConventionalCount)))
+	unused := colorCount(RoleUnused, result.Stats.UnusedCount)
+	if result.Stats.TransitiveUnusedCount > 0 {
+		unused += fmt.Sprintf(" (%d transitively)", result.Stats.TransitiveUnusedCount)
+	}
+	sb.WriteString(fmt.Sprintf("  ⚠️  Unused Assets:       %s\n", unused))
 
 	if result.Stats.PotentiallyUnusedCount > 0 {
-		sb.WriteString(fmt.Sprintf("  🤔 Potentially Unused:  %d\n", result.Stats.PotentiallyUnusedCount))
+		sb.WriteString(fmt.Sprintf("  🤔 Potentially Unused:  %s\n", colorCount(RolePotentiallyUnused, result.Stats.PotentiallyUnusedCount)))
 	}
 
 	if result.Stats.NeedsReviewCount > 0 {
-		sb.WriteString(fmt.Sprintf("  👀 Needs Review:        %d\n", result.Stats.NeedsReviewCount))
+		sb.WriteString(fmt.Sprintf("  👀 Needs Review:        %s\n", colorCount(RoleNeedsReview, result.Stats.NeedsReviewCount)))
+	}
+
+	if result.Stats.KeptCount > 0 {
+		kept := colorCount(RoleKept, result.Stats.KeptCount)
+		if result.Stats.KeptByConfigCount > 0 {
+			kept += fmt.Sprintf(" (%d by keep_paths)", result.Stats.KeptByConfigCount)
+		}
+		sb.WriteString(fmt.Sprintf("  📌 Kept:                %s\n", kept))
+	}
+
+	if result.Stats.ConventionalCount > 0 {
+		sb.WriteString(fmt.Sprintf("  🧷 Conventional:        %s\n", colorCount(RoleKept, result.Stats.ConventionalCount)))
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("  💾 Potential Savings:   %s\n", FormatBytes(result.Stats.UnusedSize)))
+	sb.WriteString(fmt.Sprintf("  💾 Potential Savings:   %s\n", Colorize(RolePositive, FormatBytes(result.Stats.UnusedSize))))
+	if result.Stats.BundleSavings > 0 {
+		sb.WriteString(fmt.Sprintf("  📱 App Size Savings:    %s (estimated %s, after compression)\n",
+			Colorize(RolePositive, FormatBytes(result.Stats.BundleSavings)), result.Stats.BundleFormat))
+	}
 	sb.WriteString(fmt.Sprintf("  ⏱️  Scan Duration:        %.2fs\n", float64(result.Duration)/1000.0))
+	if result.Stats.FilesScanned > 0 {
+		sb.WriteString(fmt.Sprintf("  📄 Files Scanned:       %d (%s)\n", result.Stats.FilesScanned, FormatBytes(result.Stats.BytesRead)))
+	}
+
+	if summary := FormatSeveritySummary(result.Stats); summary != "" {
+		sb.WriteString(fmt.Sprintf("  🚦 Severity:            %s\n", summary))
+	}
 
 	sb.WriteString("\n" + separator + "\n")
 
+	// Show per-feature breakdown if features are configured
+	if len(result.Stats.Features) > 0 {
+		sb.WriteString(FormatGroupStatistics("📦 By Feature", result.Stats.Features))
+	}
+	if len(result.Stats.Categories) > 1 {
+		sb.WriteString(FormatGroupStatistics("🗂️  By Category", result.Stats.Categories))
+	}
+	if len(result.Stats.Extensions) > 1 {
+		sb.WriteString(FormatGroupStatistics("🔤 By Extension", result.Stats.Extensions))
+	}
+
 	// Show unused assets if any
 	if result.Stats.UnusedCount > 0 {
-		sb.WriteString("\n📝 Unused Assets:\n\n")
+		sb.WriteString("\n" + Colorize(RoleHeading, "📝 Unused Assets:") + "\n\n")
 		count := 0
 		for _, asset := range result.UnusedAssets {
-			if count >= maxDisplayedAssets {
+			if count >= MaxDisplayedAssets {
 				remaining := result.Stats.UnusedCount - count
 				sb.WriteString(fmt.Sprintf("  ... and %d more\n", remaining))
 				break
 			}
-			sb.WriteString(fmt.Sprintf("  • %s (%s)\n", asset.RelativePath, FormatBytes(asset.Size)))
+			sb.WriteString(fmt.Sprintf("  • %s %s\n", Colorize(StatusRole(asset.Status), asset.RelativePath),
+				Colorize(RoleMuted, "("+FormatBytes(asset.Size)+formatAge(asset)+formatRequests(result, asset)+")")))
+			if len(asset.UnusedChain) > 0 {
+				sb.WriteString(Colorize(RoleMuted, "      only used via "+strings.Join(asset.UnusedChain, " ← ")) + "\n")
+			}
 			count++
 		}
 	}
 
+	if report := FormatReviewReasons(result.Assets); report != "" {
+		sb.WriteString(report)
+	}
+
+	if len(result.Optimizations) > 0 {
+		sb.WriteString(FormatOptimizations(result.Optimizations))
+	}
+
+	if result.Stats.UnlicensedCount > 0 {
+		sb.WriteString(fmt.Sprintf("\n📜 %d assets lack license info in %s\n",
+			result.Stats.UnlicensedCount, licenseFileName(result)))
+	}
+
+	if result.Stats.DeadCodeOnlyCount > 0 {
+		sb.WriteString(fmt.Sprintf("\n🪦 %d assets are referenced only from dead code\n", result.Stats.DeadCodeOnlyCount))
+	}
+
+	if result.Stats.UnreachableOnlyCount > 0 {
+		sb.WriteString(FormatUnreachableReport(result))
+	}
+
+	if result.Stats.PrivacyFlaggedCount > 0 {
+		sb.WriteString(FormatPrivacyReport(result.Assets))
+	}
+
+	if result.Stats.EmbeddedCopyCount > 0 {
+		sb.WriteString(FormatEmbeddedCopies(result.Assets))
+	}
+
+	if result.RequestsTracked {
+		sb.WriteString(FormatRequestReport(result))
+	}
+
+	if result.RendersTracked {
+		sb.WriteString(FormatRenderReport(result))
+	}
+
+	if len(result.ConfidenceReport) > 0 {
+		sb.WriteString(FormatConfidenceReport(result.ConfidenceReport))
+	}
+
 	sb.WriteString("\n✨ Run 'asset-cleaner review' to inspect unused assets\n")
 	sb.WriteString("✨ Run 'asset-cleaner delete --dry-run' to preview deletion\n")
 
 	return sb.String()
 }
 
+// FormatGroupStatistics formats a per-feature, category, or extension unused asset breakdown
+func FormatGroupStatistics(title string, groups map[string]*models.GroupStatistics) string {
+	var sb strings.Builder
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb.WriteString("\n" + title + ":\n\n")
+	for _, name := range names {
+		fs := groups[name]
+		unused := fmt.Sprintf("%d/%d unused", fs.UnusedCount, fs.TotalAssets)
+		if fs.UnusedCount > 0 {
+			unused = Colorize(RoleNegative, unused)
+		}
+		sb.WriteString(fmt.Sprintf("  • %-20s %s (%s of %s)\n",
+			name, unused, FormatBytes(fs.UnusedSize), FormatBytes(fs.TotalSize)))
+	}
+
+	return sb.String()
+}
+
+// FormatOptimizations formats optimization suggestions, largest savings first
+func FormatOptimizations(suggestions []models.OptimizationSuggestion) string {
+	var sb strings.Builder
+
+	sorted := make([]models.OptimizationSuggestion, len(suggestions))
+	copy(sorted, suggestions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EstimatedSavings > sorted[j].EstimatedSavings
+	})
+
+	// An asset can get both a format and a resize suggestion, each estimated
+	// from its full size; count only the larger so the total never exceeds it
+	largest := make(map[string]int64)
+	for _, s := range sorted {
+		largest[s.Path] = max(largest[s.Path], s.EstimatedSavings)
+	}
+	total := int64(0)
+	for _, savings := range largest {
+		total += savings
+	}
+
+	sb.WriteString(fmt.Sprintf("\n🗜️  Optimization Suggestions (~%s potential savings):\n\n", FormatBytes(total)))
+	for i, s := range sorted {
+		if i >= MaxDisplayedAssets {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(sorted)-i))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("  • %s: %s (~%s)\n", s.Path, s.Message, FormatBytes(s.EstimatedSavings)))
+	}
+
+	return sb.String()
+}
+
+// FormatPrivacyReport lists image assets carrying privacy-sensitive EXIF metadata
+func FormatPrivacyReport(assets []models.AssetFile) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n🔒 Privacy Report (EXIF metadata):\n\n")
+	for _, asset := range assets {
+		if len(asset.PrivacyFlags) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  • %s [%s] (%s)\n",
+			asset.RelativePath, strings.Join(asset.PrivacyFlags, ", "), asset.Status))
+	}
+
+	return sb.String()
+}
+
+// FormatEmbeddedCopies lists image files a Lottie animation also embeds, so
+// one of the two copies can usually go
+func FormatEmbeddedCopies(assets []models.AssetFile) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n🎞️  Images also embedded in Lottie animations:\n\n")
+	for _, asset := range assets {
+		if len(asset.EmbeddedIn) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  • %s (%s, %s) in %s\n",
+			asset.RelativePath, FormatBytes(asset.Size), asset.Status, strings.Join(asset.EmbeddedIn, ", ")))
+	}
+
+	return sb.String()
+}
+
+// FormatRequestReport summarizes access log evidence, listing assets the code
+// references that production never requested
+func FormatRequestReport(result *models.ScanResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("\n🌐 %d of %d assets were never requested in the access logs\n",
+		result.Stats.NeverRequestedCount, result.Stats.TotalAssets))
+
+	var referenced []models.AssetFile
+	for _, asset := range result.Assets {
+		if asset.NeverRequested && !asset.Status.IsUnused() && asset.Status != models.StatusPotentiallyUnused {
+			referenced = append(referenced, asset)
+		}
+	}
+	if len(referenced) == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("\n  Referenced in code but never requested:\n\n")
+	for i, asset := range referenced {
+		if i >= MaxDisplayedAssets {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(referenced)-i))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("  • %s (%s)\n", asset.RelativePath, asset.Status))
+	}
+
+	return sb.String()
+}
+
+// FormatRenderReport summarizes usage beacon evidence, listing assets the code
+// references that no beacon reported rendering
+func FormatRenderReport(result *models.ScanResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("\n🖼️  %d of %d assets were never rendered according to usage beacons\n",
+		result.Stats.NeverRenderedCount, result.Stats.TotalAssets))
+
+	var referenced []models.AssetFile
+	for _, asset := range result.Assets {
+		if asset.NeverRendered && len(asset.References) > 0 && !asset.Status.IsUnused() {
+			referenced = append(referenced, asset)
+		}
+	}
+	if len(referenced) == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("\n  Referenced in code but never rendered:\n\n")
+	for i, asset := range referenced {
+		if i >= MaxDisplayedAssets {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(referenced)-i))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("  • %s (%s)\n", asset.RelativePath, asset.Status))
+	}
+
+	return sb.String()
+}
+
+// FormatReviewReasons lists the Needs Review and Potentially Unused assets
+// with the reasons behind their status
+func FormatReviewReasons(assets []models.AssetFile) string {
+	var flagged []models.AssetFile
+	for _, asset := range assets {
+		if len(asset.ReviewReasons) > 0 {
+			flagged = append(flagged, asset)
+		}
+	}
+	if len(flagged) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n" + Colorize(RoleHeading, "🔎 Why Review:") + "\n\n")
+	for i, asset := range flagged {
+		if i >= MaxDisplayedAssets {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(flagged)-i))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("  • %s\n", Colorize(StatusRole(asset.Status), asset.RelativePath)))
+		for _, reason := range asset.ReviewReasons {
+			sb.WriteString(Colorize(RoleMuted, "      "+reason) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatUnreachableReport lists assets referenced only from source files no
+// entry point imports, with one of the files using each
+func FormatUnreachableReport(result *models.ScanResult) string {
+	var sb strings.Builder
+
+	total := result.Stats.UnreachableOnlyCount
+	sb.WriteString(fmt.Sprintf("\n🧭 %d assets are used only by files unreachable from entry points:\n\n", total))
+	count := 0
+	for _, asset := range result.Assets {
+		if !asset.OnlyUnreachableReferences() {
+			continue
+		}
+		if count >= MaxDisplayedAssets {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", total-count))
+			break
+		}
+		source := ""
+		for _, ref := range asset.References {
+			if ref.FromUnreachable {
+				source = ref.SourceFile
+				break
+			}
+		}
+		if rel, err := filepath.Rel(result.ProjectRoot, source); err == nil {
+			source = filepath.ToSlash(rel)
+		}
+		sb.WriteString(fmt.Sprintf("  • %s (via %s)\n", asset.RelativePath, source))
+		count++
+	}
+
+	return sb.String()
+}
+
+// FormatConfidenceReport tabulates how many references of each type and
+// confidence were found on assets of each status
+func FormatConfidenceReport(buckets []models.ConfidenceBucket) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n🎯 Confidence Report (references by status, type, and confidence):\n")
+	status := models.AssetStatus(-1)
+	for _, bucket := range buckets {
+		if bucket.Status != status {
+			status = bucket.Status
+			sb.WriteString(fmt.Sprintf("\n  %s\n", status))
+		}
+		sb.WriteString(fmt.Sprintf("    %-16s %.2f  %5d refs (%d active) on %d assets\n",
+			bucket.Type, bucket.Confidence, bucket.References, bucket.Active, bucket.Assets))
+	}
+
+	return sb.String()
+}
+
+// FormatSeveritySummary returns e.g. "2 errors, 1 warning, 3 info", or "" when there are no findings
+func FormatSeveritySummary(stats models.ScanStatistics) string {
+	var parts []string
+	if stats.ErrorCount > 0 {
+		parts = append(parts, pluralize(stats.ErrorCount, "error"))
+	}
+	if stats.WarningCount > 0 {
+		parts = append(parts, pluralize(stats.WarningCount, "warning"))
+	}
+	if stats.InfoCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d info", stats.InfoCount))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pluralize formats a count with a singular/plural noun
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// licenseFileName returns the configured license file name for messages
+func licenseFileName(result *models.ScanResult) string {
+	if result.Config != nil && result.Config.LicenseFile != "" {
+		return result.Config.LicenseFile
+	}
+	return "asset-licenses.yaml"
+}
+
+// formatAge returns a ", untouched Nd" suffix for assets older than 30 days
+func formatAge(asset models.AssetFile) string {
+	if asset.LastTouched.IsZero() {
+		return ""
+	}
+	days := int(time.Since(asset.LastTouched).Hours() / 24)
+	if days < 30 {
+		return ""
+	}
+	return fmt.Sprintf(", untouched %dd", days)
+}
+
+// formatRequests notes an asset access logs never saw requested
+func formatRequests(result *models.ScanResult, asset models.AssetFile) string {
+	if !result.RequestsTracked || !asset.NeverRequested {
+		return ""
+	}
+	return ", never requested"
+}
+
+// colorCount formats a status count in the status color, leaving zero plain
+func colorCount(role Role, n int) string {
+	if n == 0 {
+		return "0"
+	}
+	return Colorize(role, strconv.Itoa(n))
+}
+
 // FormatBytes formats bytes as a human-readable string
 func FormatBytes(bytes int64) string {
 	const unit = bytesPerKilobyte
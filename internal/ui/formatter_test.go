@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestFormatOptimizations_TotalPerAsset(t *testing.T) {
+	suggestions := []models.OptimizationSuggestion{
+		{Path: "img/hero.png", Kind: models.OptimizationFormat, Message: "convert png to webp", EstimatedSavings: 700 * 1024},
+		{Path: "img/hero.png", Kind: models.OptimizationResize, Message: "resize 4000x3000 to fit 2048px", EstimatedSavings: 600 * 1024},
+		{Path: "img/logo.jpg", Kind: models.OptimizationFormat, Message: "convert jpg to webp", EstimatedSavings: 100 * 1024},
+	}
+
+	got := FormatOptimizations(suggestions)
+	if !strings.Contains(got, "~800.0 KB potential savings") {
+		t.Errorf("FormatOptimizations() should count hero.png once (700 KB + 100 KB):\n%s", got)
+	}
+	if strings.Count(got, "img/hero.png") != 2 {
+		t.Errorf("FormatOptimizations() should still list both suggestions for hero.png:\n%s", got)
+	}
+}
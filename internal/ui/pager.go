@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultPager is used when neither EASYCLEAN_PAGER nor PAGER is set
+const defaultPager = "less"
+
+// PrintPaged writes output to stdout, through a pager when paging is enabled
+// and stdout is a terminal. Like git, LESS defaults to FRX so less exits
+// right away when the output fits on one screen and passes colors through.
+// If the pager can't be started the output is printed directly.
+func PrintPaged(output string, paging bool) error {
+	pager := pagerCommand()
+	if !paging || pager == nil || !isTerminal(os.Stdout) {
+		_, err := fmt.Print(output)
+		return err
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = strings.NewReader(output)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+
+	if err := cmd.Start(); err != nil {
+		_, err := fmt.Print(output)
+		return err
+	}
+	return cmd.Wait()
+}
+
+// pagerCommand returns the configured pager and its arguments, or nil when
+// paging is turned off with an empty value or "cat"
+func pagerCommand() []string {
+	pager, ok := os.LookupEnv("EASYCLEAN_PAGER")
+	if !ok {
+		pager, ok = os.LookupEnv("PAGER")
+	}
+	if !ok {
+		pager = defaultPager
+	}
+
+	fields := strings.Fields(pager)
+	if len(fields) == 0 || fields[0] == "cat" {
+		return nil
+	}
+	return fields
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -8,10 +8,16 @@ import (
 	"io"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
 )
 
 //go:embed web/*
@@ -19,15 +25,55 @@ var webFiles embed.FS
 
 // ReviewServer wraps an HTTP server for the review UI
 type ReviewServer struct {
-	server     *http.Server
+	server      *http.Server
+	projectRoot string
+
+	// The scan being reviewed: the one loaded from scanFile, or an earlier
+	// one from the project's scan history (scanID)
+	mu         sync.RWMutex
 	scanResult *models.ScanResult
+	refIndex   *models.ReferenceIndex
+	scanFile   string
+	scanID     string
+	historyDir string
+	verify     bool
+
+	// Review session saved in the project cache
+	sessionMu   sync.Mutex
+	sessionPath string
 }
 
-// NewReviewServer creates a new review server instance
-func NewReviewServer(result *models.ScanResult, host string, port int) (*ReviewServer, error) {
+// NewReviewServer creates a new review server instance for a result of the
+// project at projectRoot loaded from scanFile. Deletes and previews are
+// confined to projectRoot, so results whose own ProjectRoot differs are
+// refused. With verify, scans reloaded from disk must match their signature.
+func NewReviewServer(result *models.ScanResult, projectRoot, scanFile, host string, port int, verify bool) (*ReviewServer, error) {
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project root: %w", err)
+	}
 	rs := &ReviewServer{
-		scanResult: result,
+		projectRoot: absRoot,
+		scanFile:    scanFile,
+		verify:      verify,
+	}
+	if err := rs.checkRoot(result); err != nil {
+		return nil, err
+	}
+	rs.scanResult = result
+	rs.refIndex = models.BuildReferenceIndex(result)
+
+	historyDir, err := utils.GetScanHistoryDir(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate scan history: %w", err)
 	}
+	rs.historyDir = historyDir
+
+	sessionPath, err := utils.GetReviewSessionPath(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate review session: %w", err)
+	}
+	rs.sessionPath = sessionPath
 
 	// Serve embedded static files from web subdirectory
 	webFS, err := fs.Sub(webFiles, "web")
@@ -43,6 +89,12 @@ func NewReviewServer(result *models.ScanResult, host string, port int) (*ReviewS
 	mux.HandleFunc("/api/results", rs.handleGetResults)
 	mux.HandleFunc("/api/delete", rs.handleDelete)
 	mux.HandleFunc("/api/asset", rs.handleServeAsset)
+	mux.HandleFunc("/api/references", rs.handleReferences)
+	mux.HandleFunc("/api/assets", rs.handleAssets)
+	mux.HandleFunc("/api/session", rs.handleSession)
+	mux.HandleFunc("/api/export", rs.handleExport)
+	mux.HandleFunc("/api/reload", rs.handleReload)
+	mux.HandleFunc("/api/scans", rs.handleScans)
 
 	// Create HTTP server
 	rs.server = &http.Server{
@@ -72,8 +124,446 @@ func (rs *ReviewServer) handleGetResults(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	result, _ := rs.current()
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(rs.scanResult)
+	json.NewEncoder(w).Encode(result)
+}
+
+// current returns the scan being reviewed and its reference index
+func (rs *ReviewServer) current() (*models.ScanResult, *models.ReferenceIndex) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.scanResult, rs.refIndex
+}
+
+// setResult replaces the scan being reviewed
+func (rs *ReviewServer) setResult(result *models.ScanResult, id string) {
+	index := models.BuildReferenceIndex(result)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.scanResult = result
+	rs.refIndex = index
+	rs.scanID = id
+}
+
+// scanSummary describes a stored scan for switching between them
+type scanSummary struct {
+	ID          string    `json:"id"` // "" for the latest scan
+	Timestamp   time.Time `json:"timestamp"`
+	TotalAssets int       `json:"total_assets"`
+	UnusedCount int       `json:"unused_count"`
+	UnusedSize  int64     `json:"unused_size_bytes"`
+	Current     bool      `json:"current"`
+}
+
+func summarizeScan(id string, result *models.ScanResult) scanSummary {
+	return scanSummary{
+		ID:          id,
+		Timestamp:   result.Timestamp,
+		TotalAssets: result.Stats.TotalAssets,
+		UnusedCount: result.Stats.UnusedCount,
+		UnusedSize:  result.Stats.UnusedSize,
+	}
+}
+
+// handleReload re-reads the scan file from disk, e.g. after running scan again
+func (rs *ReviewServer) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := rs.loadScanFile(rs.scanFile)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reload scan results: %v", err), http.StatusInternalServerError)
+		return
+	}
+	rs.setResult(result, "")
+
+	summary := summarizeScan("", result)
+	summary.Current = true
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+// handleScans lists the latest scan and the project's scan history (GET), or
+// switches the UI to one of them (POST ?id=, empty for the latest)
+func (rs *ReviewServer) handleScans(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		rs.listScans(w)
+	case http.MethodPost:
+		rs.selectScan(w, r.URL.Query().Get("id"))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (rs *ReviewServer) listScans(w http.ResponseWriter) {
+	rs.mu.RLock()
+	currentID := rs.scanID
+	rs.mu.RUnlock()
+
+	latest, err := rs.loadScanFile(rs.scanFile)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to read scan results: %v", err), http.StatusInternalServerError)
+		return
+	}
+	scans := []scanSummary{summarizeScan("", latest)}
+
+	history, err := utils.ListScanHistory(rs.historyDir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, entry := range history {
+		result, err := rs.loadScanFile(entry.Path)
+		if err != nil || result.Timestamp.Equal(latest.Timestamp) {
+			continue // unreadable, or the latest scan's own copy
+		}
+		scans = append(scans, summarizeScan(entry.ID, result))
+	}
+
+	for i := range scans {
+		scans[i].Current = scans[i].ID == currentID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scans)
+}
+
+func (rs *ReviewServer) selectScan(w http.ResponseWriter, id string) {
+	path := rs.scanFile
+	if id != "" {
+		// Only IDs from the history listing are accepted, never paths
+		history, err := utils.ListScanHistory(rs.historyDir)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		path = ""
+		for _, entry := range history {
+			if entry.ID == id {
+				path = entry.Path
+				break
+			}
+		}
+		if path == "" {
+			http.Error(w, "Scan not found in history", http.StatusNotFound)
+			return
+		}
+	}
+
+	result, err := rs.loadScanFile(path)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to load scan results: %v", err), http.StatusInternalServerError)
+		return
+	}
+	rs.setResult(result, id)
+
+	summary := summarizeScan(id, result)
+	summary.Current = true
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+func (rs *ReviewServer) loadScanFile(path string) (*models.ScanResult, error) {
+	if rs.verify {
+		if err := utils.VerifySignedFile(path, false); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	file, err := utils.OpenMaybeGzip(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	result, err := models.ReadScanResult(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.checkRoot(result); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return result, nil
+}
+
+// checkRoot refuses results scanned from another project root than the one
+// being reviewed
+func (rs *ReviewServer) checkRoot(result *models.ScanResult) error {
+	if !utils.SamePath(result.ProjectRoot, rs.projectRoot) {
+		return fmt.Errorf("scan results are for %s, not the project at %s", result.ProjectRoot, rs.projectRoot)
+	}
+	return nil
+}
+
+// handleReferences answers ?asset= (references to an asset) or ?source= (assets used by a file)
+func (rs *ReviewServer) handleReferences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	_, index := rs.current()
+	query := r.URL.Query()
+	var response any
+	switch {
+	case query.Get("asset") != "":
+		refs, _ := index.Why(query.Get("asset"))
+		if refs == nil {
+			refs = []models.IndexedReference{}
+		}
+		response = refs
+	case query.Get("source") != "":
+		assets := index.Uses(query.Get("source"))
+		if assets == nil {
+			assets = []string{}
+		}
+		response = assets
+	default:
+		http.Error(w, "Missing asset or source parameter", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// reviewStatuses are the statuses listed for review when no status filter is given
+var reviewStatuses = []models.AssetStatus{
+	models.StatusUnused,
+	models.StatusTransitivelyUnused,
+	models.StatusPotentiallyUnused,
+	models.StatusNeedsManualReview,
+}
+
+// handleAssets lists the assets matching the filter in the query:
+// status, category (comma-separated), min_size, max_size (bytes), path (glob),
+// min_age_days, max_age_days
+func (rs *ReviewServer) handleAssets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseAssetFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, _ := rs.current()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(filter.Apply(result.Assets, time.Now()))
+}
+
+func parseAssetFilter(query url.Values) (models.AssetFilter, error) {
+	filter := models.AssetFilter{PathGlob: query.Get("path")}
+
+	for _, name := range splitList(query.Get("status")) {
+		status, err := models.ParseAssetStatus(name)
+		if err != nil {
+			return filter, err
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = reviewStatuses
+	}
+
+	for _, name := range splitList(query.Get("category")) {
+		category, err := models.ParseAssetCategory(name)
+		if err != nil {
+			return filter, err
+		}
+		filter.Categories = append(filter.Categories, category)
+	}
+
+	ints := []struct {
+		param string
+		set   func(int64)
+	}{
+		{"min_size", func(n int64) { filter.MinSize = n }},
+		{"max_size", func(n int64) { filter.MaxSize = n }},
+		{"min_age_days", func(n int64) { filter.MinAge = time.Duration(n) * 24 * time.Hour }},
+		{"max_age_days", func(n int64) { filter.MaxAge = time.Duration(n) * 24 * time.Hour }},
+	}
+	for _, p := range ints {
+		value := query.Get(p.param)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("invalid %s %q", p.param, value)
+		}
+		p.set(n)
+	}
+
+	return filter, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// handleSession loads (GET) or saves (POST) the review session, so
+// decisions survive closing the browser or restarting the server
+func (rs *ReviewServer) handleSession(w http.ResponseWriter, r *http.Request) {
+	rs.sessionMu.Lock()
+	defer rs.sessionMu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		session, err := rs.loadSession()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(session)
+
+	case http.MethodPost:
+		var session models.ReviewSession
+		if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		result, _ := rs.current()
+		session.Prune(result)
+		session.UpdatedAt = time.Now()
+
+		if err := rs.saveSession(&session); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&session)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// loadSession reads the saved review session, decrypting it like the rest of
+// the cache; a missing or corrupt session starts the review over rather than
+// blocking it, but one that can't be decrypted is an error so it isn't
+// overwritten
+func (rs *ReviewServer) loadSession() (*models.ReviewSession, error) {
+	session := &models.ReviewSession{}
+	if file, err := utils.OpenMaybeGzip(rs.sessionPath); err == nil {
+		defer file.Close()
+		if err := json.NewDecoder(file).Decode(session); err != nil {
+			session = &models.ReviewSession{}
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read review session: %w", err)
+	}
+	result, _ := rs.current()
+	session.Prune(result)
+	return session, nil
+}
+
+func (rs *ReviewServer) saveSession(session *models.ReviewSession) error {
+	if err := utils.EnsureCacheDirExists(filepath.Dir(rs.sessionPath)); err != nil {
+		return err
+	}
+	err := utils.WriteCacheFile(rs.sessionPath, false, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(session)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save review session: %w", err)
+	}
+	return nil
+}
+
+// handleExport downloads the assets selected for deletion (?set=selected, the
+// default) or those still awaiting a decision (?set=remaining) as a scan
+// result: JSON for 'easyClean delete --scan-file', or CSV (?format=csv)
+func (rs *ReviewServer) handleExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	set := query.Get("set")
+	if set == "" {
+		set = "selected"
+	}
+	if set != "selected" && set != "remaining" {
+		http.Error(w, "Invalid set (want selected or remaining)", http.StatusBadRequest)
+		return
+	}
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		http.Error(w, "Invalid format (want json or csv)", http.StatusBadRequest)
+		return
+	}
+
+	rs.sessionMu.Lock()
+	session, err := rs.loadSession()
+	rs.sessionMu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The export is the scan result narrowed to the chosen assets, with
+	// statistics recomputed for them
+	result, _ := rs.current()
+	export := *result
+	export.Assets = []models.AssetFile{}
+	for _, asset := range result.Assets {
+		decision, decided := session.Decisions[asset.RelativePath]
+		switch {
+		case set == "selected" && decision == models.DecisionDelete:
+		case set == "remaining" && !decided && containsStatus(reviewStatuses, asset.Status):
+		default:
+			continue
+		}
+		export.Assets = append(export.Assets, asset)
+	}
+	export.PopulateFilteredLists()
+	export.ComputeStatistics()
+
+	var data []byte
+	if format == "csv" {
+		var csv string
+		csv, err = export.ToCSV()
+		data = []byte(csv)
+		w.Header().Set("Content-Type", "text/csv")
+	} else {
+		data, err = export.ToJSON()
+		w.Header().Set("Content-Type", "application/json")
+	}
+	if err != nil {
+		http.Error(w, "Failed to export assets", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="easyclean-%s.%s"`, set, format))
+	w.Write(data)
+}
+
+func containsStatus(statuses []models.AssetStatus, status models.AssetStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
 }
 
 func (rs *ReviewServer) handleDelete(w http.ResponseWriter, r *http.Request) {
@@ -91,6 +581,7 @@ func (rs *ReviewServer) handleDelete(w http.ResponseWriter, r *http.Request) {
 
 	var request struct {
 		Paths []string `json:"paths"`
+		Force bool     `json:"force"`
 	}
 
 	if err := json.Unmarshal(body, &request); err != nil {
@@ -103,23 +594,41 @@ func (rs *ReviewServer) handleDelete(w http.ResponseWriter, r *http.Request) {
 	totalFreed := int64(0)
 	var errors []string
 
+	result, _ := rs.current()
+	// Assets referenced only from files a limited scan never read look
+	// unused, so an incomplete scan needs an explicit force
+	if len(result.Warnings) > 0 && !request.Force {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(struct {
+			Success    bool     `json:"success"`
+			Incomplete bool     `json:"incomplete"`
+			Errors     []string `json:"errors"`
+		}{
+			Incomplete: true,
+			Errors:     []string{"These scan results are incomplete: " + strings.Join(result.Warnings, "; ")},
+		})
+		return
+	}
+
 	for _, path := range request.Paths {
 		// Find asset in scan results
-		var assetToDelete *models.AssetFile
-		for _, asset := range rs.scanResult.UnusedAssets {
-			if asset.Path == path || asset.RelativePath == path {
-				assetToDelete = &asset
-				break
-			}
-		}
-
+		assetToDelete := rs.findAsset(result.UnusedAssets, path)
 		if assetToDelete == nil {
 			errors = append(errors, fmt.Sprintf("%s: not found in unused assets", path))
 			continue
 		}
 
+		// Never trust the scan file's paths: a tampered result could list
+		// files outside the project
+		resolved, err := utils.ResolveInRoot(rs.projectRoot, assetToDelete.Path)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", path, err))
+			continue
+		}
+
 		// Delete file
-		if err := os.Remove(assetToDelete.Path); err != nil {
+		if err := os.Remove(resolved); err != nil {
 			errors = append(errors, fmt.Sprintf("%s: %v", path, err))
 		} else {
 			deletedCount++
@@ -158,27 +667,34 @@ func (rs *ReviewServer) handleServeAsset(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Security: Validate the path is in our scan results (whitelist approach)
-	if rs.scanResult == nil {
+	result, _ := rs.current()
+	if result == nil {
 		http.Error(w, "No scan results available", http.StatusNotFound)
 		return
 	}
 
 	// Check if the requested path is in our asset list
-	isValidAsset := false
-	for _, asset := range rs.scanResult.Assets {
-		if asset.Path == assetPath {
-			isValidAsset = true
-			break
-		}
+	asset := rs.findAsset(result.Assets, assetPath)
+	if asset == nil {
+		http.Error(w, "Asset not found in scan results", http.StatusForbidden)
+		return
 	}
 
-	if !isValidAsset {
-		http.Error(w, "Asset not found in scan results", http.StatusForbidden)
+	// ...and that it really lies inside the project
+	resolved, err := utils.ResolveInRoot(rs.projectRoot, asset.Path)
+	if err != nil {
+		http.Error(w, "Asset is outside the project root", http.StatusForbidden)
+		return
+	}
+
+	file, err := os.Open(resolved)
+	if err != nil {
+		http.Error(w, "Failed to read asset file", http.StatusInternalServerError)
 		return
 	}
+	defer file.Close()
 
-	// Read the file
-	data, err := os.ReadFile(assetPath)
+	info, err := file.Stat()
 	if err != nil {
 		http.Error(w, "Failed to read asset file", http.StatusInternalServerError)
 		return
@@ -189,7 +705,24 @@ func (rs *ReviewServer) handleServeAsset(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Cache-Control", "public, max-age=3600")
 
-	w.Write(data)
+	// ServeContent answers Range requests, which browsers need to seek in
+	// audio and video
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// findAsset returns the asset whose path matches a requested absolute or
+// project-relative path after cleaning (so "a/../b.png" finds "b.png")
+func (rs *ReviewServer) findAsset(assets []models.AssetFile, path string) *models.AssetFile {
+	target := filepath.Clean(path)
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(rs.projectRoot, target)
+	}
+	for i := range assets {
+		if filepath.Clean(assets[i].Path) == target {
+			return &assets[i]
+		}
+	}
+	return nil
 }
 
 func getContentType(path string) string {
@@ -201,7 +734,7 @@ func getContentType(path string) string {
 		}
 	}
 
-	switch ext {
+	switch strings.ToLower(ext) {
 	case ".png":
 		return "image/png"
 	case ".jpg", ".jpeg":
@@ -216,6 +749,34 @@ func getContentType(path string) string {
 		return "image/x-icon"
 	case ".bmp":
 		return "image/bmp"
+	case ".mp4", ".m4v":
+		return "video/mp4"
+	case ".webm":
+		return "video/webm"
+	case ".mov":
+		return "video/quicktime"
+	case ".ogv":
+		return "video/ogg"
+	case ".mp3":
+		return "audio/mpeg"
+	case ".wav":
+		return "audio/wav"
+	case ".ogg", ".oga":
+		return "audio/ogg"
+	case ".m4a", ".aac":
+		return "audio/mp4"
+	case ".flac":
+		return "audio/flac"
+	case ".woff":
+		return "font/woff"
+	case ".woff2":
+		return "font/woff2"
+	case ".ttf":
+		return "font/ttf"
+	case ".otf":
+		return "font/otf"
+	case ".eot":
+		return "application/vnd.ms-fontobject"
 	default:
 		return "application/octet-stream"
 	}
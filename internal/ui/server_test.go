@@ -0,0 +1,304 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+	"github.com/HabibPro1999/easyClean/internal/utils"
+)
+
+// newTestServer writes files (relative path to content) under a new project
+// root and starts a review server for a scan listing assets, keyed by path
+// (relative to the root, or absolute) with their status
+func newTestServer(t *testing.T, files map[string]string, assets map[string]models.AssetStatus) (*ReviewServer, string) {
+	t.Helper()
+	t.Setenv(utils.CacheDirEnv, t.TempDir())
+
+	root := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	result := &models.ScanResult{ProjectRoot: root}
+	for path, status := range assets {
+		rel := path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		result.Assets = append(result.Assets, models.AssetFile{
+			Path:         path,
+			RelativePath: rel,
+			Name:         filepath.Base(path),
+			Size:         int64(len(files[rel])),
+			Status:       status,
+		})
+	}
+	result.PopulateFilteredLists()
+	result.ComputeStatistics()
+
+	rs, err := NewReviewServer(result, root, filepath.Join(root, "scan.json"), "localhost", 0, false)
+	if err != nil {
+		t.Fatalf("NewReviewServer() failed: %v", err)
+	}
+	return rs, root
+}
+
+func serve(rs *ReviewServer, method, target, body string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	rs.server.Handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewReviewServer_RejectsOtherProject(t *testing.T) {
+	t.Setenv(utils.CacheDirEnv, t.TempDir())
+	result := &models.ScanResult{ProjectRoot: t.TempDir()}
+
+	if _, err := NewReviewServer(result, t.TempDir(), "scan.json", "localhost", 0, false); err == nil {
+		t.Error("Expected scan results of another project to be refused")
+	}
+	if _, err := NewReviewServer(result, result.ProjectRoot, "scan.json", "localhost", 0, false); err != nil {
+		t.Errorf("NewReviewServer() failed for the scanned project: %v", err)
+	}
+}
+
+func TestReviewServer_ServeAsset(t *testing.T) {
+	outside := filepath.Join(t.TempDir(), "secret.png")
+	if err := os.WriteFile(outside, []byte("secret"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	rs, root := newTestServer(t,
+		map[string]string{"audio/intro.mp3": "0123456789"},
+		map[string]models.AssetStatus{
+			"audio/intro.mp3": models.StatusUnused,
+			outside:           models.StatusUnused, // tampered scan file
+			"img/linked.png":  models.StatusUnused,
+		})
+	if err := os.MkdirAll(filepath.Join(root, "img"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "img", "linked.png")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	rec := serve(rs, http.MethodGet, "/api/asset?path=audio/intro.mp3", "", map[string]string{"Range": "bytes=2-5"})
+	if rec.Code != http.StatusPartialContent {
+		t.Fatalf("Range request status = %d, want %d", rec.Code, http.StatusPartialContent)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "audio/mpeg" {
+		t.Errorf("Content-Type = %q, want audio/mpeg", got)
+	}
+	if got := rec.Body.String(); got != "2345" {
+		t.Errorf("Range body = %q, want %q", got, "2345")
+	}
+
+	rel, _ := filepath.Rel(root, outside)
+	for _, path := range []string{outside, rel, "img/linked.png", "img/../../etc/passwd"} {
+		rec := serve(rs, http.MethodGet, "/api/asset?path="+path, "", nil)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("GET %s status = %d, want %d", path, rec.Code, http.StatusForbidden)
+		}
+		if strings.Contains(rec.Body.String(), "secret") {
+			t.Errorf("GET %s served a file outside the project", path)
+		}
+	}
+}
+
+func TestReviewServer_DeleteConfinedToRoot(t *testing.T) {
+	outside := filepath.Join(t.TempDir(), "keep.png")
+	if err := os.WriteFile(outside, []byte("png"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	rs, root := newTestServer(t,
+		map[string]string{"img/old.png": "png", "img/used.png": "png"},
+		map[string]models.AssetStatus{
+			"img/old.png":  models.StatusUnused,
+			"img/used.png": models.StatusUsed,
+			outside:        models.StatusUnused,
+		})
+
+	rel, _ := filepath.Rel(root, outside)
+	body, _ := json.Marshal(map[string][]string{"paths": {"img/old.png", "img/used.png", rel}})
+	rec := serve(rs, http.MethodPost, "/api/delete", string(body), nil)
+
+	var response struct {
+		DeletedCount int      `json:"deleted_count"`
+		Errors       []string `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.DeletedCount != 1 || len(response.Errors) != 2 {
+		t.Errorf("Deleted %d with errors %v, want 1 deleted and 2 errors", response.DeletedCount, response.Errors)
+	}
+	if utils.Exists(filepath.Join(root, "img", "old.png")) {
+		t.Error("Expected the unused asset to be deleted")
+	}
+	if !utils.Exists(filepath.Join(root, "img", "used.png")) {
+		t.Error("Expected the used asset to be kept")
+	}
+	if !utils.Exists(outside) {
+		t.Error("Expected the file outside the project to be kept")
+	}
+}
+
+func TestReviewServer_DeleteIncompleteScan(t *testing.T) {
+	rs, root := newTestServer(t,
+		map[string]string{"img/old.png": "png"},
+		map[string]models.AssetStatus{"img/old.png": models.StatusUnused})
+	rs.scanResult.Warnings = []string{"max_files (10) reached; remaining files were not scanned"}
+
+	body := `{"paths": ["img/old.png"]}`
+	rec := serve(rs, http.MethodPost, "/api/delete", body, nil)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Delete from an incomplete scan status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if !utils.Exists(filepath.Join(root, "img", "old.png")) {
+		t.Fatal("Expected the asset to be kept without force")
+	}
+
+	body = `{"paths": ["img/old.png"], "force": true}`
+	if rec := serve(rs, http.MethodPost, "/api/delete", body, nil); rec.Code != http.StatusOK {
+		t.Errorf("Forced delete status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if utils.Exists(filepath.Join(root, "img", "old.png")) {
+		t.Error("Expected the asset to be deleted with force")
+	}
+}
+
+func TestReviewServer_SessionAndExport(t *testing.T) {
+	rs, _ := newTestServer(t,
+		map[string]string{"a.png": "aa", "b.png": "bb", "c.png": "cc"},
+		map[string]models.AssetStatus{
+			"a.png": models.StatusUnused,
+			"b.png": models.StatusUnused,
+			"c.png": models.StatusNeedsManualReview,
+		})
+
+	session := `{"decisions": {"a.png": "delete", "b.png": "keep", "gone.png": "delete"}, "filter": "status=unused"}`
+	if rec := serve(rs, http.MethodPost, "/api/session", session, nil); rec.Code != http.StatusOK {
+		t.Fatalf("POST /api/session status = %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var saved models.ReviewSession
+	rec := serve(rs, http.MethodGet, "/api/session", "", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &saved); err != nil {
+		t.Fatalf("Failed to decode session: %v", err)
+	}
+	if len(saved.Decisions) != 2 || saved.Decisions["a.png"] != models.DecisionDelete || saved.Filter != "status=unused" {
+		t.Errorf("Saved session = %+v, want a.png deleted and b.png kept", saved)
+	}
+
+	rec = serve(rs, http.MethodGet, "/api/export", "", nil)
+	var selected models.ScanResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &selected); err != nil {
+		t.Fatalf("Failed to decode export: %v", err)
+	}
+	if len(selected.Assets) != 1 || selected.Assets[0].RelativePath != "a.png" || selected.Stats.TotalAssets != 1 {
+		t.Errorf("Selected export = %+v, want only a.png", selected.Assets)
+	}
+
+	rec = serve(rs, http.MethodGet, "/api/export?set=remaining&format=csv", "", nil)
+	csv := rec.Body.String()
+	if !strings.Contains(csv, "c.png") || strings.Contains(csv, "a.png") || strings.Contains(csv, "b.png") {
+		t.Errorf("Remaining export should list only c.png:\n%s", csv)
+	}
+	if got := rec.Header().Get("Content-Disposition"); !strings.Contains(got, "easyclean-remaining.csv") {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+
+	if rec := serve(rs, http.MethodGet, "/api/export?format=xml", "", nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("Unknown format status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestReviewServer_EncryptedSession(t *testing.T) {
+	t.Setenv(utils.CacheKeyEnv, "s3cret")
+	rs, _ := newTestServer(t,
+		map[string]string{"secret-launch.png": "aa"},
+		map[string]models.AssetStatus{"secret-launch.png": models.StatusUnused})
+
+	session := `{"decisions": {"secret-launch.png": "delete"}}`
+	if rec := serve(rs, http.MethodPost, "/api/session", session, nil); rec.Code != http.StatusOK {
+		t.Fatalf("POST /api/session status = %d: %s", rec.Code, rec.Body.String())
+	}
+	raw, err := os.ReadFile(rs.sessionPath)
+	if err != nil {
+		t.Fatalf("Failed to read session: %v", err)
+	}
+	if strings.Contains(string(raw), "secret-launch") {
+		t.Error("Expected the saved session to be encrypted")
+	}
+
+	var saved models.ReviewSession
+	rec := serve(rs, http.MethodGet, "/api/session", "", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &saved); err != nil {
+		t.Fatalf("Failed to decode session: %v", err)
+	}
+	if saved.Decisions["secret-launch.png"] != models.DecisionDelete {
+		t.Errorf("Saved session = %+v, want the decision read back", saved)
+	}
+
+	// Without the key the session can't be read, and isn't silently reset
+	t.Setenv(utils.CacheKeyEnv, "")
+	if rec := serve(rs, http.MethodGet, "/api/session", "", nil); rec.Code != http.StatusInternalServerError {
+		t.Errorf("GET /api/session without the key status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestReviewServer_FilterAssets(t *testing.T) {
+	rs, _ := newTestServer(t,
+		map[string]string{"src/legacy/a.png": "aaaa", "src/b.png": "b", "c.png": "cc"},
+		map[string]models.AssetStatus{
+			"src/legacy/a.png": models.StatusUnused,
+			"src/b.png":        models.StatusUnused,
+			"c.png":            models.StatusUsed,
+		})
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"src/b.png", "src/legacy/a.png"}},
+		{"path=src/legacy/**", []string{"src/legacy/a.png"}},
+		{"min_size=2", []string{"src/legacy/a.png"}},
+		{"status=used", []string{"c.png"}},
+	}
+	for _, tt := range tests {
+		rec := serve(rs, http.MethodGet, "/api/assets?"+tt.query, "", nil)
+		var assets []models.AssetFile
+		if err := json.Unmarshal(rec.Body.Bytes(), &assets); err != nil {
+			t.Fatalf("%q: failed to decode assets: %v", tt.query, err)
+		}
+		var got []string
+		for _, asset := range assets {
+			got = append(got, asset.RelativePath)
+		}
+		sort.Strings(got)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	if rec := serve(rs, http.MethodGet, "/api/assets?min_size=-1", "", nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("Negative min_size status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
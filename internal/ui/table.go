@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// tableColumns are the columns of FormatTable, in order
+var tableColumns = []string{"PATH", "STATUS", "SIZE", "REFS", "CATEGORY", "REASON"}
+
+// FormatTable renders assets as aligned columns, listing at most top rows
+// (0 lists all). Size and reference counts are right-aligned.
+func FormatTable(assets []models.AssetFile, top int) string {
+	shown := assets
+	if top > 0 && len(shown) > top {
+		shown = shown[:top]
+	}
+
+	rows := make([][]string, len(shown))
+	widths := make([]int, len(tableColumns))
+	for i, col := range tableColumns {
+		widths[i] = len(col)
+	}
+	for i, asset := range shown {
+		rows[i] = []string{
+			asset.RelativePath,
+			asset.Status.String(),
+			FormatBytes(asset.Size),
+			strconv.Itoa(asset.RefCount),
+			asset.Category.String(),
+			firstReviewReason(asset),
+		}
+		for j, cell := range rows[i] {
+			widths[j] = max(widths[j], len(cell))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(Colorize(RoleHeading, formatTableRow(tableColumns, widths)) + "\n")
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		copy(cells, row)
+		// Pad before coloring so escape codes don't skew the alignment
+		cells[1] = Colorize(StatusRole(shown[i].Status), fmt.Sprintf("%-*s", widths[1], row[1]))
+		sb.WriteString(formatTableRow(cells, widths) + "\n")
+	}
+	if len(shown) < len(assets) {
+		sb.WriteString(Colorize(RoleMuted, fmt.Sprintf("... and %d more (use --top to show more)", len(assets)-len(shown))) + "\n")
+	}
+
+	return sb.String()
+}
+
+// firstReviewReason is the table's REASON cell: the first review reason,
+// noting how many more there are
+func firstReviewReason(asset models.AssetFile) string {
+	switch len(asset.ReviewReasons) {
+	case 0:
+		return ""
+	case 1:
+		return asset.ReviewReasons[0]
+	}
+	return fmt.Sprintf("%s (+%d)", asset.ReviewReasons[0], len(asset.ReviewReasons)-1)
+}
+
+// formatTableRow pads each cell to its column width, right-aligning SIZE and REFS
+func formatTableRow(cells []string, widths []int) string {
+	parts := make([]string, len(cells))
+	for i, cell := range cells {
+		switch {
+		case i == len(cells)-1:
+			parts[i] = cell
+		case tableColumns[i] == "SIZE" || tableColumns[i] == "REFS":
+			parts[i] = fmt.Sprintf("%*s", widths[i], cell)
+		default:
+			parts[i] = fmt.Sprintf("%-*s", widths[i], cell)
+		}
+	}
+	// Rows without a REASON would otherwise end in padding
+	return strings.TrimRight(strings.Join(parts, "  "), " ")
+}
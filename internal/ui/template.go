@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+// templateFuncs are available to report templates besides the text/template
+// builtins
+var templateFuncs = template.FuncMap{
+	"bytes": FormatBytes,
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"replace": func(from, to, s string) string {
+		return strings.ReplaceAll(s, from, to)
+	},
+}
+
+// ReportTemplate is a user-supplied Go text/template executed with the
+// *models.ScanResult as its data
+type ReportTemplate struct {
+	tmpl *template.Template
+}
+
+// LoadReportTemplate parses a report template file
+func LoadReportTemplate(path string) (*ReportTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return &ReportTemplate{tmpl: tmpl}, nil
+}
+
+// Render executes the template for a scan result
+func (rt *ReportTemplate) Render(result *models.ScanResult) (string, error) {
+	var sb strings.Builder
+	if err := rt.tmpl.Execute(&sb, result); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HabibPro1999/easyClean/internal/models"
+)
+
+func TestReportTemplate_Render(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	tmpl := `{{upper "unused"}}: {{.Stats.UnusedCount}} ({{bytes .Stats.UnusedSize}})
+{{range .UnusedAssets}}* {{replace "/" " > " .RelativePath}}
+{{end}}`
+	if err := os.WriteFile(path, []byte(tmpl), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	rt, err := LoadReportTemplate(path)
+	if err != nil {
+		t.Fatalf("LoadReportTemplate() failed: %v", err)
+	}
+	result := &models.ScanResult{Assets: []models.AssetFile{
+		{RelativePath: "img/old.png", Size: 2048, Status: models.StatusUnused},
+		{RelativePath: "img/used.png", Size: 10, Status: models.StatusUsed},
+	}}
+	result.PopulateFilteredLists()
+	result.ComputeStatistics()
+
+	got, err := rt.Render(result)
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	want := "UNUSED: 1 (2.0 KB)\n* img > old.png\n"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestReportTemplate_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadReportTemplate(filepath.Join(dir, "missing.tmpl")); err == nil {
+		t.Error("Expected an error for a missing template")
+	}
+
+	broken := filepath.Join(dir, "broken.tmpl")
+	if err := os.WriteFile(broken, []byte("{{range .Assets}}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if _, err := LoadReportTemplate(broken); err == nil {
+		t.Error("Expected an error for an unclosed range")
+	}
+
+	unknown := filepath.Join(dir, "unknown.tmpl")
+	if err := os.WriteFile(unknown, []byte("{{.NoSuchField}}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	rt, err := LoadReportTemplate(unknown)
+	if err != nil {
+		t.Fatalf("LoadReportTemplate() failed: %v", err)
+	}
+	if _, err := rt.Render(&models.ScanResult{}); err == nil {
+		t.Error("Expected an error rendering an unknown field")
+	}
+}
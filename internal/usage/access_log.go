@@ -0,0 +1,157 @@
+// Package usage reads runtime evidence of which assets a deployed app uses.
+//
+// Static analysis can only prove an asset is referenced, not that anyone
+// loads it. Access logs add production evidence to each asset:
+// - Combined/common log format (nginx, Apache, Caddy, most CDNs' raw logs)
+// - CloudFront and other W3C extended logs with a #Fields header
+// - Plain lists or CSVs of requested paths, optionally with a count column
+//
+// Apps can also report the assets each page rendered as beacons, collected
+// into JSON-lines usage files by Collector.
+package usage
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Requests counts successful requests per URL path, keyed without the
+// leading slash, query, or fragment (images/logo.png)
+type Requests map[string]int
+
+// Add records n requests for a request target (path or absolute URL)
+func (r Requests) Add(target string, n int) {
+	if p := requestPath(target); p != "" {
+		r[p] += n
+	}
+}
+
+// requestLinePattern matches the request and status of a combined/common
+// log line: "GET /images/logo.png?v=2 HTTP/1.1" 200
+var requestLinePattern = regexp.MustCompile(`"(?:GET|HEAD) (\S+) [^"]*" (\d{3})`)
+
+// ReadAccessLogs counts the requests in each log file; gzipped logs are
+// decompressed
+func ReadAccessLogs(files []string) (Requests, error) {
+	requests := make(Requests)
+	for _, file := range files {
+		if err := readAccessLog(file, requests); err != nil {
+			return nil, fmt.Errorf("failed to read access log %s: %w", file, err)
+		}
+	}
+	return requests, nil
+}
+
+func readAccessLog(file string, requests Requests) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	var r io.Reader = br
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return ParseAccessLog(r, requests)
+}
+
+// ParseAccessLog adds the successful (non-4xx/5xx) requests in r to requests
+func ParseAccessLog(r io.Reader, requests Requests) error {
+	uriField, statusField := -1, -1
+
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lines.Scan() {
+		line := lines.Text()
+
+		// W3C extended logs name their tab-separated columns up front
+		if fields, ok := strings.CutPrefix(line, "#Fields:"); ok {
+			uriField, statusField = -1, -1
+			for i, name := range strings.Fields(fields) {
+				switch name {
+				case "cs-uri-stem":
+					uriField = i
+				case "sc-status":
+					statusField = i
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if uriField >= 0 {
+			cols := strings.Split(line, "\t")
+			if uriField < len(cols) && (statusField < 0 || statusField < len(cols) && successful(cols[statusField])) {
+				requests.Add(cols[uriField], 1)
+			}
+			continue
+		}
+
+		if m := requestLinePattern.FindStringSubmatch(line); m != nil {
+			if successful(m[2]) {
+				requests.Add(m[1], 1)
+			}
+			continue
+		}
+
+		// A list of paths, one per line, optionally followed by a count
+		cols := strings.FieldsFunc(line, func(c rune) bool { return c == ',' || c == '\t' || c == ' ' })
+		if len(cols) == 0 {
+			continue
+		}
+		target := strings.Trim(cols[0], `"`)
+		if !strings.HasPrefix(target, "/") && !strings.Contains(target, "://") {
+			continue // a header row or something else
+		}
+		n := 1
+		if len(cols) > 1 {
+			if count, err := strconv.Atoi(strings.Trim(cols[1], `"`)); err == nil {
+				n = count
+			}
+		}
+		requests.Add(target, n)
+	}
+	return lines.Err()
+}
+
+// successful reports whether an HTTP status served the file (2xx or 3xx)
+func successful(status string) bool {
+	code, err := strconv.Atoi(status)
+	return err == nil && code >= 200 && code < 400
+}
+
+// requestPath reduces a request target to its clean path without the
+// leading slash, or "" when there is no path
+func requestPath(target string) string {
+	if i := strings.Index(target, "://"); i >= 0 {
+		rest := target[i+3:]
+		slash := strings.Index(rest, "/")
+		if slash < 0 {
+			return ""
+		}
+		target = rest[slash:]
+	}
+	target, _, _ = strings.Cut(target, "?")
+	target, _, _ = strings.Cut(target, "#")
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	return strings.TrimPrefix(path.Clean("/"+target), "/")
+}
@@ -0,0 +1,78 @@
+package usage
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseAccessLog(t *testing.T) {
+	tests := []struct {
+		name string
+		log  string
+		want Requests
+	}{
+		{
+			name: "combined",
+			log: `203.0.113.7 - - [10/Oct/2026:13:55:36 +0000] "GET /images/logo.png?v=2 HTTP/1.1" 200 2326 "-" "Mozilla/5.0"
+203.0.113.7 - - [10/Oct/2026:13:55:37 +0000] "GET /images/logo.png HTTP/2.0" 304 0 "-" "Mozilla/5.0"
+203.0.113.7 - - [10/Oct/2026:13:55:38 +0000] "GET /images/missing.png HTTP/1.1" 404 0 "-" "Mozilla/5.0"
+203.0.113.7 - - [10/Oct/2026:13:55:39 +0000] "POST /api/upload HTTP/1.1" 201 0 "-" "curl"`,
+			want: Requests{"images/logo.png": 2},
+		},
+		{
+			name: "cloudfront",
+			log: "#Version: 1.0\n" +
+				"#Fields: date time x-edge-location sc-bytes c-ip cs-method cs(Host) cs-uri-stem sc-status\n" +
+				"2026-10-10\t13:55:36\tIAD89\t2326\t203.0.113.7\tGET\td111.cloudfront.net\t/fonts/Inter%20Bold.woff2\t200\n" +
+				"2026-10-10\t13:55:37\tIAD89\t0\t203.0.113.7\tGET\td111.cloudfront.net\t/fonts/gone.woff2\t403\n",
+			want: Requests{"fonts/Inter Bold.woff2": 1},
+		},
+		{
+			name: "csv",
+			log:  "path,count\n/images/hero.webp,42\nhttps://cdn.example.com/static/a.svg\n",
+			want: Requests{"images/hero.webp": 42, "static/a.svg": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(Requests)
+			if err := ParseAccessLog(strings.NewReader(tt.log), got); err != nil {
+				t.Fatalf("ParseAccessLog() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseAccessLog() = %v, want %v", got, tt.want)
+			}
+			for p, n := range tt.want {
+				if got[p] != n {
+					t.Errorf("ParseAccessLog()[%s] = %d, want %d", p, got[p], n)
+				}
+			}
+		})
+	}
+}
+
+func TestReadAccessLogs_Gzip(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "access.log.gz")
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	if _, err := gz.Write([]byte(`1.2.3.4 - - [x] "GET /a.png HTTP/1.1" 200 1` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	gz.Close()
+	f.Close()
+
+	requests, err := ReadAccessLogs([]string{file})
+	if err != nil {
+		t.Fatalf("ReadAccessLogs() error = %v", err)
+	}
+	if requests["a.png"] != 1 {
+		t.Errorf("ReadAccessLogs() = %v, want a.png: 1", requests)
+	}
+}
@@ -0,0 +1,146 @@
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBeaconSize caps the body of one beacon
+const maxBeaconSize = 256 * 1024
+
+// Beacon is one runtime usage report: the assets a page rendered. Usage files
+// hold one beacon per line (JSON lines), as written by Collector; Count
+// defaults to 1 so pipelines can also write pre-aggregated lines.
+type Beacon struct {
+	Time   time.Time `json:"time,omitempty"`
+	Page   string    `json:"page,omitempty"`
+	Assets []string  `json:"assets"`
+	Count  int       `json:"count,omitempty"`
+}
+
+// ReadBeacons counts how many beacons reported each asset path across the
+// usage files
+func ReadBeacons(files []string) (Requests, error) {
+	renders := make(Requests)
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read usage file %s: %w", file, err)
+		}
+		err = ParseBeacons(f, renders)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read usage file %s: %w", file, err)
+		}
+	}
+	return renders, nil
+}
+
+// ParseBeacons adds the assets of each JSON-lines beacon in r to renders
+func ParseBeacons(r io.Reader, renders Requests) error {
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 64*1024), maxBeaconSize)
+	for n := 1; lines.Scan(); n++ {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" {
+			continue
+		}
+		var beacon Beacon
+		if err := json.Unmarshal([]byte(line), &beacon); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+		addBeacon(renders, beacon)
+	}
+	return lines.Err()
+}
+
+// addBeacon counts each asset of a beacon once
+func addBeacon(renders Requests, beacon Beacon) {
+	count := beacon.Count
+	if count <= 0 {
+		count = 1
+	}
+	seen := make(map[string]bool, len(beacon.Assets))
+	for _, asset := range beacon.Assets {
+		if p := requestPath(asset); p != "" && !seen[p] {
+			seen[p] = true
+			renders[p] += count
+		}
+	}
+}
+
+// Collector is an HTTP handler that appends the beacons apps POST to it to a
+// usage file. It accepts navigator.sendBeacon bodies (text/plain) and answers
+// CORS preflights, so pages on any origin can report to it.
+type Collector struct {
+	mu  sync.Mutex
+	out io.Writer
+	now func() time.Time
+
+	received int
+}
+
+// NewCollector returns a collector appending beacons to out
+func NewCollector(out io.Writer) *Collector {
+	return &Collector{out: out, now: time.Now}
+}
+
+// Received returns how many beacons have been stored
+func (c *Collector) Received() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.received
+}
+
+// ServeHTTP stores one beacon per POST
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodPost:
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var beacon Beacon
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBeaconSize)).Decode(&beacon); err != nil {
+		http.Error(w, "Invalid beacon JSON", http.StatusBadRequest)
+		return
+	}
+	if len(beacon.Assets) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	beacon.Time = c.now().UTC()
+	if beacon.Page == "" {
+		beacon.Page = r.Referer()
+	}
+
+	line, err := json.Marshal(beacon)
+	if err != nil {
+		http.Error(w, "Failed to encode beacon", http.StatusInternalServerError)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.out.Write(append(line, '\n')); err != nil {
+		http.Error(w, "Failed to store beacon", http.StatusInternalServerError)
+		return
+	}
+	c.received++
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,74 @@
+package usage
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBeacons(t *testing.T) {
+	log := `{"assets": ["/images/logo.png", "https://app.example.com/images/logo.png?v=2", "/fonts/inter.woff2"]}
+
+{"assets": ["/images/logo.png"], "count": 10}
+`
+	renders := make(Requests)
+	if err := ParseBeacons(strings.NewReader(log), renders); err != nil {
+		t.Fatalf("ParseBeacons() error = %v", err)
+	}
+
+	// The same asset twice in one beacon counts once
+	if renders["images/logo.png"] != 11 {
+		t.Errorf("renders[images/logo.png] = %d, want 11", renders["images/logo.png"])
+	}
+	if renders["fonts/inter.woff2"] != 1 {
+		t.Errorf("renders[fonts/inter.woff2] = %d, want 1", renders["fonts/inter.woff2"])
+	}
+
+	if err := ParseBeacons(strings.NewReader("not json\n"), make(Requests)); err == nil {
+		t.Error("ParseBeacons() should reject malformed lines")
+	}
+}
+
+func TestCollector(t *testing.T) {
+	var out bytes.Buffer
+	c := NewCollector(&out)
+	c.now = func() time.Time { return time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC) }
+
+	post := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/beacon", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/plain;charset=UTF-8")
+		req.Header.Set("Referer", "https://app.example.com/checkout")
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post(http.MethodOptions, ""); rec.Code != http.StatusNoContent || rec.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("preflight = %d %v, want 204 with CORS headers", rec.Code, rec.Header())
+	}
+	if rec := post(http.MethodPost, `{"assets": ["/a.png"]}`); rec.Code != http.StatusNoContent {
+		t.Errorf("POST beacon = %d, want 204", rec.Code)
+	}
+	if rec := post(http.MethodPost, `{`); rec.Code != http.StatusBadRequest {
+		t.Errorf("POST malformed beacon = %d, want 400", rec.Code)
+	}
+	if rec := post(http.MethodGet, ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET = %d, want 405", rec.Code)
+	}
+
+	want := `{"time":"2026-10-01T12:00:00Z","page":"https://app.example.com/checkout","assets":["/a.png"]}` + "\n"
+	if out.String() != want {
+		t.Errorf("stored %q, want %q", out.String(), want)
+	}
+	if c.Received() != 1 {
+		t.Errorf("Received() = %d, want 1", c.Received())
+	}
+
+	renders := make(Requests)
+	if err := ParseBeacons(&out, renders); err != nil || renders["a.png"] != 1 {
+		t.Errorf("stored beacons read back as %v (%v)", renders, err)
+	}
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic streams content from write into path, optionally gzip-compressed.
+// Data goes to a temporary file in the same directory which is renamed over
+// path on success, so concurrent readers never observe a partially written file.
+func WriteFileAtomic(path string, compress bool, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	// Remove the temp file on any failure path
+	committed := false
+	defer func() {
+		if !committed {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	var w io.Writer = tmp
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(tmp)
+		w = gz
+	}
+
+	if err := write(w); err != nil {
+		return err
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("failed to finish gzip stream: %w", err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	committed = true
+	return nil
+}
+
+// gzipReadCloser closes both the gzip reader and the underlying file
+type gzipReadCloser struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipReadCloser) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// bufferedFile pairs a buffered reader with the file it reads from
+type bufferedFile struct {
+	*bufio.Reader
+	file *os.File
+}
+
+func (b *bufferedFile) Close() error {
+	return b.file.Close()
+}
+
+// OpenMaybeGzip opens a file for reading, transparently decompressing it
+// when it starts with the gzip magic bytes and decrypting cache files
+// written by WriteCacheFile
+func OpenMaybeGzip(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(file)
+	if header, _ := br.Peek(len(encryptedMagic)); bytes.Equal(header, encryptedMagic) {
+		defer file.Close()
+		return openEncrypted(br)
+	}
+
+	magic, err := br.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		// Short or plain file; let the caller's decoder report content errors
+		return &bufferedFile{Reader: br, file: file}, nil
+	}
+
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	return &gzipReadCloser{Reader: gz, file: file}, nil
+}
+
+// openEncrypted decrypts a whole cache file held by r into memory
+func openEncrypted(r io.Reader) (io.ReadCloser, error) {
+	key := CacheEncryptionKey()
+	if key == nil {
+		return nil, ErrCacheKeyMissing
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := decryptCache(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plain) >= 2 && plain[0] == 0x1f && plain[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(plain))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		return gz, nil
+	}
+	return io.NopCloser(bytes.NewReader(plain)), nil
+}
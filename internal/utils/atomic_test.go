@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "results.json")
+
+		err := WriteFileAtomic(path, compress, func(w io.Writer) error {
+			_, err := io.WriteString(w, `{"ok":true}`)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("WriteFileAtomic(compress=%v) failed: %v", compress, err)
+		}
+
+		file, err := OpenMaybeGzip(path)
+		if err != nil {
+			t.Fatalf("OpenMaybeGzip() failed: %v", err)
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("ReadAll() failed: %v", err)
+		}
+
+		if string(data) != `{"ok":true}` {
+			t.Errorf("OpenMaybeGzip(compress=%v) read %q, want %q", compress, data, `{"ok":true}`)
+		}
+	}
+}
+
+func TestWriteFileAtomic_FailureKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.json")
+	if err := os.WriteFile(path, []byte("original"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	err := WriteFileAtomic(path, false, func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return errors.New("encode failed")
+	})
+	if err == nil {
+		t.Fatal("WriteFileAtomic() expected error, got nil")
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "original" {
+		t.Errorf("file content = %q, want %q", data, "original")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected temp file to be removed, found %d entries", len(entries))
+	}
+}
@@ -6,18 +6,52 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 )
 
 const (
 	appName         = "easyClean"
 	projectsSubdir  = "projects"
 	scanResultsFile = "scan-results.json"
+	referenceIndex  = "reference-index.gob"
+	reviewSession   = "review-session.json"
+	trashSubdir     = "trash"
+	historySubdir   = "history"
+	daemonSocket    = "daemon.sock"
+	checkpointDir   = "checkpoint"
 )
 
-// GetUserCacheDir returns the OS-specific cache directory for the application
+// CacheDirEnv names the environment variable that overrides the cache root
+const CacheDirEnv = "EASYCLEAN_CACHE_DIR"
+
+// cacheDirOverride is the cache root set from the config file's cache_dir
+var cacheDirOverride string
+
+// SetCacheDir sets the cache root used when CacheDirEnv is unset; empty
+// restores the OS default
+func SetCacheDir(dir string) {
+	cacheDirOverride = dir
+}
+
+// GetUserCacheDir returns the cache directory for the application:
+// $EASYCLEAN_CACHE_DIR, else the directory from SetCacheDir, else the
+// OS-specific default
 // Linux/macOS: ~/.cache/easyClean/
 // Windows: %LOCALAPPDATA%\easyClean\cache\
 func GetUserCacheDir() (string, error) {
+	dir := os.Getenv(CacheDirEnv)
+	if dir == "" {
+		dir = cacheDirOverride
+	}
+	if dir != "" {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve cache directory: %w", err)
+		}
+		return absDir, nil
+	}
+
 	cacheRoot, err := os.UserCacheDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get user cache directory: %w", err)
@@ -76,6 +110,26 @@ func GetScanResultsPath(projectRoot string) (string, error) {
 	return filepath.Join(projectCacheDir, scanResultsFile), nil
 }
 
+// GetTrashDir returns a fresh trash directory for a project, named by timestamp
+// Format: ~/.cache/easyClean/projects/<hash>/trash/<unix-nanos>/
+func GetTrashDir(projectRoot string) (string, error) {
+	projectCacheDir, err := GetProjectCacheDir(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projectCacheDir, trashSubdir, strconv.FormatInt(time.Now().UnixNano(), 10)), nil
+}
+
+// GetScanHistoryDir returns the directory holding a project's earlier scan results
+// Format: ~/.cache/easyClean/projects/<hash>/history/
+func GetScanHistoryDir(projectRoot string) (string, error) {
+	projectCacheDir, err := GetProjectCacheDir(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projectCacheDir, historySubdir), nil
+}
+
 // EnsureCacheDirExists creates the cache directory if it doesn't exist
 func EnsureCacheDirExists(path string) error {
 	if err := os.MkdirAll(path, 0755); err != nil {
@@ -84,6 +138,46 @@ func EnsureCacheDirExists(path string) error {
 	return nil
 }
 
+// GetReferenceIndexPath returns the full path to the reference index file for a project
+func GetReferenceIndexPath(projectRoot string) (string, error) {
+	projectCacheDir, err := GetProjectCacheDir(projectRoot)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(projectCacheDir, referenceIndex), nil
+}
+
+// GetCheckpointDir returns the directory holding an interrupted scan's progress
+// Format: ~/.cache/easyClean/projects/<hash>/checkpoint/
+func GetCheckpointDir(projectRoot string) (string, error) {
+	projectCacheDir, err := GetProjectCacheDir(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projectCacheDir, checkpointDir), nil
+}
+
+// GetReviewSessionPath returns the full path to the saved review UI session for a project
+func GetReviewSessionPath(projectRoot string) (string, error) {
+	projectCacheDir, err := GetProjectCacheDir(projectRoot)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(projectCacheDir, reviewSession), nil
+}
+
+// GetDaemonSocketPath returns the default socket path of the daemon serving a project
+func GetDaemonSocketPath(projectRoot string) (string, error) {
+	projectCacheDir, err := GetProjectCacheDir(projectRoot)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(projectCacheDir, daemonSocket), nil
+}
+
 // GetScanResultsPathOrDefault returns the scan results path for a project,
 // or uses the provided default path if not empty
 func GetScanResultsPathOrDefault(projectRoot, defaultPath string) (string, error) {
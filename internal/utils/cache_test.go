@@ -22,6 +22,32 @@ func TestGetUserCacheDir(t *testing.T) {
 	}
 }
 
+func TestGetUserCacheDir_Override(t *testing.T) {
+	configDir := t.TempDir()
+	envDir := t.TempDir()
+
+	t.Setenv(CacheDirEnv, "")
+	SetCacheDir(configDir)
+	defer SetCacheDir("")
+	if dir, _ := GetUserCacheDir(); dir != configDir {
+		t.Errorf("GetUserCacheDir() with SetCacheDir = %q, want %q", dir, configDir)
+	}
+
+	// The environment wins over the config file
+	t.Setenv(CacheDirEnv, envDir)
+	if dir, _ := GetUserCacheDir(); dir != envDir {
+		t.Errorf("GetUserCacheDir() with %s = %q, want %q", CacheDirEnv, dir, envDir)
+	}
+
+	resultsPath, err := GetScanResultsPath("/home/user/project")
+	if err != nil {
+		t.Fatalf("GetScanResultsPath() failed: %v", err)
+	}
+	if !strings.HasPrefix(resultsPath, envDir) {
+		t.Errorf("GetScanResultsPath() = %q, want it under %q", resultsPath, envDir)
+	}
+}
+
 func TestGetProjectHash(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// CacheKeyEnv names the environment variable holding the passphrase cached
+// scan results are encrypted with. Unset leaves the cache in plain text.
+const CacheKeyEnv = "EASYCLEAN_CACHE_ENCRYPTION_KEY"
+
+// encryptedMagic starts every encrypted cache file, followed by the GCM nonce
+// and the sealed content
+var encryptedMagic = []byte("EASYCLEAN-AESGCM1\n")
+
+// ErrCacheKeyMissing reports an encrypted cache file read without a key
+var ErrCacheKeyMissing = errors.New("cache file is encrypted; set " + CacheKeyEnv + " to read it")
+
+// CacheEncryptionKey returns the AES-256 key derived from CacheKeyEnv, or nil
+// when cache encryption is off
+func CacheEncryptionKey() []byte {
+	passphrase := os.Getenv(CacheKeyEnv)
+	if passphrase == "" {
+		return nil
+	}
+	key := sha256.Sum256([]byte(passphrase))
+	return key[:]
+}
+
+// WriteCacheFile writes a cache file like WriteFileAtomic, encrypting it with
+// AES-256-GCM when CacheKeyEnv is set
+func WriteCacheFile(path string, compress bool, write func(io.Writer) error) error {
+	key := CacheEncryptionKey()
+	if key == nil {
+		return WriteFileAtomic(path, compress, write)
+	}
+
+	// GCM seals whole messages, so the content is built in memory first
+	var plain bytes.Buffer
+	if compress {
+		gz := gzip.NewWriter(&plain)
+		if err := write(gz); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("failed to finish gzip stream: %w", err)
+		}
+	} else if err := write(&plain); err != nil {
+		return err
+	}
+
+	sealed, err := encryptCache(key, plain.Bytes())
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, false, func(w io.Writer) error {
+		_, err := w.Write(sealed)
+		return err
+	})
+}
+
+func encryptCache(key, plain []byte) ([]byte, error) {
+	gcm, err := newCacheCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := append([]byte{}, encryptedMagic...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plain, encryptedMagic), nil
+}
+
+func decryptCache(key, data []byte) ([]byte, error) {
+	gcm, err := newCacheCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	data = data[len(encryptedMagic):]
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted cache file is truncated")
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, encryptedMagic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cache file (wrong %s or corrupt file)", CacheKeyEnv)
+	}
+	return plain, nil
+}
+
+func newCacheCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
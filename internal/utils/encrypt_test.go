@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteCacheFile_Encrypted(t *testing.T) {
+	content := `{"project_root":"/srv/internal-app"}`
+
+	for _, compress := range []bool{false, true} {
+		t.Setenv(CacheKeyEnv, "s3cret")
+		path := filepath.Join(t.TempDir(), "scan-results.json")
+
+		err := WriteCacheFile(path, compress, func(w io.Writer) error {
+			_, err := io.WriteString(w, content)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("WriteCacheFile(compress=%v) failed: %v", compress, err)
+		}
+
+		raw, _ := os.ReadFile(path)
+		if bytes.Contains(raw, []byte("internal-app")) || !bytes.HasPrefix(raw, encryptedMagic) {
+			t.Fatalf("WriteCacheFile(compress=%v) left content readable on disk", compress)
+		}
+
+		file, err := OpenMaybeGzip(path)
+		if err != nil {
+			t.Fatalf("OpenMaybeGzip() failed: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		file.Close()
+		if string(data) != content {
+			t.Errorf("OpenMaybeGzip(compress=%v) read %q, want %q", compress, data, content)
+		}
+
+		t.Setenv(CacheKeyEnv, "wrong")
+		if _, err := OpenMaybeGzip(path); err == nil {
+			t.Error("OpenMaybeGzip() with the wrong key succeeded")
+		}
+		t.Setenv(CacheKeyEnv, "")
+		if _, err := OpenMaybeGzip(path); !errors.Is(err, ErrCacheKeyMissing) {
+			t.Errorf("OpenMaybeGzip() without a key = %v, want ErrCacheKeyMissing", err)
+		}
+	}
+}
+
+func TestWriteCacheFile_PlainWithoutKey(t *testing.T) {
+	t.Setenv(CacheKeyEnv, "")
+	path := filepath.Join(t.TempDir(), "scan-results.json")
+
+	err := WriteCacheFile(path, false, func(w io.Writer) error {
+		_, err := io.WriteString(w, `{}`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WriteCacheFile() failed: %v", err)
+	}
+	if raw, _ := os.ReadFile(path); string(raw) != `{}` {
+		t.Errorf("WriteCacheFile() without a key wrote %q, want plain content", raw)
+	}
+}
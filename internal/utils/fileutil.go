@@ -3,13 +3,18 @@
 // Includes helpers for:
 // - File existence and type checking
 // - Extension and pattern matching
-// - Symlink detection
+// - Symlink detection and project root confinement
 // - File size queries
+// - Glob matching with ** support
+// - Bundler content hash removal
 package utils
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 )
 
 // Exists checks if a file or directory exists
@@ -93,6 +98,68 @@ func IsSymlink(path string) bool {
 	return info.Mode()&os.ModeSymlink != 0
 }
 
+// ResolveInRoot canonicalizes path (relative paths are taken from root) with
+// its directory's symlinks resolved, and fails unless it lies inside root.
+// A symlink at path itself is kept, so removing the result removes the link,
+// but its target must lie inside root as well.
+func ResolveInRoot(root, path string) (string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	realRoot, err := filepath.EvalSymlinks(absRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project root: %w", err)
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(absRoot, path)
+	}
+	path = filepath.Clean(path)
+
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	resolved := filepath.Join(dir, filepath.Base(path))
+	if !insideDir(realRoot, resolved) {
+		return "", fmt.Errorf("%s is outside the project root", path)
+	}
+
+	target, err := filepath.EvalSymlinks(resolved)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	if err == nil && !insideDir(realRoot, target) {
+		return "", fmt.Errorf("%s links outside the project root", path)
+	}
+	return resolved, nil
+}
+
+// SamePath reports whether a and b name the same directory, once made
+// absolute and with symlinks resolved where they exist
+func SamePath(a, b string) bool {
+	canonical := func(path string) string {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		if real, err := filepath.EvalSymlinks(path); err == nil {
+			path = real
+		}
+		return filepath.Clean(path)
+	}
+	return a != "" && canonical(a) == canonical(b)
+}
+
+// insideDir reports whether path lies strictly below dir
+func insideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // GetFileSize returns the size of the file in bytes
 func GetFileSize(path string) (int64, error) {
 	info, err := os.Stat(path)
@@ -101,3 +168,58 @@ func GetFileSize(path string) (int64, error) {
 	}
 	return info.Size(), nil
 }
+
+// contentHashPattern matches the hash bundlers add to emitted file names:
+// logo.8f3a2b1c.png, logo-8f3a2b1c.png
+var contentHashPattern = regexp.MustCompile(`^(.+?)[.-][0-9a-fA-F]{8,}(\.[^.]+)$`)
+
+// StripContentHash removes a bundler content hash from a file name, so
+// logo.8f3a2b1c.png becomes logo.png; other names are returned unchanged
+func StripContentHash(name string) string {
+	if m := contentHashPattern.FindStringSubmatch(name); m != nil {
+		return m[1] + m[2]
+	}
+	return name
+}
+
+// MatchGlob reports whether a slash-separated path matches a glob pattern.
+// Unlike filepath.Match, "**" matches across directory separators, so
+// "src/checkout/**" matches every file below src/checkout/.
+func MatchGlob(pattern, path string) bool {
+	re, err := globToRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(filepath.ToSlash(path))
+}
+
+// globToRegexp converts a glob pattern into an anchored regular expression
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	pattern = filepath.ToSlash(pattern)
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			i++
+			// "**/" also matches zero directories
+			if i+1 < len(pattern) && pattern[i+1] == '/' {
+				i++
+				sb.WriteString("(?:.*/)?")
+			} else {
+				sb.WriteString(".*")
+			}
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"src/checkout/**", "src/checkout/cart.png", true},
+		{"src/checkout/**", "src/checkout/images/cart.png", true},
+		{"src/checkout/**", "src/profile/avatar.png", false},
+		{"**/*.png", "logo.png", true},
+		{"**/*.png", "a/b/logo.png", true},
+		{"src/*.png", "src/a/logo.png", false},
+		{"src/?.png", "src/a.png", true},
+		{"assets/icons/*", "assets/icons/x.svg", true},
+	}
+
+	for _, tt := range tests {
+		result := MatchGlob(tt.pattern, tt.path)
+		if result != tt.expected {
+			t.Errorf("MatchGlob(%q, %q) = %v, expected %v", tt.pattern, tt.path, result, tt.expected)
+		}
+	}
+}
+
+func TestStripContentHash(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"logo.8f3a2b1c.png", "logo.png"},
+		{"logo-8f3a2b1c9d0e.png", "logo.png"},
+		{"hero@2x.webp", "hero@2x.webp"},
+		{"icon-192.png", "icon-192.png"},
+		{"deadbeef.png", "deadbeef.png"},
+	}
+
+	for _, tt := range tests {
+		if got := StripContentHash(tt.name); got != tt.want {
+			t.Errorf("StripContentHash(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolveInRoot(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	outside := filepath.Join(base, "secret.txt")
+	if err := os.MkdirAll(filepath.Join(root, "assets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{filepath.Join(root, "assets", "logo.png"), outside} {
+		if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "assets", "escape.png")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(root, "assets", "logo.png"), filepath.Join(root, "assets", "alias.png")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(base, filepath.Join(root, "up")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{"assets/logo.png", false},
+		{filepath.Join(root, "assets", "logo.png"), false},
+		{"assets/alias.png", false},
+		{"assets/../assets/logo.png", false},
+		{"../secret.txt", true},
+		{filepath.Join(root, "assets", "..", "..", "secret.txt"), true},
+		{"assets/escape.png", true},
+		{"up/secret.txt", true},
+		{".", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveInRoot(root, tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolveInRoot(%q) = %q, %v; wantErr %v", tt.path, got, err, tt.wantErr)
+		}
+	}
+
+	// A symlink inside the root is returned as the link, not its target
+	if got, _ := ResolveInRoot(root, "assets/alias.png"); filepath.Base(got) != "alias.png" {
+		t.Errorf("ResolveInRoot(alias) = %q, want the link itself", got)
+	}
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// slowStatLatency is the median stat time above which a filesystem is taken
+// to be network-mounted or otherwise slow; local disks answer in microseconds
+const slowStatLatency = 2 * time.Millisecond
+
+// probeEntries is how many entries of the directory ProbeFilesystem stats
+const probeEntries = 16
+
+// ProbeFilesystem times stat calls on dir and up to probeEntries of its
+// entries, returning the median latency and whether it marks the
+// filesystem as slow (NFS, SMB, FUSE mounts of remote storage)
+func ProbeFilesystem(dir string) (time.Duration, bool) {
+	return probeStatLatency(dir, os.Lstat)
+}
+
+// probeStatLatency is ProbeFilesystem with the stat call replaceable
+func probeStatLatency(dir string, stat func(string) (os.FileInfo, error)) (time.Duration, bool) {
+	paths := []string{dir}
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, entry := range entries {
+			if len(paths) > probeEntries {
+				break
+			}
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	samples := make([]time.Duration, 0, len(paths))
+	for _, path := range paths {
+		start := time.Now()
+		if _, err := stat(path); err != nil {
+			continue
+		}
+		samples = append(samples, time.Since(start))
+	}
+	if len(samples) == 0 {
+		return 0, false
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	median := samples[len(samples)/2]
+	return median, median > slowStatLatency
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProbeFilesystem(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.png", "b.png", "c.png"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if latency, slow := ProbeFilesystem(dir); slow {
+		t.Errorf("ProbeFilesystem() on a temp dir = %s, slow; want fast", latency)
+	}
+
+	// A stat taking milliseconds is what a network mount looks like
+	slowStat := func(path string) (os.FileInfo, error) {
+		time.Sleep(5 * time.Millisecond)
+		return os.Lstat(path)
+	}
+	if latency, slow := probeStatLatency(dir, slowStat); !slow || latency < 5*time.Millisecond {
+		t.Errorf("probeStatLatency() with slow stats = %s, %v; want slow", latency, slow)
+	}
+
+	if _, slow := ProbeFilesystem(filepath.Join(dir, "missing")); slow {
+		t.Error("ProbeFilesystem() on a missing dir reported slow")
+	}
+}
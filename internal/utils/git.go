@@ -0,0 +1,92 @@
+// Package utils - Git history helpers
+//
+// Provides read-only access to git metadata used for staleness scoring, and
+// shallow clones for scanning remote repositories.
+// All functions degrade gracefully when git is unavailable.
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commitMarker prefixes commit timestamp lines in git log output (%x00)
+const commitMarker = "\x00"
+
+// IsGitRepository checks if the directory is inside a git work tree
+func IsGitRepository(root string) bool {
+	cmd := exec.Command("git", "-C", root, "rev-parse", "--is-inside-work-tree")
+	out, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// GitLastCommitTimes returns the time of the most recent commit touching each
+// file below root, keyed by slash-separated path relative to root.
+// A single `git log` pass is used so cost is independent of asset count.
+func GitLastCommitTimes(root string) (map[string]time.Time, error) {
+	cmd := exec.Command("git", "-c", "core.quotePath=false", "-C", root,
+		"log", "--relative", "--name-only", "--format=%x00%ct")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git history: %w", err)
+	}
+
+	return parseGitLog(out), nil
+}
+
+// GitShallowClone clones the tip of url (or of ref, a branch or tag, when set)
+// into dest, without history
+func GitShallowClone(url, ref, dest string) error {
+	args := []string{"clone", "--quiet", "--depth", "1", "--single-branch"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, "--", url, dest)
+
+	cmd := exec.Command("git", args...)
+	// Never stop to ask for credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("failed to clone %s: %s", url, msg)
+		}
+		return fmt.Errorf("failed to clone %s: %w", url, err)
+	}
+	return nil
+}
+
+// parseGitLog parses `git log --name-only --format=%x00%ct` output.
+// Commits are listed newest first, so the first time a path is seen wins.
+func parseGitLog(out []byte) map[string]time.Time {
+	times := make(map[string]time.Time)
+	var current time.Time
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, commitMarker) {
+			if ts, err := strconv.ParseInt(strings.TrimPrefix(line, commitMarker), 10, 64); err == nil {
+				current = time.Unix(ts, 0)
+			}
+			continue
+		}
+
+		if line == "" {
+			continue
+		}
+
+		path := filepath.ToSlash(line)
+		if _, seen := times[path]; !seen {
+			times[path] = current
+		}
+	}
+
+	return times
+}
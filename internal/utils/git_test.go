@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGitLog(t *testing.T) {
+	out := []byte("\x001700000000\n\nassets/logo.png\nsrc/app.js\n\x001600000000\n\nassets/logo.png\nassets/old.png\n")
+
+	times := parseGitLog(out)
+
+	tests := []struct {
+		path     string
+		expected int64
+	}{
+		{"assets/logo.png", 1700000000}, // newest commit wins
+		{"src/app.js", 1700000000},
+		{"assets/old.png", 1600000000},
+	}
+
+	for _, tt := range tests {
+		got, ok := times[tt.path]
+		if !ok {
+			t.Errorf("parseGitLog() missing %s", tt.path)
+			continue
+		}
+		if !got.Equal(time.Unix(tt.expected, 0)) {
+			t.Errorf("parseGitLog()[%s] = %v, want %v", tt.path, got, time.Unix(tt.expected, 0))
+		}
+	}
+}
+
+func TestGitShallowClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	origin := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", origin}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "--quiet", "--initial-branch=main")
+	if err := os.WriteFile(filepath.Join(origin, "logo.png"), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "--quiet", "-m", "first")
+	run("commit", "--quiet", "--allow-empty", "-m", "second")
+
+	dest := filepath.Join(t.TempDir(), "clone")
+	if err := GitShallowClone("file://"+origin, "main", dest); err != nil {
+		t.Fatalf("GitShallowClone() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "logo.png")); err != nil {
+		t.Errorf("GitShallowClone() did not check out logo.png: %v", err)
+	}
+	out, err := exec.Command("git", "-C", dest, "rev-list", "--count", "HEAD").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "1" {
+		t.Errorf("GitShallowClone() history = %q, want 1 commit", out)
+	}
+
+	if err := GitShallowClone("file://"+filepath.Join(origin, "missing"), "", filepath.Join(t.TempDir(), "x")); err == nil {
+		t.Error("GitShallowClone() of a missing repository should fail")
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryEntry is one stored scan result in a project's history
+type HistoryEntry struct {
+	ID   string // file name without extension, the scan's unix nanos
+	Path string
+	Time time.Time
+}
+
+// ScanHistoryPath names the history file for a scan taken at t
+func ScanHistoryPath(dir string, t time.Time) string {
+	return filepath.Join(dir, strconv.FormatInt(t.UnixNano(), 10)+".json")
+}
+
+// ListScanHistory returns the scans stored in a history directory, newest
+// first. A missing directory has no history.
+func ListScanHistory(dir string) ([]HistoryEntry, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scan history: %w", err)
+	}
+
+	var entries []HistoryEntry
+	for _, file := range files {
+		id, ok := strings.CutSuffix(file.Name(), ".json")
+		if !ok || file.IsDir() {
+			continue
+		}
+		nanos, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			continue // not a history file
+		}
+		entries = append(entries, HistoryEntry{
+			ID:   id,
+			Path: filepath.Join(dir, file.Name()),
+			Time: time.Unix(0, nanos),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Time.After(entries[j].Time) })
+	return entries, nil
+}
+
+// PruneScanHistory removes all but the newest keep scans from a history
+// directory, along with any older than maxAge (0 = no age limit)
+func PruneScanHistory(dir string, keep int, maxAge time.Duration) error {
+	entries, err := ListScanHistory(dir)
+	if err != nil {
+		return err
+	}
+	if maxAge > 0 {
+		cutoff := time.Now().Add(-maxAge)
+		for i := 0; i < keep && i < len(entries); i++ {
+			if entries[i].Time.Before(cutoff) {
+				keep = i
+				break
+			}
+		}
+	}
+	for i := keep; i < len(entries); i++ {
+		if err := os.Remove(entries[i].Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to prune scan history: %w", err)
+		}
+		os.Remove(SignaturePath(entries[i].Path))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScanHistory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "history")
+
+	if entries, err := ListScanHistory(dir); err != nil || len(entries) != 0 {
+		t.Fatalf("ListScanHistory(missing) = %v, %v; want no entries", entries, err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		if err := os.WriteFile(ScanHistoryPath(dir, base.Add(time.Duration(i)*time.Hour)), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ListScanHistory(dir)
+	if err != nil {
+		t.Fatalf("ListScanHistory() error = %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("ListScanHistory() returned %d entries, want 4", len(entries))
+	}
+	if !entries[0].Time.Equal(base.Add(3 * time.Hour)) {
+		t.Errorf("newest entry = %v, want %v", entries[0].Time, base.Add(3*time.Hour))
+	}
+
+	if err := PruneScanHistory(dir, 2, 0); err != nil {
+		t.Fatalf("PruneScanHistory() error = %v", err)
+	}
+	entries, _ = ListScanHistory(dir)
+	if len(entries) != 2 || !entries[1].Time.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("after pruning to 2, entries = %v", entries)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "notes.json")); err != nil {
+		t.Error("PruneScanHistory() removed a file that is not a scan")
+	}
+}
+
+func TestPruneScanHistory_MaxAge(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for _, age := range []time.Duration{time.Hour, 3 * 24 * time.Hour, 10 * 24 * time.Hour} {
+		if err := os.WriteFile(ScanHistoryPath(dir, now.Add(-age)), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := PruneScanHistory(dir, 10, 7*24*time.Hour); err != nil {
+		t.Fatalf("PruneScanHistory() error = %v", err)
+	}
+	entries, _ := ListScanHistory(dir)
+	if len(entries) != 2 {
+		t.Errorf("after pruning scans older than 7 days, %d entries remain, want 2", len(entries))
+	}
+
+	if err := PruneScanHistory(dir, 0, 0); err != nil {
+		t.Fatalf("PruneScanHistory(keep=0) error = %v", err)
+	}
+	if entries, _ := ListScanHistory(dir); len(entries) != 0 {
+		t.Errorf("PruneScanHistory(keep=0) left %d entries, want none", len(entries))
+	}
+}
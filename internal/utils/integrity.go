@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	integrityKeyFile = "integrity.key"
+	integrityKeySize = 32
+	signatureSuffix  = ".sig"
+	signaturePrefix  = "hmac-sha256 "
+)
+
+// integrityKeyRetries and integrityKeyRetryDelay bound how long a short key,
+// still being written by another process, is waited for
+const (
+	integrityKeyRetries    = 20
+	integrityKeyRetryDelay = 10 * time.Millisecond
+)
+
+var (
+	// ErrNoSignature reports a file without a signature next to it
+	ErrNoSignature = errors.New("no signature found")
+	// ErrSignatureMismatch reports a file whose content changed after signing,
+	// e.g. edited by hand or truncated by an interrupted copy
+	ErrSignatureMismatch = errors.New("signature does not match file content")
+)
+
+// SignaturePath returns where the signature of path is stored
+func SignaturePath(path string) string {
+	return path + signatureSuffix
+}
+
+// GetIntegrityKey returns the per-user key scan results are signed with,
+// creating it in the cache directory on first use
+func GetIntegrityKey() ([]byte, error) {
+	cacheDir, err := GetUserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	keyPath := filepath.Join(cacheDir, integrityKeyFile)
+
+	key, err := os.ReadFile(keyPath)
+	if err == nil && len(key) == integrityKeySize {
+		return key, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read integrity key: %w", err)
+	}
+	if err == nil {
+		// Another process may still be writing it
+		if key, ok := waitForIntegrityKey(keyPath); ok {
+			return key, nil
+		}
+	}
+	return createIntegrityKey(cacheDir, keyPath, err == nil)
+}
+
+// createIntegrityKey writes a new key to a temporary file and links it into
+// place, so readers never see a partly written key and two scans starting
+// at once agree on whichever key was linked first. With replace, a short
+// key left by a crash is overwritten instead.
+func createIntegrityKey(cacheDir, keyPath string, replace bool) ([]byte, error) {
+	key := make([]byte, integrityKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate integrity key: %w", err)
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(cacheDir, integrityKeyFile+".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create integrity key: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	_, err = tmp.Write(key)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write integrity key: %w", err)
+	}
+
+	if replace {
+		if err := os.Rename(tmpPath, keyPath); err != nil {
+			return nil, fmt.Errorf("failed to replace integrity key: %w", err)
+		}
+		return key, nil
+	}
+
+	err = os.Link(tmpPath, keyPath)
+	if os.IsExist(err) {
+		if key, ok := waitForIntegrityKey(keyPath); ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("integrity key %s is corrupt; delete it to create a new one", keyPath)
+	}
+	if err != nil {
+		// Without hard links, create the key exclusively; a reader that
+		// catches it half written waits for the rest
+		file, err := os.OpenFile(keyPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			if key, ok := waitForIntegrityKey(keyPath); ok {
+				return key, nil
+			}
+			return nil, fmt.Errorf("integrity key %s is corrupt; delete it to create a new one", keyPath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create integrity key: %w", err)
+		}
+		defer file.Close()
+		if _, err := file.Write(key); err != nil {
+			return nil, fmt.Errorf("failed to write integrity key: %w", err)
+		}
+	}
+	return key, nil
+}
+
+// waitForIntegrityKey rereads the key at keyPath until it is complete,
+// reporting false if it stays short
+func waitForIntegrityKey(keyPath string) ([]byte, bool) {
+	for i := 0; i < integrityKeyRetries; i++ {
+		if key, err := os.ReadFile(keyPath); err == nil && len(key) == integrityKeySize {
+			return key, true
+		}
+		time.Sleep(integrityKeyRetryDelay)
+	}
+	return nil, false
+}
+
+// SignFile writes an HMAC-SHA256 of path's bytes, as stored on disk, to its
+// signature file
+func SignFile(path string, key []byte) error {
+	sum, err := fileHMAC(path, key)
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(SignaturePath(path), false, func(w io.Writer) error {
+		_, err := io.WriteString(w, signaturePrefix+sum+"\n")
+		return err
+	})
+}
+
+// VerifyFile checks path against its signature file. It returns
+// ErrNoSignature when the file was never signed and ErrSignatureMismatch when
+// its content no longer matches.
+func VerifyFile(path string, key []byte) error {
+	data, err := os.ReadFile(SignaturePath(path))
+	if os.IsNotExist(err) {
+		return ErrNoSignature
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	want, ok := strings.CutPrefix(strings.TrimSpace(string(data)), signaturePrefix)
+	if !ok {
+		return fmt.Errorf("unrecognized signature format in %s", SignaturePath(path))
+	}
+
+	got, err := fileHMAC(path, key)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(got), []byte(want)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+func fileHMAC(path string, key []byte) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	mac := hmac.New(sha256.New, key)
+	if _, err := io.Copy(mac, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifySignedFile checks path against its signature using the user's
+// integrity key. An unsigned file passes unless requireSignature is set.
+func VerifySignedFile(path string, requireSignature bool) error {
+	key, err := GetIntegrityKey()
+	if err != nil {
+		return err
+	}
+	err = VerifyFile(path, key)
+	if errors.Is(err, ErrNoSignature) && !requireSignature {
+		return nil
+	}
+	return err
+}
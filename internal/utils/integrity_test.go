@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestSignFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scan-results.json")
+	key := []byte("test-key")
+	content := []byte(`{"assets":[]}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyFile(path, key); !errors.Is(err, ErrNoSignature) {
+		t.Errorf("VerifyFile(unsigned) = %v, want ErrNoSignature", err)
+	}
+
+	if err := SignFile(path, key); err != nil {
+		t.Fatalf("SignFile() error = %v", err)
+	}
+	if err := VerifyFile(path, key); err != nil {
+		t.Errorf("VerifyFile(signed) = %v, want nil", err)
+	}
+	if err := VerifyFile(path, []byte("other-key")); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("VerifyFile(wrong key) = %v, want ErrSignatureMismatch", err)
+	}
+
+	// Truncated, as by an interrupted copy
+	if err := os.WriteFile(path, content[:5], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyFile(path, key); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("VerifyFile(truncated) = %v, want ErrSignatureMismatch", err)
+	}
+}
+
+func TestGetIntegrityKey(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	first, err := GetIntegrityKey()
+	if err != nil {
+		t.Fatalf("GetIntegrityKey() error = %v", err)
+	}
+	if len(first) != 32 {
+		t.Errorf("key length = %d, want 32", len(first))
+	}
+	second, err := GetIntegrityKey()
+	if err != nil {
+		t.Fatalf("GetIntegrityKey() second call error = %v", err)
+	}
+	if string(first) != string(second) {
+		t.Error("GetIntegrityKey() generated a new key instead of reusing the stored one")
+	}
+}
+
+func TestGetIntegrityKey_Concurrent(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	keys := make([][]byte, 8)
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i := range keys {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			keys[i], errs[i] = GetIntegrityKey()
+		}(i)
+	}
+	wg.Wait()
+
+	for i, key := range keys {
+		if errs[i] != nil {
+			t.Fatalf("GetIntegrityKey() error = %v", errs[i])
+		}
+		if len(key) != 32 || string(key) != string(keys[0]) {
+			t.Errorf("scan %d got key %x, want the shared 32-byte key %x", i, key, keys[0])
+		}
+	}
+}
+
+func TestGetIntegrityKey_ReplacesShortKey(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	cacheDir, err := GetUserCacheDir()
+	if err != nil {
+		t.Fatalf("GetUserCacheDir() failed: %v", err)
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Left by a scan that crashed while writing it
+	if err := os.WriteFile(filepath.Join(cacheDir, integrityKeyFile), []byte("short"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := GetIntegrityKey()
+	if err != nil {
+		t.Fatalf("GetIntegrityKey() error = %v", err)
+	}
+	if len(key) != 32 {
+		t.Errorf("key length = %d, want 32", len(key))
+	}
+}
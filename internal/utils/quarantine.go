@@ -0,0 +1,223 @@
+// Package utils - Quarantine for two-phase deletion
+//
+// Quarantined assets are moved under <project>/.easyclean-quarantine/files/
+// with their relative layout preserved, and described by a .manifest.json
+// kept outside that tree, so no asset path can land on it.
+// The app can then be run for a grace period before the quarantine is
+// committed (deleted) or rolled back (restored).
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// QuarantineDirName is the quarantine directory inside the project root
+	QuarantineDirName  = ".easyclean-quarantine"
+	quarantineManifest = ".manifest.json"
+	quarantineFilesDir = "files"
+
+	// legacyQuarantineManifest sat among the quarantined files in older
+	// versions, where a project's own manifest.json overwrote it
+	legacyQuarantineManifest = "manifest.json"
+)
+
+// QuarantinedFile describes a single quarantined asset
+type QuarantinedFile struct {
+	RelativePath  string    `json:"relative_path"`
+	Size          int64     `json:"size_bytes"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+}
+
+// Quarantine is the on-disk quarantine of a project
+type Quarantine struct {
+	root  string
+	Files []QuarantinedFile `json:"files"`
+}
+
+// LoadQuarantine loads the quarantine manifest for a project, decrypting it
+// when it was saved with CacheKeyEnv set. An empty quarantine is returned
+// when none exists.
+func LoadQuarantine(projectRoot string) (*Quarantine, error) {
+	q := &Quarantine{root: projectRoot, Files: []QuarantinedFile{}}
+
+	file, err := OpenMaybeGzip(q.manifestPath())
+	legacy := false
+	if os.IsNotExist(err) {
+		file, err = OpenMaybeGzip(filepath.Join(q.Dir(), legacyQuarantineManifest))
+		legacy = true
+	}
+	if os.IsNotExist(err) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quarantine manifest: %w", err)
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(q); err != nil {
+		return nil, fmt.Errorf("failed to parse quarantine manifest: %w", err)
+	}
+
+	// Manifests of older versions could list a path more than once; its
+	// latest entry describes the file in quarantine
+	files := q.Files
+	q.Files = []QuarantinedFile{}
+	for _, f := range files {
+		q.record(f)
+	}
+
+	if legacy {
+		if err := q.upgradeLayout(); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// upgradeLayout moves the files of a quarantine saved by an older version
+// under files/, and its manifest out of their way
+func (q *Quarantine) upgradeLayout() error {
+	files := q.Files
+	q.Files = []QuarantinedFile{}
+	for _, f := range files {
+		// An asset quarantined as manifest.json was overwritten by the
+		// manifest, so there is nothing left to restore
+		if filepath.Clean(f.RelativePath) == legacyQuarantineManifest {
+			continue
+		}
+		q.Files = append(q.Files, f)
+
+		src := filepath.Join(q.Dir(), f.RelativePath)
+		if !Exists(src) {
+			continue // moved before an interrupted upgrade
+		}
+		if err := MoveFile(src, q.filePath(f.RelativePath)); err != nil {
+			return fmt.Errorf("failed to upgrade quarantine: %w", err)
+		}
+	}
+
+	if err := q.Save(); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(q.Dir(), legacyQuarantineManifest)); err != nil {
+		return fmt.Errorf("failed to upgrade quarantine: %w", err)
+	}
+	return nil
+}
+
+// Dir returns the quarantine directory
+func (q *Quarantine) Dir() string {
+	return filepath.Join(q.root, QuarantineDirName)
+}
+
+func (q *Quarantine) manifestPath() string {
+	return filepath.Join(q.Dir(), quarantineManifest)
+}
+
+// filePath returns where a project file (given by relative path) is kept
+func (q *Quarantine) filePath(relPath string) string {
+	return filepath.Join(q.Dir(), quarantineFilesDir, relPath)
+}
+
+// Add moves a project file (given by relative path) into quarantine,
+// replacing the entry of a path quarantined before.
+// Call Save afterwards to persist the manifest.
+func (q *Quarantine) Add(relPath string, size int64) error {
+	src := filepath.Join(q.root, relPath)
+	dst := q.filePath(relPath)
+
+	if err := MoveFile(src, dst); err != nil {
+		return err
+	}
+
+	q.record(QuarantinedFile{
+		RelativePath:  relPath,
+		Size:          size,
+		QuarantinedAt: time.Now(),
+	})
+	return nil
+}
+
+// record adds f to the manifest, replacing any entry for the same path
+func (q *Quarantine) record(f QuarantinedFile) {
+	for i, existing := range q.Files {
+		if filepath.Clean(existing.RelativePath) == filepath.Clean(f.RelativePath) {
+			q.Files[i] = f
+			return
+		}
+	}
+	q.Files = append(q.Files, f)
+}
+
+// Rollback restores every quarantined file to its original location.
+// Files that fail to restore stay in the manifest and are returned as errors.
+func (q *Quarantine) Rollback() []string {
+	var failed []string
+	var remaining []QuarantinedFile
+
+	for _, f := range q.Files {
+		src := q.filePath(f.RelativePath)
+		dst := filepath.Join(q.root, f.RelativePath)
+		if err := MoveFile(src, dst); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", f.RelativePath, err))
+			remaining = append(remaining, f)
+		}
+	}
+
+	q.Files = remaining
+	if len(remaining) == 0 {
+		os.RemoveAll(q.Dir())
+		return failed
+	}
+
+	if err := q.Save(); err != nil {
+		failed = append(failed, err.Error())
+	}
+	return failed
+}
+
+// Commit permanently deletes all quarantined files
+func (q *Quarantine) Commit() error {
+	if err := os.RemoveAll(q.Dir()); err != nil {
+		return fmt.Errorf("failed to remove quarantine: %w", err)
+	}
+	q.Files = []QuarantinedFile{}
+	return nil
+}
+
+// TotalSize returns the total size of quarantined files
+func (q *Quarantine) TotalSize() int64 {
+	total := int64(0)
+	for _, f := range q.Files {
+		total += f.Size
+	}
+	return total
+}
+
+// Save writes the manifest to disk, encrypted like the cache when
+// CacheKeyEnv is set since it lists project paths too
+func (q *Quarantine) Save() error {
+	if err := os.MkdirAll(q.Dir(), 0755); err != nil {
+		return fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal quarantine manifest: %w", err)
+	}
+
+	err = WriteCacheFile(q.manifestPath(), false, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write quarantine manifest: %w", err)
+	}
+	return nil
+}
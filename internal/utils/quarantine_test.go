@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestQuarantine_AddAndRollback(t *testing.T) {
+	projectDir := t.TempDir()
+	writeTestAsset(t, filepath.Join(projectDir, "assets", "logo.png"))
+
+	q, err := LoadQuarantine(projectDir)
+	if err != nil {
+		t.Fatalf("LoadQuarantine() failed: %v", err)
+	}
+	if err := q.Add(filepath.Join("assets", "logo.png"), 3); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := q.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	if Exists(filepath.Join(projectDir, "assets", "logo.png")) {
+		t.Error("Expected asset to be moved into quarantine")
+	}
+
+	// Reload from disk to verify the manifest round-trips
+	loaded, err := LoadQuarantine(projectDir)
+	if err != nil {
+		t.Fatalf("LoadQuarantine() failed: %v", err)
+	}
+	if len(loaded.Files) != 1 || loaded.TotalSize() != 3 {
+		t.Fatalf("Expected 1 quarantined file of 3 bytes, got %d files (%d bytes)", len(loaded.Files), loaded.TotalSize())
+	}
+
+	if failed := loaded.Rollback(); len(failed) > 0 {
+		t.Fatalf("Rollback() failed: %v", failed)
+	}
+	if !Exists(filepath.Join(projectDir, "assets", "logo.png")) {
+		t.Error("Expected asset to be restored")
+	}
+	if Exists(loaded.Dir()) {
+		t.Error("Expected quarantine directory to be removed after rollback")
+	}
+}
+
+func TestQuarantine_Commit(t *testing.T) {
+	projectDir := t.TempDir()
+	writeTestAsset(t, filepath.Join(projectDir, "logo.png"))
+
+	q, _ := LoadQuarantine(projectDir)
+	if err := q.Add("logo.png", 3); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := q.Commit(); err != nil {
+		t.Fatalf("Commit() failed: %v", err)
+	}
+
+	if Exists(filepath.Join(projectDir, "logo.png")) || Exists(q.Dir()) {
+		t.Error("Expected asset and quarantine to be gone after commit")
+	}
+}
+
+func writeTestAsset(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("png"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+func TestQuarantine_EncryptedManifest(t *testing.T) {
+	t.Setenv(CacheKeyEnv, "s3cret")
+	projectDir := t.TempDir()
+	writeTestAsset(t, filepath.Join(projectDir, "assets", "logo.png"))
+
+	q, err := LoadQuarantine(projectDir)
+	if err != nil {
+		t.Fatalf("LoadQuarantine() failed: %v", err)
+	}
+	if err := q.Add(filepath.Join("assets", "logo.png"), 3); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := q.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	raw, err := os.ReadFile(q.manifestPath())
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if bytes.Contains(raw, []byte("logo.png")) {
+		t.Error("Expected the manifest to be encrypted")
+	}
+
+	loaded, err := LoadQuarantine(projectDir)
+	if err != nil {
+		t.Fatalf("LoadQuarantine() failed: %v", err)
+	}
+	if len(loaded.Files) != 1 || loaded.Files[0].RelativePath != filepath.Join("assets", "logo.png") {
+		t.Errorf("Expected the encrypted manifest to round-trip, got %+v", loaded.Files)
+	}
+
+	t.Setenv(CacheKeyEnv, "")
+	if _, err := LoadQuarantine(projectDir); err == nil {
+		t.Error("Expected LoadQuarantine() without the key to fail")
+	}
+}
+
+func TestQuarantine_AddTwice(t *testing.T) {
+	projectDir := t.TempDir()
+	path := filepath.Join(projectDir, "logo.png")
+	writeTestAsset(t, path)
+
+	q, _ := LoadQuarantine(projectDir)
+	if err := q.Add("logo.png", 3); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	// Recreated after the first quarantine, then quarantined again
+	writeTestAsset(t, path)
+	if err := q.Add("./logo.png", 5); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := q.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	loaded, err := LoadQuarantine(projectDir)
+	if err != nil {
+		t.Fatalf("LoadQuarantine() failed: %v", err)
+	}
+	if len(loaded.Files) != 1 || loaded.TotalSize() != 5 {
+		t.Fatalf("Expected 1 quarantined file of 5 bytes, got %d files (%d bytes)", len(loaded.Files), loaded.TotalSize())
+	}
+	if failed := loaded.Rollback(); len(failed) > 0 {
+		t.Errorf("Rollback() failed: %v", failed)
+	}
+}
+
+func TestLoadQuarantine_DuplicateEntries(t *testing.T) {
+	projectDir := t.TempDir()
+	manifest := filepath.Join(projectDir, QuarantineDirName, quarantineManifest)
+	if err := os.MkdirAll(filepath.Dir(manifest), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	content := `{"files": [
+		{"relative_path": "logo.png", "size_bytes": 3},
+		{"relative_path": "icon.png", "size_bytes": 1},
+		{"relative_path": "logo.png", "size_bytes": 5}
+	]}`
+	if err := os.WriteFile(manifest, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	q, err := LoadQuarantine(projectDir)
+	if err != nil {
+		t.Fatalf("LoadQuarantine() failed: %v", err)
+	}
+	if len(q.Files) != 2 || q.Files[0].RelativePath != "logo.png" || q.Files[0].Size != 5 {
+		t.Errorf("Files = %+v, want logo.png (5 bytes) and icon.png", q.Files)
+	}
+}
+
+func TestQuarantine_ProjectManifest(t *testing.T) {
+	projectDir := t.TempDir()
+	manifest := filepath.Join(projectDir, "manifest.json")
+	content := []byte(`{"name": "My PWA", "icons": []}`)
+	if err := os.WriteFile(manifest, content, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	q, _ := LoadQuarantine(projectDir)
+	if err := q.Add("manifest.json", int64(len(content))); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := q.Save(); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	loaded, err := LoadQuarantine(projectDir)
+	if err != nil {
+		t.Fatalf("LoadQuarantine() failed: %v", err)
+	}
+	if failed := loaded.Rollback(); len(failed) > 0 {
+		t.Fatalf("Rollback() failed: %v", failed)
+	}
+	if got, err := os.ReadFile(manifest); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("restored manifest.json = %q, %v; want the project's own file", got, err)
+	}
+}
+
+func TestLoadQuarantine_LegacyLayout(t *testing.T) {
+	projectDir := t.TempDir()
+	dir := filepath.Join(projectDir, QuarantineDirName)
+	writeTestAsset(t, filepath.Join(dir, "assets", "logo.png"))
+	content := `{"files": [{"relative_path": "assets/logo.png", "size_bytes": 3}]}`
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	q, err := LoadQuarantine(projectDir)
+	if err != nil {
+		t.Fatalf("LoadQuarantine() failed: %v", err)
+	}
+	if len(q.Files) != 1 {
+		t.Fatalf("Files = %+v, want the legacy entry", q.Files)
+	}
+	if Exists(filepath.Join(dir, "manifest.json")) || !Exists(filepath.Join(dir, "files", "assets", "logo.png")) {
+		t.Error("Expected the legacy quarantine to move under files/ with its manifest replaced")
+	}
+
+	if failed := q.Rollback(); len(failed) > 0 {
+		t.Fatalf("Rollback() failed: %v", failed)
+	}
+	if !Exists(filepath.Join(projectDir, "assets", "logo.png")) {
+		t.Error("Expected the legacy asset to be restored")
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"runtime"
+	"time"
+)
+
+// Throttle limits how hard scans hit the machine: at most a number of files
+// are read at once by everything sharing the Throttle, and Pause sleeps
+// between directories. A nil *Throttle doesn't throttle.
+type Throttle struct {
+	reads chan struct{}
+	pause time.Duration
+}
+
+// NewThrottle returns a Throttle allowing maxReads concurrent reads (0 = no
+// limit) and pausing for pause between directories, or nil if neither is set
+func NewThrottle(maxReads int, pause time.Duration) *Throttle {
+	if maxReads <= 0 && pause <= 0 {
+		return nil
+	}
+	t := &Throttle{pause: pause}
+	if maxReads > 0 {
+		t.reads = make(chan struct{}, maxReads)
+	}
+	return t
+}
+
+// Acquire blocks until a read may start; each Acquire needs a Release
+func (t *Throttle) Acquire() {
+	if t != nil && t.reads != nil {
+		t.reads <- struct{}{}
+	}
+}
+
+// Release ends a read started with Acquire
+func (t *Throttle) Release() {
+	if t != nil && t.reads != nil {
+		<-t.reads
+	}
+}
+
+// Pause yields the processor and sleeps for the pause between directories
+func (t *Throttle) Pause() {
+	if t == nil {
+		return
+	}
+	runtime.Gosched()
+	if t.pause > 0 {
+		time.Sleep(t.pause)
+	}
+}
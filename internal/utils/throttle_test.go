@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottle_LimitsConcurrentReads(t *testing.T) {
+	throttle := NewThrottle(2, 0)
+
+	var active, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			throttle.Acquire()
+			defer throttle.Release()
+			n := atomic.AddInt32(&active, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("%d reads ran at once, want at most 2", peak)
+	}
+}
+
+func TestThrottle_Nil(t *testing.T) {
+	if throttle := NewThrottle(0, 0); throttle != nil {
+		t.Fatalf("NewThrottle(0, 0) = %v, want nil", throttle)
+	}
+
+	// A nil Throttle must not block or panic
+	var throttle *Throttle
+	throttle.Acquire()
+	throttle.Release()
+	throttle.Pause()
+}
@@ -0,0 +1,118 @@
+// Package utils - Reversible file trash
+//
+// Moves files into a holding directory so they can be restored if a later
+// step (such as build verification) fails. Files are moved with os.Rename
+// and fall back to copy+remove when the trash lives on another filesystem.
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// trashedFile records where a file came from and where it was moved
+type trashedFile struct {
+	original string
+	trashed  string
+}
+
+// Trash holds files that have been moved out of the project temporarily
+type Trash struct {
+	dir   string
+	files []trashedFile
+}
+
+// NewTrash creates a trash rooted at dir (created on first move)
+func NewTrash(dir string) *Trash {
+	return &Trash{dir: dir}
+}
+
+// Dir returns the trash directory
+func (t *Trash) Dir() string {
+	return t.dir
+}
+
+// Move moves path into the trash, preserving relPath as its layout inside the trash
+func (t *Trash) Move(path, relPath string) error {
+	dest := filepath.Join(t.dir, relPath)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	if err := MoveFile(path, dest); err != nil {
+		return err
+	}
+
+	t.files = append(t.files, trashedFile{original: path, trashed: dest})
+	return nil
+}
+
+// Restore moves every trashed file back to its original location.
+// It keeps going on failure and returns the paths that could not be
+// restored; those stay in the trash, which is only removed once empty.
+func (t *Trash) Restore() []string {
+	var failed []string
+	var kept []trashedFile
+	for i := len(t.files) - 1; i >= 0; i-- {
+		f := t.files[i]
+		if err := MoveFile(f.trashed, f.original); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", f.original, err))
+			kept = append([]trashedFile{f}, kept...)
+		}
+	}
+	t.files = kept
+	if len(failed) == 0 {
+		os.RemoveAll(t.dir)
+	}
+	return failed
+}
+
+// Empty permanently deletes the trash contents
+func (t *Trash) Empty() error {
+	t.files = nil
+	return os.RemoveAll(t.dir)
+}
+
+// MoveFile renames src to dst, falling back to copy+remove across filesystems
+func MoveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	if err := copyFile(src, dst); err != nil {
+		return fmt.Errorf("failed to move %s: %w", src, err)
+	}
+	return os.Remove(src)
+}
+
+// copyFile copies src to dst, preserving file mode
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
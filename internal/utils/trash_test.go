@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrash_MoveAndRestore(t *testing.T) {
+	projectDir := t.TempDir()
+	trashDir := filepath.Join(t.TempDir(), "trash")
+
+	assetPath := filepath.Join(projectDir, "assets", "logo.png")
+	if err := os.MkdirAll(filepath.Dir(assetPath), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(assetPath, []byte("png"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	trash := NewTrash(trashDir)
+	if err := trash.Move(assetPath, filepath.Join("assets", "logo.png")); err != nil {
+		t.Fatalf("Move() failed: %v", err)
+	}
+
+	if Exists(assetPath) {
+		t.Error("Expected file to be moved out of project")
+	}
+	if !Exists(filepath.Join(trashDir, "assets", "logo.png")) {
+		t.Error("Expected file to be in trash")
+	}
+
+	if failed := trash.Restore(); len(failed) > 0 {
+		t.Fatalf("Restore() failed: %v", failed)
+	}
+
+	data, err := os.ReadFile(assetPath)
+	if err != nil || string(data) != "png" {
+		t.Errorf("Expected file to be restored with original content, got %q (%v)", data, err)
+	}
+	if Exists(trashDir) {
+		t.Error("Expected trash directory to be removed after restore")
+	}
+}
+
+func TestTrash_RestoreFailureKeepsTrash(t *testing.T) {
+	projectDir := t.TempDir()
+	trashDir := filepath.Join(t.TempDir(), "trash")
+
+	assetPath := filepath.Join(projectDir, "assets", "logo.png")
+	if err := os.MkdirAll(filepath.Dir(assetPath), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(assetPath, []byte("png"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	trash := NewTrash(trashDir)
+	if err := trash.Move(assetPath, filepath.Join("assets", "logo.png")); err != nil {
+		t.Fatalf("Move() failed: %v", err)
+	}
+
+	// A file where the asset's directory was makes the restore fail
+	assetsDir := filepath.Dir(assetPath)
+	if err := os.Remove(assetsDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(assetsDir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if failed := trash.Restore(); len(failed) != 1 {
+		t.Fatalf("Restore() = %v, want one failure", failed)
+	}
+	trashed := filepath.Join(trashDir, "assets", "logo.png")
+	if data, err := os.ReadFile(trashed); err != nil || string(data) != "png" {
+		t.Fatalf("Expected unrestored file to stay in trash, got %q (%v)", data, err)
+	}
+
+	// Once the obstacle is gone, a second restore succeeds
+	if err := os.Remove(assetsDir); err != nil {
+		t.Fatal(err)
+	}
+	if failed := trash.Restore(); len(failed) > 0 {
+		t.Fatalf("second Restore() failed: %v", failed)
+	}
+	if !Exists(assetPath) || Exists(trashDir) {
+		t.Error("Expected file restored and trash removed after second restore")
+	}
+}
+
+func TestTrash_Empty(t *testing.T) {
+	projectDir := t.TempDir()
+	trashDir := filepath.Join(t.TempDir(), "trash")
+
+	assetPath := filepath.Join(projectDir, "logo.png")
+	if err := os.WriteFile(assetPath, []byte("png"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	trash := NewTrash(trashDir)
+	if err := trash.Move(assetPath, "logo.png"); err != nil {
+		t.Fatalf("Move() failed: %v", err)
+	}
+
+	if err := trash.Empty(); err != nil {
+		t.Fatalf("Empty() failed: %v", err)
+	}
+
+	if Exists(assetPath) || Exists(trashDir) {
+		t.Error("Expected file and trash to be gone after Empty()")
+	}
+}